@@ -62,6 +62,9 @@ import (
 	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 	upgradekeeper "github.com/cosmos/cosmos-sdk/x/upgrade/keeper"
 	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/cosmos/cosmos-sdk/x/nft"
+	nftkeeper "github.com/cosmos/cosmos-sdk/x/nft/keeper"
+	nftmodule "github.com/cosmos/cosmos-sdk/x/nft/module"
 	"github.com/cometbft/cometbft/abci/types"
 	abci "github.com/cometbft/cometbft/abci/types"
 	tmjson "github.com/cometbft/cometbft/libs/json"
@@ -106,6 +109,7 @@ import (
 	hyperchaintypes "github.com/nomercychain/nmxchain/x/hyperchains/types"
 
 	"github.com/nomercychain/nmxchain/x/neuropos"
+	neuroposante "github.com/nomercychain/nmxchain/x/neuropos/ante"
 	neuroposkeeper "github.com/nomercychain/nmxchain/x/neuropos/keeper"
 	neuropostypes "github.com/nomercychain/nmxchain/x/neuropos/types"
 
@@ -156,6 +160,7 @@ var (
 		slashing.AppModuleBasic{},
 		upgrade.AppModuleBasic{},
 		evidence.AppModuleBasic{},
+		nftmodule.AppModuleBasic{},
 		// Custom modules
 		neuropos.AppModuleBasic{},
 		truthgpt.AppModuleBasic{},
@@ -181,6 +186,7 @@ type NMXApp struct {
 	UpgradeKeeper    upgradekeeper.Keeper
 	ParamsKeeper     paramskeeper.Keeper
 	EvidenceKeeper   evidencekeeper.Keeper
+	NFTKeeper        nftkeeper.Keeper
 	
 	// Custom keepers
 	NeuroPoSKeeper    neuroposkeeper.Keeper
@@ -248,11 +254,11 @@ func NewNMXApp(
 		authtypes.StoreKey, banktypes.StoreKey, stakingtypes.StoreKey,
 		distrtypes.StoreKey, slashingtypes.StoreKey, govtypes.StoreKey,
 		paramstypes.StoreKey, upgradetypes.StoreKey, evidencetypes.StoreKey,
-		capabilitytypes.StoreKey, neuropostypes.StoreKey, truthgpttypes.StoreKey,
+		capabilitytypes.StoreKey, nftkeeper.StoreKey, neuropostypes.StoreKey, truthgpttypes.StoreKey,
 		deaitypes.StoreKey, dynacontracttypes.StoreKey, hyperchaintypes.StoreKey,
 	)
 	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
-	memKeys := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)
+	memKeys := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey, deaitypes.MemStoreKey)
 
 	app := &NMXApp{
 		BaseApp:           bApp,
@@ -300,7 +306,7 @@ func NewNMXApp(
 		&stakingKeeper, authtypes.FeeCollectorName,
 	)
 	app.SlashingKeeper = slashingkeeper.NewKeeper(
-		appCodec, keys[slashingtypes.StoreKey], &stakingKeeper, slashingSubspace,
+		appCodec, keys[slashingtypes.StoreKey], neuropos.NewSlashingStakingKeeper(&stakingKeeper), slashingSubspace,
 	)
 	app.CrisisKeeper = crisiskeeper.NewKeeper(
 		crisisSubspace, invCheckPeriod, app.BankKeeper, authtypes.FeeCollectorName,
@@ -329,6 +335,10 @@ func NewNMXApp(
 	// Set staking keeper
 	app.StakingKeeper = stakingKeeper
 
+	app.NFTKeeper = nftkeeper.NewKeeper(
+		keys[nftkeeper.StoreKey], appCodec, app.AccountKeeper, app.BankKeeper,
+	)
+
 	// Initialize custom module keepers
 	app.NeuroPoSKeeper = neuroposkeeper.NewKeeper(
 		appCodec,
@@ -350,9 +360,14 @@ func NewNMXApp(
 	app.DeAIKeeper = deaikeeper.NewKeeper(
 		appCodec,
 		keys[deaitypes.StoreKey],
+		memKeys[deaitypes.MemStoreKey],
 		deaiSubspace,
+		app.MsgServiceRouter(),
 		app.AccountKeeper,
 		app.BankKeeper,
+		app.NFTKeeper,
+		app.DistrKeeper,
+		app.GovKeeper,
 	)
 
 	app.DynaContractKeeper = dynacontractkeeper.NewKeeper(
@@ -387,10 +402,12 @@ func NewNMXApp(
 		gov.NewAppModule(appCodec, app.GovKeeper, app.AccountKeeper, app.BankKeeper),
 		slashing.NewAppModule(appCodec, app.SlashingKeeper, app.AccountKeeper, app.BankKeeper, app.StakingKeeper),
 		distr.NewAppModule(appCodec, app.DistrKeeper, app.AccountKeeper, app.BankKeeper, app.StakingKeeper),
-		staking.NewAppModule(appCodec, app.StakingKeeper, app.AccountKeeper, app.BankKeeper),
+		// neuropos sends the validator updates in place of staking
+		neuropos.NewStakingAppModule(staking.NewAppModule(appCodec, app.StakingKeeper, app.AccountKeeper, app.BankKeeper)),
 		upgrade.NewAppModule(app.UpgradeKeeper),
 		evidence.NewAppModule(app.EvidenceKeeper),
 		params.NewAppModule(app.ParamsKeeper),
+		nftmodule.NewAppModule(appCodec, app.NFTKeeper, app.AccountKeeper, app.BankKeeper, app.interfaceRegistry),
 		
 		// Custom app modules
 		neuropos.NewAppModule(appCodec, app.NeuroPoSKeeper, app.AccountKeeper, app.BankKeeper, app.StakingKeeper),
@@ -409,6 +426,7 @@ func NewNMXApp(
 		govtypes.ModuleName, stakingtypes.ModuleName, slashingtypes.ModuleName,
 		distrtypes.ModuleName, evidencetypes.ModuleName, authtypes.ModuleName,
 		banktypes.ModuleName, genutiltypes.ModuleName, paramstypes.ModuleName,
+		nft.ModuleName,
 		// Custom modules
 		neuropostypes.ModuleName, truthgpttypes.ModuleName, deaitypes.ModuleName,
 		dynacontracttypes.ModuleName, hyperchaintypes.ModuleName,
@@ -419,7 +437,8 @@ func NewNMXApp(
 		capabilitytypes.ModuleName, authtypes.ModuleName, banktypes.ModuleName,
 		distrtypes.ModuleName, slashingtypes.ModuleName, evidencetypes.ModuleName,
 		genutiltypes.ModuleName, paramstypes.ModuleName, upgradetypes.ModuleName,
-		// Custom modules
+		nft.ModuleName,
+		// Custom modules; neuropos weights the validator set staking selected in this block
 		neuropostypes.ModuleName, truthgpttypes.ModuleName, deaitypes.ModuleName,
 		dynacontracttypes.ModuleName, hyperchaintypes.ModuleName,
 	)
@@ -433,6 +452,8 @@ func NewNMXApp(
 		capabilitytypes.ModuleName, authtypes.ModuleName, banktypes.ModuleName, distrtypes.ModuleName,
 		stakingtypes.ModuleName, slashingtypes.ModuleName, govtypes.ModuleName, crisistypes.ModuleName,
 		genutiltypes.ModuleName, evidencetypes.ModuleName, paramstypes.ModuleName, upgradetypes.ModuleName,
+		// nft must be initialized before deai, which mints agent NFTs for imported agents
+		nft.ModuleName,
 		// Custom modules
 		neuropostypes.ModuleName, truthgpttypes.ModuleName, deaitypes.ModuleName,
 		dynacontracttypes.ModuleName, hyperchaintypes.ModuleName,
@@ -468,11 +489,14 @@ func NewNMXApp(
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
 	app.SetAnteHandler(
-		ante.NewAnteHandler(
-			app.AccountKeeper,
-			app.BankKeeper,
-			ante.DefaultSigVerificationGasConsumer,
-			encodingConfig.TxConfig.SignModeHandler(),
+		neuroposante.NewAnteHandler(
+			app.NeuroPoSKeeper,
+			ante.NewAnteHandler(
+				app.AccountKeeper,
+				app.BankKeeper,
+				ante.DefaultSigVerificationGasConsumer,
+				encodingConfig.TxConfig.SignModeHandler(),
+			),
 		),
 	)
 
@@ -623,6 +647,7 @@ func GetMaccPerms() map[string][]string {
 		stakingtypes.BondedPoolName:    {authtypes.Burner, authtypes.Staking},
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
 		govtypes.ModuleName:            {authtypes.Burner},
+		nft.ModuleName:                 nil,
 		
 		// Custom module permissions
 		neuropostypes.ModuleName:       {authtypes.Minter, authtypes.Burner, authtypes.Staking},
@@ -39,6 +39,7 @@ import (
 	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
 	"github.com/nomercychain/nmxchain/app"
 	"github.com/nomercychain/nmxchain/app/params"
+	deaicli "github.com/nomercychain/nmxchain/x/deai/client/cli"
 )
 
 // Initialize the default home directory for the application
@@ -142,6 +143,11 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig app.EncodingConfig) {
 		txCommand(),
 		keys.Commands(app.DefaultNodeHome),
 	)
+
+	// Add DeAI operator commands
+	rootCmd.AddCommand(
+		deaicli.GetDeAICmd(),
+	)
 }
 
 // newApp creates a new application
@@ -0,0 +1,66 @@
+// Package blobstore stores and retrieves content-addressed payloads that are kept off
+// chain, such as large agent states and training data sets. Chain records only hold a
+// reference to the payload together with its sha256 digest and size, which are checked
+// with Verify after the payload is retrieved.
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is returned when a store does not hold a blob
+	ErrNotFound = errors.New("blob not found")
+
+	// ErrDigestMismatch is returned when a blob does not hash to the expected digest
+	ErrDigestMismatch = errors.New("blob digest mismatch")
+
+	// ErrSizeMismatch is returned when a blob does not have the expected size
+	ErrSizeMismatch = errors.New("blob size mismatch")
+)
+
+// Store is a content-addressed blob store
+type Store interface {
+	// Put stores a blob and returns the key it can be retrieved with
+	Put(ctx context.Context, data []byte) (string, error)
+
+	// Get returns the blob stored under a key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Has returns true if the store holds a blob under a key
+	Has(ctx context.Context, key string) (bool, error)
+}
+
+// Digest returns the hex encoded sha256 digest of a blob
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks a blob against the digest and size recorded for it
+func Verify(data []byte, digest string, size uint64) error {
+	if uint64(len(data)) != size {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrSizeMismatch, size, len(data))
+	}
+	if got := Digest(data); got != digest {
+		return fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, digest, got)
+	}
+	return nil
+}
+
+// Fetch retrieves a blob from a store and verifies it against the digest and size
+// recorded for it
+func Fetch(ctx context.Context, store Store, key, digest string, size uint64) ([]byte, error) {
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(data, digest, size); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
\ No newline at end of file
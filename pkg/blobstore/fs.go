@@ -0,0 +1,106 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FSStore is a Store on the local filesystem. Blobs are keyed by their digest and
+// spread over subdirectories named after the first byte of the digest.
+type FSStore struct {
+	root string
+}
+
+var _ Store = &FSStore{}
+
+// NewFSStore returns a filesystem store rooted at a directory, creating it if needed
+func NewFSStore(root string) (*FSStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory: %w", err)
+	}
+	return &FSStore{root: root}, nil
+}
+
+// Put implements Store. Blobs are written to a temporary file first so that readers
+// never see a partially written blob.
+func (s *FSStore) Put(_ context.Context, data []byte) (string, error) {
+	key := Digest(data)
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return key, nil
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, key+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	return key, nil
+}
+
+// Get implements Store
+func (s *FSStore) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
+
+// Has implements Store
+func (s *FSStore) Has(_ context.Context, key string) (bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// path returns the file of a blob. Keys must be digests, which keeps them from
+// escaping the store's root directory.
+func (s *FSStore) path(key string) (string, error) {
+	if bz, err := hex.DecodeString(key); err != nil || len(bz) == 0 {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(s.root, key[:2], key), nil
+}
\ No newline at end of file
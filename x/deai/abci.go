@@ -1,17 +1,23 @@
 package deai
 
 import (
+	"errors"
+
 	"github.com/nomercychain/nmxchain/x/deai/keeper"
 	"github.com/nomercychain/nmxchain/x/deai/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 // BeginBlocker is called at the beginning of every block
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
-	// Process AI agent actions
-	k.ProcessAIAgentActions(ctx)
+	// Run the scheduled AI agent actions that are due
+	processScheduledActions(ctx, k)
+
+	// Have governance agents decide on the proposals in their voting period
+	k.DecideAgentGovVotes(ctx)
 	
 	// Process any pending AI agent training tasks
 	processPendingTrainingTasks(ctx, k)
@@ -21,6 +27,12 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k keeper.Keeper) []abci.ValidatorUpdate {
 	// Process expired marketplace listings
 	processExpiredListings(ctx, k)
+
+	// Revoke access of renters whose rental period has ended
+	processExpiredRentals(ctx, k)
+
+	// Renew or lapse the subscriptions whose period has ended
+	processDueSubscriptions(ctx, k)
 	
 	// Process AI agent executions
 	processAIAgentExecutions(ctx, k)
@@ -31,76 +43,229 @@ func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k keeper.Keeper) []ab
 
 // processExpiredListings processes expired marketplace listings
 func processExpiredListings(ctx sdk.Context, k keeper.Keeper) {
-	listings := k.GetAllAIAgentMarketplaceListings(ctx)
+	// The active listings that expired, collected from the status index
+	listings := k.GetExpiredAIAgentMarketplaceListings(ctx)
 	currentTime := ctx.BlockTime()
 
 	for _, listing := range listings {
-		// Skip listings that are not active or don't have an expiration
-		if listing.Status != "active" || listing.ExpiresAt.IsZero() {
+		// English auctions are settled with their highest bidder at close
+		if listing.ListingType == types.ListingTypeEnglishAuction {
+			closeEnglishAuction(ctx, k, listing)
 			continue
 		}
 
-		// Check if the listing has expired
-		if currentTime.After(listing.ExpiresAt) {
-			// Update the listing status
-			listing.Status = "expired"
-			k.SetAIAgentMarketplaceListing(ctx, listing)
-
-			// Update the agent status
-			agent, found := k.GetAIAgent(ctx, listing.AgentID)
-			if found {
-				agent.Status = types.AIAgentStatusActive
-				agent.UpdatedAt = currentTime
-				k.SetAIAgent(ctx, agent)
-				
-				// Emit an event
-				ctx.EventManager().EmitEvent(
-					sdk.NewEvent(
-						types.EventTypeMarketplaceExpired,
-						sdk.NewAttribute(types.AttributeKeyListingID, listing.ID),
-						sdk.NewAttribute(types.AttributeKeyAgentID, listing.AgentID),
-						sdk.NewAttribute(types.AttributeKeyTimestamp, currentTime.String()),
-					),
-				)
-			}
+		// Update the listing status
+		listing.Status = "expired"
+		k.SetAIAgentMarketplaceListing(ctx, listing)
+
+		// Update the agent status
+		agent, found := k.GetAIAgent(ctx, listing.AgentID)
+		if found {
+			agent.Status = types.AIAgentStatusActive
+			agent.UpdatedAt = currentTime
+			k.SetAIAgent(ctx, agent)
+
+			// Emit an event
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeMarketplaceExpired,
+					sdk.NewAttribute(types.AttributeKeyListingID, listing.ID),
+					sdk.NewAttribute(types.AttributeKeyAgentID, listing.AgentID),
+					sdk.NewAttribute(types.AttributeKeyTimestamp, currentTime.String()),
+				),
+			)
 		}
 	}
 }
 
-// processPendingTrainingTasks processes any pending AI agent training tasks
+// closeEnglishAuction settles an English auction at close, transferring the agent to the
+// highest bidder, or expires it and refunds the highest bid if the reserve was not met
+func closeEnglishAuction(ctx sdk.Context, k keeper.Keeper, listing types.AIAgentMarketplaceListing) {
+	// Settle in a cached context so a failed payout leaves the escrowed bid untouched
+	cacheCtx, write := ctx.CacheContext()
+	settlement, sold, err := k.CloseEnglishAuction(cacheCtx, listing)
+	if err != nil {
+		k.Logger(ctx).Error("failed to close auction", "listing_id", listing.ID, "error", err)
+		return
+	}
+	write()
+
+	if !sold {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeMarketplaceExpired,
+				sdk.NewAttribute(types.AttributeKeyListingID, listing.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, listing.AgentID),
+				sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
+			),
+		)
+		return
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAuctionSettled,
+			sdk.NewAttribute(types.AttributeKeyListingID, listing.ID),
+			sdk.NewAttribute(types.AttributeKeyAgentID, listing.AgentID),
+			sdk.NewAttribute(types.AttributeKeyOwner, listing.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyer, listing.HighestBidder.String()),
+			sdk.NewAttribute(types.AttributeKeyPrice, settlement.Total.String()),
+			sdk.NewAttribute(types.AttributeKeyMarketplaceFee, settlement.MarketplaceFee.String()),
+			sdk.NewAttribute(types.AttributeKeyRoyalty, settlement.Royalty.String()),
+			sdk.NewAttribute(types.AttributeKeyRoyaltyPayee, settlement.RoyaltyPayee.String()),
+			sdk.NewAttribute(types.AttributeKeySellerProceeds, settlement.SellerProceeds.String()),
+			sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
+		),
+	)
+}
+
+// processExpiredRentals expires AI agent rentals whose rental period has ended
+func processExpiredRentals(ctx sdk.Context, k keeper.Keeper) {
+	for _, rental := range k.ExpireAIAgentRentals(ctx) {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRentalExpired,
+				sdk.NewAttribute(types.AttributeKeyRentalID, rental.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, rental.AgentID),
+				sdk.NewAttribute(types.AttributeKeyRenter, rental.Renter.String()),
+				sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
+			),
+		)
+	}
+}
+
+// processDueSubscriptions renews the subscriptions whose period has ended from their
+// prepaid balance, or lapses them and refunds the balance
+func processDueSubscriptions(ctx sdk.Context, k keeper.Keeper) {
+	for _, subscription := range k.GetDueAgentSubscriptions(ctx) {
+		// Renew in a cached context so a failed payment leaves the subscription queued for the next block
+		cacheCtx, write := ctx.CacheContext()
+		renewed, reason, err := k.RenewAgentSubscription(cacheCtx, subscription)
+		if err != nil {
+			k.Logger(ctx).Error("failed to renew subscription", "plan_id", subscription.PlanID, "subscriber", subscription.Subscriber.String(), "error", err)
+			continue
+		}
+		write()
+
+		if reason != "" {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeSubscriptionLapsed,
+					sdk.NewAttribute(types.AttributeKeyPlanID, subscription.PlanID),
+					sdk.NewAttribute(types.AttributeKeyAgentID, subscription.AgentID),
+					sdk.NewAttribute(types.AttributeKeySubscriber, subscription.Subscriber.String()),
+					sdk.NewAttribute(types.AttributeKeyReason, reason),
+					sdk.NewAttribute(types.AttributeKeyRefund, subscription.Balance.String()),
+					sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
+				),
+			)
+			continue
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeSubscriptionRenewed,
+				sdk.NewAttribute(types.AttributeKeyPlanID, renewed.PlanID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, renewed.AgentID),
+				sdk.NewAttribute(types.AttributeKeySubscriber, renewed.Subscriber.String()),
+				sdk.NewAttribute(types.AttributeKeyPeriodEnd, renewed.PeriodEnd.String()),
+				sdk.NewAttribute(types.AttributeKeyBalance, renewed.Balance.String()),
+				sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
+			),
+		)
+	}
+}
+
+// processPendingTrainingTasks expires the training jobs that were not claimed or not
+// submitted before their deadline, refunding their budget
 func processPendingTrainingTasks(ctx sdk.Context, k keeper.Keeper) {
-	// Get all agents in training status
-	agents := k.GetAllAIAgents(ctx)
-	for _, agent := range agents {
-		if agent.Status == types.AIAgentStatusTraining {
-			// In a real implementation, we would check if the training is complete
-			// For now, we'll just set the agent status to active after a delay
-			if ctx.BlockHeight()%10 == 0 { // Simulate training completion every 10 blocks
-				agent.Status = types.AIAgentStatusActive
-				agent.UpdatedAt = ctx.BlockTime()
-				k.SetAIAgent(ctx, agent)
-				
-				// Emit an event
-				ctx.EventManager().EmitEvent(
-					sdk.NewEvent(
-						types.EventTypeTrainingCompleted,
-						sdk.NewAttribute(types.AttributeKeyAgentID, agent.ID),
-						sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
-					),
-				)
+	for _, job := range k.GetExpiredTrainingJobs(ctx) {
+		// Expire in a cached context so a failed refund leaves the job queued for the next block
+		cacheCtx, write := ctx.CacheContext()
+		if _, err := k.ExpireTrainingJob(cacheCtx, job); err != nil {
+			k.Logger(ctx).Error("failed to expire training job", "job_id", job.ID, "error", err)
+			continue
+		}
+		write()
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeTrainingJobExpired,
+				sdk.NewAttribute(types.AttributeKeyJobID, job.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, job.AgentID),
+				sdk.NewAttribute(types.AttributeKeyStatus, job.Status),
+				sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
+			),
+		)
+	}
+}
+
+// processScheduledActions runs the due agent schedules in the order of their next run.
+// Runs are bounded by count and by a gas limit shared by the block; the schedules left
+// over stay queued for the next block.
+func processScheduledActions(ctx sdk.Context, k keeper.Keeper) {
+	gasLimit := k.ScheduledRunsGasLimit(ctx)
+	var gasUsed uint64
+
+	for _, schedule := range k.GetDueAgentSchedules(ctx, k.MaxScheduledRuns(ctx)) {
+		if gasUsed >= gasLimit {
+			return
+		}
+
+		// Run in a cached context metered against the gas left to the block, so a failed
+		// or interrupted run leaves the schedule queued
+		gasMeter := sdk.NewGasMeter(gasLimit - gasUsed)
+		cacheCtx, write := ctx.CacheContext()
+		_, err := runAgentSchedule(cacheCtx.WithGasMeter(gasMeter), k, schedule)
+		gasUsed += gasMeter.GasConsumedToLimit()
+
+		if errors.Is(err, sdkerrors.ErrOutOfGas) {
+			if gasMeter.Limit() < gasLimit {
+				// Retry the run with the gas of a whole block
+				return
+			}
+
+			// The run does not fit in a block at all
+			cacheCtx, write = ctx.CacheContext()
+			if _, err := k.CancelAgentScheduleOverGas(cacheCtx, schedule); err != nil {
+				k.Logger(ctx).Error("failed to cancel agent schedule", "schedule_id", schedule.ID, "error", err)
+				continue
 			}
+			write()
+			continue
+		}
+		if err != nil {
+			k.Logger(ctx).Error("failed to run agent schedule", "schedule_id", schedule.ID, "error", err)
+			continue
 		}
+		write()
 	}
 }
 
-// processAIAgentExecutions processes AI agent executions
+// runAgentSchedule runs a due schedule, turning running out of gas into an error
+func runAgentSchedule(ctx sdk.Context, k keeper.Keeper, schedule types.AgentSchedule) (updated types.AgentSchedule, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "running schedule %s", schedule.ID)
+		}
+	}()
+
+	return k.RunAgentSchedule(ctx, schedule)
+}
+
+// processAIAgentExecutions finalizes the execution requests whose reveal deadline has
+// been reached, accepting the result a quorum of executors agreed on or refunding the fee
 func processAIAgentExecutions(ctx sdk.Context, k keeper.Keeper) {
-	// In a real implementation, we would process any pending AI agent executions
-	// For now, we'll just simulate the completion of executions
-	
-	// This would typically involve:
-	// 1. Fetching pending executions from the store
-	// 2. Processing them using the AI model
-	// 3. Updating the execution status and result
-	// 4. Distributing fees to the agent owner
+	for _, request := range k.GetDueExecutionRequests(ctx) {
+		// Finalize in a cached context so a failed payout leaves the request queued for the next block
+		cacheCtx, write := ctx.CacheContext()
+		if _, err := k.FinalizeExecutionRequest(cacheCtx, request); err != nil {
+			k.Logger(ctx).Error("failed to finalize execution request", "request_id", request.ID, "error", err)
+			continue
+		}
+		write()
+	}
 }
\ No newline at end of file
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/nomercychain/nmxchain/x/deai/executor"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// Flags for the deai operator commands
+const (
+	FlagBackend      = "backend"
+	FlagPollInterval = "poll-interval"
+)
+
+// GetDeAICmd returns the DeAI operator commands that run alongside a node, such as the
+// executor worker and the verification of off-chain payloads
+func GetDeAICmd() *cobra.Command {
+	deaiCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "DeAI operator commands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	deaiCmd.AddCommand(
+		NewExecutorCmd(),
+		NewFetchVerifyCmd(),
+		NewGenerateEncryptionKeyCmd(),
+		NewDecryptTrainingDataCmd(),
+	)
+
+	return deaiCmd
+}
+
+// NewExecutorCmd returns the command that runs an executor worker
+func NewExecutorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "executor",
+		Short: "Run an executor that computes and submits AI agent execution results",
+		Long: `Run an executor that polls for pending AI agent execution requests, computes their
+results with an inference backend and submits them using commit-reveal.
+
+The --from account must be registered with "tx deai register-executor". Committed
+results are kept in memory until they are revealed, so an executor stopped between
+the commit and the reveal of a request is slashed for that request.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			// The executor signs its transactions unattended
+			clientCtx = clientCtx.WithSkipConfirmation(true)
+
+			txf, err := tx.NewFactoryCLI(clientCtx, cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			backendName, err := cmd.Flags().GetString(FlagBackend)
+			if err != nil {
+				return err
+			}
+			backend, err := executor.NewBackend(backendName)
+			if err != nil {
+				return err
+			}
+
+			pollInterval, err := cmd.Flags().GetDuration(FlagPollInterval)
+			if err != nil {
+				return err
+			}
+
+			logger := log.NewTMLogger(log.NewSyncWriter(cmd.ErrOrStderr())).With("module", "deai-executor")
+			worker := executor.NewWorker(clientCtx, txf, backend, pollInterval, logger)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return worker.Run(ctx)
+		},
+	}
+
+	cmd.Flags().String(FlagBackend, executor.EchoBackendName, fmt.Sprintf("Inference backend to run requests with (%s)", strings.Join(executor.BackendNames(), ", ")))
+	cmd.Flags().Duration(FlagPollInterval, 2*time.Second, "Interval between polls for pending execution requests")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
\ No newline at end of file
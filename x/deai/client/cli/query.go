@@ -3,14 +3,22 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/nomercychain/nmxchain/x/deai/types"
 )
 
+// Flags for the deai query commands
+const (
+	FlagActiveOnly = "active-only"
+	FlagSort       = "sort"
+)
+
 // GetQueryCmd returns the query commands for the deai module
 func GetQueryCmd() *cobra.Command {
 	deaiQueryCmd := &cobra.Command{
@@ -32,6 +40,31 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdQueryAIAgentTrainingData(),
 		GetCmdQueryMarketplaceListings(),
 		GetCmdQueryMarketplaceListing(),
+		GetCmdQueryAIAgentRentals(),
+		GetCmdQueryMyRentals(),
+		GetCmdQueryExecutor(),
+		GetCmdQueryExecutors(),
+		GetCmdQueryExecutionRequest(),
+		GetCmdQueryPendingExecutionRequests(),
+		GetCmdQueryTrainingJob(),
+		GetCmdQueryTrainingJobs(),
+		GetCmdQueryAIAgentStateHistory(),
+		GetCmdQueryAIAgentStateAt(),
+		GetCmdQueryPipeline(),
+		GetCmdQueryPipelines(),
+		GetCmdQueryAgentWallet(),
+		GetCmdQueryAgentSchedule(),
+		GetCmdQueryAgentSchedules(),
+		GetCmdQueryAgentQuote(),
+		GetCmdQueryGovDelegation(),
+		GetCmdQueryAgentGovVotes(),
+		GetCmdQueryAgentReputation(),
+		GetCmdQueryCreatorReputation(),
+		GetCmdQueryAgentRatings(),
+		GetCmdQueryAgentShares(),
+		GetCmdQueryAgentShareholder(),
+		GetCmdQuerySubscriptionPlans(),
+		GetCmdQuerySubscriptions(),
 	)
 
 	return deaiQueryCmd
@@ -305,6 +338,7 @@ func GetCmdQueryMarketplaceListings() *cobra.Command {
 			if len(args) > 1 {
 				req.ListingType = args[1]
 			}
+			req.Sort, _ = cmd.Flags().GetString(FlagSort)
 
 			res, err := queryClient.MarketplaceListings(context.Background(), req)
 			if err != nil {
@@ -315,6 +349,7 @@ func GetCmdQueryMarketplaceListings() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String(FlagSort, "", "Sort order of the listings (rating)")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
@@ -346,6 +381,823 @@ func GetCmdQueryMarketplaceListing() *cobra.Command {
 		},
 	}
 
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAIAgentRentals returns the command to query the rentals of an AI agent
+func GetCmdQueryAIAgentRentals() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rentals [agent-id]",
+		Short: "Query all rentals of an AI agent",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAIAgentRentalsRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.AIAgentRentals(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryMyRentals returns the command to query the rentals taken out by an account
+func GetCmdQueryMyRentals() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "my-rentals [addr]",
+		Short: "Query the AI agent rentals of an account, defaulting to the --from account",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			renter := clientCtx.GetFromAddress()
+			if len(args) > 0 {
+				renter, err = sdk.AccAddressFromBech32(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid renter address: %w", err)
+				}
+			}
+			if renter.Empty() {
+				return fmt.Errorf("an address argument or the --%s flag is required", flags.FlagFrom)
+			}
+
+			activeOnly, err := cmd.Flags().GetBool(FlagActiveOnly)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryRenterRentalsRequest{
+				Renter:     renter.String(),
+				ActiveOnly: activeOnly,
+			}
+
+			res, err := queryClient.RenterRentals(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().Bool(FlagActiveOnly, false, "Only return rentals that are still active")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryExecutor returns the command to query a specific executor
+func GetCmdQueryExecutor() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "executor [address]",
+		Short: "Query a specific executor by address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryExecutorRequest{
+				Address: args[0],
+			}
+
+			res, err := queryClient.Executor(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryExecutors returns the command to query all executors
+func GetCmdQueryExecutors() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "executors",
+		Short: "Query all executors",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.Executors(context.Background(), &types.QueryExecutorsRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryExecutionRequest returns the command to query a specific execution request
+func GetCmdQueryExecutionRequest() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execution-request [id]",
+		Short: "Query a specific execution request by ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryExecutionRequestRequest{
+				ID: args[0],
+			}
+
+			res, err := queryClient.ExecutionRequest(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryPendingExecutionRequests returns the command to query the execution requests waiting for executors
+func GetCmdQueryPendingExecutionRequests() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending-executions",
+		Short: "Query the execution requests waiting for executors",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.PendingExecutionRequests(context.Background(), &types.QueryPendingExecutionRequestsRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryTrainingJob returns the command to query a specific training job
+func GetCmdQueryTrainingJob() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "training-job [id]",
+		Short: "Query a specific training job by ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryTrainingJobRequest{
+				ID: args[0],
+			}
+
+			res, err := queryClient.TrainingJob(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryTrainingJobs returns the command to query training jobs
+func GetCmdQueryTrainingJobs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "training-jobs [agent-id] [status]",
+		Short: "Query training jobs, optionally filtered by agent and status",
+		Long: `Query training jobs, optionally filtered by agent and status. Trainers looking for
+work can list the open jobs of all agents with:
+
+$ nmxchaind query deai training-jobs "" open`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryTrainingJobsRequest{}
+			if len(args) > 0 {
+				req.AgentID = args[0]
+			}
+			if len(args) > 1 {
+				req.Status = args[1]
+			}
+
+			res, err := queryClient.TrainingJobs(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAIAgentStateHistory returns the command to query the state history of an AI agent
+func GetCmdQueryAIAgentStateHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-state-history [agent-id]",
+		Short: "Query the retained versions of an AI agent's state",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAIAgentStateHistoryRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.AIAgentStateHistory(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAIAgentStateAt returns the command to query a version of an AI agent's state
+func GetCmdQueryAIAgentStateAt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-state-at [agent-id] [version]",
+		Short: "Query a specific version of an AI agent's state",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			version, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid version: %w", err)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAIAgentStateAtRequest{
+				AgentID: args[0],
+				Version: version,
+			}
+
+			res, err := queryClient.AIAgentStateAt(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryPipeline returns the command to query a pipeline
+func GetCmdQueryPipeline() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pipeline [id]",
+		Short: "Query a pipeline by ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryPipelineRequest{
+				ID: args[0],
+			}
+
+			res, err := queryClient.Pipeline(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryPipelines returns the command to query all pipelines
+func GetCmdQueryPipelines() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pipelines [owner]",
+		Short: "Query all pipelines, optionally filtered by owner",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryPipelinesRequest{}
+			if len(args) > 0 {
+				req.Owner = args[0]
+			}
+
+			res, err := queryClient.Pipelines(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentWallet returns the command to query the wallet of an AI agent
+func GetCmdQueryAgentWallet() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-wallet [agent-id]",
+		Short: "Query the wallet address, balance and spending policy of an AI agent",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentWalletRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.AgentWallet(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentSchedule returns the command to query an agent schedule
+func GetCmdQueryAgentSchedule() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule [id]",
+		Short: "Query an agent schedule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentScheduleRequest{
+				ID: args[0],
+			}
+
+			res, err := queryClient.AgentSchedule(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentSchedules returns the command to query agent schedules
+func GetCmdQueryAgentSchedules() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedules [agent-id] [owner]",
+		Short: "Query agent schedules, optionally filtered by agent and owner",
+		Long: `Query agent schedules, optionally filtered by agent and owner. The schedules of an
+owner across all agents are listed with:
+
+$ nmxchaind query deai schedules "" [owner]`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentSchedulesRequest{}
+			if len(args) > 0 {
+				req.AgentID = args[0]
+			}
+			if len(args) > 1 {
+				req.Owner = args[1]
+			}
+
+			res, err := queryClient.AgentSchedules(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentQuote returns the command to query the fee of a prospective agent call
+func GetCmdQueryAgentQuote() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quote [agent-id] [action-type] [caller]",
+		Short: "Query the exact fee of a prospective call of an AI agent",
+		Long: `Query the exact fee of a prospective call of an AI agent, taking the free tier and
+volume discounts of the caller into account. Without a caller the fee of a first
+call is returned. Calls of agents without a price list, and calls by the owner or a
+renter, are not priced and pay the fee the caller chooses.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentQuoteRequest{
+				AgentID:    args[0],
+				ActionType: args[1],
+			}
+			if len(args) > 2 {
+				req.Caller = args[2]
+			}
+
+			res, err := queryClient.AgentQuote(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryGovDelegation returns the command to query the governance delegation of an account
+func GetCmdQueryGovDelegation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gov-delegation [delegator]",
+		Short: "Query the governance agent an account delegates its voting power to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryGovDelegationRequest{
+				Delegator: args[0],
+			}
+
+			res, err := queryClient.GovDelegation(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentGovVotes returns the command to query the voting record of a governance agent
+func GetCmdQueryAgentGovVotes() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-votes [agent-id]",
+		Short: "Query the votes a governance agent cast on proposals for its delegators",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentGovVotesRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.AgentGovVotes(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentReputation returns the command to query the rating-based reputation of an agent and of its creator
+func GetCmdQueryAgentReputation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-reputation [agent-id]",
+		Short: "Query the rating-based reputation of an agent and of its creator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentReputationRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.AgentReputation(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryCreatorReputation returns the command to query the rating-based reputation of a creator across their agents
+func GetCmdQueryCreatorReputation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "creator-reputation [creator]",
+		Short: "Query the rating-based reputation of a creator across their agents",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryCreatorReputationRequest{
+				Creator: args[0],
+			}
+
+			res, err := queryClient.CreatorReputation(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentRatings returns the command to query the ratings of an agent
+func GetCmdQueryAgentRatings() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-ratings [agent-id]",
+		Short: "Query the ratings of an agent",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentRatingsRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.AgentRatings(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentShares returns the command to query the shares of a fractionalized agent
+func GetCmdQueryAgentShares() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-shares [agent-id]",
+		Short: "Query the shares and shareholders of a fractionalized agent",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentSharesRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.AgentShares(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAgentShareholder returns the command to query the claimable income of an agent shareholder
+func GetCmdQueryAgentShareholder() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent-shareholder [agent-id] [holder]",
+		Short: "Query the income a holder of shares of an agent can claim",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryAgentShareholderRequest{
+				AgentID: args[0],
+				Holder:  args[1],
+			}
+
+			res, err := queryClient.AgentShareholder(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQuerySubscriptionPlans returns the command to query the subscription plans of an agent
+func GetCmdQuerySubscriptionPlans() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscription-plans [agent-id]",
+		Short: "Query the subscription plans of an AI agent",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QuerySubscriptionPlansRequest{
+				AgentID: args[0],
+			}
+
+			res, err := queryClient.SubscriptionPlans(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQuerySubscriptions returns the command to query the active subscriptions of an account
+func GetCmdQuerySubscriptions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscriptions [subscriber]",
+		Short: "Query the active subscriptions of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QuerySubscriptionsRequest{
+				Subscriber: args[0],
+			}
+
+			res, err := queryClient.Subscriptions(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
\ No newline at end of file
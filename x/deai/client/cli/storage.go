@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/nomercychain/nmxchain/pkg/blobstore"
+	deaiclient "github.com/nomercychain/nmxchain/x/deai/client"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// Flags for storing payloads off chain
+const (
+	FlagStorageScheme = "storage-scheme"
+	FlagCID           = "cid"
+	FlagBlobDir       = "blob-dir"
+	FlagEncrypted     = "encrypted"
+	FlagOutput        = "output-file"
+)
+
+// addStorageFlags adds the flags to store a command's payload off chain
+func addStorageFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagStorageScheme, "", fmt.Sprintf("Store the payload off chain with this scheme (%s, %s or %s) and submit only its reference", types.StorageTypeIPFS, types.StorageTypeArweave, types.StorageTypeFile))
+	cmd.Flags().String(FlagCID, "", "Content identifier of the payload on the storage network; the file scheme uses the payload digest")
+	cmd.Flags().String(FlagBlobDir, "", "Local blob store directory to put the payload in")
+	cmd.Flags().Bool(FlagEncrypted, false, "The payload is ciphertext")
+}
+
+// storageRefFromFlags returns the reference to a payload stored off chain, or nil if
+// the payload is submitted inline. With --blob-dir the payload is put in the local
+// blob store first.
+func storageRefFromFlags(cmd *cobra.Command, data []byte) (*types.StorageRef, error) {
+	scheme, _ := cmd.Flags().GetString(FlagStorageScheme)
+	if scheme == "" {
+		return nil, nil
+	}
+
+	cid, _ := cmd.Flags().GetString(FlagCID)
+	blobDir, _ := cmd.Flags().GetString(FlagBlobDir)
+	encrypted, _ := cmd.Flags().GetBool(FlagEncrypted)
+
+	if blobDir != "" {
+		store, err := blobstore.NewFSStore(blobDir)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := store.Put(cmd.Context(), data); err != nil {
+			return nil, err
+		}
+	}
+
+	// Blob stores key blobs by their digest
+	if cid == "" && scheme == types.StorageTypeFile {
+		cid = blobstore.Digest(data)
+	}
+	if cid == "" {
+		return nil, fmt.Errorf("--%s is required for the %s scheme", FlagCID, scheme)
+	}
+
+	ref := &types.StorageRef{
+		Scheme:    scheme,
+		CID:       cid,
+		Digest:    blobstore.Digest(data),
+		Size:      uint64(len(data)),
+		Encrypted: encrypted,
+	}
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// NewFetchVerifyCmd returns the command that retrieves an off-chain payload and checks
+// it against the digest recorded on chain
+func NewFetchVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fetch-verify [agent-id] [training-data-id]",
+		Short: "Retrieve an agent's off-chain state or training data and verify it",
+		Long: `Retrieve the state of an AI agent, or one of its training data sets if a training
+data ID is given, from a local blob store and check it against the digest and size
+recorded on chain. The verified payload is written to --output-file or stdout.
+
+Blobs are looked up in --blob-dir by the digest of their storage reference, so
+payloads kept on IPFS or Arweave must be mirrored into the directory first, for
+example by storing them with --blob-dir when they are submitted.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			var ref *types.StorageRef
+			if len(args) == 1 {
+				res, err := queryClient.AIAgentState(context.Background(), &types.QueryAIAgentStateRequest{
+					AgentID: args[0],
+				})
+				if err != nil {
+					return err
+				}
+				ref = res.State.StorageRef
+			} else {
+				data, err := queryTrainingData(queryClient, args[0], args[1])
+				if err != nil {
+					return err
+				}
+				ref = data.StorageRef
+			}
+			if ref == nil {
+				return fmt.Errorf("the payload is stored on chain, not by reference")
+			}
+
+			data, err := fetchVerified(cmd, ref)
+			if err != nil {
+				return err
+			}
+
+			if err := writeOutput(cmd, data); err != nil {
+				return err
+			}
+
+			cmd.PrintErrf("verified %d bytes with digest %s\n", ref.Size, ref.Digest)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagBlobDir, "", "Local blob store directory to retrieve the payload from")
+	cmd.Flags().String(FlagOutput, "", "File to write the verified payload to instead of stdout")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewDecryptTrainingDataCmd returns the command that decrypts training data whose data
+// key was wrapped for a local key pair
+func NewDecryptTrainingDataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decrypt-training-data [agent-id] [training-data-id]",
+		Short: "Decrypt encrypted training data with a key pair it was granted to",
+		Long: `Decrypt training data encrypted to an agent's encryption key with the key pair in
+--key-file: the agent's own key pair, or the key pair a trainer or executor registered
+and was granted the data key for. Data stored off chain is retrieved from --blob-dir
+and verified first. The plaintext is written to --output-file or stdout.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			keyFile, _ := cmd.Flags().GetString(FlagKeyFile)
+			if keyFile == "" {
+				return fmt.Errorf("--%s is required", FlagKeyFile)
+			}
+			key, err := deaiclient.LoadEncryptionKey(keyFile)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			data, err := queryTrainingData(queryClient, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			var ciphertext []byte
+			if data.StorageRef != nil {
+				ciphertext, err = fetchVerified(cmd, data.StorageRef)
+				if err != nil {
+					return err
+				}
+			}
+
+			plaintext, err := deaiclient.DecryptTrainingData(data, ciphertext, key)
+			if err != nil {
+				return err
+			}
+
+			return writeOutput(cmd, plaintext)
+		},
+	}
+
+	cmd.Flags().String(FlagKeyFile, "", "Key file of the key pair the data key was granted to")
+	cmd.Flags().String(FlagBlobDir, "", "Local blob store directory to retrieve data stored off chain from")
+	cmd.Flags().String(FlagOutput, "", "File to write the plaintext to instead of stdout")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewGenerateEncryptionKeyCmd returns the command that generates an X25519 key pair
+func NewGenerateEncryptionKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate-encryption-key [key-file]",
+		Short: "Generate an X25519 key pair for encrypted training data",
+		Long: `Generate an X25519 key pair and write it to a key file readable only by you. Set
+its public key as an agent's encryption key with "tx deai set-agent-encryption-key",
+or register it with "tx deai register-encryption-key" to be granted encrypted data.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(args[0]); err == nil {
+				return fmt.Errorf("%s already exists", args[0])
+			}
+
+			key, err := deaiclient.GenerateEncryptionKey()
+			if err != nil {
+				return err
+			}
+			if err := deaiclient.SaveEncryptionKey(args[0], key); err != nil {
+				return err
+			}
+
+			cmd.Printf("public key: %s\n", hex.EncodeToString(key.PublicKey[:]))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// queryTrainingData returns a training data set of an agent
+func queryTrainingData(queryClient types.QueryClient, agentID, dataID string) (types.AIAgentTrainingData, error) {
+	res, err := queryClient.AIAgentTrainingData(context.Background(), &types.QueryAIAgentTrainingDataRequest{
+		AgentID: agentID,
+	})
+	if err != nil {
+		return types.AIAgentTrainingData{}, err
+	}
+	for _, data := range res.TrainingData {
+		if data.ID == dataID {
+			return data, nil
+		}
+	}
+	return types.AIAgentTrainingData{}, fmt.Errorf("training data %s of agent %s not found", dataID, agentID)
+}
+
+// fetchVerified retrieves a payload stored off chain from the blob store in --blob-dir,
+// which keys blobs by their digest, and checks it against its storage reference
+func fetchVerified(cmd *cobra.Command, ref *types.StorageRef) ([]byte, error) {
+	blobDir, _ := cmd.Flags().GetString(FlagBlobDir)
+	if blobDir == "" {
+		return nil, fmt.Errorf("--%s is required", FlagBlobDir)
+	}
+	store, err := blobstore.NewFSStore(blobDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// References stored before digests were normalized may hold an upper case digest
+	digest, err := types.NormalizeDigest(ref.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return blobstore.Fetch(cmd.Context(), store, digest, digest, ref.Size)
+}
+
+// writeOutput writes a payload to --output-file, or to stdout if it is not set
+func writeOutput(cmd *cobra.Command, data []byte) error {
+	output, _ := cmd.Flags().GetString(FlagOutput)
+	if output == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := ioutil.WriteFile(output, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return nil
+}
\ No newline at end of file
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -14,9 +15,42 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	deaiclient "github.com/nomercychain/nmxchain/x/deai/client"
 	"github.com/nomercychain/nmxchain/x/deai/types"
 )
 
+// Flags for the deai tx commands
+const (
+	FlagReservePrice    = "reserve-price"
+	FlagDatasetRef      = "dataset-ref"
+	FlagHyperparameters = "hyperparameters"
+	FlagBudget          = "budget"
+	FlagParameters      = "parameters"
+	FlagMetrics         = "metrics"
+	FlagReason          = "reason"
+	FlagDescription     = "description"
+	FlagOutputStep      = "output-step"
+	FlagRecipient       = "recipient"
+	FlagAllowedMsgTypes = "allowed-msg-types"
+	FlagRequesters      = "requesters"
+	FlagStartTime       = "start-time"
+	FlagStartHeight     = "start-height"
+	FlagInterval        = "interval"
+	FlagIntervalBlocks  = "interval-blocks"
+	FlagMaxRuns         = "max-runs"
+	FlagActionPrice     = "action-price"
+	FlagFreeCalls       = "free-calls"
+	FlagPeriod          = "period"
+	FlagVolumeDiscount  = "volume-discount"
+	FlagActionID        = "action-id"
+	FlagRentalID        = "rental-id"
+	FlagCommentHash     = "comment-hash"
+	FlagActionQuota     = "action-quota"
+	FlagAutoRenew       = "auto-renew"
+	FlagEncrypt         = "encrypt"
+	FlagKeyFile         = "key-file"
+)
+
 // GetTxCmd returns the transaction commands for the deai module
 func GetTxCmd() *cobra.Command {
 	deaiTxCmd := &cobra.Command{
@@ -36,6 +70,39 @@ func GetTxCmd() *cobra.Command {
 		NewBuyAIAgentCmd(),
 		NewRentAIAgentCmd(),
 		NewCancelMarketListingCmd(),
+		NewPlaceBidCmd(),
+		NewRegisterExecutorCmd(),
+		NewUnregisterExecutorCmd(),
+		NewClaimTrainingJobCmd(),
+		NewSubmitTrainingResultCmd(),
+		NewReviewTrainingResultCmd(),
+		NewUpdateAIAgentStateCmd(),
+		NewRollbackAgentStateCmd(),
+		NewCreatePipelineCmd(),
+		NewDeletePipelineCmd(),
+		NewExecutePipelineCmd(),
+		NewFundAgentWalletCmd(),
+		NewWithdrawAgentWalletCmd(),
+		NewSetAgentWalletPolicyCmd(),
+		NewScheduleAgentActionCmd(),
+		NewCancelAgentScheduleCmd(),
+		NewSetAgentPricingCmd(),
+		NewDelegateGovVotesCmd(),
+		NewUndelegateGovVotesCmd(),
+		NewRateAgentCmd(),
+		NewFractionalizeAgentCmd(),
+		NewTransferAgentSharesCmd(),
+		NewClaimAgentIncomeCmd(),
+		NewBuyoutAgentCmd(),
+		NewCreateSubscriptionPlanCmd(),
+		NewCloseSubscriptionPlanCmd(),
+		NewSubscribeAgentCmd(),
+		NewFundSubscriptionCmd(),
+		NewCancelSubscriptionCmd(),
+		NewRegisterEncryptionKeyCmd(),
+		NewSetAgentEncryptionKeyCmd(),
+		NewGrantTrainingDataKeyCmd(),
+		NewSetAgentModelCmd(),
 	)
 
 	return deaiTxCmd
@@ -159,8 +226,21 @@ func NewUpdateAIAgentCmd() *cobra.Command {
 func NewTrainAIAgentCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "train-agent [agent-id] [data-type] [data-file] [source]",
-		Short: "Train an AI agent with new data",
-		Args:  cobra.ExactArgs(4),
+		Short: "Open a training job for an AI agent with new data",
+		Long: `Store new training data for an AI agent and open a training job for it. The
+budget is required; it is held in escrow and paid to the trainer if the trained
+model is accepted, and refunded if the model is rejected or the job times out. The
+agent stays usable until a trainer claims the job.
+
+Only the owner can train an agent unless its permission policy declares a "train"
+action rule admitting other callers.
+
+Data larger than the max_training_data_size param must be stored off chain: with
+--storage-scheme only the reference to the data file and its digest are submitted.
+
+With --encrypt the data is encrypted to the agent's encryption key before it is
+submitted or stored, and only parties the owner grants the data key can read it.`,
+		Args: cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -178,10 +258,68 @@ func NewTrainAIAgentCmd() *cobra.Command {
 				return fmt.Errorf("failed to read data file: %w", err)
 			}
 
-			// Validate JSON
-			var dataJSON interface{}
-			if err := json.Unmarshal(dataBytes, &dataJSON); err != nil {
-				return fmt.Errorf("invalid data JSON: %w", err)
+			// Encrypt the data to the agent's encryption key
+			encrypt, _ := cmd.Flags().GetBool(FlagEncrypt)
+			var wrappedKey []byte
+			if encrypt {
+				var dataJSON interface{}
+				if err := json.Unmarshal(dataBytes, &dataJSON); err != nil {
+					return fmt.Errorf("invalid data JSON: %w", err)
+				}
+
+				queryClient := types.NewQueryClient(clientCtx)
+				res, err := queryClient.AIAgent(context.Background(), &types.QueryAIAgentRequest{
+					ID: agentID,
+				})
+				if err != nil {
+					return err
+				}
+				if len(res.Agent.EncryptionKey) == 0 {
+					return fmt.Errorf("agent %s has no encryption key", agentID)
+				}
+
+				dataBytes, wrappedKey, err = deaiclient.EncryptTrainingData(res.Agent.EncryptionKey, dataBytes)
+				if err != nil {
+					return err
+				}
+			}
+
+			storageRef, err := storageRefFromFlags(cmd, dataBytes)
+			if err != nil {
+				return err
+			}
+			if encrypt && storageRef != nil {
+				storageRef.Encrypted = true
+			}
+
+			if encrypt && storageRef == nil {
+				// Inline ciphertext is submitted as a JSON string
+				dataBytes, err = deaiclient.EncodeInlineCiphertext(dataBytes)
+				if err != nil {
+					return err
+				}
+			} else if storageRef == nil {
+				// Validate JSON of inline data; data stored by reference may be opaque
+				var dataJSON interface{}
+				if err := json.Unmarshal(dataBytes, &dataJSON); err != nil {
+					return fmt.Errorf("invalid data JSON: %w", err)
+				}
+			} else {
+				dataBytes = nil
+			}
+
+			datasetRefs, _ := cmd.Flags().GetStringSlice(FlagDatasetRef)
+
+			hyperparametersFile, _ := cmd.Flags().GetString(FlagHyperparameters)
+			hyperparameters, err := readJSONFile(hyperparametersFile)
+			if err != nil {
+				return err
+			}
+
+			budgetStr, _ := cmd.Flags().GetString(FlagBudget)
+			budget, err := sdk.ParseCoinsNormalized(budgetStr)
+			if err != nil {
+				return fmt.Errorf("invalid budget: %w", err)
 			}
 
 			msg := types.NewMsgTrainAIAgent(
@@ -190,12 +328,22 @@ func NewTrainAIAgentCmd() *cobra.Command {
 				dataType,
 				dataBytes,
 				source,
+				datasetRefs,
+				hyperparameters,
+				budget,
+				storageRef,
 			)
+			msg.WrappedKey = wrappedKey
 
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
 	}
 
+	cmd.Flags().StringSlice(FlagDatasetRef, nil, "Additional datasets to train on (repeatable)")
+	cmd.Flags().Bool(FlagEncrypt, false, "Encrypt the data to the agent's encryption key")
+	cmd.Flags().String(FlagHyperparameters, "", "JSON file with the training hyperparameters")
+	cmd.Flags().String(FlagBudget, "", "Budget paid to the trainer if the trained model is accepted (required)")
+	addStorageFlags(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -204,8 +352,14 @@ func NewTrainAIAgentCmd() *cobra.Command {
 func NewExecuteAIAgentCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "execute-agent [agent-id] [action-type] [data-file] [fee]",
-		Short: "Execute an action using an AI agent",
-		Args:  cobra.ExactArgs(4),
+		Short: "Request the execution of an action by an AI agent",
+		Long: `Request the execution of an action by an AI agent. The fee is held in escrow
+while registered executors compute the result; query the returned action ID once
+the request has been finalized to read the result.
+
+If the agent has a price list, the fee may be omitted and the quoted fee of the call
+is paid. A fee above the price is not charged.`,
+		Args: cobra.RangeArgs(3, 4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -215,9 +369,27 @@ func NewExecuteAIAgentCmd() *cobra.Command {
 			agentID := args[0]
 			actionType := args[1]
 			dataFile := args[2]
-			fee, err := sdk.ParseCoinNormalized(args[3])
-			if err != nil {
-				return fmt.Errorf("invalid fee: %w", err)
+
+			var fee sdk.Coin
+			if len(args) > 3 {
+				fee, err = sdk.ParseCoinNormalized(args[3])
+				if err != nil {
+					return fmt.Errorf("invalid fee: %w", err)
+				}
+			} else {
+				queryClient := types.NewQueryClient(clientCtx)
+				res, err := queryClient.AgentQuote(context.Background(), &types.QueryAgentQuoteRequest{
+					AgentID:    agentID,
+					Caller:     clientCtx.GetFromAddress().String(),
+					ActionType: actionType,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to quote the call: %w", err)
+				}
+				if !res.Priced {
+					return fmt.Errorf("the call of agent %s is not priced; specify the fee", agentID)
+				}
+				fee = res.Quote.Fee
 			}
 
 			// Read data from file
@@ -252,8 +424,11 @@ func NewExecuteAIAgentCmd() *cobra.Command {
 func NewListAIAgentForSaleCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list-agent [agent-id] [price] [rental-price] [rental-duration] [listing-type] [expiration-days]",
-		Short: "List an AI agent for sale or rent on the marketplace",
-		Args:  cobra.ExactArgs(6),
+		Short: "List an AI agent for sale, rent or auction on the marketplace",
+		Long: `List an AI agent on the marketplace. The listing type is one of 'sale', 'rent', 'both',
+'english_auction' or 'dutch_auction'. For auctions the price is the starting price and
+--reserve-price sets the minimum winning bid (English) or the floor price (Dutch).`,
+		Args: cobra.ExactArgs(6),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -278,8 +453,11 @@ func NewListAIAgentForSaleCmd() *cobra.Command {
 			}
 			
 			listingType := args[4]
-			if listingType != "sale" && listingType != "rent" && listingType != "both" {
-				return fmt.Errorf("listing type must be 'sale', 'rent', or 'both'")
+			switch listingType {
+			case types.ListingTypeSale, types.ListingTypeRent, types.ListingTypeBoth,
+				types.ListingTypeEnglishAuction, types.ListingTypeDutchAuction:
+			default:
+				return fmt.Errorf("listing type must be 'sale', 'rent', 'both', 'english_auction' or 'dutch_auction'")
 			}
 			
 			expirationDays, err := strconv.ParseUint(args[5], 10, 64)
@@ -287,6 +465,15 @@ func NewListAIAgentForSaleCmd() *cobra.Command {
 				return fmt.Errorf("invalid expiration days: %w", err)
 			}
 
+			var reservePrice sdk.Coins
+			reservePriceStr, _ := cmd.Flags().GetString(FlagReservePrice)
+			if reservePriceStr != "" {
+				reservePrice, err = sdk.ParseCoinsNormalized(reservePriceStr)
+				if err != nil {
+					return fmt.Errorf("invalid reserve price: %w", err)
+				}
+			}
+
 			msg := types.NewMsgListAIAgentForSale(
 				clientCtx.GetFromAddress(),
 				agentID,
@@ -295,12 +482,14 @@ func NewListAIAgentForSaleCmd() *cobra.Command {
 				rentalDuration,
 				listingType,
 				expirationDays,
+				reservePrice,
 			)
 
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
 	}
 
+	cmd.Flags().String(FlagReservePrice, "", "Reserve price of an auction listing")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -388,6 +577,1179 @@ func NewCancelMarketListingCmd() *cobra.Command {
 		},
 	}
 
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewPlaceBidCmd returns a CLI command handler for bidding on an auction listing
+func NewPlaceBidCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "place-bid [listing-id] [amount]",
+		Short: "Place a bid on an auction listing",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			listingID := args[0]
+
+			amount, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid amount: %w", err)
+			}
+
+			msg := types.NewMsgPlaceBid(
+				clientCtx.GetFromAddress(),
+				listingID,
+				amount,
+			)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewRegisterExecutorCmd returns a CLI command handler for registering as an executor
+func NewRegisterExecutorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-executor [bond]",
+		Short: "Register as an executor of AI agent requests, or top up the executor bond",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bond, err := sdk.ParseCoinsNormalized(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid bond: %w", err)
+			}
+
+			msg := types.NewMsgRegisterExecutor(
+				clientCtx.GetFromAddress(),
+				bond,
+			)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewUnregisterExecutorCmd returns a CLI command handler for unregistering an executor
+func NewUnregisterExecutorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unregister-executor",
+		Short: "Unregister as an executor and withdraw the executor bond",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUnregisterExecutor(clientCtx.GetFromAddress())
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewClaimTrainingJobCmd returns a CLI command handler for claiming a training job
+func NewClaimTrainingJobCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-training-job [job-id]",
+		Short: "Claim an open training job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimTrainingJob(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewSubmitTrainingResultCmd returns a CLI command handler for submitting a trained model
+func NewSubmitTrainingResultCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-training-result [job-id] [model-hash] [model-uri]",
+		Short: "Submit the model trained for a claimed training job",
+		Long: `Submit the model trained for a claimed training job. The model hash is the hex
+encoded sha256 digest of the model. On-chain models must also provide their new
+parameters with --parameters.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			parametersFile, _ := cmd.Flags().GetString(FlagParameters)
+			parameters, err := readJSONFile(parametersFile)
+			if err != nil {
+				return err
+			}
+
+			metricsFile, _ := cmd.Flags().GetString(FlagMetrics)
+			metrics, err := readJSONFile(metricsFile)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSubmitTrainingResult(
+				clientCtx.GetFromAddress(),
+				args[0],
+				args[1],
+				args[2],
+				parameters,
+				metrics,
+			)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagParameters, "", "JSON file with the parameters of an on-chain model")
+	cmd.Flags().String(FlagMetrics, "", "JSON file with the evaluation metrics of the model")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewReviewTrainingResultCmd returns a CLI command handler for reviewing a trained model
+func NewReviewTrainingResultCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review-training-result [job-id] [accept|reject]",
+		Short: "Accept or reject the model submitted for a training job",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var accept bool
+			switch args[1] {
+			case "accept":
+				accept = true
+			case "reject":
+				accept = false
+			default:
+				return fmt.Errorf("decision must be accept or reject, got %s", args[1])
+			}
+
+			reason, _ := cmd.Flags().GetString(FlagReason)
+
+			msg := types.NewMsgReviewTrainingResult(clientCtx.GetFromAddress(), args[0], accept, reason)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagReason, "", "Reason for rejecting the model")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewUpdateAIAgentStateCmd returns a CLI command handler for replacing an AI agent's state
+func NewUpdateAIAgentStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-agent-state [agent-id] [state-file]",
+		Short: "Replace the state of an AI agent",
+		Long: `Replace the state of an AI agent with the contents of a file. States larger than
+the max_inline_state_size param must be stored off chain: with --storage-scheme only
+the reference to the state file and its digest are submitted.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			stateData, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return fmt.Errorf("failed to read state file: %w", err)
+			}
+
+			storageRef, err := storageRefFromFlags(cmd, stateData)
+			if err != nil {
+				return err
+			}
+			if storageRef != nil {
+				stateData = nil
+			}
+
+			msg := types.NewMsgUpdateAIAgentState(clientCtx.GetFromAddress(), args[0], stateData, storageRef)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	addStorageFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewRollbackAgentStateCmd returns a CLI command handler for restoring a previous version of an AI agent's state
+func NewRollbackAgentStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback-agent-state [agent-id] [version]",
+		Short: "Restore a previous version of an AI agent's state",
+		Long: `Restore a previous version of an AI agent's state. The restored state is stored as
+a new version, so the rollback can itself be undone while the replaced versions are
+retained. List the retained versions with "query deai agent-state-history".`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			version, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid version: %w", err)
+			}
+
+			msg := types.NewMsgRollbackAgentState(clientCtx.GetFromAddress(), args[0], version)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewCreatePipelineCmd returns a CLI command handler for composing AI agents into a pipeline
+func NewCreatePipelineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-pipeline [name] [steps-file]",
+		Short: "Compose AI agents into a pipeline",
+		Long: `Compose AI agents into a pipeline. The steps file holds a JSON array of steps in
+execution order, each running an agent or a previously created pipeline:
+
+[
+  {"id": "score", "agent_id": "agent-1", "action_type": "infer", "fee": [{"denom": "unmx", "amount": "100"}]},
+  {"id": "rank", "agent_id": "agent-2", "action_type": "infer", "inputs": [{"step": "score", "indices": [0]}, {"indices": [2, 3]}]}
+]
+
+A step without inputs takes the result of the previous step. Each agent must have
+an on-chain model, and its owner is paid the step fee when the pipeline executes.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			stepsJSON, err := readJSONFile(args[1])
+			if err != nil {
+				return err
+			}
+			var steps []types.PipelineStep
+			if err := json.Unmarshal(stepsJSON, &steps); err != nil {
+				return fmt.Errorf("invalid steps: %w", err)
+			}
+
+			description, _ := cmd.Flags().GetString(FlagDescription)
+			outputStep, _ := cmd.Flags().GetString(FlagOutputStep)
+
+			msg := types.NewMsgCreatePipeline(clientCtx.GetFromAddress(), args[0], description, steps, outputStep)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagDescription, "", "Description of the pipeline")
+	cmd.Flags().String(FlagOutputStep, "", "Step whose result is the pipeline result; the last step by default")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewDeletePipelineCmd returns a CLI command handler for deleting a pipeline
+func NewDeletePipelineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-pipeline [pipeline-id]",
+		Short: "Delete a pipeline you own",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgDeletePipeline(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewExecutePipelineCmd returns a CLI command handler for executing a pipeline
+func NewExecutePipelineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute-pipeline [pipeline-id] [data-file] [max-fee]",
+		Short: "Run a pipeline on an input",
+		Long: `Run the steps of a pipeline on the input in the data file. All steps run within
+the transaction, which fails if the step fees together exceed max-fee. The result of
+every step is recorded in a single action.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			data, err := readJSONFile(args[1])
+			if err != nil {
+				return err
+			}
+			maxFee, err := sdk.ParseCoinsNormalized(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid max fee: %w", err)
+			}
+
+			msg := types.NewMsgExecutePipeline(clientCtx.GetFromAddress(), args[0], data, maxFee)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewFundAgentWalletCmd returns a CLI command handler for funding the wallet of an AI agent
+func NewFundAgentWalletCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fund-agent-wallet [agent-id] [amount]",
+		Short: "Send coins to the wallet of an AI agent",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid amount: %w", err)
+			}
+
+			msg := types.NewMsgFundAgentWallet(clientCtx.GetFromAddress(), args[0], amount)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewWithdrawAgentWalletCmd returns a CLI command handler for withdrawing from the wallet of an AI agent
+func NewWithdrawAgentWalletCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-agent-wallet [agent-id] [amount]",
+		Short: "Send coins out of the wallet of an AI agent you own",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid amount: %w", err)
+			}
+
+			recipient := clientCtx.GetFromAddress()
+			if recipientStr, _ := cmd.Flags().GetString(FlagRecipient); recipientStr != "" {
+				recipient, err = sdk.AccAddressFromBech32(recipientStr)
+				if err != nil {
+					return fmt.Errorf("invalid recipient: %w", err)
+				}
+			}
+
+			msg := types.NewMsgWithdrawAgentWallet(clientCtx.GetFromAddress(), args[0], recipient, amount)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagRecipient, "", "Address to send the coins to; the owner by default")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewSetAgentWalletPolicyCmd returns a CLI command handler for setting the wallet policy of an AI agent
+func NewSetAgentWalletPolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-agent-wallet-policy [agent-id] [spend-limit] [period]",
+		Short: "Set what an AI agent may do with its wallet",
+		Long: fmt.Sprintf(`Set the messages an AI agent may send from its wallet and the most the wallet may
+lose per period, e.g. "1000unmx 24h". Accepted execution results send the messages
+listed under "wallet_msgs" in the result, signed by the agent wallet. Only results of
+actions requested by the owner or by the accounts given with --requesters send
+messages.
+
+Allowed message types are given as a comma separated list of type URLs: %s,
+%s and %s.`, types.AgentWalletMsgBankSend, types.AgentWalletMsgDelegate, types.AgentWalletMsgExecuteContract),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			spendLimit, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid spend limit: %w", err)
+			}
+			period, err := time.ParseDuration(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid period: %w", err)
+			}
+
+			var allowedMsgTypes []string
+			if typesStr, _ := cmd.Flags().GetString(FlagAllowedMsgTypes); typesStr != "" {
+				allowedMsgTypes = strings.Split(typesStr, ",")
+			}
+
+			var allowedRequesters []sdk.AccAddress
+			if requestersStr, _ := cmd.Flags().GetString(FlagRequesters); requestersStr != "" {
+				for _, addrStr := range strings.Split(requestersStr, ",") {
+					addr, err := sdk.AccAddressFromBech32(addrStr)
+					if err != nil {
+						return fmt.Errorf("invalid allowed requester %s: %w", addrStr, err)
+					}
+					allowedRequesters = append(allowedRequesters, addr)
+				}
+			}
+
+			msg := types.NewMsgSetAgentWalletPolicy(clientCtx.GetFromAddress(), args[0], spendLimit, period, allowedMsgTypes, allowedRequesters)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagAllowedMsgTypes, "", "Comma separated type URLs of the messages the agent may send")
+	cmd.Flags().String(FlagRequesters, "", "Comma separated addresses besides the owner whose requests may send messages")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewScheduleAgentActionCmd returns a CLI command handler for scheduling an action of an AI agent
+func NewScheduleAgentActionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule-agent-action [agent-id] [action-type] [data-file] [fee-per-run] [budget]",
+		Short: "Schedule an action of an AI agent, paid from a prepaid budget",
+		Long: `Schedule an action of an AI agent to run at a block time (--start-time, RFC3339) or
+at a block height (--start-height). Time-based schedules repeat every --interval and
+height-based schedules every --interval-blocks; without an interval the action runs
+once. The budget is held in escrow and pays the fee of every run. The schedule is
+cancelled and the rest of the budget refunded once it no longer covers a run or the
+agent is no longer active.
+
+$ nmxchaind tx deai schedule-agent-action [agent-id] analyze data.json 10unmx 1000unmx --start-time 2025-01-01T00:00:00Z --interval 24h --max-runs 30`,
+		Args: cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			data, err := readJSONFile(args[2])
+			if err != nil {
+				return err
+			}
+			feePerRun, err := sdk.ParseCoinsNormalized(args[3])
+			if err != nil {
+				return fmt.Errorf("invalid fee per run: %w", err)
+			}
+			budget, err := sdk.ParseCoinsNormalized(args[4])
+			if err != nil {
+				return fmt.Errorf("invalid budget: %w", err)
+			}
+
+			var startTime time.Time
+			if startStr, _ := cmd.Flags().GetString(FlagStartTime); startStr != "" {
+				startTime, err = time.Parse(time.RFC3339, startStr)
+				if err != nil {
+					return fmt.Errorf("invalid start time: %w", err)
+				}
+			}
+			startHeight, _ := cmd.Flags().GetInt64(FlagStartHeight)
+			interval, _ := cmd.Flags().GetDuration(FlagInterval)
+			intervalBlocks, _ := cmd.Flags().GetUint64(FlagIntervalBlocks)
+			maxRuns, _ := cmd.Flags().GetUint64(FlagMaxRuns)
+
+			msg := types.NewMsgScheduleAgentAction(
+				clientCtx.GetFromAddress(),
+				args[0],
+				args[1],
+				data,
+				feePerRun,
+				budget,
+				startTime,
+				startHeight,
+				interval,
+				intervalBlocks,
+				maxRuns,
+			)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagStartTime, "", "Block time of the first run (RFC3339)")
+	cmd.Flags().Int64(FlagStartHeight, 0, "Block height of the first run")
+	cmd.Flags().Duration(FlagInterval, 0, "Time between runs of a time-based schedule")
+	cmd.Flags().Uint64(FlagIntervalBlocks, 0, "Blocks between runs of a height-based schedule")
+	cmd.Flags().Uint64(FlagMaxRuns, 0, "Number of runs after which the schedule completes (0 runs until the budget is exhausted)")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewCancelAgentScheduleCmd returns a CLI command handler for cancelling an agent schedule
+func NewCancelAgentScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-agent-schedule [schedule-id]",
+		Short: "Cancel an agent schedule and refund the rest of its budget",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelAgentSchedule(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// readJSONFile reads and validates an optional JSON file; an empty path yields no data
+func readJSONFile(path string) (json.RawMessage, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if !json.Valid(bz) {
+		return nil, fmt.Errorf("%s does not contain valid JSON", path)
+	}
+
+	return bz, nil
+}
+
+// NewSetAgentPricingCmd returns a CLI command handler for setting the price of calls of an AI agent
+func NewSetAgentPricingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-agent-pricing [agent-id] [default-price]",
+		Short: "Set the price of calls of an AI agent",
+		Long: `Set the price of calls of an AI agent, replacing its price list. Action types
+without a price of their own (--action-price action=amount) cost the default price.
+Each caller gets --free-calls free calls per --period, and callers that made at least
+a number of calls in the period get a volume discount (--volume-discount calls=discount).
+The owner and renters of the agent are not charged.
+
+$ nmxchaind tx deai set-agent-pricing [agent-id] 10unmx --action-price predict=25 --free-calls 5 --period 720h --volume-discount 100=0.1 --volume-discount 1000=0.25`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			defaultPrice, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid default price: %w", err)
+			}
+
+			pricing := types.AgentPricing{
+				AgentID:      args[0],
+				Denom:        defaultPrice.Denom,
+				DefaultPrice: defaultPrice.Amount,
+			}
+
+			actionPrices, _ := cmd.Flags().GetStringArray(FlagActionPrice)
+			for _, actionPrice := range actionPrices {
+				parts := strings.SplitN(actionPrice, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid action price %s, expected action=amount", actionPrice)
+				}
+				price, ok := sdk.NewIntFromString(parts[1])
+				if !ok {
+					return fmt.Errorf("invalid price of action %s: %s", parts[0], parts[1])
+				}
+				pricing.ActionPrices = append(pricing.ActionPrices, types.AgentActionPrice{
+					ActionType: parts[0],
+					Price:      price,
+				})
+			}
+
+			volumeDiscounts, _ := cmd.Flags().GetStringArray(FlagVolumeDiscount)
+			for _, volumeDiscount := range volumeDiscounts {
+				parts := strings.SplitN(volumeDiscount, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid volume discount %s, expected calls=discount", volumeDiscount)
+				}
+				minCalls, err := strconv.ParseUint(parts[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid volume discount calls: %w", err)
+				}
+				discount, err := sdk.NewDecFromStr(parts[1])
+				if err != nil {
+					return fmt.Errorf("invalid volume discount: %w", err)
+				}
+				pricing.VolumeDiscounts = append(pricing.VolumeDiscounts, types.AgentVolumeDiscount{
+					MinCalls: minCalls,
+					Discount: discount,
+				})
+			}
+
+			pricing.FreeCalls, _ = cmd.Flags().GetUint64(FlagFreeCalls)
+			pricing.Period, _ = cmd.Flags().GetDuration(FlagPeriod)
+
+			msg := types.NewMsgSetAgentPricing(clientCtx.GetFromAddress(), pricing)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().StringArray(FlagActionPrice, nil, "Price of an action type, as action=amount (repeatable)")
+	cmd.Flags().Uint64(FlagFreeCalls, 0, "Free calls per caller and period")
+	cmd.Flags().Duration(FlagPeriod, 0, "Period over which calls are counted (0 counts calls forever)")
+	cmd.Flags().StringArray(FlagVolumeDiscount, nil, "Discount for callers with at least a number of calls, as calls=discount (repeatable)")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewDelegateGovVotesCmd returns a CLI command handler for delegating governance voting power to an agent
+func NewDelegateGovVotesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegate-gov-votes [agent-id]",
+		Short: "Delegate your governance voting power to a governance agent",
+		Long: `Delegate your governance voting power to a governance agent, replacing any earlier
+delegation. When a proposal enters its voting period the agent decides on it and its
+decision is cast as your vote. Voting on the proposal yourself before the voting
+period ends overrides the agent's vote.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgDelegateGovVotes(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewUndelegateGovVotesCmd returns a CLI command handler for ending a governance delegation
+func NewUndelegateGovVotesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undelegate-gov-votes",
+		Short: "End the delegation of your governance voting power to an agent",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUndelegateGovVotes(clientCtx.GetFromAddress())
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewRateAgentCmd returns a CLI command handler for rating an action or rental of an AI agent
+func NewRateAgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rate-agent [agent-id] [score]",
+		Short: "Rate an action or rental of an AI agent",
+		Long: `Rate an action you requested from an AI agent (--action-id) or a rental of it you
+took out (--rental-id) with a score from 1 to 5. Each action and rental can be rated
+once. An optional hex SHA-256 hash of an off-chain comment can be attached.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			score, err := strconv.ParseUint(args[1], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid score: %w", err)
+			}
+
+			actionID, _ := cmd.Flags().GetString(FlagActionID)
+			rentalID, _ := cmd.Flags().GetString(FlagRentalID)
+			commentHash, _ := cmd.Flags().GetString(FlagCommentHash)
+
+			msg := types.NewMsgRateAgent(clientCtx.GetFromAddress(), args[0], actionID, rentalID, uint32(score), commentHash)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagActionID, "", "ID of the rated action")
+	cmd.Flags().String(FlagRentalID, "", "ID of the rated rental")
+	cmd.Flags().String(FlagCommentHash, "", "Hex SHA-256 hash of an off-chain comment")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewFractionalizeAgentCmd returns a CLI command handler for splitting the ownership of an agent into shares
+func NewFractionalizeAgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fractionalize-agent [agent-id] [supply] [reserve-price]",
+		Short: "Split the ownership of an AI agent into a fixed supply of shares",
+		Long: `Split the ownership of an AI agent into a fixed supply of the bank denom
+deai/agent/{agent-id}, minted to you. The agent is held by the module for the
+shareholders, who earn its execution fees and rental income pro rata. You stay on as
+curator and may list the agent for rent. A holder of a supermajority of the shares can
+buy out the others at the reserve price per share.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			supply, ok := sdk.NewIntFromString(args[1])
+			if !ok {
+				return fmt.Errorf("invalid supply: %s", args[1])
+			}
+
+			reservePrice, err := sdk.ParseCoinsNormalized(args[2])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgFractionalizeAgent(clientCtx.GetFromAddress(), args[0], supply, reservePrice)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewTransferAgentSharesCmd returns a CLI command handler for transferring shares of an agent
+func NewTransferAgentSharesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-agent-shares [agent-id] [recipient] [amount]",
+		Short: "Transfer shares of a fractionalized AI agent",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			recipient, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			amount, ok := sdk.NewIntFromString(args[2])
+			if !ok {
+				return fmt.Errorf("invalid amount: %s", args[2])
+			}
+
+			msg := types.NewMsgTransferAgentShares(clientCtx.GetFromAddress(), recipient, args[0], amount)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewClaimAgentIncomeCmd returns a CLI command handler for claiming the income of agent shares
+func NewClaimAgentIncomeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-agent-income [agent-id]",
+		Short: "Claim the income your shares of an AI agent earned",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimAgentIncome(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewBuyoutAgentCmd returns a CLI command handler for buying out the other shareholders of an agent
+func NewBuyoutAgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "buyout-agent [agent-id]",
+		Short: "Redeem full ownership of a fractionalized AI agent",
+		Long: `Redeem full ownership of a fractionalized AI agent you hold a supermajority of
+the shares of. Every other holder is paid the reserve price for each of their shares,
+all shares are burned and the agent is transferred to you.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBuyoutAgent(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewCreateSubscriptionPlanCmd returns a CLI command handler for publishing a subscription plan for an agent
+func NewCreateSubscriptionPlanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-subscription-plan [agent-id] [name] [period] [price]",
+		Short: "Publish a subscription plan for an AI agent",
+		Long: `Publish a subscription plan for an AI agent. Subscribers pay the price upfront for
+every period, e.g. 720h, and their calls of the agent within the period are free up
+to the action quota.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			period, err := time.ParseDuration(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid period: %w", err)
+			}
+
+			price, err := sdk.ParseCoinNormalized(args[3])
+			if err != nil {
+				return err
+			}
+
+			actionQuota, _ := cmd.Flags().GetUint64(FlagActionQuota)
+
+			msg := types.NewMsgCreateSubscriptionPlan(clientCtx.GetFromAddress(), args[0], args[1], period, price, actionQuota)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Uint64(FlagActionQuota, 0, "Calls per subscriber and period (0 is unlimited)")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewCloseSubscriptionPlanCmd returns a CLI command handler for closing a subscription plan
+func NewCloseSubscriptionPlanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close-subscription-plan [plan-id]",
+		Short: "Stop a subscription plan from taking new subscribers",
+		Long: `Stop a subscription plan from taking new subscribers. Its subscriptions run until
+the end of their current period and then lapse.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCloseSubscriptionPlan(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewSubscribeAgentCmd returns a CLI command handler for subscribing to a subscription plan
+func NewSubscribeAgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscribe-agent [plan-id] [deposit]",
+		Short: "Subscribe to a subscription plan of an AI agent",
+		Long: `Subscribe to a subscription plan of an AI agent. The deposit pays the first period
+and the rest is kept as a prepaid balance the subscription renews from at the end of
+every period. The subscription lapses when the balance no longer covers the price,
+and whatever is left of the balance is refunded.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return err
+			}
+
+			autoRenew, _ := cmd.Flags().GetBool(FlagAutoRenew)
+
+			msg := types.NewMsgSubscribeAgent(clientCtx.GetFromAddress(), args[0], deposit, autoRenew)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Bool(FlagAutoRenew, true, "Renew the subscription from the prepaid balance at the end of every period")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewFundSubscriptionCmd returns a CLI command handler for topping up the balance of a subscription
+func NewFundSubscriptionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fund-subscription [plan-id] [amount]",
+		Short: "Add to the prepaid balance of your subscription to a plan",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgFundSubscription(clientCtx.GetFromAddress(), args[0], amount)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewCancelSubscriptionCmd returns a CLI command handler for cancelling a subscription
+func NewCancelSubscriptionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-subscription [plan-id]",
+		Short: "Stop the renewal of your subscription to a plan and refund its balance",
+		Long: `Stop the renewal of your subscription to a plan and refund its prepaid balance.
+The subscription keeps covering calls until the end of the paid period.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelSubscription(clientCtx.GetFromAddress(), args[0])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewRegisterEncryptionKeyCmd returns a CLI command handler for registering the key
+// data keys are wrapped for when an account is granted access to encrypted data
+func NewRegisterEncryptionKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-encryption-key [key-file]",
+		Short: "Register the X25519 public key of a key file to be granted encrypted training data",
+		Long: `Register the X25519 public key of a key file created with "deai
+generate-encryption-key". Agent owners wrap the data keys of encrypted training data
+for this key when they grant you access as a trainer or executor.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			key, err := deaiclient.LoadEncryptionKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRegisterEncryptionKey(clientCtx.GetFromAddress(), key.PublicKey[:])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewSetAgentEncryptionKeyCmd returns a CLI command handler for setting the key training
+// data of an agent is encrypted to
+func NewSetAgentEncryptionKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-agent-encryption-key [agent-id] [key-file]",
+		Short: "Set the X25519 public key training data of an agent is encrypted to",
+		Long: `Set the X25519 public key of a key file created with "deai generate-encryption-key"
+as the key training data of an agent is encrypted to. Keep the key file: it is needed
+to grant trainers and executors access to the data.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			key, err := deaiclient.LoadEncryptionKey(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetAgentEncryptionKey(clientCtx.GetFromAddress(), args[0], key.PublicKey[:])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewGrantTrainingDataKeyCmd returns a CLI command handler for granting a trainer or
+// executor access to encrypted training data
+func NewGrantTrainingDataKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant-training-data-key [agent-id] [training-data-id] [grantee]",
+		Short: "Grant a trainer or executor the data key of encrypted training data",
+		Long: `Unwrap the data key of encrypted training data with the agent's key pair in
+--key-file and wrap it for the encryption key the grantee registered. The grantee must
+be an active executor or the trainer of a pending training job on the data.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			grantee, err := sdk.AccAddressFromBech32(args[2])
+			if err != nil {
+				return err
+			}
+
+			keyFile, _ := cmd.Flags().GetString(FlagKeyFile)
+			if keyFile == "" {
+				return fmt.Errorf("--%s is required", FlagKeyFile)
+			}
+			key, err := deaiclient.LoadEncryptionKey(keyFile)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			data, err := queryTrainingData(queryClient, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.EncryptionKey(context.Background(), &types.QueryEncryptionKeyRequest{
+				Address: grantee.String(),
+			})
+			if err != nil {
+				return err
+			}
+
+			wrappedKey, err := deaiclient.RewrapTrainingDataKey(data, key, res.Key.PublicKey)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgGrantTrainingDataKey(clientCtx.GetFromAddress(), data.ID, grantee, wrappedKey)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagKeyFile, "", "Key file of the agent's encryption key")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// NewSetAgentModelCmd returns a CLI command handler for attaching an on-chain model to an AI agent
+func NewSetAgentModelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-agent-model [agent-id] [model-type] [parameters-file]",
+		Short: "Attach a model evaluated on-chain to an AI agent",
+		Long: `Attach a model evaluated inside consensus to an AI agent as the next version of
+its model. The model type is one of mlp, logistic or decision_tree, and the parameters
+file holds the JSON parameters of the model, which must be valid for the type.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			parameters, err := readJSONFile(args[2])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetAgentModel(clientCtx.GetFromAddress(), args[0], args[1], parameters)
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
\ No newline at end of file
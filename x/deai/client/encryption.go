@@ -0,0 +1,220 @@
+// Package client provides helpers for clients of the deai module that must run off
+// chain, such as the encryption of training data.
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+const nonceSize = 24
+
+// EncryptionKey is an X25519 key pair training data is encrypted to
+type EncryptionKey struct {
+	PublicKey  [types.X25519KeySize]byte
+	PrivateKey [types.X25519KeySize]byte
+}
+
+// encryptionKeyFile is the JSON encoding of an EncryptionKey in a key file
+type encryptionKeyFile struct {
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key"`
+}
+
+// GenerateEncryptionKey generates a new X25519 key pair
+func GenerateEncryptionKey() (EncryptionKey, error) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return EncryptionKey{}, err
+	}
+	return EncryptionKey{PublicKey: *pub, PrivateKey: *priv}, nil
+}
+
+// SaveEncryptionKey writes a key pair to a file readable only by its owner
+func SaveEncryptionKey(path string, key EncryptionKey) error {
+	bz, err := json.MarshalIndent(encryptionKeyFile{
+		PublicKey:  hex.EncodeToString(key.PublicKey[:]),
+		PrivateKey: hex.EncodeToString(key.PrivateKey[:]),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bz, 0o600)
+}
+
+// LoadEncryptionKey reads a key pair written by SaveEncryptionKey
+func LoadEncryptionKey(path string) (EncryptionKey, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return EncryptionKey{}, err
+	}
+
+	var file encryptionKeyFile
+	if err := json.Unmarshal(bz, &file); err != nil {
+		return EncryptionKey{}, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+
+	var key EncryptionKey
+	if err := decodeKey(key.PublicKey[:], file.PublicKey); err != nil {
+		return EncryptionKey{}, fmt.Errorf("invalid public key in %s: %w", path, err)
+	}
+	if err := decodeKey(key.PrivateKey[:], file.PrivateKey); err != nil {
+		return EncryptionKey{}, fmt.Errorf("invalid private key in %s: %w", path, err)
+	}
+	return key, nil
+}
+
+func decodeKey(dst []byte, s string) error {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(bz) != len(dst) {
+		return fmt.Errorf("key must be %d bytes", len(dst))
+	}
+	copy(dst, bz)
+	return nil
+}
+
+// EncryptTrainingData encrypts training data with a new data key, and returns the
+// ciphertext and the data key wrapped for the agent's encryption key. Ciphertext
+// submitted inline must be encoded with EncodeInlineCiphertext first.
+func EncryptTrainingData(agentKey []byte, plaintext []byte) (ciphertext []byte, wrappedKey []byte, err error) {
+	var dataKey [types.DataKeySize]byte
+	if _, err := rand.Read(dataKey[:]); err != nil {
+		return nil, nil, err
+	}
+
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, nil, err
+	}
+
+	// The nonce is prepended to the sealed data
+	ciphertext = secretbox.Seal(nonce[:], plaintext, &nonce, &dataKey)
+
+	wrappedKey, err = WrapDataKey(agentKey, dataKey[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return ciphertext, wrappedKey, nil
+}
+
+// EncodeInlineCiphertext encodes ciphertext as a JSON string so that it can be
+// submitted as inline training data
+func EncodeInlineCiphertext(ciphertext []byte) ([]byte, error) {
+	return json.Marshal(ciphertext)
+}
+
+// WrapDataKey wraps a data key for an X25519 public key
+func WrapDataKey(publicKey []byte, dataKey []byte) ([]byte, error) {
+	if err := types.ValidateEncryptionKey(publicKey); err != nil {
+		return nil, err
+	}
+	if len(dataKey) != types.DataKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes", types.DataKeySize)
+	}
+
+	var recipient [types.X25519KeySize]byte
+	copy(recipient[:], publicKey)
+	return box.SealAnonymous(nil, dataKey, &recipient, rand.Reader)
+}
+
+// OpenDataKey unwraps a data key wrapped for a key pair
+func OpenDataKey(wrappedKey []byte, key EncryptionKey) ([]byte, error) {
+	dataKey, ok := box.OpenAnonymous(nil, wrappedKey, &key.PublicKey, &key.PrivateKey)
+	if !ok || len(dataKey) != types.DataKeySize {
+		return nil, fmt.Errorf("the data key was not wrapped for this key")
+	}
+	return dataKey, nil
+}
+
+// RewrapDataKey unwraps a data key with a key pair, such as the agent's, and wraps it
+// for the public key of a recipient granted access to the data
+func RewrapDataKey(wrappedKey []byte, key EncryptionKey, recipient []byte) ([]byte, error) {
+	dataKey, err := OpenDataKey(wrappedKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return WrapDataKey(recipient, dataKey)
+}
+
+// RewrapTrainingDataKey returns the data key of encrypted training data wrapped for a
+// recipient, opening the envelope of the given key pair
+func RewrapTrainingDataKey(data types.AIAgentTrainingData, key EncryptionKey, recipient []byte) ([]byte, error) {
+	envelope, err := trainingDataEnvelope(data, key)
+	if err != nil {
+		return nil, err
+	}
+	return RewrapDataKey(envelope.WrappedKey, key, recipient)
+}
+
+// DecryptTrainingData decrypts the ciphertext of training data with a key pair the
+// data key was wrapped for. The ciphertext is the inline data of the training data,
+// or the payload retrieved from its storage reference if it is stored off chain.
+func DecryptTrainingData(data types.AIAgentTrainingData, ciphertext []byte, key EncryptionKey) ([]byte, error) {
+	envelope, err := trainingDataEnvelope(data, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.StorageRef == nil {
+		if ciphertext == nil {
+			ciphertext = data.Data
+		}
+		var decoded []byte
+		if err := json.Unmarshal(ciphertext, &decoded); err != nil {
+			return nil, fmt.Errorf("invalid inline ciphertext: %w", err)
+		}
+		ciphertext = decoded
+	}
+
+	dataKey, err := OpenDataKey(envelope.WrappedKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptPayload(ciphertext, dataKey)
+}
+
+// DecryptPayload decrypts ciphertext sealed by EncryptTrainingData with its data key
+func DecryptPayload(ciphertext []byte, dataKey []byte) ([]byte, error) {
+	if len(ciphertext) < nonceSize+secretbox.Overhead {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	if len(dataKey) != types.DataKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes", types.DataKeySize)
+	}
+
+	var nonce [nonceSize]byte
+	copy(nonce[:], ciphertext[:nonceSize])
+	var secretKey [types.DataKeySize]byte
+	copy(secretKey[:], dataKey)
+
+	plaintext, ok := secretbox.Open(nil, ciphertext[nonceSize:], &nonce, &secretKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to decrypt the payload")
+	}
+	return plaintext, nil
+}
+
+func trainingDataEnvelope(data types.AIAgentTrainingData, key EncryptionKey) (types.KeyEnvelope, error) {
+	if !data.IsEncrypted() {
+		return types.KeyEnvelope{}, fmt.Errorf("training data %s is not encrypted", data.ID)
+	}
+	if data.Encryption.Scheme != types.EncryptionSchemeX25519 {
+		return types.KeyEnvelope{}, fmt.Errorf("unknown encryption scheme: %s", data.Encryption.Scheme)
+	}
+	envelope, found := data.Encryption.Envelope(key.PublicKey[:])
+	if !found {
+		return types.KeyEnvelope{}, fmt.Errorf("the data key of training data %s was not granted to this key", data.ID)
+	}
+	return envelope, nil
+}
\ No newline at end of file
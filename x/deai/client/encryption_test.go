@@ -0,0 +1,113 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+func TestEncryptTrainingData(t *testing.T) {
+	agentKey, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	trainerKey, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	otherKey, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+
+	plaintext := []byte(`[{"inputs": ["1", "0"], "outputs": ["1"]}]`)
+	ciphertext, wrappedKey, err := EncryptTrainingData(agentKey.PublicKey[:], plaintext)
+	require.NoError(t, err)
+	require.Len(t, wrappedKey, types.WrappedKeySize)
+	require.NoError(t, types.ValidateWrappedKey(wrappedKey))
+
+	inline, err := EncodeInlineCiphertext(ciphertext)
+	require.NoError(t, err)
+	data := types.AIAgentTrainingData{
+		ID:   "data",
+		Data: inline,
+		Encryption: &types.TrainingDataEncryption{
+			Scheme:    types.EncryptionSchemeX25519,
+			Envelopes: []types.KeyEnvelope{{PublicKey: agentKey.PublicKey[:], WrappedKey: wrappedKey}},
+		},
+	}
+	require.NoError(t, data.ValidateEncryption())
+
+	decrypted, err := DecryptTrainingData(data, nil, agentKey)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	// A trainer can only decrypt once the owner has re-wrapped the data key for them
+	_, err = DecryptTrainingData(data, nil, trainerKey)
+	require.Error(t, err)
+
+	rewrapped, err := RewrapTrainingDataKey(data, agentKey, trainerKey.PublicKey[:])
+	require.NoError(t, err)
+	data.Encryption.Envelopes = append(data.Encryption.Envelopes, types.KeyEnvelope{
+		Grantee:    sdk.AccAddress([]byte("trainer_____________")),
+		PublicKey:  trainerKey.PublicKey[:],
+		WrappedKey: rewrapped,
+	})
+	require.NoError(t, data.ValidateEncryption())
+
+	decrypted, err = DecryptTrainingData(data, nil, trainerKey)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	// An envelope only opens with the key it was wrapped for
+	_, err = OpenDataKey(rewrapped, agentKey)
+	require.Error(t, err)
+	_, err = RewrapTrainingDataKey(data, otherKey, otherKey.PublicKey[:])
+	require.Error(t, err)
+}
+
+func TestDecryptPayloadRejectsTampering(t *testing.T) {
+	agentKey, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+
+	ciphertext, wrappedKey, err := EncryptTrainingData(agentKey.PublicKey[:], []byte("secret"))
+	require.NoError(t, err)
+	dataKey, err := OpenDataKey(wrappedKey, agentKey)
+	require.NoError(t, err)
+
+	plaintext, err := DecryptPayload(ciphertext, dataKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), plaintext)
+
+	ciphertext[len(ciphertext)-1] ^= 1
+	_, err = DecryptPayload(ciphertext, dataKey)
+	require.Error(t, err)
+
+	_, err = DecryptPayload(ciphertext[:10], dataKey)
+	require.Error(t, err)
+}
+
+func TestWrapDataKeyValidatesKeys(t *testing.T) {
+	agentKey, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+
+	_, err = WrapDataKey(make([]byte, types.X25519KeySize), make([]byte, types.DataKeySize))
+	require.Error(t, err)
+	_, err = WrapDataKey(agentKey.PublicKey[:16], make([]byte, types.DataKeySize))
+	require.Error(t, err)
+	_, err = WrapDataKey(agentKey.PublicKey[:], make([]byte, 16))
+	require.Error(t, err)
+}
+
+func TestEncryptionKeyFile(t *testing.T) {
+	key, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, SaveEncryptionKey(path, key))
+
+	loaded, err := LoadEncryptionKey(path)
+	require.NoError(t, err)
+	require.Equal(t, key, loaded)
+
+	_, err = LoadEncryptionKey(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// InferenceBackend computes the result of an execution request. Executors only agree,
+// and are only paid, when their backends return byte-identical results for the same
+// request, so implementations must be deterministic.
+type InferenceBackend interface {
+	// Name returns the name the backend is selected by
+	Name() string
+	// Infer computes the result of an execution request
+	Infer(ctx context.Context, request types.ExecutionRequest) (json.RawMessage, error)
+}
+
+// BackendFactory creates an inference backend
+type BackendFactory func() (InferenceBackend, error)
+
+// EchoBackendName is the name of the echo backend
+const EchoBackendName = "echo"
+
+var backends = map[string]BackendFactory{
+	EchoBackendName: func() (InferenceBackend, error) { return EchoBackend{}, nil },
+}
+
+// RegisterBackend makes an inference backend available to the executor command
+func RegisterBackend(name string, factory BackendFactory) {
+	backends[name] = factory
+}
+
+// NewBackend creates the registered inference backend with the given name
+func NewBackend(name string) (InferenceBackend, error) {
+	factory, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown inference backend: %s", name)
+	}
+	return factory()
+}
+
+// BackendNames returns the names of the registered inference backends in sorted order
+func BackendNames() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EchoBackend is a deterministic stub backend that echoes the request back as its
+// result. It allows executors to be run against a local network, and in tests,
+// without any model being available.
+type EchoBackend struct{}
+
+var _ InferenceBackend = EchoBackend{}
+
+// echoResult is the result produced by the echo backend
+type echoResult struct {
+	AgentID    string `json:"agent_id"`
+	ActionType string `json:"action_type"`
+	Input      []byte `json:"input"`
+}
+
+// Name implements InferenceBackend
+func (EchoBackend) Name() string {
+	return EchoBackendName
+}
+
+// Infer implements InferenceBackend
+func (EchoBackend) Infer(_ context.Context, request types.ExecutionRequest) (json.RawMessage, error) {
+	return json.Marshal(echoResult{
+		AgentID:    request.AgentID,
+		ActionType: request.ActionType,
+		Input:      request.Data,
+	})
+}
\ No newline at end of file
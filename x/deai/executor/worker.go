@@ -0,0 +1,149 @@
+package executor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// saltLength is the length of the random salt of each commitment
+const saltLength = 32
+
+// pendingReveal is a committed result that has not been revealed yet
+type pendingReveal struct {
+	Result json.RawMessage
+	Salt   []byte
+}
+
+// Worker polls the chain for pending execution requests, computes their results with an
+// inference backend and submits them with the commit-reveal scheme of the deai module.
+//
+// Committed results are kept in memory until they are revealed. A worker restarted
+// between the commit and the reveal of a request cannot reveal it and will be slashed.
+type Worker struct {
+	clientCtx    client.Context
+	txf          tx.Factory
+	backend      InferenceBackend
+	pollInterval time.Duration
+	logger       log.Logger
+	reveals      map[string]pendingReveal
+}
+
+// NewWorker creates a worker that signs with the from address of the client context
+func NewWorker(clientCtx client.Context, txf tx.Factory, backend InferenceBackend, pollInterval time.Duration, logger log.Logger) *Worker {
+	return &Worker{
+		clientCtx:    clientCtx,
+		txf:          txf,
+		backend:      backend,
+		pollInterval: pollInterval,
+		logger:       logger,
+		reveals:      make(map[string]pendingReveal),
+	}
+}
+
+// Run polls for pending execution requests until the context is cancelled
+func (w *Worker) Run(ctx context.Context) error {
+	w.logger.Info("starting executor", "address", w.clientCtx.GetFromAddress().String(), "backend", w.backend.Name())
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Poll(ctx); err != nil {
+			w.logger.Error("failed to process execution requests", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll commits to the results of requests in their commit phase and reveals the results
+// of requests in their reveal phase. All messages of a poll are broadcast in a single
+// transaction so that they do not compete for the account sequence.
+func (w *Worker) Poll(ctx context.Context) error {
+	queryClient := types.NewQueryClient(w.clientCtx)
+	res, err := queryClient.PendingExecutionRequests(ctx, &types.QueryPendingExecutionRequestsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to query pending execution requests: %w", err)
+	}
+
+	addr := w.clientCtx.GetFromAddress()
+	pending := make(map[string]bool)
+
+	// A message broadcast now is included at the earliest in the next block
+	nextHeight := res.Height + 1
+
+	var msgs []sdk.Msg
+	for _, request := range res.Requests {
+		pending[request.ID] = true
+
+		i := request.FindCommit(addr)
+		_, committed := w.reveals[request.ID]
+		switch {
+		// A commit broadcast by an earlier poll may still wait for inclusion; committing
+		// again would replace the salt of the commitment that lands on chain
+		case i < 0 && !committed && request.InCommitPhase(nextHeight):
+			msg, err := w.commit(ctx, addr, request)
+			if err != nil {
+				w.logger.Error("failed to compute result", "request_id", request.ID, "error", err)
+				continue
+			}
+			msgs = append(msgs, msg)
+
+		case i >= 0 && !request.Commits[i].Revealed && request.InRevealPhase(nextHeight):
+			reveal, ok := w.reveals[request.ID]
+			if !ok {
+				w.logger.Error("no local result for committed request", "request_id", request.ID)
+				continue
+			}
+			msgs = append(msgs, types.NewMsgRevealExecutionResult(addr, request.ID, reveal.Result, reveal.Salt))
+		}
+	}
+
+	// Forget the results of requests that have been finalized
+	for id := range w.reveals {
+		if !pending[id] {
+			delete(w.reveals, id)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	w.logger.Info("submitting execution results", "messages", len(msgs), "height", res.Height)
+	return tx.BroadcastTx(w.clientCtx, w.txf, msgs...)
+}
+
+// commit computes the result of a request and returns the commitment message for it.
+// The result and salt are kept until the reveal phase; Poll commits to each request once.
+func (w *Worker) commit(ctx context.Context, addr sdk.AccAddress, request types.ExecutionRequest) (sdk.Msg, error) {
+	result, err := w.backend.Infer(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	w.reveals[request.ID] = pendingReveal{
+		Result: result,
+		Salt:   salt,
+	}
+
+	return types.NewMsgCommitExecutionResult(addr, request.ID, types.ComputeResultCommitment(addr, salt, result)), nil
+}
\ No newline at end of file
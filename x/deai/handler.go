@@ -42,6 +42,111 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		case *types.MsgCancelMarketListing:
 			res, err := msgServer.CancelMarketListing(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgPlaceBid:
+			res, err := msgServer.PlaceBid(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRegisterExecutor:
+			res, err := msgServer.RegisterExecutor(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgUnregisterExecutor:
+			res, err := msgServer.UnregisterExecutor(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCommitExecutionResult:
+			res, err := msgServer.CommitExecutionResult(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRevealExecutionResult:
+			res, err := msgServer.RevealExecutionResult(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgClaimTrainingJob:
+			res, err := msgServer.ClaimTrainingJob(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSubmitTrainingResult:
+			res, err := msgServer.SubmitTrainingResult(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgReviewTrainingResult:
+			res, err := msgServer.ReviewTrainingResult(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgUpdateAIAgentState:
+			res, err := msgServer.UpdateAIAgentState(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRollbackAgentState:
+			res, err := msgServer.RollbackAgentState(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCreatePipeline:
+			res, err := msgServer.CreatePipeline(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgDeletePipeline:
+			res, err := msgServer.DeletePipeline(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgExecutePipeline:
+			res, err := msgServer.ExecutePipeline(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgFundAgentWallet:
+			res, err := msgServer.FundAgentWallet(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgWithdrawAgentWallet:
+			res, err := msgServer.WithdrawAgentWallet(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetAgentWalletPolicy:
+			res, err := msgServer.SetAgentWalletPolicy(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgScheduleAgentAction:
+			res, err := msgServer.ScheduleAgentAction(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCancelAgentSchedule:
+			res, err := msgServer.CancelAgentSchedule(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetAgentPricing:
+			res, err := msgServer.SetAgentPricing(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgDelegateGovVotes:
+			res, err := msgServer.DelegateGovVotes(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgUndelegateGovVotes:
+			res, err := msgServer.UndelegateGovVotes(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRateAgent:
+			res, err := msgServer.RateAgent(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgFractionalizeAgent:
+			res, err := msgServer.FractionalizeAgent(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgTransferAgentShares:
+			res, err := msgServer.TransferAgentShares(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgClaimAgentIncome:
+			res, err := msgServer.ClaimAgentIncome(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgBuyoutAgent:
+			res, err := msgServer.BuyoutAgent(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCreateSubscriptionPlan:
+			res, err := msgServer.CreateSubscriptionPlan(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCloseSubscriptionPlan:
+			res, err := msgServer.CloseSubscriptionPlan(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSubscribeAgent:
+			res, err := msgServer.SubscribeAgent(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgFundSubscription:
+			res, err := msgServer.FundSubscription(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgCancelSubscription:
+			res, err := msgServer.CancelSubscription(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetAgentEncryptionKey:
+			res, err := msgServer.SetAgentEncryptionKey(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgRegisterEncryptionKey:
+			res, err := msgServer.RegisterEncryptionKey(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgGrantTrainingDataKey:
+			res, err := msgServer.GrantTrainingDataKey(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetAgentModel:
+			res, err := msgServer.SetAgentModel(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
@@ -51,6 +156,12 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 
 // BeginBlocker executes all ABCI BeginBlock logic respective to the deai module.
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
+	// Run the scheduled AI agent actions that are due
+	processScheduledActions(ctx, k)
+
+	// Have governance agents decide on the proposals in their voting period
+	k.DecideAgentGovVotes(ctx)
+
 	// Process any pending AI agent training tasks
 	processPendingTrainingTasks(ctx, k)
 }
@@ -59,6 +170,12 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k keeper.Keeper) []abci.ValidatorUpdate {
 	// Process expired marketplace listings
 	processExpiredListings(ctx, k)
+
+	// Revoke access of renters whose rental period has ended
+	processExpiredRentals(ctx, k)
+
+	// Renew or lapse the subscriptions whose period has ended
+	processDueSubscriptions(ctx, k)
 	
 	// Process AI agent executions
 	processAIAgentExecutions(ctx, k)
@@ -66,71 +183,75 @@ func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k keeper.Keeper) []ab
 	return []abci.ValidatorUpdate{}
 }
 
-// processPendingTrainingTasks processes any pending AI agent training tasks
+// processPendingTrainingTasks expires the training jobs that were not claimed or not
+// submitted before their deadline, refunding their budget
 func processPendingTrainingTasks(ctx sdk.Context, k keeper.Keeper) {
-	// Get all agents in training status
-	agents := k.GetAllAIAgents(ctx)
-	for _, agent := range agents {
-		if agent.Status == types.AIAgentStatusTraining {
-			// In a real implementation, we would check if the training is complete
-			// For now, we'll just set the agent status to active after a delay
-			if ctx.BlockHeight()%10 == 0 { // Simulate training completion every 10 blocks
-				agent.Status = types.AIAgentStatusActive
-				agent.UpdatedAt = ctx.BlockTime()
-				k.SetAIAgent(ctx, agent)
-				
-				// Emit an event
-				ctx.EventManager().EmitEvent(
-					sdk.NewEvent(
-						"agent_training_completed",
-						sdk.NewAttribute("agent_id", agent.ID),
-						sdk.NewAttribute("timestamp", ctx.BlockTime().String()),
-					),
-				)
-			}
+	for _, job := range k.GetExpiredTrainingJobs(ctx) {
+		// Expire in a cached context so a failed refund leaves the job queued for the next block
+		cacheCtx, write := ctx.CacheContext()
+		if _, err := k.ExpireTrainingJob(cacheCtx, job); err != nil {
+			k.Logger(ctx).Error("failed to expire training job", "job_id", job.ID, "error", err)
+			continue
 		}
+		write()
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeTrainingJobExpired,
+				sdk.NewAttribute(types.AttributeKeyJobID, job.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, job.AgentID),
+				sdk.NewAttribute(types.AttributeKeyStatus, job.Status),
+				sdk.NewAttribute(types.AttributeKeyTimestamp, ctx.BlockTime().String()),
+			),
+		)
 	}
 }
 
 // processExpiredListings processes expired marketplace listings
 func processExpiredListings(ctx sdk.Context, k keeper.Keeper) {
-	// Get all marketplace listings
-	listings := k.GetAllAIAgentMarketplaceListings(ctx)
+	// Get the active listings that expired from the status index
+	listings := k.GetExpiredAIAgentMarketplaceListings(ctx)
 	for _, listing := range listings {
-		if listing.Status == "active" && !listing.ExpiresAt.IsZero() && listing.ExpiresAt.Before(ctx.BlockTime()) {
-			// Cancel the listing
-			listing.Status = "expired"
-			k.SetAIAgentMarketplaceListing(ctx, listing)
-			
-			// Update the agent status
-			agent, found := k.GetAIAgent(ctx, listing.AgentID)
-			if found {
-				agent.Status = types.AIAgentStatusActive
-				agent.UpdatedAt = ctx.BlockTime()
-				k.SetAIAgent(ctx, agent)
-				
-				// Emit an event
-				ctx.EventManager().EmitEvent(
-					sdk.NewEvent(
-						"marketplace_listing_expired",
-						sdk.NewAttribute("listing_id", listing.ID),
-						sdk.NewAttribute("agent_id", listing.AgentID),
-						sdk.NewAttribute("timestamp", ctx.BlockTime().String()),
-					),
-				)
-			}
+		// English auctions are settled with their highest bidder at close
+		if listing.ListingType == types.ListingTypeEnglishAuction {
+			closeEnglishAuction(ctx, k, listing)
+			continue
+		}
+
+		// Cancel the listing
+		listing.Status = "expired"
+		k.SetAIAgentMarketplaceListing(ctx, listing)
+
+		// Update the agent status
+		agent, found := k.GetAIAgent(ctx, listing.AgentID)
+		if found {
+			agent.Status = types.AIAgentStatusActive
+			agent.UpdatedAt = ctx.BlockTime()
+			k.SetAIAgent(ctx, agent)
+
+			// Emit an event
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					"marketplace_listing_expired",
+					sdk.NewAttribute("listing_id", listing.ID),
+					sdk.NewAttribute("agent_id", listing.AgentID),
+					sdk.NewAttribute("timestamp", ctx.BlockTime().String()),
+				),
+			)
 		}
 	}
 }
 
-// processAIAgentExecutions processes AI agent executions
+// processAIAgentExecutions finalizes the execution requests whose reveal deadline has
+// been reached, accepting the result a quorum of executors agreed on or refunding the fee
 func processAIAgentExecutions(ctx sdk.Context, k keeper.Keeper) {
-	// In a real implementation, we would process any pending AI agent executions
-	// For now, we'll just simulate the completion of executions
-	
-	// This would typically involve:
-	// 1. Fetching pending executions from the store
-	// 2. Processing them using the AI model
-	// 3. Updating the execution status and result
-	// 4. Distributing fees to the agent owner
+	for _, request := range k.GetDueExecutionRequests(ctx) {
+		// Finalize in a cached context so a failed payout leaves the request queued for the next block
+		cacheCtx, write := ctx.CacheContext()
+		if _, err := k.FinalizeExecutionRequest(cacheCtx, request); err != nil {
+			k.Logger(ctx).Error("failed to finalize execution request", "request_id", request.ID, "error", err)
+			continue
+		}
+		write()
+	}
 }
\ No newline at end of file
@@ -0,0 +1,126 @@
+// Package inference evaluates small AI agent models inside consensus. Models and inputs
+// use sdk.Dec fixed-point arithmetic only, so every validator computes bit-identical
+// outputs, and every multiply-accumulate is charged to the gas meter before it runs.
+package inference
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Model types that can be evaluated on-chain
+const (
+	ModelTypeMLP          = "mlp"
+	ModelTypeLogistic     = "logistic"
+	ModelTypeDecisionTree = "decision_tree"
+)
+
+// Gas costs of model evaluation
+const (
+	GasPerMultiplyAccumulate uint64 = 10
+	GasPerActivation         uint64 = 100
+	GasPerTreeNode           uint64 = 10
+)
+
+// GasMeter is the subset of the SDK gas meter used to charge for evaluation
+type GasMeter interface {
+	ConsumeGas(amount uint64, descriptor string)
+}
+
+// Model is a model that can be evaluated deterministically
+type Model interface {
+	// Validate checks the structure of the model parameters
+	Validate() error
+	// Evaluate computes the model outputs, charging gas before doing the work
+	Evaluate(meter GasMeter, inputs []sdk.Dec) ([]sdk.Dec, error)
+}
+
+// Input is the execution data of an on-chain inference, e.g. {"inputs": ["0.5", "-1.25"]}
+type Input struct {
+	Inputs []sdk.Dec `json:"inputs"`
+}
+
+// Output is the result of an on-chain inference
+type Output struct {
+	Outputs []sdk.Dec `json:"outputs"`
+}
+
+// IsSupportedModelType returns true if models of the type can be evaluated on-chain
+func IsSupportedModelType(modelType string) bool {
+	switch modelType {
+	case ModelTypeMLP, ModelTypeLogistic, ModelTypeDecisionTree:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseModel decodes and validates the parameters of a model of the given type
+func ParseModel(modelType string, parameters json.RawMessage) (Model, error) {
+	var model Model
+	switch modelType {
+	case ModelTypeMLP:
+		model = &MLP{}
+	case ModelTypeLogistic:
+		model = &Logistic{}
+	case ModelTypeDecisionTree:
+		model = &DecisionTree{}
+	default:
+		return nil, fmt.Errorf("model type %q cannot be evaluated on-chain", modelType)
+	}
+
+	if err := json.Unmarshal(parameters, model); err != nil {
+		return nil, fmt.Errorf("invalid %s parameters: %w", modelType, err)
+	}
+	if err := model.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s parameters: %w", modelType, err)
+	}
+
+	return model, nil
+}
+
+// Run evaluates a model on the JSON encoded input and returns the JSON encoded output
+func Run(meter GasMeter, modelType string, parameters json.RawMessage, data json.RawMessage) (json.RawMessage, error) {
+	model, err := ParseModel(modelType, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	var input Input
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("invalid inference input: %w", err)
+	}
+	if err := validateDecs("inputs", input.Inputs); err != nil {
+		return nil, err
+	}
+
+	outputs, err := model.Evaluate(meter, input.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Output{Outputs: outputs})
+}
+
+// guard runs f and turns a panic of the decimal arithmetic, such as an overflow, into an
+// error. Gas must be charged outside of f so that out of gas panics are not swallowed.
+func guard(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("arithmetic error: %v", r)
+		}
+	}()
+	return f()
+}
+
+// validateDecs checks that no value of a decoded vector is missing
+func validateDecs(name string, values []sdk.Dec) error {
+	for i, v := range values {
+		if v.IsNil() {
+			return fmt.Errorf("%s[%d] is missing", name, i)
+		}
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,125 @@
+package inference
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// gasCounter is a gas meter that records the gas consumed
+type gasCounter struct {
+	consumed uint64
+}
+
+func (g *gasCounter) ConsumeGas(amount uint64, _ string) {
+	g.consumed += amount
+}
+
+func TestRunGoldenVectors(t *testing.T) {
+	tests := []struct {
+		name       string
+		modelType  string
+		parameters string
+		input      string
+		output     string
+		gas        uint64
+	}{
+		{
+			name:      "mlp",
+			modelType: ModelTypeMLP,
+			parameters: `{"layers": [{"weights": [["0.5", "-1"], ["1", "1"]], "biases": ["0", "0.1"], "activation": "relu"},
+				{"weights": [["1", "-1"]], "biases": ["0"], "activation": "sigmoid"}]}`,
+			input:  `{"inputs": ["0.5", "-1.25"]}`,
+			output: `{"outputs":["0.817574476193643663"]}`,
+			gas:    360,
+		},
+		{
+			name:       "logistic",
+			modelType:  ModelTypeLogistic,
+			parameters: `{"weights": ["0.8", "-0.3"], "bias": "0.1"}`,
+			input:      `{"inputs": ["0.5", "-1.25"]}`,
+			output:     `{"outputs":["0.705785027837011231"]}`,
+			gas:        120,
+		},
+		{
+			name:      "decision tree left",
+			modelType: ModelTypeDecisionTree,
+			parameters: `{"nodes": [{"feature": 0, "threshold": "0.5", "left": 1, "right": 2},
+				{"leaf": true, "value": ["0"]}, {"leaf": true, "value": ["1"]}]}`,
+			input:  `{"inputs": ["0.5", "-1.25"]}`,
+			output: `{"outputs":["0.000000000000000000"]}`,
+			gas:    20,
+		},
+		{
+			name:      "decision tree right",
+			modelType: ModelTypeDecisionTree,
+			parameters: `{"nodes": [{"feature": 0, "threshold": "0.5", "left": 1, "right": 2},
+				{"leaf": true, "value": ["0"]}, {"leaf": true, "value": ["1"]}]}`,
+			input:  `{"inputs": ["0.500000000000000001", "-1.25"]}`,
+			output: `{"outputs":["1.000000000000000000"]}`,
+			gas:    20,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			meter := &gasCounter{}
+			output, err := Run(meter, tc.modelType, json.RawMessage(tc.parameters), json.RawMessage(tc.input))
+			require.NoError(t, err)
+			require.Equal(t, tc.output, string(output))
+			require.Equal(t, tc.gas, meter.consumed)
+		})
+	}
+}
+
+func TestParseModelErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		modelType  string
+		parameters string
+	}{
+		{"unsupported type", "transformer", `{}`},
+		{"malformed json", ModelTypeLogistic, `{"weights": [`},
+		{"mlp without layers", ModelTypeMLP, `{"layers": []}`},
+		{"mlp bias count", ModelTypeMLP, `{"layers": [{"weights": [["1"]], "biases": []}]}`},
+		{"mlp unknown activation", ModelTypeMLP, `{"layers": [{"weights": [["1"]], "biases": ["0"], "activation": "softmax"}]}`},
+		{"mlp ragged weights", ModelTypeMLP, `{"layers": [{"weights": [["1", "1"], ["1"]], "biases": ["0", "0"]}]}`},
+		{"mlp layer widths", ModelTypeMLP, `{"layers": [{"weights": [["1"], ["1"]], "biases": ["0", "0"]}, {"weights": [["1"]], "biases": ["0"]}]}`},
+		{"mlp missing weight", ModelTypeMLP, `{"layers": [{"weights": [[null]], "biases": ["0"]}]}`},
+		{"logistic without bias", ModelTypeLogistic, `{"weights": ["1"]}`},
+		{"logistic without weights", ModelTypeLogistic, `{"weights": [], "bias": "0"}`},
+		{"tree without nodes", ModelTypeDecisionTree, `{"nodes": []}`},
+		{"tree leaf without value", ModelTypeDecisionTree, `{"nodes": [{"leaf": true}]}`},
+		{"tree without threshold", ModelTypeDecisionTree, `{"nodes": [{"left": 1, "right": 2}, {"leaf": true, "value": ["0"]}, {"leaf": true, "value": ["1"]}]}`},
+		{"tree cycle", ModelTypeDecisionTree, `{"nodes": [{"threshold": "0", "left": 0, "right": 1}, {"leaf": true, "value": ["0"]}]}`},
+		{"tree child out of range", ModelTypeDecisionTree, `{"nodes": [{"threshold": "0", "left": 1, "right": 2}, {"leaf": true, "value": ["0"]}]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseModel(tc.modelType, json.RawMessage(tc.parameters))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	mlp := json.RawMessage(`{"layers": [{"weights": [["1", "1"]], "biases": ["0"]}]}`)
+	tree := json.RawMessage(`{"nodes": [{"feature": 3, "threshold": "0", "left": 1, "right": 2},
+		{"leaf": true, "value": ["0"]}, {"leaf": true, "value": ["1"]}]}`)
+
+	_, err := Run(&gasCounter{}, ModelTypeMLP, mlp, json.RawMessage(`{"inputs": ["1"]}`))
+	require.Error(t, err)
+
+	_, err = Run(&gasCounter{}, ModelTypeMLP, mlp, json.RawMessage(`{"inputs": ["1", null]}`))
+	require.Error(t, err)
+
+	_, err = Run(&gasCounter{}, ModelTypeDecisionTree, tree, json.RawMessage(`{"inputs": ["1"]}`))
+	require.Error(t, err)
+
+	// Overflowing arithmetic is an error rather than a panic
+	huge := json.RawMessage(`{"layers": [{"weights": [["1000000000000000000000000000000000000000"]], "biases": ["0"]}]}`)
+	_, err = Run(&gasCounter{}, ModelTypeMLP, huge, json.RawMessage(`{"inputs": ["1000000000000000000000000000000000000000"]}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package inference
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Activation functions
+const (
+	ActivationLinear  = "linear"
+	ActivationReLU    = "relu"
+	ActivationSigmoid = "sigmoid"
+	ActivationTanh    = "tanh"
+)
+
+// sigmoidSaturation is the magnitude beyond which the sigmoid is 0 or 1 at sdk.Dec precision
+var sigmoidSaturation = sdk.NewDec(42)
+
+// expReductionSteps is the number of squarings undoing the range reduction of exp
+const expReductionSteps = 6
+
+// expTaylorTerms is the number of Taylor series terms of exp after range reduction
+const expTaylorTerms = 16
+
+// isValidActivation returns true if the activation function is known
+func isValidActivation(activation string) bool {
+	switch activation {
+	case "", ActivationLinear, ActivationReLU, ActivationSigmoid, ActivationTanh:
+		return true
+	default:
+		return false
+	}
+}
+
+// activate applies an activation function; an empty activation is linear
+func activate(activation string, x sdk.Dec) sdk.Dec {
+	switch activation {
+	case ActivationReLU:
+		if x.IsNegative() {
+			return sdk.ZeroDec()
+		}
+		return x
+	case ActivationSigmoid:
+		return sigmoid(x)
+	case ActivationTanh:
+		return tanh(x)
+	default:
+		return x
+	}
+}
+
+// exp returns e^x. The argument is reduced to x/2^6 so that the Taylor series converges
+// within sdk.Dec precision, and the result is squared back up. Callers bound |x|.
+func exp(x sdk.Dec) sdk.Dec {
+	r := x.QuoInt64(1 << expReductionSteps)
+
+	sum := sdk.OneDec()
+	term := sdk.OneDec()
+	for i := int64(1); i <= expTaylorTerms; i++ {
+		term = term.Mul(r).QuoInt64(i)
+		sum = sum.Add(term)
+	}
+
+	for i := 0; i < expReductionSteps; i++ {
+		sum = sum.Mul(sum)
+	}
+	return sum
+}
+
+// sigmoid returns 1 / (1 + e^-x), evaluated on the side where e^x does not grow
+func sigmoid(x sdk.Dec) sdk.Dec {
+	if x.GTE(sigmoidSaturation) {
+		return sdk.OneDec()
+	}
+	if x.LTE(sigmoidSaturation.Neg()) {
+		return sdk.ZeroDec()
+	}
+
+	if !x.IsNegative() {
+		return sdk.OneDec().Quo(sdk.OneDec().Add(exp(x.Neg())))
+	}
+	e := exp(x)
+	return e.Quo(sdk.OneDec().Add(e))
+}
+
+// tanh returns the hyperbolic tangent of x as 2 * sigmoid(2x) - 1
+func tanh(x sdk.Dec) sdk.Dec {
+	return sigmoid(x.MulInt64(2)).MulInt64(2).Sub(sdk.OneDec())
+}
\ No newline at end of file
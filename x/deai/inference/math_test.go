@@ -0,0 +1,60 @@
+package inference
+
+import (
+	"math"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivationGoldenVectors(t *testing.T) {
+	tests := []struct {
+		x       string
+		exp     string
+		sigmoid string
+		tanh    string
+	}{
+		{"0", "1.000000000000000000", "0.500000000000000000", "0.000000000000000000"},
+		{"1", "2.718281828459044972", "0.731058578630004880", "0.761594155955764890"},
+		{"-1", "0.367879441171442321", "0.268941421369995120", "-0.761594155955764890"},
+		{"0.5", "1.648721270700127967", "0.622459331201854565", "0.462117157260009760"},
+		{"-10", "0.000045399929762485", "0.000045397868702435", "-0.999999995877692764"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.x, func(t *testing.T) {
+			x := sdk.MustNewDecFromStr(tc.x)
+			require.Equal(t, tc.exp, exp(x).String())
+			require.Equal(t, tc.sigmoid, sigmoid(x).String())
+			require.Equal(t, tc.tanh, tanh(x).String())
+		})
+	}
+}
+
+func TestActivationAccuracy(t *testing.T) {
+	for x := -20.0; x <= 20.0; x += 0.125 {
+		d := sdk.NewDecWithPrec(int64(x*1000), 3)
+		require.InDelta(t, 1/(1+math.Exp(-x)), sigmoid(d).MustFloat64(), 1e-12, "sigmoid(%v)", x)
+		require.InDelta(t, math.Tanh(x), tanh(d).MustFloat64(), 1e-12, "tanh(%v)", x)
+	}
+}
+
+func TestSigmoidSaturation(t *testing.T) {
+	require.Equal(t, sdk.OneDec(), sigmoid(sdk.NewDec(42)))
+	require.Equal(t, sdk.ZeroDec(), sigmoid(sdk.NewDec(-42)))
+	// Saturated inputs are not passed to exp, which would overflow
+	require.Equal(t, sdk.OneDec(), sigmoid(sdk.NewDec(1_000_000)))
+	require.Equal(t, sdk.ZeroDec(), sigmoid(sdk.NewDec(-1_000_000)))
+}
+
+func TestActivate(t *testing.T) {
+	x := sdk.MustNewDecFromStr("-1.5")
+	require.Equal(t, x, activate("", x))
+	require.Equal(t, x, activate(ActivationLinear, x))
+	require.Equal(t, sdk.ZeroDec(), activate(ActivationReLU, x))
+	require.Equal(t, x.Neg(), activate(ActivationReLU, x.Neg()))
+	require.Equal(t, sigmoid(x), activate(ActivationSigmoid, x))
+	require.Equal(t, tanh(x), activate(ActivationTanh, x))
+	require.False(t, isValidActivation("softmax"))
+}
@@ -0,0 +1,225 @@
+package inference
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Layer is a fully connected layer. Weights holds one row of input weights per neuron.
+type Layer struct {
+	Weights    [][]sdk.Dec `json:"weights"`
+	Biases     []sdk.Dec   `json:"biases"`
+	Activation string      `json:"activation,omitempty"`
+}
+
+// MLP is a multi-layer perceptron, e.g.
+//
+//	{"layers": [{"weights": [["0.5", "-1"], ["1", "1"]], "biases": ["0", "0.1"], "activation": "relu"},
+//	            {"weights": [["1", "-1"]], "biases": ["0"], "activation": "sigmoid"}]}
+type MLP struct {
+	Layers []Layer `json:"layers"`
+}
+
+var _ Model = &MLP{}
+
+// Validate implements Model
+func (m MLP) Validate() error {
+	if len(m.Layers) == 0 {
+		return fmt.Errorf("mlp has no layers")
+	}
+
+	width := 0
+	for i, layer := range m.Layers {
+		if len(layer.Weights) == 0 {
+			return fmt.Errorf("layer %d has no neurons", i)
+		}
+		if len(layer.Biases) != len(layer.Weights) {
+			return fmt.Errorf("layer %d has %d biases for %d neurons", i, len(layer.Biases), len(layer.Weights))
+		}
+		if !isValidActivation(layer.Activation) {
+			return fmt.Errorf("layer %d has unknown activation %q", i, layer.Activation)
+		}
+
+		inputs := len(layer.Weights[0])
+		if inputs == 0 {
+			return fmt.Errorf("layer %d has no inputs", i)
+		}
+		if i > 0 && inputs != width {
+			return fmt.Errorf("layer %d takes %d inputs but layer %d has %d outputs", i, inputs, i-1, width)
+		}
+		for j, row := range layer.Weights {
+			if len(row) != inputs {
+				return fmt.Errorf("layer %d neuron %d has %d weights, expected %d", i, j, len(row), inputs)
+			}
+			if err := validateDecs(fmt.Sprintf("layer %d weights", i), row); err != nil {
+				return err
+			}
+		}
+		if err := validateDecs(fmt.Sprintf("layer %d biases", i), layer.Biases); err != nil {
+			return err
+		}
+
+		width = len(layer.Weights)
+	}
+
+	return nil
+}
+
+// Evaluate implements Model
+func (m MLP) Evaluate(meter GasMeter, inputs []sdk.Dec) ([]sdk.Dec, error) {
+	if expected := len(m.Layers[0].Weights[0]); len(inputs) != expected {
+		return nil, fmt.Errorf("mlp expects %d inputs, got %d", expected, len(inputs))
+	}
+
+	values := inputs
+	for _, layer := range m.Layers {
+		meter.ConsumeGas(uint64(len(layer.Weights)*len(values))*GasPerMultiplyAccumulate, "deai inference multiply-accumulate")
+		if layer.Activation != "" && layer.Activation != ActivationLinear {
+			meter.ConsumeGas(uint64(len(layer.Weights))*GasPerActivation, "deai inference activation")
+		}
+
+		in := values
+		out := make([]sdk.Dec, len(layer.Weights))
+		err := guard(func() error {
+			for j, row := range layer.Weights {
+				out[j] = activate(layer.Activation, dot(row, in).Add(layer.Biases[j]))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		values = out
+	}
+
+	return values, nil
+}
+
+// Logistic is a logistic regression model, e.g. {"weights": ["0.8", "-0.3"], "bias": "0.1"}.
+// Its single output is the probability of the positive class.
+type Logistic struct {
+	Weights []sdk.Dec `json:"weights"`
+	Bias    sdk.Dec   `json:"bias"`
+}
+
+var _ Model = &Logistic{}
+
+// Validate implements Model
+func (m Logistic) Validate() error {
+	if len(m.Weights) == 0 {
+		return fmt.Errorf("logistic model has no weights")
+	}
+	if m.Bias.IsNil() {
+		return fmt.Errorf("logistic model has no bias")
+	}
+	return validateDecs("weights", m.Weights)
+}
+
+// Evaluate implements Model
+func (m Logistic) Evaluate(meter GasMeter, inputs []sdk.Dec) ([]sdk.Dec, error) {
+	if len(inputs) != len(m.Weights) {
+		return nil, fmt.Errorf("logistic model expects %d inputs, got %d", len(m.Weights), len(inputs))
+	}
+
+	meter.ConsumeGas(uint64(len(m.Weights))*GasPerMultiplyAccumulate, "deai inference multiply-accumulate")
+	meter.ConsumeGas(GasPerActivation, "deai inference activation")
+
+	var out sdk.Dec
+	err := guard(func() error {
+		out = sigmoid(dot(m.Weights, inputs).Add(m.Bias))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []sdk.Dec{out}, nil
+}
+
+// TreeNode is a node of a decision tree. A split node sends inputs whose feature is at
+// most the threshold to its left child and all others to its right child.
+type TreeNode struct {
+	Leaf      bool      `json:"leaf,omitempty"`
+	Feature   int       `json:"feature,omitempty"`
+	Threshold sdk.Dec   `json:"threshold,omitempty"`
+	Left      int       `json:"left,omitempty"`
+	Right     int       `json:"right,omitempty"`
+	Value     []sdk.Dec `json:"value,omitempty"`
+}
+
+// DecisionTree is a binary decision tree rooted at the first node, e.g.
+//
+//	{"nodes": [{"feature": 0, "threshold": "0.5", "left": 1, "right": 2},
+//	           {"leaf": true, "value": ["0"]}, {"leaf": true, "value": ["1"]}]}
+//
+// Children must come after their parent, which rules out cycles.
+type DecisionTree struct {
+	Nodes []TreeNode `json:"nodes"`
+}
+
+var _ Model = &DecisionTree{}
+
+// Validate implements Model
+func (m DecisionTree) Validate() error {
+	if len(m.Nodes) == 0 {
+		return fmt.Errorf("decision tree has no nodes")
+	}
+
+	for i, node := range m.Nodes {
+		if node.Leaf {
+			if len(node.Value) == 0 {
+				return fmt.Errorf("leaf %d has no value", i)
+			}
+			if err := validateDecs(fmt.Sprintf("leaf %d value", i), node.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if node.Feature < 0 {
+			return fmt.Errorf("node %d has negative feature index", i)
+		}
+		if node.Threshold.IsNil() {
+			return fmt.Errorf("node %d has no threshold", i)
+		}
+		for _, child := range []int{node.Left, node.Right} {
+			if child <= i || child >= len(m.Nodes) {
+				return fmt.Errorf("node %d has invalid child %d", i, child)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Evaluate implements Model
+func (m DecisionTree) Evaluate(meter GasMeter, inputs []sdk.Dec) ([]sdk.Dec, error) {
+	i := 0
+	for {
+		meter.ConsumeGas(GasPerTreeNode, "deai inference tree node")
+
+		node := m.Nodes[i]
+		if node.Leaf {
+			return append([]sdk.Dec(nil), node.Value...), nil
+		}
+
+		if node.Feature >= len(inputs) {
+			return nil, fmt.Errorf("node %d splits on feature %d but only %d inputs were given", i, node.Feature, len(inputs))
+		}
+		if inputs[node.Feature].LTE(node.Threshold) {
+			i = node.Left
+		} else {
+			i = node.Right
+		}
+	}
+}
+
+// dot returns the dot product of two vectors of equal length
+func dot(a, b []sdk.Dec) sdk.Dec {
+	sum := sdk.ZeroDec()
+	for i := range a {
+		sum = sum.Add(a[i].Mul(b[i]))
+	}
+	return sum
+}
\ No newline at end of file
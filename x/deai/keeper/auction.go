@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// PlaceEnglishAuctionBid escrows a bid on an English auction in the module account,
+// refunds the previous highest bidder and extends the auction if the bid arrives
+// within the anti-sniping window
+func (k Keeper) PlaceEnglishAuctionBid(ctx sdk.Context, listing types.AIAgentMarketplaceListing, bidder sdk.AccAddress, amount sdk.Coins) (types.AIAgentMarketplaceListing, error) {
+	minBid := listing.MinNextBid(k.MinBidIncrementRate(ctx))
+	if !amount.IsAllGTE(minBid) {
+		return listing, sdkerrors.Wrapf(types.ErrBidTooLow, "bid must be at least %s", minBid)
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, bidder, types.ModuleName, amount); err != nil {
+		return listing, sdkerrors.Wrap(err, "failed to escrow bid")
+	}
+
+	listing, err := k.RefundHighestBid(ctx, listing)
+	if err != nil {
+		return listing, err
+	}
+
+	listing.HighestBid = amount
+	listing.HighestBidder = bidder
+	listing.BidCount++
+
+	window := time.Duration(k.AuctionExtensionSeconds(ctx)) * time.Second
+	if listing.ExpiresAt.Sub(ctx.BlockTime()) < window {
+		listing.ExpiresAt = ctx.BlockTime().Add(window)
+	}
+
+	k.SetAIAgentMarketplaceListing(ctx, listing)
+	return listing, nil
+}
+
+// RefundHighestBid returns the escrowed highest bid of an auction to its bidder and
+// clears it from the listing. The caller is responsible for storing the listing.
+func (k Keeper) RefundHighestBid(ctx sdk.Context, listing types.AIAgentMarketplaceListing) (types.AIAgentMarketplaceListing, error) {
+	if listing.HighestBidder.Empty() {
+		return listing, nil
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, listing.HighestBidder, listing.HighestBid); err != nil {
+		return listing, sdkerrors.Wrap(err, "failed to refund bid")
+	}
+
+	listing.HighestBid = nil
+	listing.HighestBidder = nil
+	return listing, nil
+}
+
+// CloseEnglishAuction settles an English auction that has reached its end time. If the
+// highest bid meets the reserve price the agent is transferred to the highest bidder and
+// the escrowed bid is paid out; otherwise (including when the seller has transferred the
+// agent NFT away in the meantime) the bid is refunded and the listing expires.
+// It returns the settlement and whether the agent was sold.
+func (k Keeper) CloseEnglishAuction(ctx sdk.Context, listing types.AIAgentMarketplaceListing) (types.MarketplaceSettlement, bool, error) {
+	agent, found := k.GetAIAgent(ctx, listing.AgentID)
+
+	sold := found && agent.Owner.Equals(listing.Seller) &&
+		!listing.HighestBidder.Empty() && listing.HighestBid.IsAllGTE(listing.ReservePrice)
+	if !sold {
+		listing, err := k.RefundHighestBid(ctx, listing)
+		if err != nil {
+			return types.MarketplaceSettlement{}, false, err
+		}
+
+		listing.Status = "expired"
+		k.SetAIAgentMarketplaceListing(ctx, listing)
+
+		if found {
+			agent.Status = types.AIAgentStatusActive
+			agent.UpdatedAt = ctx.BlockTime()
+			k.SetAIAgent(ctx, agent)
+		}
+		return types.MarketplaceSettlement{}, false, nil
+	}
+
+	settlement, err := k.DistributeEscrowedPayment(ctx, listing.Seller, agent, listing.HighestBid)
+	if err != nil {
+		return settlement, false, err
+	}
+
+	agent, err = k.TransferAIAgent(ctx, agent, listing.HighestBidder)
+	if err != nil {
+		return settlement, false, err
+	}
+	agent.Status = types.AIAgentStatusActive
+	agent.UpdatedAt = ctx.BlockTime()
+	listing.Status = "completed"
+
+	k.SetAIAgent(ctx, agent)
+	k.SetAIAgentMarketplaceListing(ctx, listing)
+
+	return settlement, true, nil
+}
\ No newline at end of file
@@ -0,0 +1,173 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetAccountEncryptionKey stores the encryption key of an account
+func (k Keeper) SetAccountEncryptionKey(ctx sdk.Context, key types.AccountEncryptionKey) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAccountEncryptionKeyKey(key.Address), k.cdc.MustMarshal(&key))
+}
+
+// GetAccountEncryptionKey returns the encryption key an account registered
+func (k Keeper) GetAccountEncryptionKey(ctx sdk.Context, addr sdk.AccAddress) (types.AccountEncryptionKey, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAccountEncryptionKeyKey(addr))
+	if value == nil {
+		return types.AccountEncryptionKey{}, false
+	}
+
+	var key types.AccountEncryptionKey
+	k.cdc.MustUnmarshal(value, &key)
+	return key, true
+}
+
+// GetAllAccountEncryptionKeys returns the encryption keys of all accounts
+func (k Keeper) GetAllAccountEncryptionKeys(ctx sdk.Context) []types.AccountEncryptionKey {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AccountEncryptionKeyKey)
+	defer iterator.Close()
+
+	var keys []types.AccountEncryptionKey
+	for ; iterator.Valid(); iterator.Next() {
+		var key types.AccountEncryptionKey
+		k.cdc.MustUnmarshal(iterator.Value(), &key)
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// RegisterEncryptionKey records the X25519 public key data keys are wrapped for when
+// the account is granted access to encrypted training data, replacing any earlier key
+func (k Keeper) RegisterEncryptionKey(ctx sdk.Context, addr sdk.AccAddress, publicKey []byte) (types.AccountEncryptionKey, error) {
+	if err := types.ValidateEncryptionKey(publicKey); err != nil {
+		return types.AccountEncryptionKey{}, sdkerrors.Wrap(types.ErrInvalidEncryption, err.Error())
+	}
+
+	key := types.AccountEncryptionKey{
+		Address:   addr,
+		PublicKey: publicKey,
+		UpdatedAt: ctx.BlockTime(),
+	}
+	k.SetAccountEncryptionKey(ctx, key)
+	return key, nil
+}
+
+// SetAgentEncryptionKey sets the X25519 public key training data of an agent is
+// encrypted to. Data submitted under an earlier key stays wrapped for that key only.
+func (k Keeper) SetAgentEncryptionKey(ctx sdk.Context, owner sdk.AccAddress, agentID string, publicKey []byte) error {
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+	if !k.AgentSeller(ctx, agent).Equals(owner) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can set the agent encryption key")
+	}
+	if err := types.ValidateEncryptionKey(publicKey); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidEncryption, err.Error())
+	}
+
+	agent.EncryptionKey = publicKey
+	agent.UpdatedAt = ctx.BlockTime()
+	k.SetAIAgent(ctx, agent)
+	return nil
+}
+
+// SealTrainingData marks training data as ciphertext whose data key was wrapped for
+// the agent's current encryption key
+func (k Keeper) SealTrainingData(ctx sdk.Context, agent types.AIAgent, data types.AIAgentTrainingData, wrappedKey []byte) (types.AIAgentTrainingData, error) {
+	if len(agent.EncryptionKey) == 0 {
+		return types.AIAgentTrainingData{}, sdkerrors.Wrapf(types.ErrNoEncryptionKey, "agent %s", agent.ID)
+	}
+
+	data.Encryption = &types.TrainingDataEncryption{
+		Scheme: types.EncryptionSchemeX25519,
+		Envelopes: []types.KeyEnvelope{{
+			PublicKey:  agent.EncryptionKey,
+			WrappedKey: wrappedKey,
+			GrantedAt:  ctx.BlockTime(),
+		}},
+	}
+	if err := data.ValidateEncryption(); err != nil {
+		return types.AIAgentTrainingData{}, sdkerrors.Wrap(types.ErrInvalidEncryption, err.Error())
+	}
+	return data, nil
+}
+
+// GrantTrainingDataKey records the data key of encrypted training data re-wrapped by
+// the agent's owner for the registered encryption key of a trainer or executor. The
+// grantee must be an active executor or the trainer of a pending job of the agent.
+func (k Keeper) GrantTrainingDataKey(ctx sdk.Context, owner sdk.AccAddress, dataID string, grantee sdk.AccAddress, wrappedKey []byte) (types.KeyEnvelope, error) {
+	data, found := k.GetAIAgentTrainingData(ctx, dataID)
+	if !found {
+		return types.KeyEnvelope{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("training data not found: %s", dataID))
+	}
+	if !data.IsEncrypted() {
+		return types.KeyEnvelope{}, sdkerrors.Wrap(types.ErrInvalidEncryption, "the training data is not encrypted")
+	}
+	agent, found := k.GetAIAgent(ctx, data.AgentID)
+	if !found {
+		return types.KeyEnvelope{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", data.AgentID))
+	}
+	if !k.AgentSeller(ctx, agent).Equals(owner) {
+		return types.KeyEnvelope{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can grant access to the agent's training data")
+	}
+	if !k.isTrainingDataKeyGrantee(ctx, data, grantee) {
+		return types.KeyEnvelope{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "the grantee is neither an active executor nor a trainer of the agent")
+	}
+	key, found := k.GetAccountEncryptionKey(ctx, grantee)
+	if !found {
+		return types.KeyEnvelope{}, sdkerrors.Wrap(types.ErrNoEncryptionKey, grantee.String())
+	}
+	if err := types.ValidateWrappedKey(wrappedKey); err != nil {
+		return types.KeyEnvelope{}, sdkerrors.Wrap(types.ErrInvalidEncryption, err.Error())
+	}
+
+	envelope := types.KeyEnvelope{
+		Grantee:    grantee,
+		PublicKey:  key.PublicKey,
+		WrappedKey: wrappedKey,
+		GrantedAt:  ctx.BlockTime(),
+	}
+
+	// A new grant replaces the grantee's earlier envelope, e.g. after a key rotation
+	envelopes := []types.KeyEnvelope{}
+	for _, existing := range data.Encryption.Envelopes {
+		if !existing.Grantee.Empty() && existing.Grantee.Equals(grantee) {
+			continue
+		}
+		envelopes = append(envelopes, existing)
+	}
+	data.Encryption.Envelopes = append(envelopes, envelope)
+	k.SetAIAgentTrainingData(ctx, data)
+
+	return envelope, nil
+}
+
+// isTrainingDataKeyGrantee returns true if an account may be granted the data key of
+// training data: it is an active executor of the network or the trainer of a pending
+// training job on the data
+func (k Keeper) isTrainingDataKeyGrantee(ctx sdk.Context, data types.AIAgentTrainingData, grantee sdk.AccAddress) bool {
+	if executor, found := k.GetExecutor(ctx, grantee); found && executor.Status == types.ExecutorStatusActive {
+		return true
+	}
+
+	for _, job := range k.GetTrainingJobs(ctx, data.AgentID, "") {
+		if !job.IsPending() || !job.Trainer.Equals(grantee) {
+			continue
+		}
+		for _, ref := range job.DatasetRefs {
+			if ref == data.ID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
\ No newline at end of file
@@ -0,0 +1,495 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetExecutor stores an executor
+func (k Keeper) SetExecutor(ctx sdk.Context, executor types.Executor) {
+	store := ctx.KVStore(k.storeKey)
+	value := k.cdc.MustMarshal(&executor)
+	store.Set(types.GetExecutorKey(executor.Address), value)
+}
+
+// GetExecutor returns an executor by address
+func (k Keeper) GetExecutor(ctx sdk.Context, addr sdk.AccAddress) (types.Executor, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetExecutorKey(addr))
+	if value == nil {
+		return types.Executor{}, false
+	}
+
+	var executor types.Executor
+	k.cdc.MustUnmarshal(value, &executor)
+	return executor, true
+}
+
+// GetAllExecutors returns all executors
+func (k Keeper) GetAllExecutors(ctx sdk.Context) []types.Executor {
+	var executors []types.Executor
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ExecutorKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var executor types.Executor
+		k.cdc.MustUnmarshal(iterator.Value(), &executor)
+		executors = append(executors, executor)
+	}
+
+	return executors
+}
+
+// RegisterExecutor escrows a bond in the module account and registers the sender as an
+// executor. Registering again tops up the bond and reactivates an executor whose bond
+// had been slashed below the minimum.
+func (k Keeper) RegisterExecutor(ctx sdk.Context, addr sdk.AccAddress, bond sdk.Coins) (types.Executor, error) {
+	executor, found := k.GetExecutor(ctx, addr)
+	if !found {
+		executor = types.Executor{
+			Address:      addr,
+			Bond:         sdk.NewCoins(),
+			RegisteredAt: ctx.BlockTime(),
+		}
+	}
+
+	minBond := sdk.NewCoins(k.MinExecutorBond(ctx))
+	total := executor.Bond.Add(bond...)
+	if !total.IsAllGTE(minBond) {
+		return executor, sdkerrors.Wrapf(types.ErrInsufficientDeposit, "executor bond must be at least %s", minBond)
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, bond); err != nil {
+		return executor, sdkerrors.Wrap(err, "failed to escrow executor bond")
+	}
+
+	executor.Bond = total
+	executor.Status = types.ExecutorStatusActive
+	k.SetExecutor(ctx, executor)
+
+	return executor, nil
+}
+
+// UnregisterExecutor removes an executor and returns its bond. Executors with
+// commitments on unresolved requests cannot leave, as they may still be slashed.
+func (k Keeper) UnregisterExecutor(ctx sdk.Context, addr sdk.AccAddress) (sdk.Coins, error) {
+	executor, found := k.GetExecutor(ctx, addr)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrExecutorNotFound, addr.String())
+	}
+
+	if executor.PendingCommits > 0 {
+		return nil, sdkerrors.Wrapf(types.ErrExecutorBusy, "%d unresolved commitments", executor.PendingCommits)
+	}
+
+	if !executor.Bond.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, executor.Bond); err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to return executor bond")
+		}
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetExecutorKey(addr))
+
+	return executor.Bond, nil
+}
+
+// SetExecutionRequest stores an execution request
+func (k Keeper) SetExecutionRequest(ctx sdk.Context, request types.ExecutionRequest) {
+	store := ctx.KVStore(k.storeKey)
+	value := k.cdc.MustMarshal(&request)
+	store.Set(types.GetExecutionRequestKey(request.ID), value)
+}
+
+// GetExecutionRequest returns an execution request by ID
+func (k Keeper) GetExecutionRequest(ctx sdk.Context, id string) (types.ExecutionRequest, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetExecutionRequestKey(id))
+	if value == nil {
+		return types.ExecutionRequest{}, false
+	}
+
+	var request types.ExecutionRequest
+	k.cdc.MustUnmarshal(value, &request)
+	return request, true
+}
+
+// GetAllExecutionRequests returns all execution requests
+func (k Keeper) GetAllExecutionRequests(ctx sdk.Context) []types.ExecutionRequest {
+	var requests []types.ExecutionRequest
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ExecutionRequestKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var request types.ExecutionRequest
+		k.cdc.MustUnmarshal(iterator.Value(), &request)
+		requests = append(requests, request)
+	}
+
+	return requests
+}
+
+// GetPendingExecutionRequests returns the unresolved execution requests in reveal
+// deadline order. Only unresolved requests are kept in the reveal queue.
+func (k Keeper) GetPendingExecutionRequests(ctx sdk.Context) []types.ExecutionRequest {
+	return k.getQueuedExecutionRequests(ctx, sdk.PrefixEndBytes(types.ExecutionRevealQueueKey))
+}
+
+// GetDueExecutionRequests returns the unresolved execution requests whose reveal
+// deadline is the current block or has passed
+func (k Keeper) GetDueExecutionRequests(ctx sdk.Context) []types.ExecutionRequest {
+	end := sdk.PrefixEndBytes(types.GetExecutionRevealQueueHeightPrefix(ctx.BlockHeight()))
+	return k.getQueuedExecutionRequests(ctx, end)
+}
+
+// getQueuedExecutionRequests resolves the requests in the reveal queue up to end
+func (k Keeper) getQueuedExecutionRequests(ctx sdk.Context, end []byte) []types.ExecutionRequest {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.ExecutionRevealQueueKey, end)
+	defer iterator.Close()
+
+	var requests []types.ExecutionRequest
+	for ; iterator.Valid(); iterator.Next() {
+		request, found := k.GetExecutionRequest(ctx, string(iterator.Value()))
+		if found && request.Status == types.ExecutionStatusPending {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+// insertExecutionRevealQueue adds a request to the reveal queue keyed by its reveal deadline
+func (k Keeper) insertExecutionRevealQueue(ctx sdk.Context, request types.ExecutionRequest) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetExecutionRevealQueueKey(request.RevealDeadline, request.ID), []byte(request.ID))
+}
+
+// RequestAIAgentExecution escrows the execution fee in the module account and records
+// a pending action together with the execution request that executors pick up. The
+// request shares its ID with the action. Authorization of the requester is the
+// responsibility of the caller.
+func (k Keeper) RequestAIAgentExecution(ctx sdk.Context, agent types.AIAgent, requester sdk.AccAddress, actionType string, data json.RawMessage, fee sdk.Coins) (types.ExecutionRequest, error) {
+	actionID := fmt.Sprintf("%s-%s-%d", agent.ID, actionType, k.nextSequence(ctx))
+
+	if !fee.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, requester, types.ModuleName, fee); err != nil {
+			return types.ExecutionRequest{}, sdkerrors.Wrap(err, "failed to collect fee")
+		}
+	}
+
+	action := types.AIAgentAction{
+		ID:         actionID,
+		AgentID:    agent.ID,
+		ActionType: actionType,
+		Timestamp:  ctx.BlockTime(),
+		Data:       data,
+		Status:     types.ExecutionStatusPending,
+		Requester:  requester,
+		Fee:        fee,
+	}
+
+	commitDeadline := ctx.BlockHeight() + int64(k.ExecutionCommitBlocks(ctx))
+	request := types.ExecutionRequest{
+		ID:             actionID,
+		AgentID:        agent.ID,
+		Requester:      requester,
+		ActionType:     actionType,
+		Data:           data,
+		Fee:            fee,
+		Status:         types.ExecutionStatusPending,
+		CommitDeadline: commitDeadline,
+		RevealDeadline: commitDeadline + int64(k.ExecutionRevealBlocks(ctx)),
+		CreatedAt:      ctx.BlockTime(),
+	}
+
+	k.SetAIAgentAction(ctx, action)
+	k.SetExecutionRequest(ctx, request)
+	k.insertExecutionRevealQueue(ctx, request)
+
+	return request, nil
+}
+
+// CommitExecutionResult records an executor's commitment to the result of a request
+func (k Keeper) CommitExecutionResult(ctx sdk.Context, addr sdk.AccAddress, requestID string, commitment []byte) error {
+	executor, found := k.GetExecutor(ctx, addr)
+	if !found {
+		return sdkerrors.Wrap(types.ErrExecutorNotFound, addr.String())
+	}
+	if executor.Status != types.ExecutorStatusActive {
+		return sdkerrors.Wrap(types.ErrExecutorNotActive, addr.String())
+	}
+
+	request, found := k.GetExecutionRequest(ctx, requestID)
+	if !found {
+		return sdkerrors.Wrap(types.ErrExecutionRequestNotFound, requestID)
+	}
+	if !request.InCommitPhase(ctx.BlockHeight()) {
+		return sdkerrors.Wrapf(types.ErrInvalidExecutionPhase, "commitments for %s closed at height %d", requestID, request.CommitDeadline)
+	}
+	if request.FindCommit(addr) >= 0 {
+		return sdkerrors.Wrap(types.ErrAlreadyCommitted, requestID)
+	}
+
+	request.Commits = append(request.Commits, types.ExecutionCommit{
+		Executor:   addr,
+		Commitment: commitment,
+	})
+	executor.PendingCommits++
+
+	k.SetExecutionRequest(ctx, request)
+	k.SetExecutor(ctx, executor)
+
+	return nil
+}
+
+// RevealExecutionResult checks a revealed result against the executor's commitment and
+// records it. Once every committed executor has revealed, the request is finalized
+// without waiting for the reveal deadline.
+func (k Keeper) RevealExecutionResult(ctx sdk.Context, addr sdk.AccAddress, requestID string, result json.RawMessage, salt []byte) (types.ExecutionRequest, error) {
+	request, found := k.GetExecutionRequest(ctx, requestID)
+	if !found {
+		return request, sdkerrors.Wrap(types.ErrExecutionRequestNotFound, requestID)
+	}
+	if !request.InRevealPhase(ctx.BlockHeight()) {
+		return request, sdkerrors.Wrapf(types.ErrInvalidExecutionPhase, "reveals for %s are accepted from height %d to %d", requestID, request.CommitDeadline+1, request.RevealDeadline)
+	}
+
+	i := request.FindCommit(addr)
+	if i < 0 {
+		return request, sdkerrors.Wrapf(types.ErrExecutorNotFound, "%s did not commit to %s", addr, requestID)
+	}
+	if request.Commits[i].Revealed {
+		return request, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "result for %s already revealed", requestID)
+	}
+	if !bytes.Equal(types.ComputeResultCommitment(addr, salt, result), request.Commits[i].Commitment) {
+		return request, sdkerrors.Wrap(types.ErrCommitmentMismatch, requestID)
+	}
+
+	request.Commits[i].Revealed = true
+	request.Commits[i].Result = result
+	k.SetExecutionRequest(ctx, request)
+
+	if request.AllRevealed() {
+		return k.FinalizeExecutionRequest(ctx, request)
+	}
+	return request, nil
+}
+
+// FinalizeExecutionRequest resolves an execution request. If at least ExecutionQuorum
+// executors revealed the same result, and no other result has as many reveals, the
+// result is accepted: the agreeing executors share ExecutorFeeRate of the escrowed fee,
+// the agent owner receives the remainder, and executors that revealed a different
+// result are slashed, and the messages the result asks the agent to send from its
+// wallet are sent. Otherwise the request fails and the fee is refunded. Executors that
+// committed but never revealed are slashed in both cases.
+func (k Keeper) FinalizeExecutionRequest(ctx sdk.Context, request types.ExecutionRequest) (types.ExecutionRequest, error) {
+	winner, agreeing := tallyExecutionResults(request.Commits, k.ExecutionQuorum(ctx))
+
+	var executorReward, ownerProceeds sdk.Coins
+	if winner != nil {
+		var err error
+		executorReward, ownerProceeds, err = k.payExecutionFee(ctx, request, agreeing)
+		if err != nil {
+			return request, err
+		}
+		request.Status = types.ExecutionStatusCompleted
+		request.Result = winner
+	} else {
+		if !request.Fee.IsZero() {
+			if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, request.Requester, request.Fee); err != nil {
+				return request, sdkerrors.Wrap(err, "failed to refund execution fee")
+			}
+		}
+		request.Status = types.ExecutionStatusFailed
+	}
+
+	for _, commit := range request.Commits {
+		executor, found := k.GetExecutor(ctx, commit.Executor)
+		if !found {
+			continue
+		}
+		if executor.PendingCommits > 0 {
+			executor.PendingCommits--
+		}
+
+		// Dissenting reveals can only be judged against an accepted result
+		dissented := winner != nil && commit.Revealed && !bytes.Equal(commit.Result, winner)
+		switch {
+		case !commit.Revealed || dissented:
+			var err error
+			executor, err = k.slashExecutor(ctx, executor, request.ID)
+			if err != nil {
+				return request, err
+			}
+		case winner != nil:
+			executor.Completed++
+		}
+		k.SetExecutor(ctx, executor)
+	}
+
+	action, found := k.GetAIAgentAction(ctx, request.ID)
+	if found {
+		action.Status = request.Status
+		action.Result = request.Result
+		k.SetAIAgentAction(ctx, action)
+	}
+
+	if winner != nil {
+		if state, found := k.GetAIAgentState(ctx, request.AgentID); found {
+			state.UpdatedAt = ctx.BlockTime()
+			k.SetAIAgentState(ctx, state)
+		}
+		// Governance decisions cast votes; they never spend from the agent wallet
+		if request.ActionType != types.AIAgentActionTypeGovVote {
+			k.dispatchAgentWalletMsgs(ctx, request.AgentID, request.ID, request.Requester, winner)
+		}
+	}
+	k.completeAgentGovVote(ctx, request.ID, winner)
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetExecutionRevealQueueKey(request.RevealDeadline, request.ID))
+	k.SetExecutionRequest(ctx, request)
+
+	if winner != nil {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeExecutionCompleted,
+				sdk.NewAttribute(types.AttributeKeyRequestID, request.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, request.AgentID),
+				sdk.NewAttribute(types.AttributeKeyExecutors, joinAddresses(agreeing)),
+				sdk.NewAttribute(types.AttributeKeyExecutorReward, executorReward.String()),
+				sdk.NewAttribute(types.AttributeKeyOwnerProceeds, ownerProceeds.String()),
+			),
+		)
+	} else {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeExecutionFailed,
+				sdk.NewAttribute(types.AttributeKeyRequestID, request.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, request.AgentID),
+				sdk.NewAttribute(types.AttributeKeyFee, request.Fee.String()),
+			),
+		)
+	}
+
+	return request, nil
+}
+
+// payExecutionFee splits the escrowed fee of an accepted request between the agreeing
+// executors and the agent owner, or its shareholders if the agent is fractionalized.
+// The fee is refunded to the requester if the agent no longer exists. It returns the
+// reward paid to each executor and the owner proceeds.
+func (k Keeper) payExecutionFee(ctx sdk.Context, request types.ExecutionRequest, executors []sdk.AccAddress) (sdk.Coins, sdk.Coins, error) {
+	if request.Fee.IsZero() {
+		return sdk.NewCoins(), sdk.NewCoins(), nil
+	}
+
+	executorShare := sdk.NewDecCoinsFromCoins(mulCoinsTruncate(request.Fee, k.ExecutorFeeRate(ctx))...)
+	reward, _ := executorShare.QuoDecTruncate(sdk.NewDec(int64(len(executors)))).TruncateDecimal()
+
+	remainder := request.Fee
+	if !reward.IsZero() {
+		for _, executor := range executors {
+			if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, executor, reward); err != nil {
+				return nil, nil, sdkerrors.Wrap(err, "failed to pay executor")
+			}
+			remainder = remainder.Sub(reward...)
+		}
+	}
+
+	payee := request.Requester
+	if agent, found := k.GetAIAgent(ctx, request.AgentID); found {
+		payee = agent.Owner
+	}
+	if err := k.payAgentIncome(ctx, request.AgentID, payee, remainder); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "failed to pay agent owner")
+	}
+
+	return reward, remainder, nil
+}
+
+// slashExecutor burns ExecutorSlashRate of an executor's bond into the community pool
+// and deactivates the executor if its bond falls below the minimum. The caller is
+// responsible for storing the executor.
+func (k Keeper) slashExecutor(ctx sdk.Context, executor types.Executor, requestID string) (types.Executor, error) {
+	amount := mulCoinsTruncate(executor.Bond, k.ExecutorSlashRate(ctx))
+	if !amount.IsZero() {
+		moduleAddr := k.accountKeeper.GetModuleAddress(types.ModuleName)
+		if err := k.distrKeeper.FundCommunityPool(ctx, amount, moduleAddr); err != nil {
+			return executor, sdkerrors.Wrap(err, "failed to slash executor")
+		}
+		executor.Bond = executor.Bond.Sub(amount...)
+	}
+
+	executor.Slashed++
+	if !executor.Bond.IsAllGTE(sdk.NewCoins(k.MinExecutorBond(ctx))) {
+		executor.Status = types.ExecutorStatusInactive
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeExecutorSlashed,
+			sdk.NewAttribute(types.AttributeKeyExecutor, executor.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyRequestID, requestID),
+			sdk.NewAttribute(types.AttributeKeyAmount, amount.String()),
+			sdk.NewAttribute(types.AttributeKeyStatus, executor.Status),
+		),
+	)
+
+	return executor, nil
+}
+
+// tallyExecutionResults returns the result revealed by the largest group of executors,
+// and that group, if the group reaches the quorum and is strictly larger than any other
+func tallyExecutionResults(commits []types.ExecutionCommit, quorum uint64) (json.RawMessage, []sdk.AccAddress) {
+	groups := make(map[[sha256.Size]byte][]sdk.AccAddress)
+	results := make(map[[sha256.Size]byte]json.RawMessage)
+	var order [][sha256.Size]byte
+	for _, commit := range commits {
+		if !commit.Revealed {
+			continue
+		}
+		h := sha256.Sum256(commit.Result)
+		if _, ok := groups[h]; !ok {
+			order = append(order, h)
+			results[h] = commit.Result
+		}
+		groups[h] = append(groups[h], commit.Executor)
+	}
+
+	var best [sha256.Size]byte
+	bestCount, runnerUp := 0, 0
+	for _, h := range order {
+		switch count := len(groups[h]); {
+		case count > bestCount:
+			runnerUp = bestCount
+			best, bestCount = h, count
+		case count > runnerUp:
+			runnerUp = count
+		}
+	}
+
+	if bestCount == 0 || uint64(bestCount) < quorum || bestCount == runnerUp {
+		return nil, nil
+	}
+	return results[best], groups[best]
+}
+
+// joinAddresses formats addresses as a comma separated list for event attributes
+func joinAddresses(addrs []sdk.AccAddress) string {
+	strs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		strs[i] = addr.String()
+	}
+	return strings.Join(strs, ",")
+}
\ No newline at end of file
@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+func TestTallyExecutionResults(t *testing.T) {
+	executors := make([]sdk.AccAddress, 5)
+	for i := range executors {
+		executors[i] = sdk.AccAddress([]byte{byte(i + 1), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19})
+	}
+	reveal := func(executor int, result string) types.ExecutionCommit {
+		return types.ExecutionCommit{Executor: executors[executor], Revealed: true, Result: json.RawMessage(result)}
+	}
+
+	tests := []struct {
+		name     string
+		commits  []types.ExecutionCommit
+		quorum   uint64
+		result   string
+		agreeing []sdk.AccAddress
+	}{
+		{
+			name:     "unanimous",
+			commits:  []types.ExecutionCommit{reveal(0, `{"y":1}`), reveal(1, `{"y":1}`)},
+			quorum:   2,
+			result:   `{"y":1}`,
+			agreeing: executors[:2],
+		},
+		{
+			name:     "majority",
+			commits:  []types.ExecutionCommit{reveal(0, `{"y":1}`), reveal(1, `{"y":2}`), reveal(2, `{"y":2}`)},
+			quorum:   2,
+			result:   `{"y":2}`,
+			agreeing: []sdk.AccAddress{executors[1], executors[2]},
+		},
+		{
+			name:    "below quorum",
+			commits: []types.ExecutionCommit{reveal(0, `{"y":1}`), reveal(1, `{"y":1}`), reveal(2, `{"y":2}`)},
+			quorum:  3,
+		},
+		{
+			name:    "tie",
+			commits: []types.ExecutionCommit{reveal(0, `{"y":1}`), reveal(1, `{"y":1}`), reveal(2, `{"y":2}`), reveal(3, `{"y":2}`)},
+			quorum:  2,
+		},
+		{
+			name: "unrevealed commits do not count",
+			commits: []types.ExecutionCommit{
+				reveal(0, `{"y":1}`),
+				{Executor: executors[1], Commitment: []byte{1}},
+				{Executor: executors[2], Commitment: []byte{2}},
+			},
+			quorum: 2,
+		},
+		{
+			name:     "results are compared byte for byte",
+			commits:  []types.ExecutionCommit{reveal(0, `{"y":1}`), reveal(1, `{"y": 1}`), reveal(2, `{"y":1}`)},
+			quorum:   2,
+			result:   `{"y":1}`,
+			agreeing: []sdk.AccAddress{executors[0], executors[2]},
+		},
+		{
+			name:   "no reveals",
+			quorum: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, agreeing := tallyExecutionResults(tc.commits, tc.quorum)
+			if tc.result == "" {
+				require.Nil(t, result)
+				require.Nil(t, agreeing)
+				return
+			}
+			require.Equal(t, tc.result, string(result))
+			require.Equal(t, tc.agreeing, agreeing)
+		})
+	}
+}
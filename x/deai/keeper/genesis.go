@@ -7,8 +7,23 @@ import (
 
 // InitGenesis initializes the deai module's state from a provided genesis state.
 func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) []abci.ValidatorUpdate {
-	// Set all the agents
+	if err := k.EnsureAgentNFTClass(ctx); err != nil {
+		panic(err)
+	}
+
+	// Set all the agents, minting an NFT for agents that are not represented by one yet
+	// and deriving the wallet of agents exported before they had one
 	for _, agent := range genState.Agents {
+		if agent.Wallet.Empty() {
+			agent.Wallet = types.AgentWalletAddress(agent.ID)
+		}
+		if agent.NFTID == "" || !k.nftKeeper.HasNFT(ctx, types.AgentNFTClassID, agent.NFTID) {
+			var err error
+			agent, err = k.MintAIAgentNFT(ctx, agent)
+			if err != nil {
+				panic(err)
+			}
+		}
 		k.SetAIAgent(ctx, agent)
 	}
 
@@ -17,9 +32,88 @@ func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) []abci
 		k.SetAIAgentModel(ctx, model)
 	}
 
-	// Set all the states
+	// Set all the pipelines
+	for _, pipeline := range genState.Pipelines {
+		k.SetPipeline(ctx, pipeline)
+	}
+
+	// Set all the agent wallet policies
+	for _, policy := range genState.WalletPolicies {
+		k.SetAgentWalletPolicy(ctx, policy)
+	}
+
+	// Set all the agent schedules, re-queueing the active ones for their next run
+	for _, schedule := range genState.Schedules {
+		k.SetAgentSchedule(ctx, schedule)
+		if schedule.Status == types.AgentScheduleStatusActive {
+			k.insertAgentScheduleQueue(ctx, schedule)
+		}
+	}
+
+	// Set all the agent pricings
+	for _, pricing := range genState.Pricings {
+		k.SetAgentPricing(ctx, pricing)
+	}
+
+	// Set all the governance delegations
+	for _, delegation := range genState.GovDelegations {
+		k.SetGovDelegation(ctx, delegation)
+	}
+
+	// Set all the governance agent votes, indexing the pending ones by action
+	for _, vote := range genState.AgentGovVotes {
+		if vote.Status == types.AgentGovVoteStatusPending {
+			k.setPendingAgentGovVote(ctx, vote)
+			continue
+		}
+		k.SetAgentGovVote(ctx, vote)
+	}
+
+	// Set all the agent ratings and the reputations aggregated from them
+	for _, rating := range genState.AgentRatings {
+		k.SetAgentRating(ctx, rating)
+	}
+	for _, reputation := range genState.AgentReputations {
+		k.SetAgentReputation(ctx, reputation)
+	}
+	for _, reputation := range genState.CreatorReputations {
+		k.SetCreatorReputation(ctx, reputation)
+	}
+
+	// Set all the fractionalized agents and the income records of their shareholders;
+	// the share balances and disabled sends are part of the bank genesis
+	for _, shares := range genState.AgentShares {
+		k.SetAgentShares(ctx, shares)
+	}
+	for _, holder := range genState.AgentShareholders {
+		k.SetAgentShareholder(ctx, holder)
+	}
+
+	// Set all the subscription plans and subscriptions, queueing the subscriptions for renewal
+	for _, plan := range genState.SubscriptionPlans {
+		k.SetSubscriptionPlan(ctx, plan)
+	}
+	for _, subscription := range genState.AgentSubscriptions {
+		k.SetAgentSubscription(ctx, subscription)
+		k.insertAgentSubscriptionQueue(ctx, subscription)
+	}
+
+	// Set all the registered encryption keys
+	for _, key := range genState.EncryptionKeys {
+		k.SetAccountEncryptionKey(ctx, key)
+	}
+
+	// Set the state history, then the current states; states exported before they were
+	// versioned start their history at version 1
+	for _, state := range genState.StateHistory {
+		k.setAIAgentStateVersion(ctx, state)
+	}
 	for _, state := range genState.States {
-		k.SetAIAgentState(ctx, state)
+		if state.Version == 0 {
+			k.SetAIAgentState(ctx, state)
+		} else {
+			k.setAIAgentStateRecord(ctx, state)
+		}
 	}
 
 	// Set all the actions
@@ -37,6 +131,40 @@ func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) []abci
 		k.SetAIAgentMarketplaceListing(ctx, listing)
 	}
 
+	// Set all the rentals, re-queueing the active ones for expiry
+	for _, rental := range genState.Rentals {
+		k.SetAIAgentRental(ctx, rental)
+		if rental.Status == types.AIAgentRentalStatusActive {
+			k.insertRentalExpiryQueue(ctx, rental)
+		}
+	}
+
+	// Set all the executors
+	for _, executor := range genState.Executors {
+		k.SetExecutor(ctx, executor)
+	}
+
+	// Set all the execution requests, re-queueing the unresolved ones for finalization
+	for _, request := range genState.ExecutionRequests {
+		k.SetExecutionRequest(ctx, request)
+		if request.Status == types.ExecutionStatusPending {
+			k.insertExecutionRevealQueue(ctx, request)
+		}
+	}
+
+	// Set all the training jobs, re-queueing the open and claimed ones for timeout
+	for _, job := range genState.TrainingJobs {
+		k.SetTrainingJob(ctx, job)
+		if job.Status == types.TrainingJobStatusOpen || job.Status == types.TrainingJobStatusClaimed {
+			k.insertTrainingJobQueue(ctx, job)
+		}
+	}
+
+	// Continue the module sequence of the exported chain
+	if genState.NextSequence != 0 {
+		k.SetNextSequence(ctx, genState.NextSequence)
+	}
+
 	// Set module parameters
 	k.SetParams(ctx, genState.Params)
 
@@ -52,6 +180,26 @@ func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
 		Actions:             k.GetAllAIAgentActions(ctx),
 		TrainingData:        k.GetAllAIAgentTrainingData(ctx),
 		MarketplaceListings: k.GetAllAIAgentMarketplaceListings(ctx),
+		Rentals:             k.GetAllAIAgentRentals(ctx),
+		Executors:           k.GetAllExecutors(ctx),
+		ExecutionRequests:   k.GetAllExecutionRequests(ctx),
+		TrainingJobs:        k.GetAllTrainingJobs(ctx),
+		StateHistory:        k.GetAllAIAgentStateHistory(ctx),
+		Pipelines:           k.GetAllPipelines(ctx),
+		WalletPolicies:      k.GetAllAgentWalletPolicies(ctx),
+		Schedules:           k.GetAllAgentSchedules(ctx),
+		Pricings:            k.GetAllAgentPricings(ctx),
+		GovDelegations:      k.GetAllGovDelegations(ctx),
+		AgentGovVotes:       k.GetAllAgentGovVotes(ctx),
+		AgentRatings:        k.GetAllAgentRatings(ctx),
+		AgentReputations:    k.GetAllAgentReputations(ctx),
+		CreatorReputations:  k.GetAllCreatorReputations(ctx),
+		AgentShares:         k.GetAllAgentShares(ctx),
+		AgentShareholders:   k.GetAllAgentShareholders(ctx),
+		SubscriptionPlans:   k.GetAllSubscriptionPlans(ctx),
+		AgentSubscriptions:  k.GetAllAgentSubscriptions(ctx),
+		EncryptionKeys:      k.GetAllAccountEncryptionKeys(ctx),
+		NextSequence:        k.GetNextSequence(ctx),
 		Params:              k.GetParams(ctx),
 	}
 }
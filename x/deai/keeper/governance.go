@@ -0,0 +1,415 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// govQueueEnd lies past the end of the voting period of every active proposal
+var govQueueEnd = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// SetGovDelegation stores a governance delegation and indexes it by agent
+func (k Keeper) SetGovDelegation(ctx sdk.Context, delegation types.GovDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetGovDelegationKey(delegation.Delegator), k.cdc.MustMarshal(&delegation))
+	store.Set(types.GetGovDelegationByAgentKey(delegation.AgentID, delegation.Delegator), []byte{})
+	k.setGovDelegateCount(ctx, delegation.AgentID, k.getGovDelegateCount(ctx, delegation.AgentID)+1)
+}
+
+// GetGovDelegation returns the governance delegation of a delegator
+func (k Keeper) GetGovDelegation(ctx sdk.Context, delegator sdk.AccAddress) (types.GovDelegation, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetGovDelegationKey(delegator))
+	if value == nil {
+		return types.GovDelegation{}, false
+	}
+
+	var delegation types.GovDelegation
+	k.cdc.MustUnmarshal(value, &delegation)
+	return delegation, true
+}
+
+// GetAllGovDelegations returns all governance delegations
+func (k Keeper) GetAllGovDelegations(ctx sdk.Context) []types.GovDelegation {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GovDelegationKey)
+	defer iterator.Close()
+
+	var delegations []types.GovDelegation
+	for ; iterator.Valid(); iterator.Next() {
+		var delegation types.GovDelegation
+		k.cdc.MustUnmarshal(iterator.Value(), &delegation)
+		delegations = append(delegations, delegation)
+	}
+
+	return delegations
+}
+
+// GetGovDelegators returns the accounts that delegate their voting power to a governance agent
+func (k Keeper) GetGovDelegators(ctx sdk.Context, agentID string) []sdk.AccAddress {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetGovDelegationByAgentPrefix(agentID)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var delegators []sdk.AccAddress
+	for ; iterator.Valid(); iterator.Next() {
+		delegators = append(delegators, sdk.AccAddress(iterator.Key()[len(prefix):]))
+	}
+
+	return delegators
+}
+
+// GetGovDelegateAgents returns the IDs of the governance agents that have delegators
+func (k Keeper) GetGovDelegateAgents(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GovDelegateKey)
+	defer iterator.Close()
+
+	var agentIDs []string
+	for ; iterator.Valid(); iterator.Next() {
+		agentIDs = append(agentIDs, string(iterator.Key()[len(types.GovDelegateKey):]))
+	}
+
+	return agentIDs
+}
+
+// DelegateGovVotes delegates the governance voting power of an account to a governance
+// agent, replacing any earlier delegation. The votes the agent already cast on proposals
+// still in their voting period are cast for the new delegator as well.
+func (k Keeper) DelegateGovVotes(ctx sdk.Context, delegator sdk.AccAddress, agentID string) error {
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+	if agent.AgentType != types.AIAgentTypeGovernance {
+		return sdkerrors.Wrap(types.ErrNotGovernanceAgent, agentID)
+	}
+	if !isAgentUsable(agent) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent is not active")
+	}
+
+	if existing, found := k.GetGovDelegation(ctx, delegator); found {
+		if existing.AgentID == agentID {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "already delegating to agent %s", agentID)
+		}
+		k.removeGovDelegation(ctx, existing)
+	}
+
+	k.SetGovDelegation(ctx, types.GovDelegation{
+		Delegator: delegator,
+		AgentID:   agentID,
+		CreatedAt: ctx.BlockTime(),
+	})
+
+	for _, vote := range k.GetAgentGovVotes(ctx, agentID) {
+		if vote.Status != types.AgentGovVoteStatusCast {
+			continue
+		}
+		proposal, found := k.govKeeper.GetProposal(ctx, vote.ProposalID)
+		if !found || proposal.Status != govv1.StatusVotingPeriod {
+			continue
+		}
+		if k.castGovVoteFor(ctx, vote, delegator) {
+			vote.Delegators++
+			vote.UpdatedAt = ctx.BlockTime()
+			k.SetAgentGovVote(ctx, vote)
+		}
+	}
+
+	return nil
+}
+
+// UndelegateGovVotes ends the governance delegation of an account. Votes the agent
+// already cast for the account stay in place until the account votes itself.
+func (k Keeper) UndelegateGovVotes(ctx sdk.Context, delegator sdk.AccAddress) (types.GovDelegation, error) {
+	delegation, found := k.GetGovDelegation(ctx, delegator)
+	if !found {
+		return types.GovDelegation{}, sdkerrors.Wrap(types.ErrGovDelegationNotFound, delegator.String())
+	}
+
+	k.removeGovDelegation(ctx, delegation)
+	return delegation, nil
+}
+
+// removeGovDelegation deletes a governance delegation and its index entry
+func (k Keeper) removeGovDelegation(ctx sdk.Context, delegation types.GovDelegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetGovDelegationKey(delegation.Delegator))
+	store.Delete(types.GetGovDelegationByAgentKey(delegation.AgentID, delegation.Delegator))
+	if count := k.getGovDelegateCount(ctx, delegation.AgentID); count > 0 {
+		k.setGovDelegateCount(ctx, delegation.AgentID, count-1)
+	}
+}
+
+// getGovDelegateCount returns the number of delegators of a governance agent
+func (k Keeper) getGovDelegateCount(ctx sdk.Context, agentID string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetGovDelegateKey(agentID))
+	if value == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(value)
+}
+
+// setGovDelegateCount stores the number of delegators of a governance agent, removing
+// agents without delegators from the set of delegates
+func (k Keeper) setGovDelegateCount(ctx sdk.Context, agentID string, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+	if count == 0 {
+		store.Delete(types.GetGovDelegateKey(agentID))
+		return
+	}
+	store.Set(types.GetGovDelegateKey(agentID), sdk.Uint64ToBigEndian(count))
+}
+
+// SetAgentGovVote stores a governance agent's vote on a proposal
+func (k Keeper) SetAgentGovVote(ctx sdk.Context, vote types.AgentGovVote) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentGovVoteKey(vote.AgentID, vote.ProposalID), k.cdc.MustMarshal(&vote))
+}
+
+// GetAgentGovVote returns a governance agent's vote on a proposal
+func (k Keeper) GetAgentGovVote(ctx sdk.Context, agentID string, proposalID uint64) (types.AgentGovVote, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAgentGovVoteKey(agentID, proposalID))
+	if value == nil {
+		return types.AgentGovVote{}, false
+	}
+
+	var vote types.AgentGovVote
+	k.cdc.MustUnmarshal(value, &vote)
+	return vote, true
+}
+
+// GetAgentGovVotes returns the voting record of a governance agent, ordered by proposal
+func (k Keeper) GetAgentGovVotes(ctx sdk.Context, agentID string) []types.AgentGovVote {
+	return k.getAgentGovVotes(ctx, types.GetAgentGovVotePrefix(agentID))
+}
+
+// GetAllAgentGovVotes returns the votes of all governance agents
+func (k Keeper) GetAllAgentGovVotes(ctx sdk.Context) []types.AgentGovVote {
+	return k.getAgentGovVotes(ctx, types.AgentGovVoteKey)
+}
+
+func (k Keeper) getAgentGovVotes(ctx sdk.Context, prefix []byte) []types.AgentGovVote {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var votes []types.AgentGovVote
+	for ; iterator.Valid(); iterator.Next() {
+		var vote types.AgentGovVote
+		k.cdc.MustUnmarshal(iterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+
+	return votes
+}
+
+// setPendingAgentGovVote stores a vote whose decision is computed by executors and
+// indexes it by the action computing it
+func (k Keeper) setPendingAgentGovVote(ctx sdk.Context, vote types.AgentGovVote) {
+	k.SetAgentGovVote(ctx, vote)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentGovVoteByActionKey(vote.ActionID), types.GetAgentGovVoteKey(vote.AgentID, vote.ProposalID))
+}
+
+// DecideAgentGovVotes has every governance agent with delegators decide on the active
+// proposals it has not voted on yet. An agent decides on one proposal per block, which
+// bounds the decisions a block runs to the number of governance agents.
+func (k Keeper) DecideAgentGovVotes(ctx sdk.Context) {
+	agentIDs := k.GetGovDelegateAgents(ctx)
+	if len(agentIDs) == 0 {
+		return
+	}
+
+	var proposals []govv1.Proposal
+	k.govKeeper.IterateActiveProposalsQueue(ctx, govQueueEnd, func(proposal govv1.Proposal) bool {
+		proposals = append(proposals, proposal)
+		return false
+	})
+
+	for _, agentID := range agentIDs {
+		for _, proposal := range proposals {
+			if _, found := k.GetAgentGovVote(ctx, agentID, proposal.Id); found {
+				continue
+			}
+			k.decideAgentGovVote(ctx, agentID, proposal)
+			break
+		}
+	}
+}
+
+// decideAgentGovVote runs a governance agent on a proposal through its execution path.
+// The decision of an on-chain model is cast right away; the decision computed by
+// executors is cast once its request is finalized.
+func (k Keeper) decideAgentGovVote(ctx sdk.Context, agentID string, proposal govv1.Proposal) {
+	vote := types.AgentGovVote{
+		AgentID:    agentID,
+		ProposalID: proposal.Id,
+		CreatedAt:  ctx.BlockTime(),
+		UpdatedAt:  ctx.BlockTime(),
+	}
+
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found || !isAgentUsable(agent) {
+		k.failAgentGovVote(ctx, vote, "agent is not active")
+		return
+	}
+
+	data, err := json.Marshal(agentGovProposal(proposal))
+	if err != nil {
+		k.failAgentGovVote(ctx, vote, err.Error())
+		return
+	}
+
+	if model, found := k.GetOnChainModel(ctx, agent); found {
+		// Decide in a cached context metered by the decision gas limit, so a failed
+		// decision records nothing but the failure
+		cacheCtx, write := ctx.CacheContext()
+		action, err := k.executeAgentGovDecision(cacheCtx.WithGasMeter(sdk.NewGasMeter(k.GovVoteGasLimit(ctx))), agent, model, data)
+		if err != nil {
+			k.failAgentGovVote(ctx, vote, err.Error())
+			return
+		}
+		write()
+
+		vote.ActionID = action.ID
+		k.castAgentGovVote(ctx, vote, action.Result)
+		return
+	}
+
+	request, err := k.RequestAIAgentExecution(ctx, agent, agent.Owner, types.AIAgentActionTypeGovVote, data, nil)
+	if err != nil {
+		k.failAgentGovVote(ctx, vote, err.Error())
+		return
+	}
+
+	vote.ActionID = request.ID
+	vote.Status = types.AgentGovVoteStatusPending
+	k.setPendingAgentGovVote(ctx, vote)
+}
+
+// executeAgentGovDecision evaluates a governance agent's on-chain model on a proposal,
+// reporting a decision that runs out of gas as an error
+func (k Keeper) executeAgentGovDecision(ctx sdk.Context, agent types.AIAgent, model types.AIAgentModel, data json.RawMessage) (action types.AIAgentAction, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			outOfGas, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, outOfGas.Descriptor)
+		}
+	}()
+
+	return k.ExecuteOnChainInference(ctx, agent, model, agent.Owner, types.AIAgentActionTypeGovVote, data, nil)
+}
+
+// completeAgentGovVote casts the pending governance vote decided by a finalized
+// execution request. A request without an accepted result fails the vote.
+func (k Keeper) completeAgentGovVote(ctx sdk.Context, actionID string, result json.RawMessage) {
+	store := ctx.KVStore(k.storeKey)
+	voteKey := store.Get(types.GetAgentGovVoteByActionKey(actionID))
+	if voteKey == nil {
+		return
+	}
+	store.Delete(types.GetAgentGovVoteByActionKey(actionID))
+
+	var vote types.AgentGovVote
+	k.cdc.MustUnmarshal(store.Get(voteKey), &vote)
+
+	if result == nil {
+		k.failAgentGovVote(ctx, vote, "executors reached no result")
+		return
+	}
+	k.castAgentGovVote(ctx, vote, result)
+}
+
+// castAgentGovVote casts a governance agent's decision as a weighted vote for each of
+// its delegators that has not voted itself
+func (k Keeper) castAgentGovVote(ctx sdk.Context, vote types.AgentGovVote, result json.RawMessage) {
+	options, err := types.ParseAgentGovDecision(result)
+	if err != nil {
+		k.failAgentGovVote(ctx, vote, err.Error())
+		return
+	}
+
+	vote.Status = types.AgentGovVoteStatusCast
+	vote.Options = options
+	for _, delegator := range k.GetGovDelegators(ctx, vote.AgentID) {
+		if k.castGovVoteFor(ctx, vote, delegator) {
+			vote.Delegators++
+		}
+	}
+	vote.UpdatedAt = ctx.BlockTime()
+	k.SetAgentGovVote(ctx, vote)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAgentGovVoteCast,
+			sdk.NewAttribute(types.AttributeKeyAgentID, vote.AgentID),
+			sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", vote.ProposalID)),
+			sdk.NewAttribute(types.AttributeKeyActionID, vote.ActionID),
+			sdk.NewAttribute(types.AttributeKeyOptions, types.GovVoteOptions(options).String()),
+			sdk.NewAttribute(types.AttributeKeyDelegators, fmt.Sprintf("%d", vote.Delegators)),
+		),
+	)
+}
+
+// castGovVoteFor casts a governance agent's vote for one delegator through x/gov. It
+// returns false if the delegator voted itself, which overrides the agent's vote.
+func (k Keeper) castGovVoteFor(ctx sdk.Context, vote types.AgentGovVote, delegator sdk.AccAddress) bool {
+	if _, found := k.govKeeper.GetVote(ctx, vote.ProposalID, delegator); found {
+		return false
+	}
+
+	metadata := fmt.Sprintf("cast by deai agent %s", vote.AgentID)
+	if err := k.govKeeper.AddVote(ctx, vote.ProposalID, delegator, types.GovVoteOptions(vote.Options), metadata); err != nil {
+		k.Logger(ctx).Error("failed to cast agent vote", "agent_id", vote.AgentID, "proposal_id", vote.ProposalID, "delegator", delegator.String(), "error", err)
+		return false
+	}
+	return true
+}
+
+// failAgentGovVote records that a governance agent made no valid decision on a proposal
+func (k Keeper) failAgentGovVote(ctx sdk.Context, vote types.AgentGovVote, reason string) {
+	vote.Status = types.AgentGovVoteStatusFailed
+	vote.Error = reason
+	vote.UpdatedAt = ctx.BlockTime()
+	k.SetAgentGovVote(ctx, vote)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAgentGovVoteFailed,
+			sdk.NewAttribute(types.AttributeKeyAgentID, vote.AgentID),
+			sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", vote.ProposalID)),
+			sdk.NewAttribute(types.AttributeKeyError, reason),
+		),
+	)
+}
+
+// agentGovProposal returns the input of a governance agent's decision on a proposal
+func agentGovProposal(proposal govv1.Proposal) types.AgentGovProposal {
+	input := types.AgentGovProposal{
+		ProposalID: proposal.Id,
+		Title:      proposal.Title,
+		Summary:    proposal.Summary,
+		Metadata:   proposal.Metadata,
+		Proposer:   proposal.Proposer,
+	}
+	for _, msg := range proposal.Messages {
+		input.Messages = append(input.Messages, msg.TypeUrl)
+	}
+	if proposal.VotingEndTime != nil {
+		input.VotingEndTime = *proposal.VotingEndTime
+	}
+	return input
+}
\ No newline at end of file
@@ -97,7 +97,6 @@ func (k Keeper) AIAgentActions(c context.Context, req *types.QueryAIAgentActions
 	ctx := sdk.UnwrapSDKContext(c)
 	actions := k.GetAIAgentActionsByAgent(ctx, req.AgentId)
 	var pageRes *query.PageResponse
-	var err error
 
 	// Apply pagination
 	if req.Pagination != nil {
@@ -222,18 +221,14 @@ func (k Keeper) MarketplaceListings(c context.Context, req *types.QueryMarketpla
 	}
 
 	ctx := sdk.UnwrapSDKContext(c)
-	listings := k.GetAllAIAgentMarketplaceListings(ctx)
+	var listings []types.AIAgentMarketplaceListing
 	var filteredListings []types.AIAgentMarketplaceListing
 
 	// Filter by status if specified
 	if req.Status != "" {
-		for _, listing := range listings {
-			if listing.Status == req.Status {
-				filteredListings = append(filteredListings, listing)
-			}
-		}
-		listings = filteredListings
-		filteredListings = []types.AIAgentMarketplaceListing{}
+		listings = k.GetAIAgentMarketplaceListingsByStatus(ctx, req.Status)
+	} else {
+		listings = k.GetAllAIAgentMarketplaceListings(ctx)
 	}
 
 	// Filter by listing type if specified
@@ -246,6 +241,11 @@ func (k Keeper) MarketplaceListings(c context.Context, req *types.QueryMarketpla
 		listings = filteredListings
 	}
 
+	listings, err := k.SortMarketplaceListings(ctx, listings, req.Sort)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	var pageRes *query.PageResponse
 	var err error
 
@@ -285,3 +285,494 @@ func (k Keeper) MarketplaceListing(c context.Context, req *types.QueryMarketplac
 		Listing: listing,
 	}, nil
 }
+
+// AIAgentRentals returns all rentals of a specific AI agent
+func (k Keeper) AIAgentRentals(c context.Context, req *types.QueryAIAgentRentalsRequest) (*types.QueryAIAgentRentalsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	rentals := k.GetAIAgentRentalsByAgent(ctx, req.AgentID)
+
+	return &types.QueryAIAgentRentalsResponse{
+		Rentals: rentals,
+	}, nil
+}
+
+// RenterRentals returns all rentals taken out by a specific renter
+func (k Keeper) RenterRentals(c context.Context, req *types.QueryRenterRentalsRequest) (*types.QueryRenterRentalsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	renter, err := sdk.AccAddressFromBech32(req.Renter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid renter address")
+	}
+
+	var rentals []types.AIAgentRental
+	for _, rental := range k.GetAIAgentRentalsByRenter(ctx, renter) {
+		if req.ActiveOnly && !rental.IsActive(ctx.BlockTime()) {
+			continue
+		}
+		rentals = append(rentals, rental)
+	}
+
+	return &types.QueryRenterRentalsResponse{
+		Rentals: rentals,
+	}, nil
+}
+
+// Executor returns a specific executor
+func (k Keeper) Executor(c context.Context, req *types.QueryExecutorRequest) (*types.QueryExecutorResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid executor address")
+	}
+
+	executor, found := k.GetExecutor(ctx, addr)
+	if !found {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+
+	return &types.QueryExecutorResponse{
+		Executor: executor,
+	}, nil
+}
+
+// Executors returns all executors
+func (k Keeper) Executors(c context.Context, req *types.QueryExecutorsRequest) (*types.QueryExecutorsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryExecutorsResponse{
+		Executors: k.GetAllExecutors(ctx),
+	}, nil
+}
+
+// ExecutionRequest returns a specific execution request
+func (k Keeper) ExecutionRequest(c context.Context, req *types.QueryExecutionRequestRequest) (*types.QueryExecutionRequestResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	request, found := k.GetExecutionRequest(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "execution request not found")
+	}
+
+	return &types.QueryExecutionRequestResponse{
+		Request: request,
+	}, nil
+}
+
+// PendingExecutionRequests returns all execution requests waiting for executors
+func (k Keeper) PendingExecutionRequests(c context.Context, req *types.QueryPendingExecutionRequestsRequest) (*types.QueryPendingExecutionRequestsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryPendingExecutionRequestsResponse{
+		Requests: k.GetPendingExecutionRequests(ctx),
+		Height:   ctx.BlockHeight(),
+	}, nil
+}
+
+// TrainingJob returns a specific training job
+func (k Keeper) TrainingJob(c context.Context, req *types.QueryTrainingJobRequest) (*types.QueryTrainingJobResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	job, found := k.GetTrainingJob(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "training job not found")
+	}
+
+	return &types.QueryTrainingJobResponse{
+		Job: job,
+	}, nil
+}
+
+// TrainingJobs returns training jobs, optionally filtered by agent and status
+func (k Keeper) TrainingJobs(c context.Context, req *types.QueryTrainingJobsRequest) (*types.QueryTrainingJobsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryTrainingJobsResponse{
+		Jobs: k.GetTrainingJobs(ctx, req.AgentID, req.Status),
+	}, nil
+}
+
+// AIAgentStateHistory returns the retained versions of an AI agent's state
+func (k Keeper) AIAgentStateHistory(c context.Context, req *types.QueryAIAgentStateHistoryRequest) (*types.QueryAIAgentStateHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryAIAgentStateHistoryResponse{
+		States: k.GetAIAgentStateHistory(ctx, req.AgentID),
+	}, nil
+}
+
+// AIAgentStateAt returns a specific version of an AI agent's state
+func (k Keeper) AIAgentStateAt(c context.Context, req *types.QueryAIAgentStateAtRequest) (*types.QueryAIAgentStateAtResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	state, found := k.GetAIAgentStateAt(ctx, req.AgentID, req.Version)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent state version not found")
+	}
+
+	return &types.QueryAIAgentStateAtResponse{
+		State: state,
+	}, nil
+}
+
+// Pipeline returns a pipeline by ID
+func (k Keeper) Pipeline(c context.Context, req *types.QueryPipelineRequest) (*types.QueryPipelineResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	pipeline, found := k.GetPipeline(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "pipeline not found")
+	}
+
+	return &types.QueryPipelineResponse{
+		Pipeline: pipeline,
+	}, nil
+}
+
+// Pipelines returns all pipelines, optionally filtered by owner
+func (k Keeper) Pipelines(c context.Context, req *types.QueryPipelinesRequest) (*types.QueryPipelinesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	var owner sdk.AccAddress
+	if req.Owner != "" {
+		ownerAddr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid owner address")
+		}
+		owner = ownerAddr
+	}
+
+	return &types.QueryPipelinesResponse{
+		Pipelines: k.GetPipelinesByOwner(ctx, owner),
+	}, nil
+}
+
+// AgentWallet returns the wallet of an AI agent
+func (k Keeper) AgentWallet(c context.Context, req *types.QueryAgentWalletRequest) (*types.QueryAgentWalletResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	if _, found := k.GetAIAgent(ctx, req.AgentID); !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	wallet := types.AgentWalletAddress(req.AgentID)
+	res := &types.QueryAgentWalletResponse{
+		Address: wallet.String(),
+		Balance: k.bankKeeper.GetAllBalances(ctx, wallet),
+	}
+	if policy, found := k.GetAgentWalletPolicy(ctx, req.AgentID); found {
+		res.Policy = &policy
+	}
+
+	return res, nil
+}
+
+// AgentSchedule returns an agent schedule by ID
+func (k Keeper) AgentSchedule(c context.Context, req *types.QueryAgentScheduleRequest) (*types.QueryAgentScheduleResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	schedule, found := k.GetAgentSchedule(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent schedule not found")
+	}
+
+	return &types.QueryAgentScheduleResponse{
+		Schedule: schedule,
+	}, nil
+}
+
+// AgentSchedules returns all agent schedules, optionally filtered by agent and owner
+func (k Keeper) AgentSchedules(c context.Context, req *types.QueryAgentSchedulesRequest) (*types.QueryAgentSchedulesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	var owner sdk.AccAddress
+	if req.Owner != "" {
+		ownerAddr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid owner address")
+		}
+		owner = ownerAddr
+	}
+
+	return &types.QueryAgentSchedulesResponse{
+		Schedules: k.GetAgentSchedules(ctx, req.AgentID, owner),
+	}, nil
+}
+
+// AgentQuote returns the fee of a prospective call of an AI agent
+func (k Keeper) AgentQuote(c context.Context, req *types.QueryAgentQuoteRequest) (*types.QueryAgentQuoteResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	agent, found := k.GetAIAgent(ctx, req.AgentID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	var caller sdk.AccAddress
+	if req.Caller != "" {
+		callerAddr, err := sdk.AccAddressFromBech32(req.Caller)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid caller address")
+		}
+		caller = callerAddr
+	}
+
+	quote, priced := k.QuoteAgentCall(ctx, agent, caller, req.ActionType)
+	return &types.QueryAgentQuoteResponse{
+		Priced: priced,
+		Quote:  quote,
+	}, nil
+}
+
+// GovDelegation returns the governance delegation of an account
+func (k Keeper) GovDelegation(c context.Context, req *types.QueryGovDelegationRequest) (*types.QueryGovDelegationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	delegator, err := sdk.AccAddressFromBech32(req.Delegator)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid delegator address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	delegation, found := k.GetGovDelegation(ctx, delegator)
+	if !found {
+		return nil, status.Error(codes.NotFound, "governance delegation not found")
+	}
+
+	return &types.QueryGovDelegationResponse{Delegation: delegation}, nil
+}
+
+// AgentGovVotes returns the voting record of a governance agent
+func (k Keeper) AgentGovVotes(c context.Context, req *types.QueryAgentGovVotesRequest) (*types.QueryAgentGovVotesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	if _, found := k.GetAIAgent(ctx, req.AgentID); !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	return &types.QueryAgentGovVotesResponse{
+		Votes:      k.GetAgentGovVotes(ctx, req.AgentID),
+		Delegators: k.getGovDelegateCount(ctx, req.AgentID),
+	}, nil
+}
+
+// AgentReputation returns the reputation of an agent and of its creator
+func (k Keeper) AgentReputation(c context.Context, req *types.QueryAgentReputationRequest) (*types.QueryAgentReputationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	agent, found := k.GetAIAgent(ctx, req.AgentID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	return &types.QueryAgentReputationResponse{
+		Agent:   k.AgentReputationScore(ctx, agent.ID),
+		Creator: k.CreatorReputationScore(ctx, agent.Creator),
+	}, nil
+}
+
+// CreatorReputation returns the reputation of a creator across their agents
+func (k Keeper) CreatorReputation(c context.Context, req *types.QueryCreatorReputationRequest) (*types.QueryCreatorReputationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	creator, err := sdk.AccAddressFromBech32(req.Creator)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid creator address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryCreatorReputationResponse{
+		Reputation: k.CreatorReputationScore(ctx, creator),
+	}, nil
+}
+
+// AgentRatings returns the ratings of an agent
+func (k Keeper) AgentRatings(c context.Context, req *types.QueryAgentRatingsRequest) (*types.QueryAgentRatingsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	if _, found := k.GetAIAgent(ctx, req.AgentID); !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	return &types.QueryAgentRatingsResponse{
+		Ratings: k.GetAgentRatings(ctx, req.AgentID),
+	}, nil
+}
+
+// AgentShares returns the shares and shareholders of a fractionalized agent
+func (k Keeper) AgentShares(c context.Context, req *types.QueryAgentSharesRequest) (*types.QueryAgentSharesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	shares, found := k.GetAgentShares(ctx, req.AgentID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent is not fractionalized")
+	}
+
+	return &types.QueryAgentSharesResponse{
+		Shares:  shares,
+		Holders: k.GetAgentShareholders(ctx, req.AgentID),
+	}, nil
+}
+
+// AgentShareholder returns the income a holder of shares of an agent can claim
+func (k Keeper) AgentShareholder(c context.Context, req *types.QueryAgentShareholderRequest) (*types.QueryAgentShareholderResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	holder, err := sdk.AccAddressFromBech32(req.Holder)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid holder address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	shareholder, found := k.AgentShareholderIncome(ctx, req.AgentID, holder)
+	if !found {
+		return nil, status.Error(codes.NotFound, "shareholder not found")
+	}
+	claimable, _ := shareholder.Unclaimed.TruncateDecimal()
+
+	return &types.QueryAgentShareholderResponse{
+		Shareholder: shareholder,
+		Claimable:   claimable,
+	}, nil
+}
+
+// SubscriptionPlans returns the subscription plans of an agent
+func (k Keeper) SubscriptionPlans(c context.Context, req *types.QuerySubscriptionPlansRequest) (*types.QuerySubscriptionPlansResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QuerySubscriptionPlansResponse{
+		Plans: k.GetSubscriptionPlans(ctx, req.AgentID),
+	}, nil
+}
+
+// Subscriptions returns the active subscriptions of a subscriber
+func (k Keeper) Subscriptions(c context.Context, req *types.QuerySubscriptionsRequest) (*types.QuerySubscriptionsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	subscriber, err := sdk.AccAddressFromBech32(req.Subscriber)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscriber address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QuerySubscriptionsResponse{
+		Subscriptions: k.GetAgentSubscriptions(ctx, subscriber),
+	}, nil
+}
+
+// EncryptionKey returns the encryption key an account registered
+func (k Keeper) EncryptionKey(c context.Context, req *types.QueryEncryptionKeyRequest) (*types.QueryEncryptionKeyResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	key, found := k.GetAccountEncryptionKey(ctx, addr)
+	if !found {
+		return nil, status.Error(codes.NotFound, "no encryption key registered")
+	}
+
+	return &types.QueryEncryptionKeyResponse{Key: key}, nil
+}
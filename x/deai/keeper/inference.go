@@ -0,0 +1,118 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/inference"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// GetOnChainModel returns the model of an agent if it is evaluated inside consensus
+func (k Keeper) GetOnChainModel(ctx sdk.Context, agent types.AIAgent) (types.AIAgentModel, bool) {
+	if agent.ModelID == "" {
+		return types.AIAgentModel{}, false
+	}
+	model, found := k.GetAIAgentModel(ctx, agent.ModelID)
+	if !found || !model.OnChain {
+		return types.AIAgentModel{}, false
+	}
+	return model, true
+}
+
+// SetAgentOnChainModel attaches a model evaluated inside consensus to an agent as the
+// next version of its model. The parameters must be valid for the model type. Models
+// cannot be attached while a training job of the agent is pending, as its result
+// becomes the next version of the model the job was opened on.
+func (k Keeper) SetAgentOnChainModel(ctx sdk.Context, owner sdk.AccAddress, agentID string, modelType string, parameters json.RawMessage) (types.AIAgentModel, error) {
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return types.AIAgentModel{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+	if !k.AgentSeller(ctx, agent).Equals(owner) {
+		return types.AIAgentModel{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can set the agent model")
+	}
+	if _, err := inference.ParseModel(modelType, parameters); err != nil {
+		return types.AIAgentModel{}, sdkerrors.Wrap(types.ErrInvalidOnChainModel, err.Error())
+	}
+	for _, job := range k.GetTrainingJobs(ctx, agent.ID, "") {
+		if job.IsPending() {
+			return types.AIAgentModel{}, sdkerrors.Wrapf(types.ErrInvalidTrainingJobStatus, "training job %s of agent %s is pending", job.ID, agent.ID)
+		}
+	}
+
+	model, err := k.newAgentModelVersion(ctx, agent, agent.ModelID, owner)
+	if err != nil {
+		return model, err
+	}
+	model.ModelType = modelType
+	model.Parameters = parameters
+	model.OnChain = true
+	model.ModelHash = ""
+	model.ModelURI = ""
+	k.SetAIAgentModel(ctx, model)
+
+	agent.ModelID = model.ID
+	agent.UpdatedAt = ctx.BlockTime()
+	k.SetAIAgent(ctx, agent)
+
+	return model, nil
+}
+
+// ExecuteOnChainInference evaluates an agent's on-chain model within the transaction and
+// records the completed action. The gas of the evaluation is charged to the transaction,
+// and as no executors are involved the whole fee goes to the agent owner.
+func (k Keeper) ExecuteOnChainInference(ctx sdk.Context, agent types.AIAgent, model types.AIAgentModel, requester sdk.AccAddress, actionType string, data json.RawMessage, fee sdk.Coins) (types.AIAgentAction, error) {
+	actionID := fmt.Sprintf("%s-%s-%d", agent.ID, actionType, k.nextSequence(ctx))
+
+	result, gasUsed, err := k.runOnChainInference(ctx, agent, model, requester, data, fee)
+	if err != nil {
+		return types.AIAgentAction{}, err
+	}
+
+	action := types.AIAgentAction{
+		ID:         actionID,
+		AgentID:    agent.ID,
+		ActionType: actionType,
+		Timestamp:  ctx.BlockTime(),
+		Data:       data,
+		Result:     result,
+		Status:     types.ExecutionStatusCompleted,
+		GasUsed:    gasUsed,
+		Requester:  requester,
+		Fee:        fee,
+	}
+	k.SetAIAgentAction(ctx, action)
+
+	if state, found := k.GetAIAgentState(ctx, agent.ID); found {
+		state.UpdatedAt = ctx.BlockTime()
+		k.SetAIAgentState(ctx, state)
+	}
+
+	return action, nil
+}
+
+// runOnChainInference evaluates an agent's on-chain model and pays the fee to the agent
+// owner, or its shareholders if the agent is fractionalized. It returns the result and
+// the gas used by the evaluation.
+func (k Keeper) runOnChainInference(ctx sdk.Context, agent types.AIAgent, model types.AIAgentModel, requester sdk.AccAddress, data json.RawMessage, fee sdk.Coins) (json.RawMessage, uint64, error) {
+	gasBefore := ctx.GasMeter().GasConsumed()
+	result, err := inference.Run(ctx.GasMeter(), model.ModelType, model.Parameters, data)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(types.ErrInferenceFailed, err.Error())
+	}
+	gasUsed := ctx.GasMeter().GasConsumed() - gasBefore
+
+	if !fee.IsZero() && !requester.Equals(agent.Owner) {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, requester, types.ModuleName, fee); err != nil {
+			return nil, 0, sdkerrors.Wrap(err, "failed to collect fee")
+		}
+		if err := k.payAgentIncome(ctx, agent.ID, agent.Owner, fee); err != nil {
+			return nil, 0, sdkerrors.Wrap(err, "failed to pay agent owner")
+		}
+	}
+
+	return result, gasUsed, nil
+}
\ No newline at end of file
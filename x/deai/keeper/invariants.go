@@ -77,6 +77,9 @@ func ValidMarketplaceListingsInvariant(k Keeper) sdk.Invariant {
 				} else if listing.ListingType == "both" && agent.Status != types.AIAgentStatusForSale && agent.Status != types.AIAgentStatusForRent {
 					invalidListings = append(invalidListings, listing.ID)
 					broken = true
+				} else if listing.IsAuction() && agent.Status != types.AIAgentStatusForSale {
+					invalidListings = append(invalidListings, listing.ID)
+					broken = true
 				}
 			}
 
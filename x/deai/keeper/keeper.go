@@ -7,6 +7,7 @@ import (
 
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
@@ -17,23 +18,29 @@ import (
 type Keeper struct {
 	storeKey   sdk.StoreKey
 	memKey     sdk.StoreKey
-	cdc        codec.BinaryCodec
+	cdc        codec.Codec
 	paramstore paramtypes.Subspace
+	router     baseapp.MessageRouter // routes the messages agents send from their wallets
 
 	accountKeeper types.AccountKeeper
 	bankKeeper    types.BankKeeper
 	nftKeeper     types.NFTKeeper
+	distrKeeper   types.DistributionKeeper
+	govKeeper     types.GovKeeper
 }
 
 // NewKeeper creates a new deai Keeper instance
 func NewKeeper(
-	cdc codec.BinaryCodec,
+	cdc codec.Codec,
 	storeKey,
 	memKey sdk.StoreKey,
 	ps paramtypes.Subspace,
+	router baseapp.MessageRouter,
 	accountKeeper types.AccountKeeper,
 	bankKeeper types.BankKeeper,
 	nftKeeper types.NFTKeeper,
+	distrKeeper types.DistributionKeeper,
+	govKeeper types.GovKeeper,
 ) Keeper {
 	// set KeyTable if it has not already been set
 	if !ps.HasKeyTable() {
@@ -45,9 +52,12 @@ func NewKeeper(
 		memKey:        memKey,
 		cdc:           cdc,
 		paramstore:    ps,
+		router:        router,
 		accountKeeper: accountKeeper,
 		bankKeeper:    bankKeeper,
 		nftKeeper:     nftKeeper,
+		distrKeeper:   distrKeeper,
+		govKeeper:     govKeeper,
 	}
 }
 
@@ -56,6 +66,32 @@ func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
 }
 
+// GetNextSequence returns the next number of the module sequence, which makes the IDs of
+// actions, pipelines, schedules and subscription plans unique however many are created
+// in a block. Chains that used height-based IDs before the sequence existed continue
+// after the current height, so new IDs never collide with them.
+func (k Keeper) GetNextSequence(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.NextSequenceKey)
+	if bz == nil {
+		return uint64(ctx.BlockHeight()) + 1
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// SetNextSequence sets the next number of the module sequence
+func (k Keeper) SetNextSequence(ctx sdk.Context, sequence uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextSequenceKey, sdk.Uint64ToBigEndian(sequence))
+}
+
+// nextSequence returns the next number of the module sequence and increments it
+func (k Keeper) nextSequence(ctx sdk.Context) uint64 {
+	sequence := k.GetNextSequence(ctx)
+	k.SetNextSequence(ctx, sequence+1)
+	return sequence
+}
+
 // SetAIAgent sets an AI agent
 func (k Keeper) SetAIAgent(ctx sdk.Context, agent types.AIAgent) {
 	store := ctx.KVStore(k.storeKey)
@@ -75,7 +111,7 @@ func (k Keeper) GetAIAgent(ctx sdk.Context, id string) (types.AIAgent, bool) {
 
 	var agent types.AIAgent
 	k.cdc.MustUnmarshal(value, &agent)
-	return agent, true
+	return k.withNFTOwner(ctx, agent), true
 }
 
 // DeleteAIAgent deletes an AI agent
@@ -95,19 +131,19 @@ func (k Keeper) GetAllAIAgents(ctx sdk.Context) []types.AIAgent {
 	for ; iterator.Valid(); iterator.Next() {
 		var agent types.AIAgent
 		k.cdc.MustUnmarshal(iterator.Value(), &agent)
-		agents = append(agents, agent)
+		agents = append(agents, k.withNFTOwner(ctx, agent))
 	}
 
 	return agents
 }
 
-// GetAIAgentsByOwner returns all AI agents owned by an address
+// GetAIAgentsByOwner returns all AI agents owned by an address. The agents are looked up
+// in the x/nft owner index of agent NFTs, so an agent whose NFT was sent in x/nft
+// directly is listed under its new owner at once.
 func (k Keeper) GetAIAgentsByOwner(ctx sdk.Context, owner sdk.AccAddress) []types.AIAgent {
 	var agents []types.AIAgent
-	allAgents := k.GetAllAIAgents(ctx)
-
-	for _, agent := range allAgents {
-		if agent.Owner.Equals(owner) {
+	for _, token := range k.nftKeeper.GetNFTsOfClassByOwner(ctx, types.AgentNFTClassID, owner) {
+		if agent, found := k.GetAIAgent(ctx, token.Id); found {
 			agents = append(agents, agent)
 		}
 	}
@@ -153,8 +189,20 @@ func (k Keeper) GetAllAIAgentModels(ctx sdk.Context) []types.AIAgentModel {
 	return models
 }
 
-// SetAIAgentState sets an AI agent state
+// SetAIAgentState stores a new version of an AI agent state. The version is assigned
+// here and the state is appended to the agent's state history.
 func (k Keeper) SetAIAgentState(ctx sdk.Context, state types.AIAgentState) {
+	state.Version = 1
+	if current, found := k.GetAIAgentState(ctx, state.AgentID); found {
+		state.Version = current.Version + 1
+	}
+
+	k.setAIAgentStateRecord(ctx, state)
+	k.appendAIAgentStateHistory(ctx, state)
+}
+
+// setAIAgentStateRecord stores the current state of an agent as is
+func (k Keeper) setAIAgentStateRecord(ctx sdk.Context, state types.AIAgentState) {
 	store := ctx.KVStore(k.storeKey)
 	key := append(types.AIAgentStateKey, []byte(state.AgentID)...)
 	value := k.cdc.MustMarshal(&state)
@@ -175,12 +223,20 @@ func (k Keeper) GetAIAgentState(ctx sdk.Context, agentID string) (types.AIAgentS
 	return state, true
 }
 
-// SetAIAgentAction records an AI agent action
+// SetAIAgentAction records an AI agent action and keeps its agent index up to date
 func (k Keeper) SetAIAgentAction(ctx sdk.Context, action types.AIAgentAction) {
 	store := ctx.KVStore(k.storeKey)
 	key := append(types.AIAgentActionKey, []byte(action.ID)...)
+
+	if previous := store.Get(key); previous != nil {
+		var stored types.AIAgentAction
+		k.cdc.MustUnmarshal(previous, &stored)
+		store.Delete(types.GetAIAgentActionByAgentKey(stored.AgentID, stored.Timestamp, stored.ID))
+	}
+
 	value := k.cdc.MustMarshal(&action)
 	store.Set(key, value)
+	store.Set(types.GetAIAgentActionByAgentKey(action.AgentID, action.Timestamp, action.ID), []byte(action.ID))
 }
 
 // GetAIAgentAction returns an AI agent action by ID
@@ -197,17 +253,16 @@ func (k Keeper) GetAIAgentAction(ctx sdk.Context, id string) (types.AIAgentActio
 	return action, true
 }
 
-// GetAIAgentActionsByAgent returns all actions for an AI agent
+// GetAIAgentActionsByAgent returns all actions for an AI agent, oldest first
 func (k Keeper) GetAIAgentActionsByAgent(ctx sdk.Context, agentID string) []types.AIAgentAction {
 	var actions []types.AIAgentAction
 	store := ctx.KVStore(k.storeKey)
-	iterator := sdk.KVStorePrefixIterator(store, types.AIAgentActionKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetAIAgentActionByAgentPrefix(agentID))
 	defer iterator.Close()
 
 	for ; iterator.Valid(); iterator.Next() {
-		var action types.AIAgentAction
-		k.cdc.MustUnmarshal(iterator.Value(), &action)
-		if action.AgentID == agentID {
+		action, found := k.GetAIAgentAction(ctx, string(iterator.Value()))
+		if found {
 			actions = append(actions, action)
 		}
 	}
@@ -255,12 +310,21 @@ func (k Keeper) GetAIAgentTrainingDataByAgent(ctx sdk.Context, agentID string) [
 	return dataList
 }
 
-// SetAIAgentMarketplaceListing sets an AI agent marketplace listing
+// SetAIAgentMarketplaceListing sets an AI agent marketplace listing and keeps its status
+// index up to date
 func (k Keeper) SetAIAgentMarketplaceListing(ctx sdk.Context, listing types.AIAgentMarketplaceListing) {
 	store := ctx.KVStore(k.storeKey)
 	key := append(types.AIAgentMarketplaceKey, []byte(listing.ID)...)
+
+	if previous := store.Get(key); previous != nil {
+		var stored types.AIAgentMarketplaceListing
+		k.cdc.MustUnmarshal(previous, &stored)
+		store.Delete(types.GetAIAgentMarketplaceByStatusKey(stored.Status, stored.ExpiresAt, stored.ID))
+	}
+
 	value := k.cdc.MustMarshal(&listing)
 	store.Set(key, value)
+	store.Set(types.GetAIAgentMarketplaceByStatusKey(listing.Status, listing.ExpiresAt, listing.ID), []byte(listing.ID))
 }
 
 // GetAIAgentMarketplaceListing returns an AI agent marketplace listing by ID
@@ -293,8 +357,47 @@ func (k Keeper) GetAllAIAgentMarketplaceListings(ctx sdk.Context) []types.AIAgen
 	return listings
 }
 
+// GetAIAgentMarketplaceListingsByStatus returns the marketplace listings with a status,
+// ordered by expiry
+func (k Keeper) GetAIAgentMarketplaceListingsByStatus(ctx sdk.Context, status string) []types.AIAgentMarketplaceListing {
+	prefix := types.GetAIAgentMarketplaceByStatusPrefix(status)
+	return k.getAIAgentMarketplaceListingsByIndex(ctx, prefix, sdk.PrefixEndBytes(prefix))
+}
+
+// GetExpiredAIAgentMarketplaceListings returns the active marketplace listings that
+// expired before the current block time. Listings without an expiry are never returned.
+func (k Keeper) GetExpiredAIAgentMarketplaceListings(ctx sdk.Context) []types.AIAgentMarketplaceListing {
+	// Listings without an expiry are indexed under the zero time and sort first
+	start := sdk.PrefixEndBytes(types.GetAIAgentMarketplaceByStatusTimePrefix("active", time.Time{}))
+	end := types.GetAIAgentMarketplaceByStatusTimePrefix("active", ctx.BlockTime())
+	return k.getAIAgentMarketplaceListingsByIndex(ctx, start, end)
+}
+
+// getAIAgentMarketplaceListingsByIndex resolves the listing IDs stored in the status
+// index in the range [start, end)
+func (k Keeper) getAIAgentMarketplaceListingsByIndex(ctx sdk.Context, start, end []byte) []types.AIAgentMarketplaceListing {
+	var listings []types.AIAgentMarketplaceListing
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(start, end)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		listing, found := k.GetAIAgentMarketplaceListing(ctx, string(iterator.Value()))
+		if found {
+			listings = append(listings, listing)
+		}
+	}
+
+	return listings
+}
+
 // CreateAIAgent creates a new AI agent
 func (k Keeper) CreateAIAgent(ctx sdk.Context, creator sdk.AccAddress, name string, description string, agentType types.AIAgentType, modelID string, permissions json.RawMessage, metadata json.RawMessage) (string, error) {
+	// Validate the permission policy
+	if _, err := types.ParseAgentPolicy(permissions); err != nil {
+		return "", err
+	}
+
 	// Generate a unique ID for the agent
 	id := fmt.Sprintf("%s-%d", creator.String(), ctx.BlockHeight())
 
@@ -314,6 +417,12 @@ func (k Keeper) CreateAIAgent(ctx sdk.Context, creator sdk.AccAddress, name stri
 		Metadata:    metadata,
 	}
 
+	// Mint the NFT representing the agent to its creator
+	agent, err := k.MintAIAgentNFT(ctx, agent)
+	if err != nil {
+		return "", err
+	}
+
 	// Store the agent
 	k.SetAIAgent(ctx, agent)
 
@@ -321,7 +430,7 @@ func (k Keeper) CreateAIAgent(ctx sdk.Context, creator sdk.AccAddress, name stri
 	state := types.AIAgentState{
 		AgentID:     id,
 		StateData:   json.RawMessage(`{}`),
-		StorageType: "chain",
+		StorageType: types.StorageTypeChain,
 		UpdatedAt:   ctx.BlockTime(),
 	}
 	k.SetAIAgentState(ctx, state)
@@ -329,7 +438,8 @@ func (k Keeper) CreateAIAgent(ctx sdk.Context, creator sdk.AccAddress, name stri
 	return id, nil
 }
 
-// TrainAIAgent trains an AI agent with new data
+// TrainAIAgent stores training data for an AI agent. Training jobs need a budget and
+// are opened with MsgTrainAIAgent, which can reference the stored data.
 func (k Keeper) TrainAIAgent(ctx sdk.Context, agentID string, owner sdk.AccAddress, dataType string, data json.RawMessage, source string) error {
 	// Get the agent
 	agent, found := k.GetAIAgent(ctx, agentID)
@@ -337,40 +447,24 @@ func (k Keeper) TrainAIAgent(ctx sdk.Context, agentID string, owner sdk.AccAddre
 		return fmt.Errorf("agent not found: %s", agentID)
 	}
 
-	// Check if the caller is the owner
-	if !agent.Owner.Equals(owner) {
-		return fmt.Errorf("only the owner can train the agent")
+	// Check if the caller may train the agent
+	if err := k.AuthorizeAgentTraining(ctx, agent, owner); err != nil {
+		return err
 	}
 
-	// Set agent status to training
-	agent.Status = types.AIAgentStatusTraining
-	agent.UpdatedAt = ctx.BlockTime()
-	k.SetAIAgent(ctx, agent)
-
 	// Create training data record
-	trainingData := types.AIAgentTrainingData{
-		ID:          fmt.Sprintf("%s-%d", agentID, ctx.BlockHeight()),
-		AgentID:     agentID,
-		DataType:    dataType,
-		Data:        data,
-		Source:      source,
-		Timestamp:   ctx.BlockTime(),
-		StorageType: "chain",
+	trainingData, err := k.NewAIAgentTrainingData(ctx, fmt.Sprintf("%s-%d", agentID, ctx.BlockHeight()), agentID, dataType, data, source, nil)
+	if err != nil {
+		return err
 	}
 	k.SetAIAgentTrainingData(ctx, trainingData)
 
-	// In a real implementation, we would initiate the training process here
-	// For now, we'll just update the agent status after a delay
-
-	// This would be handled by an external service or in EndBlocker
-	// For this example, we'll just set it back to active
-	agent.Status = types.AIAgentStatusActive
-	k.SetAIAgent(ctx, agent)
-
 	return nil
 }
 
-// ExecuteAIAgentAction executes an action using an AI agent
+// ExecuteAIAgentAction requests the execution of an action by an AI agent without a
+// fee. The result is produced asynchronously by the executors; the returned result is
+// empty and the recorded action is completed once the request is finalized.
 func (k Keeper) ExecuteAIAgentAction(ctx sdk.Context, agentID string, caller sdk.AccAddress, actionType string, actionData json.RawMessage) (json.RawMessage, error) {
 	// Get the agent
 	agent, found := k.GetAIAgent(ctx, agentID)
@@ -378,49 +472,39 @@ func (k Keeper) ExecuteAIAgentAction(ctx sdk.Context, agentID string, caller sdk
 		return nil, fmt.Errorf("agent not found: %s", agentID)
 	}
 
-	// Check if the agent is active
-	if agent.Status != types.AIAgentStatusActive {
+	// Check if the agent is active; listed agents remain usable by their owner and renters
+	if agent.Status != types.AIAgentStatusActive && agent.Status != types.AIAgentStatusForRent && agent.Status != types.AIAgentStatusForSale {
 		return nil, fmt.Errorf("agent is not active")
 	}
 
-	// Check permissions
-	// In a real implementation, we would check if the caller has permission to use this agent
-	// For simplicity, we'll just check if the caller is the owner
-	if !agent.Owner.Equals(caller) {
-		return nil, fmt.Errorf("only the owner can use this agent")
+	// Calls without a fee are only admitted by priced agents within the free tier
+	if _, err := k.ChargeAgentCall(ctx, agent, caller, actionType, nil); err != nil {
+		return nil, err
 	}
 
-	// Get the agent state
-	state, found := k.GetAIAgentState(ctx, agentID)
-	if !found {
-		return nil, fmt.Errorf("agent state not found")
+	// Check permissions against the agent's policy
+	if err := k.AuthorizeAgentCall(ctx, agent, caller, actionType, nil); err != nil {
+		return nil, err
 	}
 
-	// In a real implementation, we would:
-	// 1. Load the AI model
-	// 2. Process the action using the model
-	// 3. Update the agent state based on the result
-	// 4. Return the result
-
-	// For now, we'll just record the action and return a dummy result
-	action := types.AIAgentAction{
-		ID:         fmt.Sprintf("%s-%s-%d", agentID, actionType, ctx.BlockHeight()),
-		AgentID:    agentID,
-		ActionType: actionType,
-		Timestamp:  ctx.BlockTime(),
-		Data:       actionData,
-		Result:     json.RawMessage(`{"status": "success"}`),
-		Status:     "completed",
-		GasUsed:    1000, // Dummy value
+	// Check that the agent state exists
+	if _, found := k.GetAIAgentState(ctx, agentID); !found {
+		return nil, fmt.Errorf("agent state not found")
 	}
 
-	k.SetAIAgentAction(ctx, action)
+	if model, found := k.GetOnChainModel(ctx, agent); found {
+		action, err := k.ExecuteOnChainInference(ctx, agent, model, caller, actionType, actionData, nil)
+		if err != nil {
+			return nil, err
+		}
+		return action.Result, nil
+	}
 
-	// Update the agent state
-	state.UpdatedAt = ctx.BlockTime()
-	k.SetAIAgentState(ctx, state)
+	if _, err := k.RequestAIAgentExecution(ctx, agent, caller, actionType, actionData, nil); err != nil {
+		return nil, err
+	}
 
-	return action.Result, nil
+	return nil, nil
 }
 
 // ListAIAgentForSale lists an AI agent for sale on the marketplace
@@ -499,14 +583,22 @@ func (k Keeper) BuyAIAgent(ctx sdk.Context, listingID string, buyer sdk.AccAddre
 		return fmt.Errorf("agent not found: %s", listing.AgentID)
 	}
 
-	// Transfer payment from buyer to seller
-	err := k.bankKeeper.SendCoinsFromAccountToAccount(ctx, buyer, listing.Seller, listing.Price)
+	// The agent NFT may have been transferred since it was listed
+	if !agent.Owner.Equals(listing.Seller) {
+		return fmt.Errorf("seller no longer owns the agent")
+	}
+
+	// Settle the payment through the module account
+	_, err := k.SettleMarketplacePayment(ctx, buyer, listing.Seller, agent, listing.Price)
 	if err != nil {
 		return err
 	}
 
-	// Update the agent owner
-	agent.Owner = buyer
+	// Transfer the agent NFT to the buyer
+	agent, err = k.TransferAIAgent(ctx, agent, buyer)
+	if err != nil {
+		return err
+	}
 	agent.Status = types.AIAgentStatusActive
 	agent.UpdatedAt = ctx.BlockTime()
 
@@ -544,33 +636,28 @@ func (k Keeper) RentAIAgent(ctx sdk.Context, listingID string, renter sdk.AccAdd
 		return fmt.Errorf("agent not found: %s", listing.AgentID)
 	}
 
-	// Transfer rental payment from renter to seller
-	err := k.bankKeeper.SendCoinsFromAccountToAccount(ctx, renter, listing.Seller, listing.RentalPrice)
-	if err != nil {
-		return err
+	// The agent NFT may have been transferred since it was listed
+	if !agent.Owner.Equals(listing.Seller) {
+		return fmt.Errorf("seller no longer owns the agent")
 	}
 
-	// In a real implementation, we would:
-	// 1. Create a rental agreement
-	// 2. Set up a temporary permission for the renter
-	// 3. Schedule the return of the agent after the rental period
+	// The owner already has access and a renter cannot hold two overlapping rentals
+	if agent.Owner.Equals(renter) {
+		return fmt.Errorf("owner cannot rent their own agent")
+	}
+	if k.HasActiveAIAgentRental(ctx, listing.AgentID, renter) {
+		return fmt.Errorf("renter already has an active rental for this agent")
+	}
 
-	// For now, we'll just update the listing status
-	listing.Status = "rented"
+	// Settle the rental payment through the module account
+	_, err := k.SettleMarketplacePayment(ctx, renter, listing.Seller, agent, listing.RentalPrice)
+	if err != nil {
+		return err
+	}
 
-	// Store the updated listing
-	k.SetAIAgentMarketplaceListing(ctx, listing)
+	// Grant the renter access for the full rental duration of the listing.
+	// The listing stays active so the agent can be rented by others as well.
+	k.CreateAIAgentRental(ctx, listing, renter, listing.RentalDuration, listing.RentalPrice)
 
 	return nil
-}
-
-// ProcessAIAgentActions processes pending AI agent actions
-func (k Keeper) ProcessAIAgentActions(ctx sdk.Context) {
-	// In a real implementation, this would:
-	// 1. Get all active AI agents
-	// 2. For each agent, check if it has any scheduled or automated actions
-	// 3. Execute those actions based on the agent's AI model and current state
-	// 4. Update the agent state and record the actions
-
-	// For now, this is just a placeholder
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// Migrator is a struct for handling in-place store migrations
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates the store from consensus version 1 to 2 by setting the default
+// params added since version 1, minting the NFTs of the existing agents and building the
+// agent index of actions and the status index of marketplace listings
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	k := m.keeper
+	defaults := types.DefaultParams()
+
+	k.paramstore.Set(ctx, types.KeyMarketplaceFeeRecipient, defaults.MarketplaceFeeRecipient)
+	k.paramstore.Set(ctx, types.KeyCreatorRoyaltyRate, defaults.CreatorRoyaltyRate)
+	k.paramstore.Set(ctx, types.KeyAuctionExtensionSeconds, defaults.AuctionExtensionSeconds)
+	k.paramstore.Set(ctx, types.KeyMinBidIncrementRate, defaults.MinBidIncrementRate)
+	k.paramstore.Set(ctx, types.KeyMinExecutorBond, defaults.MinExecutorBond)
+	k.paramstore.Set(ctx, types.KeyExecutionQuorum, defaults.ExecutionQuorum)
+	k.paramstore.Set(ctx, types.KeyExecutionCommitBlocks, defaults.ExecutionCommitBlocks)
+	k.paramstore.Set(ctx, types.KeyExecutionRevealBlocks, defaults.ExecutionRevealBlocks)
+	k.paramstore.Set(ctx, types.KeyExecutorFeeRate, defaults.ExecutorFeeRate)
+	k.paramstore.Set(ctx, types.KeyExecutorSlashRate, defaults.ExecutorSlashRate)
+	k.paramstore.Set(ctx, types.KeyTrainingClaimBlocks, defaults.TrainingClaimBlocks)
+	k.paramstore.Set(ctx, types.KeyTrainingSubmitBlocks, defaults.TrainingSubmitBlocks)
+	k.paramstore.Set(ctx, types.KeyTrainingReviewBlocks, defaults.TrainingReviewBlocks)
+	k.paramstore.Set(ctx, types.KeyMaxInlineStateSize, defaults.MaxInlineStateSize)
+	k.paramstore.Set(ctx, types.KeyStateHistoryRetention, defaults.StateHistoryRetention)
+	k.paramstore.Set(ctx, types.KeyMaxPipelineDepth, defaults.MaxPipelineDepth)
+	k.paramstore.Set(ctx, types.KeyMaxPipelineSteps, defaults.MaxPipelineSteps)
+	k.paramstore.Set(ctx, types.KeyMaxAgentWalletMsgs, defaults.MaxAgentWalletMsgs)
+	k.paramstore.Set(ctx, types.KeyMaxScheduledRuns, defaults.MaxScheduledRuns)
+	k.paramstore.Set(ctx, types.KeyScheduledRunsGasLimit, defaults.ScheduledRunsGasLimit)
+	k.paramstore.Set(ctx, types.KeyGovVoteGasLimit, defaults.GovVoteGasLimit)
+	k.paramstore.Set(ctx, types.KeyRatingPriorMean, defaults.RatingPriorMean)
+	k.paramstore.Set(ctx, types.KeyRatingPriorWeight, defaults.RatingPriorWeight)
+	k.paramstore.Set(ctx, types.KeyRatingHalfLifeSeconds, defaults.RatingHalfLifeSeconds)
+	k.paramstore.Set(ctx, types.KeyBuyoutThreshold, defaults.BuyoutThreshold)
+
+	// Collect the records first: the setters write the indexes into the store the
+	// records are read from
+	agents := k.GetAllAIAgents(ctx)
+	actions := k.GetAllAIAgentActions(ctx)
+	listings := k.GetAllAIAgentMarketplaceListings(ctx)
+
+	// Agents are listed by owner through the x/nft owner index, so every agent needs an
+	// NFT; existing agents are minted to their owner and get their wallet derived
+	if err := k.EnsureAgentNFTClass(ctx); err != nil {
+		return err
+	}
+	for _, agent := range agents {
+		if agent.Wallet.Empty() {
+			agent.Wallet = types.AgentWalletAddress(agent.ID)
+		}
+		if agent.NFTID == "" || !k.nftKeeper.HasNFT(ctx, types.AgentNFTClassID, agent.NFTID) {
+			var err error
+			agent, err = k.MintAIAgentNFT(ctx, agent)
+			if err != nil {
+				return err
+			}
+		}
+		k.SetAIAgent(ctx, agent)
+	}
+	for _, action := range actions {
+		k.SetAIAgentAction(ctx, action)
+	}
+	for _, listing := range listings {
+		k.SetAIAgentMarketplaceListing(ctx, listing)
+	}
+
+	k.Logger(ctx).Info("migrated deai store", "agents", len(agents), "actions", len(actions), "listings", len(listings))
+	return nil
+}
\ No newline at end of file
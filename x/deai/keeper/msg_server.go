@@ -2,7 +2,9 @@ package keeper
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -26,6 +28,11 @@ var _ types.MsgServer = msgServer{}
 func (k msgServer) CreateAIAgent(goCtx context.Context, msg *types.MsgCreateAIAgent) (*types.MsgCreateAIAgentResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
+	// Validate the permission policy
+	if _, err := types.ParseAgentPolicy(msg.Permissions); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidPermissions, err.Error())
+	}
+
 	// Generate a unique ID for the agent
 	id := fmt.Sprintf("%s-%d", msg.Creator.String(), ctx.BlockHeight())
 
@@ -43,6 +50,13 @@ func (k msgServer) CreateAIAgent(goCtx context.Context, msg *types.MsgCreateAIAg
 		UpdatedAt:   ctx.BlockTime(),
 		Permissions: msg.Permissions,
 		Metadata:    msg.Metadata,
+		Wallet:      types.AgentWalletAddress(id),
+	}
+
+	// Mint the NFT representing the agent to its creator
+	agent, err := k.MintAIAgentNFT(ctx, agent)
+	if err != nil {
+		return nil, err
 	}
 
 	// Store the agent
@@ -52,7 +66,7 @@ func (k msgServer) CreateAIAgent(goCtx context.Context, msg *types.MsgCreateAIAg
 	state := types.AIAgentState{
 		AgentID:     id,
 		StateData:   []byte("{}"),
-		StorageType: "chain",
+		StorageType: types.StorageTypeChain,
 		UpdatedAt:   ctx.BlockTime(),
 	}
 	k.SetAIAgentState(ctx, state)
@@ -65,6 +79,7 @@ func (k msgServer) CreateAIAgent(goCtx context.Context, msg *types.MsgCreateAIAg
 			sdk.NewAttribute("creator", msg.Creator.String()),
 			sdk.NewAttribute("name", msg.Name),
 			sdk.NewAttribute("model_id", msg.ModelID),
+			sdk.NewAttribute("nft_id", agent.NFTID),
 		),
 	)
 
@@ -88,6 +103,11 @@ func (k msgServer) UpdateAIAgent(goCtx context.Context, msg *types.MsgUpdateAIAg
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can update the agent")
 	}
 
+	// Validate the permission policy
+	if _, err := types.ParseAgentPolicy(msg.Permissions); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidPermissions, err.Error())
+	}
+
 	// Update the agent
 	agent.Name = msg.Name
 	agent.Description = msg.Description
@@ -111,7 +131,8 @@ func (k msgServer) UpdateAIAgent(goCtx context.Context, msg *types.MsgUpdateAIAg
 	return &types.MsgUpdateAIAgentResponse{}, nil
 }
 
-// TrainAIAgent trains an AI agent with new data
+// TrainAIAgent opens a training job for an AI agent. The budget is held in escrow
+// until the owner reviews the trained model or the job times out.
 func (k msgServer) TrainAIAgent(goCtx context.Context, msg *types.MsgTrainAIAgent) (*types.MsgTrainAIAgentResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -121,27 +142,36 @@ func (k msgServer) TrainAIAgent(goCtx context.Context, msg *types.MsgTrainAIAgen
 		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", msg.AgentID))
 	}
 
-	// Check if the caller is the owner
-	if !agent.Owner.Equals(msg.Owner) {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can train the agent")
+	// Check if the caller may train the agent
+	if err := k.AuthorizeAgentTraining(ctx, agent, msg.Owner); err != nil {
+		return nil, err
 	}
 
-	// Set agent status to training
-	agent.Status = types.AIAgentStatusTraining
-	agent.UpdatedAt = ctx.BlockTime()
-	k.SetAIAgent(ctx, agent)
+	// Store submitted training data, or its storage reference, and reference it from the job
+	datasetRefs := msg.DatasetRefs
+	trainingDataID := ""
+	if len(msg.Data) > 0 || msg.StorageRef != nil {
+		trainingData, err := k.NewAIAgentTrainingData(ctx, fmt.Sprintf("%s-%d", msg.AgentID, ctx.BlockHeight()), msg.AgentID, msg.DataType, msg.Data, msg.Source, msg.StorageRef)
+		if err != nil {
+			return nil, err
+		}
+		// Encrypted data is only readable by the agent's key holder and the parties it grants
+		if len(msg.WrappedKey) > 0 {
+			trainingData, err = k.SealTrainingData(ctx, agent, trainingData, msg.WrappedKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+		k.SetAIAgentTrainingData(ctx, trainingData)
 
-	// Create training data record
-	trainingData := types.AIAgentTrainingData{
-		ID:          fmt.Sprintf("%s-%d", msg.AgentID, ctx.BlockHeight()),
-		AgentID:     msg.AgentID,
-		DataType:    msg.DataType,
-		Data:        msg.Data,
-		Source:      msg.Source,
-		Timestamp:   ctx.BlockTime(),
-		StorageType: "chain",
+		trainingDataID = trainingData.ID
+		datasetRefs = append([]string{trainingData.ID}, datasetRefs...)
+	}
+
+	job, err := k.CreateTrainingJob(ctx, agent, msg.Owner, datasetRefs, msg.Hyperparameters, msg.Budget)
+	if err != nil {
+		return nil, err
 	}
-	k.SetAIAgentTrainingData(ctx, trainingData)
 
 	// Emit event
 	ctx.EventManager().EmitEvent(
@@ -149,17 +179,23 @@ func (k msgServer) TrainAIAgent(goCtx context.Context, msg *types.MsgTrainAIAgen
 			"ai_agent_training_started",
 			sdk.NewAttribute("agent_id", msg.AgentID),
 			sdk.NewAttribute("owner", msg.Owner.String()),
-			sdk.NewAttribute("data_type", msg.DataType),
-			sdk.NewAttribute("training_data_id", trainingData.ID),
+			sdk.NewAttribute("job_id", job.ID),
+			sdk.NewAttribute("budget", msg.Budget.String()),
+			sdk.NewAttribute("training_data_id", trainingDataID),
+			sdk.NewAttribute("deadline", fmt.Sprintf("%d", job.Deadline)),
 		),
 	)
 
 	return &types.MsgTrainAIAgentResponse{
-		TrainingDataID: trainingData.ID,
+		TrainingDataID: trainingDataID,
+		JobID:          job.ID,
 	}, nil
 }
 
-// ExecuteAIAgent executes an action using an AI agent
+// ExecuteAIAgent requests the execution of an action by an AI agent. Agents with an
+// on-chain model are evaluated immediately; otherwise the fee is held in escrow until
+// the executors' results are finalized. Calls of priced agents are charged the agent's
+// price, and the part of the offered fee above it is not collected.
 func (k msgServer) ExecuteAIAgent(goCtx context.Context, msg *types.MsgExecuteAIAgent) (*types.MsgExecuteAIAgentResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -169,81 +205,84 @@ func (k msgServer) ExecuteAIAgent(goCtx context.Context, msg *types.MsgExecuteAI
 		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", msg.AgentID))
 	}
 
-	// Check if the agent is active
-	if agent.Status != types.AIAgentStatusActive {
+	// Check if the agent is active; listed agents remain usable by their owner and renters
+	if agent.Status != types.AIAgentStatusActive && agent.Status != types.AIAgentStatusForRent && agent.Status != types.AIAgentStatusForSale {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent is not active")
 	}
 
-	// Check permissions
-	// In a real implementation, we would check if the caller has permission to use this agent
-	// For simplicity, we'll just check if the caller is the owner or if the agent is public
-	if !agent.Owner.Equals(msg.Sender) {
-		// Check if the agent has public permissions
-		// This would be implemented based on the permissions field
-		isPublic := false // Placeholder for permission check
-		if !isPublic {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "no permission to use this agent")
-		}
+	// Count the call against the sender's subscription, or charge the agent's price, or
+	// the offered fee if the call is not priced
+	offered := sdk.NewCoins(msg.Fee)
+	fee, err := k.ChargeAgentCall(ctx, agent, msg.Sender, msg.ActionType, offered)
+	if err != nil {
+		return nil, err
 	}
+	refund := offered.Sub(fee...)
 
-	// Collect the fee
-	err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, msg.Sender, types.ModuleName, sdk.NewCoins(msg.Fee))
-	if err != nil {
-		return nil, sdkerrors.Wrap(err, "failed to collect fee")
+	// Check permissions against the agent's policy
+	if err := k.AuthorizeAgentCall(ctx, agent, msg.Sender, msg.ActionType, fee); err != nil {
+		return nil, err
 	}
 
-	// Get the agent state
-	state, found := k.GetAIAgentState(ctx, msg.AgentID)
-	if !found {
+	// Check that the agent state exists
+	if _, found := k.GetAIAgentState(ctx, msg.AgentID); !found {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, "agent state not found")
 	}
 
-	// In a real implementation, we would:
-	// 1. Load the AI model
-	// 2. Process the action using the model
-	// 3. Update the agent state based on the result
-	// 4. Return the result
+	// Small models flagged on_chain are evaluated within the transaction
+	if model, found := k.GetOnChainModel(ctx, agent); found {
+		action, err := k.ExecuteOnChainInference(ctx, agent, model, msg.Sender, msg.ActionType, msg.Data, fee)
+		if err != nil {
+			return nil, err
+		}
 
-	// For now, we'll just record the action and return a dummy result
-	actionID := fmt.Sprintf("%s-%s-%d", msg.AgentID, msg.ActionType, ctx.BlockHeight())
-	action := types.AIAgentAction{
-		ID:         actionID,
-		AgentID:    msg.AgentID,
-		ActionType: msg.ActionType,
-		Timestamp:  ctx.BlockTime(),
-		Data:       msg.Data,
-		Result:     []byte(`{"status": "success", "message": "Action executed successfully"}`),
-		Status:     "completed",
-		GasUsed:    1000, // Dummy value
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"ai_agent_executed",
+				sdk.NewAttribute("agent_id", msg.AgentID),
+				sdk.NewAttribute("sender", msg.Sender.String()),
+				sdk.NewAttribute("action_type", msg.ActionType),
+				sdk.NewAttribute("action_id", action.ID),
+				sdk.NewAttribute("fee", fee.String()),
+				sdk.NewAttribute("refund", refund.String()),
+				sdk.NewAttribute("gas_used", fmt.Sprintf("%d", action.GasUsed)),
+			),
+		)
+
+		return &types.MsgExecuteAIAgentResponse{
+			ActionID: action.ID,
+			Result:   action.Result,
+			Fee:      fee,
+			Refund:   refund,
+		}, nil
 	}
 
-	k.SetAIAgentAction(ctx, action)
-
-	// Update the agent state
-	state.UpdatedAt = ctx.BlockTime()
-	k.SetAIAgentState(ctx, state)
-
-	// Distribute the fee to the agent owner
-	err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, agent.Owner, sdk.NewCoins(msg.Fee))
+	// Escrow the fee and record the execution request. The result is produced by the
+	// executors, which commit to and reveal it within the following blocks.
+	request, err := k.RequestAIAgentExecution(ctx, agent, msg.Sender, msg.ActionType, msg.Data, fee)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "failed to distribute fee")
+		return nil, err
 	}
 
 	// Emit event
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
-			"ai_agent_executed",
+			"ai_agent_execution_requested",
 			sdk.NewAttribute("agent_id", msg.AgentID),
 			sdk.NewAttribute("sender", msg.Sender.String()),
 			sdk.NewAttribute("action_type", msg.ActionType),
-			sdk.NewAttribute("action_id", actionID),
-			sdk.NewAttribute("fee", msg.Fee.String()),
+			sdk.NewAttribute("action_id", request.ID),
+			sdk.NewAttribute("fee", fee.String()),
+			sdk.NewAttribute("refund", refund.String()),
+			sdk.NewAttribute("commit_deadline", fmt.Sprintf("%d", request.CommitDeadline)),
+			sdk.NewAttribute("reveal_deadline", fmt.Sprintf("%d", request.RevealDeadline)),
 		),
 	)
 
 	return &types.MsgExecuteAIAgentResponse{
-		ActionID: actionID,
-		Result:   action.Result,
+		ActionID: request.ID,
+		Fee:      fee,
+		Refund:   refund,
 	}, nil
 }
 
@@ -257,10 +296,13 @@ func (k msgServer) ListAIAgentForSale(goCtx context.Context, msg *types.MsgListA
 		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", msg.AgentID))
 	}
 
-	// Check if the caller is the owner
-	if !agent.Owner.Equals(msg.Seller) {
+	// Check if the caller is the owner, or the curator of a fractionalized agent
+	if !k.AgentSeller(ctx, agent).Equals(msg.Seller) {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can list the agent for sale")
 	}
+	if k.IsAgentFractionalized(ctx, agent.ID) && msg.ListingType != types.ListingTypeRent {
+		return nil, sdkerrors.Wrap(types.ErrAgentFractionalized, "a fractionalized agent can only be listed for rent")
+	}
 
 	// Check if the agent is already listed
 	if agent.Status == types.AIAgentStatusForSale || agent.Status == types.AIAgentStatusForRent {
@@ -285,6 +327,7 @@ func (k msgServer) ListAIAgentForSale(goCtx context.Context, msg *types.MsgListA
 		Status:          "active",
 		CreatedAt:       ctx.BlockTime(),
 		ExpiresAt:       expiresAt,
+		ReservePrice:    msg.ReservePrice,
 	}
 
 	// Update the agent status
@@ -344,14 +387,22 @@ func (k msgServer) BuyAIAgent(goCtx context.Context, msg *types.MsgBuyAIAgent) (
 		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", listing.AgentID))
 	}
 
-	// Transfer payment from buyer to seller
-	err := k.bankKeeper.SendCoinsFromAccountToAccount(ctx, msg.Buyer, listing.Seller, listing.Price)
+	// The agent NFT may have been transferred since it was listed
+	if !agent.Owner.Equals(listing.Seller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "seller no longer owns the agent")
+	}
+
+	// Settle the payment through the module account
+	settlement, err := k.SettleMarketplacePayment(ctx, msg.Buyer, listing.Seller, agent, listing.Price)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "failed to transfer payment")
+		return nil, err
 	}
 
-	// Update the agent owner
-	agent.Owner = msg.Buyer
+	// Transfer the agent NFT to the buyer
+	agent, err = k.TransferAIAgent(ctx, agent, msg.Buyer)
+	if err != nil {
+		return nil, err
+	}
 	agent.Status = types.AIAgentStatusActive
 	agent.UpdatedAt = ctx.BlockTime()
 
@@ -371,6 +422,10 @@ func (k msgServer) BuyAIAgent(goCtx context.Context, msg *types.MsgBuyAIAgent) (
 			sdk.NewAttribute("seller", listing.Seller.String()),
 			sdk.NewAttribute("buyer", msg.Buyer.String()),
 			sdk.NewAttribute("price", listing.Price.String()),
+			sdk.NewAttribute("marketplace_fee", settlement.MarketplaceFee.String()),
+			sdk.NewAttribute("royalty", settlement.Royalty.String()),
+			sdk.NewAttribute("royalty_payee", settlement.RoyaltyPayee.String()),
+			sdk.NewAttribute("seller_proceeds", settlement.SellerProceeds.String()),
 		),
 	)
 
@@ -410,42 +465,54 @@ func (k msgServer) RentAIAgent(goCtx context.Context, msg *types.MsgRentAIAgent)
 		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", listing.AgentID))
 	}
 
-	// Calculate the rental price based on the requested duration
-	rentalPrice := listing.RentalPrice
-	if msg.Duration < listing.RentalDuration {
-		// Adjust price proportionally
-		for i := range rentalPrice {
-			amount := rentalPrice[i].Amount.MulRaw(int64(msg.Duration)).QuoRaw(int64(listing.RentalDuration))
-			rentalPrice[i] = sdk.NewCoin(rentalPrice[i].Denom, amount)
-		}
+	// The agent NFT may have been transferred since it was listed
+	if !k.AgentSeller(ctx, agent).Equals(listing.Seller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "seller no longer owns the agent")
+	}
+
+	// The owner already has access and a renter cannot hold two overlapping rentals
+	if agent.Owner.Equals(msg.Renter) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "owner cannot rent their own agent")
 	}
+	if k.HasActiveAIAgentRental(ctx, listing.AgentID, msg.Renter) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "renter already has an active rental for this agent")
+	}
+
+	// Calculate the rental price based on the requested duration
+	rentalPrice := listing.RentalPriceFor(msg.Duration)
 
-	// Transfer payment from renter to owner
-	err := k.bankKeeper.SendCoinsFromAccountToAccount(ctx, msg.Renter, listing.Seller, rentalPrice)
+	// Settle the payment through the module account
+	settlement, err := k.SettleMarketplacePayment(ctx, msg.Renter, listing.Seller, agent, rentalPrice)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "failed to transfer payment")
+		return nil, err
 	}
 
-	// Create a rental record
-	// In a real implementation, we would create a record of the rental
-	// and grant temporary access to the agent
+	// Create a rental record granting the renter access until the rental ends
+	rental := k.CreateAIAgentRental(ctx, listing, msg.Renter, msg.Duration, rentalPrice)
 
-	// For now, we'll just emit an event
+	// Emit event
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			"ai_agent_rented",
 			sdk.NewAttribute("listing_id", msg.ListingID),
 			sdk.NewAttribute("agent_id", listing.AgentID),
+			sdk.NewAttribute("rental_id", rental.ID),
 			sdk.NewAttribute("owner", listing.Seller.String()),
 			sdk.NewAttribute("renter", msg.Renter.String()),
 			sdk.NewAttribute("duration", fmt.Sprintf("%d", msg.Duration)),
 			sdk.NewAttribute("price", rentalPrice.String()),
+			sdk.NewAttribute("marketplace_fee", settlement.MarketplaceFee.String()),
+			sdk.NewAttribute("royalty", settlement.Royalty.String()),
+			sdk.NewAttribute("royalty_payee", settlement.RoyaltyPayee.String()),
+			sdk.NewAttribute("seller_proceeds", settlement.SellerProceeds.String()),
+			sdk.NewAttribute("expires_at", rental.EndTime.String()),
 		),
 	)
 
 	return &types.MsgRentAIAgentResponse{
 		AgentID:  listing.AgentID,
 		Duration: msg.Duration,
+		RentalID: rental.ID,
 	}, nil
 }
 
@@ -469,6 +536,11 @@ func (k msgServer) CancelMarketListing(goCtx context.Context, msg *types.MsgCanc
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the seller can cancel the listing")
 	}
 
+	// Bids are escrowed until the auction closes
+	if listing.ListingType == types.ListingTypeEnglishAuction && !listing.HighestBidder.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "cannot cancel an auction that has received bids")
+	}
+
 	// Get the agent
 	agent, found := k.GetAIAgent(ctx, listing.AgentID)
 	if !found {
@@ -497,4 +569,972 @@ func (k msgServer) CancelMarketListing(goCtx context.Context, msg *types.MsgCanc
 	)
 
 	return &types.MsgCancelMarketListingResponse{}, nil
+}
+
+// PlaceBid places a bid on an auction listing. Bids on English auctions are escrowed
+// until the auction closes; a bid on a Dutch auction that meets the current asking
+// price buys the agent immediately at that price.
+func (k msgServer) PlaceBid(goCtx context.Context, msg *types.MsgPlaceBid) (*types.MsgPlaceBidResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	// Get the listing
+	listing, found := k.GetAIAgentMarketplaceListing(ctx, msg.ListingID)
+	if !found {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("listing not found: %s", msg.ListingID))
+	}
+
+	// Check if the listing is an open auction
+	if !listing.IsAuction() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "listing is not an auction")
+	}
+	if listing.Status != "active" || ctx.BlockTime().After(listing.ExpiresAt) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionClosed, msg.ListingID)
+	}
+	if listing.Seller.Equals(msg.Bidder) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "seller cannot bid on their own auction")
+	}
+
+	// Get the agent
+	agent, found := k.GetAIAgent(ctx, listing.AgentID)
+	if !found {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", listing.AgentID))
+	}
+
+	// The agent NFT may have been transferred since it was listed
+	if !agent.Owner.Equals(listing.Seller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "seller no longer owns the agent")
+	}
+
+	if listing.ListingType == types.ListingTypeEnglishAuction {
+		listing, err := k.PlaceEnglishAuctionBid(ctx, listing, msg.Bidder, msg.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		// Emit event
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				"ai_agent_bid_placed",
+				sdk.NewAttribute("listing_id", msg.ListingID),
+				sdk.NewAttribute("agent_id", listing.AgentID),
+				sdk.NewAttribute("bidder", msg.Bidder.String()),
+				sdk.NewAttribute("bid_amount", msg.Amount.String()),
+				sdk.NewAttribute("expires_at", listing.ExpiresAt.String()),
+			),
+		)
+
+		return &types.MsgPlaceBidResponse{
+			ListingID: msg.ListingID,
+		}, nil
+	}
+
+	// Dutch auction: the first bid at or above the current asking price wins
+	price := listing.DutchAuctionPrice(ctx.BlockTime())
+	if !msg.Amount.IsAllGTE(price) {
+		return nil, sdkerrors.Wrapf(types.ErrBidTooLow, "current price is %s", price)
+	}
+
+	settlement, err := k.SettleMarketplacePayment(ctx, msg.Bidder, listing.Seller, agent, price)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transfer the agent NFT to the winning bidder
+	agent, err = k.TransferAIAgent(ctx, agent, msg.Bidder)
+	if err != nil {
+		return nil, err
+	}
+	agent.Status = types.AIAgentStatusActive
+	agent.UpdatedAt = ctx.BlockTime()
+
+	// Update the listing
+	listing.HighestBid = price
+	listing.HighestBidder = msg.Bidder
+	listing.BidCount++
+	listing.Status = "completed"
+
+	// Store the updated agent and listing
+	k.SetAIAgent(ctx, agent)
+	k.SetAIAgentMarketplaceListing(ctx, listing)
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"ai_agent_sold",
+			sdk.NewAttribute("listing_id", msg.ListingID),
+			sdk.NewAttribute("agent_id", listing.AgentID),
+			sdk.NewAttribute("listing_type", listing.ListingType),
+			sdk.NewAttribute("seller", listing.Seller.String()),
+			sdk.NewAttribute("buyer", msg.Bidder.String()),
+			sdk.NewAttribute("price", price.String()),
+			sdk.NewAttribute("marketplace_fee", settlement.MarketplaceFee.String()),
+			sdk.NewAttribute("royalty", settlement.Royalty.String()),
+			sdk.NewAttribute("royalty_payee", settlement.RoyaltyPayee.String()),
+			sdk.NewAttribute("seller_proceeds", settlement.SellerProceeds.String()),
+		),
+	)
+
+	return &types.MsgPlaceBidResponse{
+		ListingID: msg.ListingID,
+		Sold:      true,
+	}, nil
+}
+
+// RegisterExecutor registers the sender as an executor, or tops up its bond
+func (k msgServer) RegisterExecutor(goCtx context.Context, msg *types.MsgRegisterExecutor) (*types.MsgRegisterExecutorResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	executor, err := k.Keeper.RegisterExecutor(ctx, msg.Executor, msg.Bond)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"executor_registered",
+			sdk.NewAttribute("executor", msg.Executor.String()),
+			sdk.NewAttribute("amount", msg.Bond.String()),
+			sdk.NewAttribute("bond", executor.Bond.String()),
+		),
+	)
+
+	return &types.MsgRegisterExecutorResponse{
+		Bond: executor.Bond,
+	}, nil
+}
+
+// UnregisterExecutor unregisters an executor and returns its bond
+func (k msgServer) UnregisterExecutor(goCtx context.Context, msg *types.MsgUnregisterExecutor) (*types.MsgUnregisterExecutorResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	refunded, err := k.Keeper.UnregisterExecutor(ctx, msg.Executor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"executor_unregistered",
+			sdk.NewAttribute("executor", msg.Executor.String()),
+			sdk.NewAttribute("amount", refunded.String()),
+		),
+	)
+
+	return &types.MsgUnregisterExecutorResponse{
+		Refunded: refunded,
+	}, nil
+}
+
+// CommitExecutionResult commits an executor to the result of an execution request
+func (k msgServer) CommitExecutionResult(goCtx context.Context, msg *types.MsgCommitExecutionResult) (*types.MsgCommitExecutionResultResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.Keeper.CommitExecutionResult(ctx, msg.Executor, msg.RequestID, msg.Commitment); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"execution_result_committed",
+			sdk.NewAttribute("executor", msg.Executor.String()),
+			sdk.NewAttribute("request_id", msg.RequestID),
+		),
+	)
+
+	return &types.MsgCommitExecutionResultResponse{}, nil
+}
+
+// RevealExecutionResult reveals a committed result. The request is finalized as soon as
+// every committed executor has revealed.
+func (k msgServer) RevealExecutionResult(goCtx context.Context, msg *types.MsgRevealExecutionResult) (*types.MsgRevealExecutionResultResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	request, err := k.Keeper.RevealExecutionResult(ctx, msg.Executor, msg.RequestID, msg.Result, msg.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"execution_result_revealed",
+			sdk.NewAttribute("executor", msg.Executor.String()),
+			sdk.NewAttribute("request_id", msg.RequestID),
+			sdk.NewAttribute("status", request.Status),
+		),
+	)
+
+	return &types.MsgRevealExecutionResultResponse{
+		Finalized: request.Status != types.ExecutionStatusPending,
+		Status:    request.Status,
+	}, nil
+}
+
+// ClaimTrainingJob assigns an open training job to the trainer
+func (k msgServer) ClaimTrainingJob(goCtx context.Context, msg *types.MsgClaimTrainingJob) (*types.MsgClaimTrainingJobResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	job, err := k.Keeper.ClaimTrainingJob(ctx, msg.Trainer, msg.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"training_job_claimed",
+			sdk.NewAttribute("job_id", job.ID),
+			sdk.NewAttribute("agent_id", job.AgentID),
+			sdk.NewAttribute("trainer", msg.Trainer.String()),
+			sdk.NewAttribute("deadline", fmt.Sprintf("%d", job.Deadline)),
+		),
+	)
+
+	return &types.MsgClaimTrainingJobResponse{
+		Deadline: job.Deadline,
+	}, nil
+}
+
+// SubmitTrainingResult records the trained model of a claimed job for the owner's review
+func (k msgServer) SubmitTrainingResult(goCtx context.Context, msg *types.MsgSubmitTrainingResult) (*types.MsgSubmitTrainingResultResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	job, err := k.Keeper.SubmitTrainingResult(ctx, msg.Trainer, msg.JobID, msg.ModelHash, msg.ModelURI, msg.Parameters, msg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"training_result_submitted",
+			sdk.NewAttribute("job_id", job.ID),
+			sdk.NewAttribute("agent_id", job.AgentID),
+			sdk.NewAttribute("trainer", msg.Trainer.String()),
+			sdk.NewAttribute("model_hash", msg.ModelHash),
+			sdk.NewAttribute("deadline", fmt.Sprintf("%d", job.Deadline)),
+		),
+	)
+
+	return &types.MsgSubmitTrainingResultResponse{
+		Deadline: job.Deadline,
+	}, nil
+}
+
+// ReviewTrainingResult accepts or rejects the trained model of a job
+func (k msgServer) ReviewTrainingResult(goCtx context.Context, msg *types.MsgReviewTrainingResult) (*types.MsgReviewTrainingResultResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	job, err := k.Keeper.ReviewTrainingResult(ctx, msg.Owner, msg.JobID, msg.Accept, msg.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"training_result_reviewed",
+			sdk.NewAttribute("job_id", job.ID),
+			sdk.NewAttribute("agent_id", job.AgentID),
+			sdk.NewAttribute("trainer", job.Trainer.String()),
+			sdk.NewAttribute("status", job.Status),
+			sdk.NewAttribute("model_id", job.ResultModelID),
+		),
+	)
+
+	return &types.MsgReviewTrainingResultResponse{
+		ModelID: job.ResultModelID,
+	}, nil
+}
+
+// UpdateAIAgentState replaces the state of an AI agent with inline data or a reference
+// to state stored off chain
+func (k msgServer) UpdateAIAgentState(goCtx context.Context, msg *types.MsgUpdateAIAgentState) (*types.MsgUpdateAIAgentStateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	// Get the agent
+	agent, found := k.GetAIAgent(ctx, msg.AgentID)
+	if !found {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", msg.AgentID))
+	}
+
+	// Check if the caller is the owner, or the curator of a fractionalized agent
+	if !k.AgentSeller(ctx, agent).Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can update the agent state")
+	}
+
+	state, err := k.UpdateAIAgentStateData(ctx, msg.AgentID, msg.StateData, msg.StorageRef)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := ""
+	if state.StorageRef != nil {
+		digest = state.StorageRef.Digest
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"ai_agent_state_updated",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("storage_type", state.StorageType),
+			sdk.NewAttribute("digest", digest),
+		),
+	)
+
+	return &types.MsgUpdateAIAgentStateResponse{}, nil
+}
+
+// RollbackAgentState restores a previous version of an AI agent's state
+func (k msgServer) RollbackAgentState(goCtx context.Context, msg *types.MsgRollbackAgentState) (*types.MsgRollbackAgentStateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	action, err := k.RollbackAIAgentState(ctx, msg.AgentID, msg.Owner, msg.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	state, _ := k.GetAIAgentState(ctx, msg.AgentID)
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"ai_agent_state_rolled_back",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("restored_version", fmt.Sprintf("%d", msg.Version)),
+			sdk.NewAttribute("version", fmt.Sprintf("%d", state.Version)),
+			sdk.NewAttribute("action_id", action.ID),
+		),
+	)
+
+	return &types.MsgRollbackAgentStateResponse{
+		ActionID: action.ID,
+		Version:  state.Version,
+	}, nil
+}
+
+// CreatePipeline composes AI agents into a pipeline
+func (k msgServer) CreatePipeline(goCtx context.Context, msg *types.MsgCreatePipeline) (*types.MsgCreatePipelineResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	pipeline, err := k.Keeper.CreatePipeline(ctx, types.Pipeline{
+		Owner:       msg.Owner,
+		Name:        msg.Name,
+		Description: msg.Description,
+		Steps:       msg.Steps,
+		OutputStep:  msg.OutputStep,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"pipeline_created",
+			sdk.NewAttribute("pipeline_id", pipeline.ID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("steps", fmt.Sprintf("%d", len(pipeline.Steps))),
+		),
+	)
+
+	return &types.MsgCreatePipelineResponse{
+		PipelineID: pipeline.ID,
+	}, nil
+}
+
+// DeletePipeline deletes a pipeline
+func (k msgServer) DeletePipeline(goCtx context.Context, msg *types.MsgDeletePipeline) (*types.MsgDeletePipelineResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	pipeline, found := k.GetPipeline(ctx, msg.PipelineID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrPipelineNotFound, msg.PipelineID)
+	}
+	if !pipeline.Owner.Equals(msg.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can delete the pipeline")
+	}
+
+	k.Keeper.DeletePipeline(ctx, msg.PipelineID)
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"pipeline_deleted",
+			sdk.NewAttribute("pipeline_id", msg.PipelineID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+		),
+	)
+
+	return &types.MsgDeletePipelineResponse{}, nil
+}
+
+// ExecutePipeline runs the steps of a pipeline within the transaction
+func (k msgServer) ExecutePipeline(goCtx context.Context, msg *types.MsgExecutePipeline) (*types.MsgExecutePipelineResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	action, err := k.Keeper.ExecutePipeline(ctx, msg.PipelineID, msg.Sender, msg.Data, msg.MaxFee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"pipeline_executed",
+			sdk.NewAttribute("pipeline_id", msg.PipelineID),
+			sdk.NewAttribute("sender", msg.Sender.String()),
+			sdk.NewAttribute("action_id", action.ID),
+			sdk.NewAttribute("gas_used", fmt.Sprintf("%d", action.GasUsed)),
+		),
+	)
+
+	return &types.MsgExecutePipelineResponse{
+		ActionID: action.ID,
+		Result:   action.Result,
+	}, nil
+}
+
+// FundAgentWallet sends coins to the wallet of an AI agent
+func (k msgServer) FundAgentWallet(goCtx context.Context, msg *types.MsgFundAgentWallet) (*types.MsgFundAgentWalletResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	wallet, err := k.Keeper.FundAgentWallet(ctx, msg.AgentID, msg.Sender, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_wallet_funded",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("sender", msg.Sender.String()),
+			sdk.NewAttribute("wallet", wallet.String()),
+			sdk.NewAttribute("amount", msg.Amount.String()),
+		),
+	)
+
+	return &types.MsgFundAgentWalletResponse{
+		Wallet: wallet.String(),
+	}, nil
+}
+
+// WithdrawAgentWallet sends coins out of the wallet of an AI agent
+func (k msgServer) WithdrawAgentWallet(goCtx context.Context, msg *types.MsgWithdrawAgentWallet) (*types.MsgWithdrawAgentWalletResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.Keeper.WithdrawAgentWallet(ctx, msg.AgentID, msg.Owner, msg.Recipient, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_wallet_withdrawn",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("recipient", msg.Recipient.String()),
+			sdk.NewAttribute("amount", msg.Amount.String()),
+		),
+	)
+
+	return &types.MsgWithdrawAgentWalletResponse{}, nil
+}
+
+// SetAgentWalletPolicy sets what an AI agent may do with its wallet
+func (k msgServer) SetAgentWalletPolicy(goCtx context.Context, msg *types.MsgSetAgentWalletPolicy) (*types.MsgSetAgentWalletPolicyResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	policy, err := k.UpdateAgentWalletPolicy(ctx, msg.Owner, msg.Policy())
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_wallet_policy_set",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("spend_limit", policy.SpendLimit.String()),
+			sdk.NewAttribute("period", policy.Period.String()),
+			sdk.NewAttribute("allowed_msg_types", strings.Join(policy.AllowedMsgTypes, ",")),
+			sdk.NewAttribute("allowed_requesters", joinAddresses(policy.AllowedRequesters)),
+		),
+	)
+
+	return &types.MsgSetAgentWalletPolicyResponse{}, nil
+}
+
+// ScheduleAgentAction escrows the budget of a new agent schedule and queues its first run
+func (k msgServer) ScheduleAgentAction(goCtx context.Context, msg *types.MsgScheduleAgentAction) (*types.MsgScheduleAgentActionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	schedule, err := k.Keeper.ScheduleAgentAction(ctx, msg.Schedule())
+	if err != nil {
+		return nil, err
+	}
+
+	next := schedule.NextRunTime.String()
+	if schedule.IsHeightBased() {
+		next = fmt.Sprintf("%d", schedule.NextRunHeight)
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_action_scheduled",
+			sdk.NewAttribute("schedule_id", schedule.ID),
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("action_type", msg.ActionType),
+			sdk.NewAttribute("fee_per_run", msg.FeePerRun.String()),
+			sdk.NewAttribute("budget", msg.Budget.String()),
+			sdk.NewAttribute("next_run", next),
+		),
+	)
+
+	return &types.MsgScheduleAgentActionResponse{
+		ScheduleID: schedule.ID,
+	}, nil
+}
+
+// CancelAgentSchedule cancels an agent schedule and refunds the rest of its budget
+func (k msgServer) CancelAgentSchedule(goCtx context.Context, msg *types.MsgCancelAgentSchedule) (*types.MsgCancelAgentScheduleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	schedule, refund, err := k.Keeper.CancelAgentSchedule(ctx, msg.Owner, msg.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_schedule_cancelled",
+			sdk.NewAttribute("schedule_id", msg.ScheduleID),
+			sdk.NewAttribute("agent_id", schedule.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("runs", fmt.Sprintf("%d", schedule.Runs)),
+			sdk.NewAttribute("refund", refund.String()),
+		),
+	)
+
+	return &types.MsgCancelAgentScheduleResponse{
+		Refund: refund,
+	}, nil
+}
+
+// SetAgentPricing sets the price of calls of an AI agent
+func (k msgServer) SetAgentPricing(goCtx context.Context, msg *types.MsgSetAgentPricing) (*types.MsgSetAgentPricingResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.UpdateAgentPricing(ctx, msg.Owner, msg.Pricing()); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_pricing_set",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("default_price", sdk.NewCoin(msg.Denom, msg.DefaultPrice).String()),
+			sdk.NewAttribute("free_calls", fmt.Sprintf("%d", msg.FreeCalls)),
+		),
+	)
+
+	return &types.MsgSetAgentPricingResponse{}, nil
+}
+
+// DelegateGovVotes delegates governance voting power to a governance agent
+func (k msgServer) DelegateGovVotes(goCtx context.Context, msg *types.MsgDelegateGovVotes) (*types.MsgDelegateGovVotesResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.Keeper.DelegateGovVotes(ctx, msg.Delegator, msg.AgentID); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"gov_votes_delegated",
+			sdk.NewAttribute("delegator", msg.Delegator.String()),
+			sdk.NewAttribute("agent_id", msg.AgentID),
+		),
+	)
+
+	return &types.MsgDelegateGovVotesResponse{}, nil
+}
+
+// UndelegateGovVotes ends a governance delegation
+func (k msgServer) UndelegateGovVotes(goCtx context.Context, msg *types.MsgUndelegateGovVotes) (*types.MsgUndelegateGovVotesResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	delegation, err := k.Keeper.UndelegateGovVotes(ctx, msg.Delegator)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"gov_votes_undelegated",
+			sdk.NewAttribute("delegator", msg.Delegator.String()),
+			sdk.NewAttribute("agent_id", delegation.AgentID),
+		),
+	)
+
+	return &types.MsgUndelegateGovVotesResponse{
+		AgentID: delegation.AgentID,
+	}, nil
+}
+
+// RateAgent rates an action or rental of an AI agent
+func (k msgServer) RateAgent(goCtx context.Context, msg *types.MsgRateAgent) (*types.MsgRateAgentResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	rating, err := k.Keeper.RateAgent(ctx, msg.Rating())
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_rated",
+			sdk.NewAttribute("agent_id", rating.AgentID),
+			sdk.NewAttribute("rater", rating.Rater.String()),
+			sdk.NewAttribute("subject", rating.Subject),
+			sdk.NewAttribute("subject_id", rating.SubjectID),
+			sdk.NewAttribute("score", fmt.Sprintf("%d", rating.Score)),
+			sdk.NewAttribute("volume", rating.Volume.String()),
+		),
+	)
+
+	return &types.MsgRateAgentResponse{
+		Volume: rating.Volume,
+	}, nil
+}
+
+// FractionalizeAgent splits the ownership of an AI agent into shares
+func (k msgServer) FractionalizeAgent(goCtx context.Context, msg *types.MsgFractionalizeAgent) (*types.MsgFractionalizeAgentResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	shares, err := k.Keeper.FractionalizeAgent(ctx, msg.Owner, msg.AgentID, msg.Supply, msg.ReservePrice)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_fractionalized",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("curator", msg.Owner.String()),
+			sdk.NewAttribute("denom", shares.Denom),
+			sdk.NewAttribute("supply", shares.Supply.String()),
+			sdk.NewAttribute("reserve_price", shares.ReservePrice.String()),
+		),
+	)
+
+	return &types.MsgFractionalizeAgentResponse{
+		Denom: shares.Denom,
+	}, nil
+}
+
+// TransferAgentShares transfers shares of a fractionalized AI agent
+func (k msgServer) TransferAgentShares(goCtx context.Context, msg *types.MsgTransferAgentShares) (*types.MsgTransferAgentSharesResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.Keeper.TransferAgentShares(ctx, msg.Sender, msg.Recipient, msg.AgentID, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_shares_transferred",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("sender", msg.Sender.String()),
+			sdk.NewAttribute("recipient", msg.Recipient.String()),
+			sdk.NewAttribute("amount", msg.Amount.String()),
+		),
+	)
+
+	return &types.MsgTransferAgentSharesResponse{}, nil
+}
+
+// ClaimAgentIncome pays a shareholder the income its shares of an AI agent earned
+func (k msgServer) ClaimAgentIncome(goCtx context.Context, msg *types.MsgClaimAgentIncome) (*types.MsgClaimAgentIncomeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	amount, err := k.Keeper.ClaimAgentIncome(ctx, msg.Holder, msg.AgentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_income_claimed",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("holder", msg.Holder.String()),
+			sdk.NewAttribute("amount", amount.String()),
+		),
+	)
+
+	return &types.MsgClaimAgentIncomeResponse{
+		Amount: amount,
+	}, nil
+}
+
+// BuyoutAgent redeems full ownership of a fractionalized AI agent
+func (k msgServer) BuyoutAgent(goCtx context.Context, msg *types.MsgBuyoutAgent) (*types.MsgBuyoutAgentResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	_, cost, err := k.Keeper.BuyoutAgent(ctx, msg.Buyer, msg.AgentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_bought_out",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("buyer", msg.Buyer.String()),
+			sdk.NewAttribute("cost", cost.String()),
+		),
+	)
+
+	return &types.MsgBuyoutAgentResponse{
+		Cost: cost,
+	}, nil
+}
+
+// CreateSubscriptionPlan publishes a subscription plan for an AI agent
+func (k msgServer) CreateSubscriptionPlan(goCtx context.Context, msg *types.MsgCreateSubscriptionPlan) (*types.MsgCreateSubscriptionPlanResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	plan, err := k.Keeper.CreateSubscriptionPlan(ctx, msg.Owner, types.SubscriptionPlan{
+		AgentID:     msg.AgentID,
+		Name:        msg.Name,
+		Period:      msg.Period,
+		Price:       msg.Price,
+		ActionQuota: msg.ActionQuota,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"subscription_plan_created",
+			sdk.NewAttribute("plan_id", plan.ID),
+			sdk.NewAttribute("agent_id", plan.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("period", plan.Period.String()),
+			sdk.NewAttribute("price", plan.Price.String()),
+			sdk.NewAttribute("action_quota", fmt.Sprintf("%d", plan.ActionQuota)),
+		),
+	)
+
+	return &types.MsgCreateSubscriptionPlanResponse{
+		PlanID: plan.ID,
+	}, nil
+}
+
+// CloseSubscriptionPlan stops a subscription plan from taking new subscribers
+func (k msgServer) CloseSubscriptionPlan(goCtx context.Context, msg *types.MsgCloseSubscriptionPlan) (*types.MsgCloseSubscriptionPlanResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	plan, err := k.Keeper.CloseSubscriptionPlan(ctx, msg.Owner, msg.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"subscription_plan_closed",
+			sdk.NewAttribute("plan_id", plan.ID),
+			sdk.NewAttribute("agent_id", plan.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+		),
+	)
+
+	return &types.MsgCloseSubscriptionPlanResponse{}, nil
+}
+
+// SubscribeAgent subscribes to a subscription plan of an AI agent
+func (k msgServer) SubscribeAgent(goCtx context.Context, msg *types.MsgSubscribeAgent) (*types.MsgSubscribeAgentResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	subscription, err := k.Keeper.SubscribeAgent(ctx, msg.Subscriber, msg.PlanID, msg.Deposit, msg.AutoRenew)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_subscribed",
+			sdk.NewAttribute("plan_id", subscription.PlanID),
+			sdk.NewAttribute("agent_id", subscription.AgentID),
+			sdk.NewAttribute("subscriber", msg.Subscriber.String()),
+			sdk.NewAttribute("balance", subscription.Balance.String()),
+			sdk.NewAttribute("auto_renew", fmt.Sprintf("%t", subscription.AutoRenew)),
+			sdk.NewAttribute("period_end", subscription.PeriodEnd.String()),
+		),
+	)
+
+	return &types.MsgSubscribeAgentResponse{
+		PeriodEnd: subscription.PeriodEnd,
+		Balance:   subscription.Balance,
+	}, nil
+}
+
+// FundSubscription adds to the prepaid balance of a subscription
+func (k msgServer) FundSubscription(goCtx context.Context, msg *types.MsgFundSubscription) (*types.MsgFundSubscriptionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	subscription, err := k.Keeper.FundAgentSubscription(ctx, msg.Subscriber, msg.PlanID, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"subscription_funded",
+			sdk.NewAttribute("plan_id", msg.PlanID),
+			sdk.NewAttribute("subscriber", msg.Subscriber.String()),
+			sdk.NewAttribute("amount", msg.Amount.String()),
+			sdk.NewAttribute("balance", subscription.Balance.String()),
+		),
+	)
+
+	return &types.MsgFundSubscriptionResponse{
+		Balance: subscription.Balance,
+	}, nil
+}
+
+// CancelSubscription stops the renewal of a subscription and refunds its prepaid balance
+func (k msgServer) CancelSubscription(goCtx context.Context, msg *types.MsgCancelSubscription) (*types.MsgCancelSubscriptionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	refund, err := k.Keeper.CancelAgentSubscription(ctx, msg.Subscriber, msg.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"subscription_cancelled",
+			sdk.NewAttribute("plan_id", msg.PlanID),
+			sdk.NewAttribute("subscriber", msg.Subscriber.String()),
+			sdk.NewAttribute("refund", refund.String()),
+		),
+	)
+
+	return &types.MsgCancelSubscriptionResponse{
+		Refund: refund,
+	}, nil
+}
+
+// SetAgentEncryptionKey sets the key training data of an AI agent is encrypted to
+func (k msgServer) SetAgentEncryptionKey(goCtx context.Context, msg *types.MsgSetAgentEncryptionKey) (*types.MsgSetAgentEncryptionKeyResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.Keeper.SetAgentEncryptionKey(ctx, msg.Owner, msg.AgentID, msg.PublicKey); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_encryption_key_set",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("public_key", hex.EncodeToString(msg.PublicKey)),
+		),
+	)
+
+	return &types.MsgSetAgentEncryptionKeyResponse{}, nil
+}
+
+// RegisterEncryptionKey registers the key data keys are wrapped for when an account is granted access
+func (k msgServer) RegisterEncryptionKey(goCtx context.Context, msg *types.MsgRegisterEncryptionKey) (*types.MsgRegisterEncryptionKeyResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, err := k.Keeper.RegisterEncryptionKey(ctx, msg.Address, msg.PublicKey); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"encryption_key_registered",
+			sdk.NewAttribute("address", msg.Address.String()),
+			sdk.NewAttribute("public_key", hex.EncodeToString(msg.PublicKey)),
+		),
+	)
+
+	return &types.MsgRegisterEncryptionKeyResponse{}, nil
+}
+
+// GrantTrainingDataKey grants a trainer or executor access to encrypted training data
+func (k msgServer) GrantTrainingDataKey(goCtx context.Context, msg *types.MsgGrantTrainingDataKey) (*types.MsgGrantTrainingDataKeyResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, err := k.Keeper.GrantTrainingDataKey(ctx, msg.Owner, msg.TrainingDataID, msg.Grantee, msg.WrappedKey); err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"training_data_key_granted",
+			sdk.NewAttribute("training_data_id", msg.TrainingDataID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("grantee", msg.Grantee.String()),
+		),
+	)
+
+	return &types.MsgGrantTrainingDataKeyResponse{}, nil
+}
+
+// SetAgentModel attaches a model evaluated on-chain to an AI agent
+func (k msgServer) SetAgentModel(goCtx context.Context, msg *types.MsgSetAgentModel) (*types.MsgSetAgentModelResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	model, err := k.SetAgentOnChainModel(ctx, msg.Owner, msg.AgentID, msg.ModelType, msg.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			"agent_model_set",
+			sdk.NewAttribute("agent_id", msg.AgentID),
+			sdk.NewAttribute("owner", msg.Owner.String()),
+			sdk.NewAttribute("model_id", model.ID),
+			sdk.NewAttribute("model_type", model.ModelType),
+		),
+	)
+
+	return &types.MsgSetAgentModelResponse{
+		ModelID: model.ID,
+	}, nil
 }
\ No newline at end of file
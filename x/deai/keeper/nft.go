@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/nft"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// EnsureAgentNFTClass creates the x/nft class of AI agents if it does not exist yet
+func (k Keeper) EnsureAgentNFTClass(ctx sdk.Context) error {
+	if k.nftKeeper.HasClass(ctx, types.AgentNFTClassID) {
+		return nil
+	}
+
+	return k.nftKeeper.SaveClass(ctx, nft.Class{
+		Id:          types.AgentNFTClassID,
+		Name:        "DeAI Agents",
+		Symbol:      "DEAI",
+		Description: "AI agents of the NoMercyChain DeAI module",
+	})
+}
+
+// MintAIAgentNFT mints the NFT representing an agent to its owner and records the NFT
+// ID on the agent. The caller is responsible for storing the agent.
+func (k Keeper) MintAIAgentNFT(ctx sdk.Context, agent types.AIAgent) (types.AIAgent, error) {
+	if err := k.EnsureAgentNFTClass(ctx); err != nil {
+		return agent, sdkerrors.Wrap(err, "failed to create agent NFT class")
+	}
+
+	token := nft.NFT{
+		ClassId: types.AgentNFTClassID,
+		Id:      agent.ID,
+	}
+	if err := k.nftKeeper.Mint(ctx, token, agent.Owner); err != nil {
+		return agent, sdkerrors.Wrap(err, "failed to mint agent NFT")
+	}
+
+	agent.NFTID = token.Id
+	return agent, nil
+}
+
+// TransferAIAgent transfers the agent NFT to a new owner and updates the agent owner.
+// Agents created before the NFT integration are minted to the new owner instead.
+// The caller is responsible for storing the agent.
+func (k Keeper) TransferAIAgent(ctx sdk.Context, agent types.AIAgent, newOwner sdk.AccAddress) (types.AIAgent, error) {
+	agent.Owner = newOwner
+
+	if agent.NFTID == "" || !k.nftKeeper.HasNFT(ctx, types.AgentNFTClassID, agent.NFTID) {
+		return k.MintAIAgentNFT(ctx, agent)
+	}
+
+	if err := k.nftKeeper.Transfer(ctx, types.AgentNFTClassID, agent.NFTID, newOwner); err != nil {
+		return agent, sdkerrors.Wrap(err, "failed to transfer agent NFT")
+	}
+
+	return agent, nil
+}
+
+// withNFTOwner sets the agent owner to the holder of its NFT. The NFT is authoritative
+// so that agents can be transferred by wallets and modules that only understand x/nft.
+func (k Keeper) withNFTOwner(ctx sdk.Context, agent types.AIAgent) types.AIAgent {
+	if agent.NFTID == "" {
+		return agent
+	}
+
+	if owner := k.nftKeeper.GetOwner(ctx, types.AgentNFTClassID, agent.NFTID); !owner.Empty() {
+		agent.Owner = owner
+	}
+	return agent
+}
\ No newline at end of file
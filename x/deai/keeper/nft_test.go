@@ -0,0 +1,123 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/nft"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// fakeNFTKeeper is an in-memory x/nft keeper
+type fakeNFTKeeper struct {
+	classes map[string]nft.Class
+	owners  map[string]sdk.AccAddress // by class ID and NFT ID
+}
+
+var _ types.NFTKeeper = &fakeNFTKeeper{}
+
+func newFakeNFTKeeper() *fakeNFTKeeper {
+	return &fakeNFTKeeper{classes: map[string]nft.Class{}, owners: map[string]sdk.AccAddress{}}
+}
+
+func (f *fakeNFTKeeper) SaveClass(_ sdk.Context, class nft.Class) error {
+	f.classes[class.Id] = class
+	return nil
+}
+
+func (f *fakeNFTKeeper) HasClass(_ sdk.Context, classID string) bool {
+	_, found := f.classes[classID]
+	return found
+}
+
+func (f *fakeNFTKeeper) Mint(_ sdk.Context, token nft.NFT, receiver sdk.AccAddress) error {
+	if _, found := f.classes[token.ClassId]; !found {
+		return fmt.Errorf("class %s not found", token.ClassId)
+	}
+	if _, found := f.owners[token.ClassId+"/"+token.Id]; found {
+		return fmt.Errorf("nft %s already exists", token.Id)
+	}
+	f.owners[token.ClassId+"/"+token.Id] = receiver
+	return nil
+}
+
+func (f *fakeNFTKeeper) Transfer(_ sdk.Context, classID string, nftID string, receiver sdk.AccAddress) error {
+	if _, found := f.owners[classID+"/"+nftID]; !found {
+		return fmt.Errorf("nft %s not found", nftID)
+	}
+	f.owners[classID+"/"+nftID] = receiver
+	return nil
+}
+
+func (f *fakeNFTKeeper) HasNFT(_ sdk.Context, classID, id string) bool {
+	_, found := f.owners[classID+"/"+id]
+	return found
+}
+
+func (f *fakeNFTKeeper) GetOwner(_ sdk.Context, classID string, nftID string) sdk.AccAddress {
+	return f.owners[classID+"/"+nftID]
+}
+
+func (f *fakeNFTKeeper) GetNFTsOfClassByOwner(_ sdk.Context, classID string, owner sdk.AccAddress) []nft.NFT {
+	var nfts []nft.NFT
+	for key, holder := range f.owners {
+		if holder.Equals(owner) && strings.HasPrefix(key, classID+"/") {
+			nfts = append(nfts, nft.NFT{ClassId: classID, Id: strings.TrimPrefix(key, classID+"/")})
+		}
+	}
+	return nfts
+}
+
+func TestAIAgentNFTOwnership(t *testing.T) {
+	nftKeeper := newFakeNFTKeeper()
+	k := Keeper{nftKeeper: nftKeeper}
+	ctx := sdk.Context{}
+
+	creator := sdk.AccAddress([]byte("creator_____________"))
+	buyer := sdk.AccAddress([]byte("buyer_______________"))
+	holder := sdk.AccAddress([]byte("holder______________"))
+
+	agent, err := k.MintAIAgentNFT(ctx, types.AIAgent{ID: "agent", Owner: creator})
+	require.NoError(t, err)
+	require.Equal(t, "agent", agent.NFTID)
+	require.True(t, nftKeeper.HasClass(ctx, types.AgentNFTClassID))
+	require.Equal(t, creator, nftKeeper.GetOwner(ctx, types.AgentNFTClassID, agent.NFTID))
+
+	// Minting the class again is a no-op
+	_, err = k.MintAIAgentNFT(ctx, types.AIAgent{ID: "other", Owner: creator})
+	require.NoError(t, err)
+	require.Len(t, nftKeeper.classes, 1)
+
+	agent, err = k.TransferAIAgent(ctx, agent, buyer)
+	require.NoError(t, err)
+	require.Equal(t, buyer, agent.Owner)
+	require.Equal(t, buyer, nftKeeper.GetOwner(ctx, types.AgentNFTClassID, agent.NFTID))
+
+	// The NFT holder is the owner even if the NFT moved outside of deai
+	require.NoError(t, nftKeeper.Transfer(ctx, types.AgentNFTClassID, agent.NFTID, holder))
+	require.Equal(t, holder, k.withNFTOwner(ctx, agent).Owner)
+}
+
+func TestTransferAIAgentMintsLegacyAgents(t *testing.T) {
+	nftKeeper := newFakeNFTKeeper()
+	k := Keeper{nftKeeper: nftKeeper}
+	ctx := sdk.Context{}
+
+	owner := sdk.AccAddress([]byte("owner_______________"))
+	buyer := sdk.AccAddress([]byte("buyer_______________"))
+
+	// Agents created before the NFT integration keep their stored owner
+	legacy := types.AIAgent{ID: "legacy", Owner: owner}
+	require.Equal(t, owner, k.withNFTOwner(ctx, legacy).Owner)
+
+	// and are minted to their buyer on transfer
+	agent, err := k.TransferAIAgent(ctx, legacy, buyer)
+	require.NoError(t, err)
+	require.Equal(t, "legacy", agent.NFTID)
+	require.Equal(t, buyer, agent.Owner)
+	require.Equal(t, buyer, nftKeeper.GetOwner(ctx, types.AgentNFTClassID, "legacy"))
+}
@@ -8,12 +8,37 @@ import (
 // GetParams gets all parameters as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 	return types.Params{
-		MinAgentDeposit:        k.MinAgentDeposit(ctx),
-		MaxAgentNameLength:     k.MaxAgentNameLength(ctx),
-		MaxAgentDescLength:     k.MaxAgentDescLength(ctx),
-		MaxTrainingDataSize:    k.MaxTrainingDataSize(ctx),
-		MaxMarketplaceListings: k.MaxMarketplaceListings(ctx),
-		MarketplaceFeeRate:     k.MarketplaceFeeRate(ctx),
+		MinAgentDeposit:         k.MinAgentDeposit(ctx),
+		MaxAgentNameLength:      k.MaxAgentNameLength(ctx),
+		MaxAgentDescLength:      k.MaxAgentDescLength(ctx),
+		MaxTrainingDataSize:     k.MaxTrainingDataSize(ctx),
+		MaxMarketplaceListings:  k.MaxMarketplaceListings(ctx),
+		MarketplaceFeeRate:      k.MarketplaceFeeRate(ctx),
+		MarketplaceFeeRecipient: k.MarketplaceFeeRecipient(ctx),
+		CreatorRoyaltyRate:      k.CreatorRoyaltyRate(ctx),
+		AuctionExtensionSeconds: k.AuctionExtensionSeconds(ctx),
+		MinBidIncrementRate:     k.MinBidIncrementRate(ctx),
+		MinExecutorBond:         k.MinExecutorBond(ctx),
+		ExecutionQuorum:         k.ExecutionQuorum(ctx),
+		ExecutionCommitBlocks:   k.ExecutionCommitBlocks(ctx),
+		ExecutionRevealBlocks:   k.ExecutionRevealBlocks(ctx),
+		ExecutorFeeRate:         k.ExecutorFeeRate(ctx),
+		ExecutorSlashRate:       k.ExecutorSlashRate(ctx),
+		TrainingClaimBlocks:     k.TrainingClaimBlocks(ctx),
+		TrainingSubmitBlocks:    k.TrainingSubmitBlocks(ctx),
+		TrainingReviewBlocks:    k.TrainingReviewBlocks(ctx),
+		MaxInlineStateSize:      k.MaxInlineStateSize(ctx),
+		StateHistoryRetention:   k.StateHistoryRetention(ctx),
+		MaxPipelineDepth:        k.MaxPipelineDepth(ctx),
+		MaxPipelineSteps:        k.MaxPipelineSteps(ctx),
+		MaxAgentWalletMsgs:      k.MaxAgentWalletMsgs(ctx),
+		MaxScheduledRuns:        k.MaxScheduledRuns(ctx),
+		ScheduledRunsGasLimit:   k.ScheduledRunsGasLimit(ctx),
+		GovVoteGasLimit:         k.GovVoteGasLimit(ctx),
+		RatingPriorMean:         k.RatingPriorMean(ctx),
+		RatingPriorWeight:       k.RatingPriorWeight(ctx),
+		RatingHalfLifeSeconds:   k.RatingHalfLifeSeconds(ctx),
+		BuyoutThreshold:         k.BuyoutThreshold(ctx),
 	}
 }
 
@@ -56,4 +81,154 @@ func (k Keeper) MaxMarketplaceListings(ctx sdk.Context) (res uint64) {
 func (k Keeper) MarketplaceFeeRate(ctx sdk.Context) (res sdk.Dec) {
 	k.paramstore.Get(ctx, types.KeyMarketplaceFeeRate, &res)
 	return
+}
+
+// MarketplaceFeeRecipient returns the MarketplaceFeeRecipient param
+func (k Keeper) MarketplaceFeeRecipient(ctx sdk.Context) (res string) {
+	k.paramstore.Get(ctx, types.KeyMarketplaceFeeRecipient, &res)
+	return
+}
+
+// CreatorRoyaltyRate returns the CreatorRoyaltyRate param
+func (k Keeper) CreatorRoyaltyRate(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyCreatorRoyaltyRate, &res)
+	return
+}
+
+// AuctionExtensionSeconds returns the AuctionExtensionSeconds param
+func (k Keeper) AuctionExtensionSeconds(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyAuctionExtensionSeconds, &res)
+	return
+}
+
+// MinBidIncrementRate returns the MinBidIncrementRate param
+func (k Keeper) MinBidIncrementRate(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyMinBidIncrementRate, &res)
+	return
+}
+
+// MinExecutorBond returns the MinExecutorBond param
+func (k Keeper) MinExecutorBond(ctx sdk.Context) (res sdk.Coin) {
+	k.paramstore.Get(ctx, types.KeyMinExecutorBond, &res)
+	return
+}
+
+// ExecutionQuorum returns the ExecutionQuorum param
+func (k Keeper) ExecutionQuorum(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyExecutionQuorum, &res)
+	return
+}
+
+// ExecutionCommitBlocks returns the ExecutionCommitBlocks param
+func (k Keeper) ExecutionCommitBlocks(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyExecutionCommitBlocks, &res)
+	return
+}
+
+// ExecutionRevealBlocks returns the ExecutionRevealBlocks param
+func (k Keeper) ExecutionRevealBlocks(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyExecutionRevealBlocks, &res)
+	return
+}
+
+// ExecutorFeeRate returns the ExecutorFeeRate param
+func (k Keeper) ExecutorFeeRate(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyExecutorFeeRate, &res)
+	return
+}
+
+// ExecutorSlashRate returns the ExecutorSlashRate param
+func (k Keeper) ExecutorSlashRate(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyExecutorSlashRate, &res)
+	return
+}
+
+// TrainingClaimBlocks returns the TrainingClaimBlocks param
+func (k Keeper) TrainingClaimBlocks(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyTrainingClaimBlocks, &res)
+	return
+}
+
+// TrainingSubmitBlocks returns the TrainingSubmitBlocks param
+func (k Keeper) TrainingSubmitBlocks(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyTrainingSubmitBlocks, &res)
+	return
+}
+
+// TrainingReviewBlocks returns the TrainingReviewBlocks param
+func (k Keeper) TrainingReviewBlocks(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyTrainingReviewBlocks, &res)
+	return
+}
+
+// MaxInlineStateSize returns the MaxInlineStateSize param
+func (k Keeper) MaxInlineStateSize(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxInlineStateSize, &res)
+	return
+}
+
+// StateHistoryRetention returns the StateHistoryRetention param
+func (k Keeper) StateHistoryRetention(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyStateHistoryRetention, &res)
+	return
+}
+
+// MaxPipelineDepth returns the MaxPipelineDepth param
+func (k Keeper) MaxPipelineDepth(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxPipelineDepth, &res)
+	return
+}
+
+// MaxPipelineSteps returns the MaxPipelineSteps param
+func (k Keeper) MaxPipelineSteps(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxPipelineSteps, &res)
+	return
+}
+
+// MaxAgentWalletMsgs returns the MaxAgentWalletMsgs param
+func (k Keeper) MaxAgentWalletMsgs(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxAgentWalletMsgs, &res)
+	return
+}
+
+// MaxScheduledRuns returns the MaxScheduledRuns param
+func (k Keeper) MaxScheduledRuns(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyMaxScheduledRuns, &res)
+	return
+}
+
+// ScheduledRunsGasLimit returns the ScheduledRunsGasLimit param
+func (k Keeper) ScheduledRunsGasLimit(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyScheduledRunsGasLimit, &res)
+	return
+}
+
+// GovVoteGasLimit returns the GovVoteGasLimit param
+func (k Keeper) GovVoteGasLimit(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyGovVoteGasLimit, &res)
+	return
+}
+
+// RatingPriorMean returns the RatingPriorMean param
+func (k Keeper) RatingPriorMean(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyRatingPriorMean, &res)
+	return
+}
+
+// RatingPriorWeight returns the RatingPriorWeight param
+func (k Keeper) RatingPriorWeight(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyRatingPriorWeight, &res)
+	return
+}
+
+// RatingHalfLifeSeconds returns the RatingHalfLifeSeconds param
+func (k Keeper) RatingHalfLifeSeconds(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyRatingHalfLifeSeconds, &res)
+	return
+}
+
+// BuyoutThreshold returns the BuyoutThreshold param
+func (k Keeper) BuyoutThreshold(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyBuyoutThreshold, &res)
+	return
 }
\ No newline at end of file
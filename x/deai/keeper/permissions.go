@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// agentRateLimitScope is the call counter scope of the agent-wide rate limit
+const agentRateLimitScope = "*"
+
+// actionRateLimitScope returns the call counter scope of the rate limit of an action
+// type. Action scopes are prefixed so that no action type can name the agent-wide scope.
+func actionRateLimitScope(actionType string) string {
+	return "action/" + actionType
+}
+
+// AuthorizeAgentCall is the single authorization path for calls against an AI agent.
+// The owner, or the curator while the agent is fractionalized, renters holding an
+// active rental and subscribers holding an active subscription are always admitted;
+// every other caller is evaluated against the agent's permission policy (expiry,
+// denylist, visibility, action rules, max fee and rate limits). On success the call is
+// counted against the caller's rate limits.
+func (k Keeper) AuthorizeAgentCall(ctx sdk.Context, agent types.AIAgent, caller sdk.AccAddress, actionType string, fee sdk.Coins) error {
+	// A fractionalized agent is owned by the module account; its curator stands in for the owner
+	if k.AgentSeller(ctx, agent).Equals(caller) || k.HasActiveAIAgentRental(ctx, agent.ID, caller) || k.HasActiveAgentSubscription(ctx, agent.ID, caller) {
+		return nil
+	}
+
+	policy, err := types.ParseAgentPolicy(agent.Permissions)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidPermissions, err.Error())
+	}
+
+	if policy.IsExpired(ctx.BlockTime()) {
+		return sdkerrors.Wrapf(types.ErrPermissionsExpired, "agent %s", agent.ID)
+	}
+
+	if policy.IsDenied(caller) {
+		return sdkerrors.Wrapf(types.ErrUnauthorized, "%s is denied access to agent %s", caller, agent.ID)
+	}
+
+	rule, found := policy.ActionRule(actionType)
+	if !found || rule.Disabled {
+		return sdkerrors.Wrapf(types.ErrUnauthorized, "action %s is not allowed on agent %s", actionType, agent.ID)
+	}
+
+	// An action allowlist admits its callers even when the agent itself is not visible to them
+	if !policy.IsVisibleTo(caller) && !rule.IsAllowlisted(caller) {
+		return sdkerrors.Wrapf(types.ErrUnauthorized, "no permission to use agent %s", agent.ID)
+	}
+
+	if policy.MaxFee != nil && !fee.IsAllLTE(policy.MaxFee) {
+		return sdkerrors.Wrapf(types.ErrFeeExceedsMaximum, "fee %s exceeds %s", fee, policy.MaxFee)
+	}
+	if rule.MaxFee != nil && !fee.IsAllLTE(rule.MaxFee) {
+		return sdkerrors.Wrapf(types.ErrFeeExceedsMaximum, "fee %s exceeds %s for action %s", fee, rule.MaxFee, actionType)
+	}
+
+	// Check every applicable rate limit before counting the call against any of them
+	actionScope := actionRateLimitScope(actionType)
+	limits := map[string]*types.AgentRateLimit{
+		agentRateLimitScope: policy.RateLimit,
+		actionScope:         rule.RateLimit,
+	}
+	counters := make(map[string]types.AgentCallCounter)
+	for _, scope := range []string{agentRateLimitScope, actionScope} {
+		limit := limits[scope]
+		if limit == nil {
+			continue
+		}
+
+		counter := k.getAgentCallCounter(ctx, agent.ID, caller, scope, *limit)
+		if counter.Count >= limit.MaxCalls {
+			return sdkerrors.Wrapf(types.ErrRateLimitExceeded, "%d calls per %ds", limit.MaxCalls, limit.WindowSeconds)
+		}
+		counters[scope] = counter
+	}
+
+	for _, scope := range []string{agentRateLimitScope, actionScope} {
+		counter, ok := counters[scope]
+		if !ok {
+			continue
+		}
+		counter.Count++
+		k.setAgentCallCounter(ctx, counter)
+	}
+
+	return nil
+}
+
+// AuthorizeAgentTraining authorizes a caller to open a training job for an agent.
+// Training replaces the agent's model, so besides the owner (or the curator while the
+// agent is fractionalized) only callers admitted by an explicit train action rule may
+// train it; renters and subscribers are not admitted without one.
+func (k Keeper) AuthorizeAgentTraining(ctx sdk.Context, agent types.AIAgent, caller sdk.AccAddress) error {
+	if k.AgentSeller(ctx, agent).Equals(caller) {
+		return nil
+	}
+
+	policy, err := types.ParseAgentPolicy(agent.Permissions)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidPermissions, err.Error())
+	}
+	if !policy.HasActionRule(types.AgentActionTypeTrain) {
+		return sdkerrors.Wrapf(types.ErrUnauthorized, "only the owner can train agent %s", agent.ID)
+	}
+
+	return k.AuthorizeAgentCall(ctx, agent, caller, types.AgentActionTypeTrain, nil)
+}
+
+// getAgentCallCounter returns the caller's counter for the current window, starting a
+// new window if the previous one has elapsed
+func (k Keeper) getAgentCallCounter(ctx sdk.Context, agentID string, caller sdk.AccAddress, scope string, limit types.AgentRateLimit) types.AgentCallCounter {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAIAgentCallCounterKey(agentID, caller, scope))
+
+	var counter types.AgentCallCounter
+	if value != nil {
+		k.cdc.MustUnmarshal(value, &counter)
+	}
+
+	if value == nil || !ctx.BlockTime().Before(counter.WindowStart.Add(limit.Window())) {
+		counter = types.AgentCallCounter{
+			AgentID:     agentID,
+			Caller:      caller,
+			Scope:       scope,
+			WindowStart: ctx.BlockTime(),
+		}
+	}
+
+	return counter
+}
+
+// setAgentCallCounter stores a caller's call counter
+func (k Keeper) setAgentCallCounter(ctx sdk.Context, counter types.AgentCallCounter) {
+	store := ctx.KVStore(k.storeKey)
+	value := k.cdc.MustMarshal(&counter)
+	store.Set(types.GetAIAgentCallCounterKey(counter.AgentID, counter.Caller, counter.Scope), value)
+}
\ No newline at end of file
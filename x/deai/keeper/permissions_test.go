@@ -0,0 +1,14 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionRateLimitScope(t *testing.T) {
+	// No action type shares the call counter of the agent-wide rate limit
+	require.NotEqual(t, agentRateLimitScope, actionRateLimitScope(agentRateLimitScope))
+	require.NotEqual(t, agentRateLimitScope, actionRateLimitScope(""))
+	require.NotEqual(t, actionRateLimitScope("predict"), actionRateLimitScope("classify"))
+}
@@ -0,0 +1,291 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/inference"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetPipeline stores a pipeline
+func (k Keeper) SetPipeline(ctx sdk.Context, pipeline types.Pipeline) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPipelineKey(pipeline.ID), k.cdc.MustMarshal(&pipeline))
+}
+
+// GetPipeline returns a pipeline by ID
+func (k Keeper) GetPipeline(ctx sdk.Context, id string) (types.Pipeline, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetPipelineKey(id))
+	if value == nil {
+		return types.Pipeline{}, false
+	}
+
+	var pipeline types.Pipeline
+	k.cdc.MustUnmarshal(value, &pipeline)
+	return pipeline, true
+}
+
+// DeletePipeline removes a pipeline
+func (k Keeper) DeletePipeline(ctx sdk.Context, id string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPipelineKey(id))
+}
+
+// GetAllPipelines returns all pipelines
+func (k Keeper) GetAllPipelines(ctx sdk.Context) []types.Pipeline {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.PipelineKey)
+	defer iterator.Close()
+
+	var pipelines []types.Pipeline
+	for ; iterator.Valid(); iterator.Next() {
+		var pipeline types.Pipeline
+		k.cdc.MustUnmarshal(iterator.Value(), &pipeline)
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines
+}
+
+// GetPipelinesByOwner returns the pipelines of an owner, or all pipelines if the owner is empty
+func (k Keeper) GetPipelinesByOwner(ctx sdk.Context, owner sdk.AccAddress) []types.Pipeline {
+	var pipelines []types.Pipeline
+	for _, pipeline := range k.GetAllPipelines(ctx) {
+		if owner.Empty() || pipeline.Owner.Equals(owner) {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+	return pipelines
+}
+
+// CreatePipeline validates and stores a new pipeline. Nested pipelines must already
+// exist, so pipelines cannot reference each other in a cycle.
+func (k Keeper) CreatePipeline(ctx sdk.Context, pipeline types.Pipeline) (types.Pipeline, error) {
+	pipeline.ID = fmt.Sprintf("pipeline-%s-%d", pipeline.Owner, k.nextSequence(ctx))
+	pipeline.CreatedAt = ctx.BlockTime()
+
+	if err := pipeline.Validate(); err != nil {
+		return types.Pipeline{}, sdkerrors.Wrap(types.ErrInvalidPipeline, err.Error())
+	}
+	if maxSteps := k.MaxPipelineSteps(ctx); uint64(len(pipeline.Steps)) > maxSteps {
+		return types.Pipeline{}, sdkerrors.Wrapf(types.ErrInvalidPipeline, "%d steps exceed the maximum of %d", len(pipeline.Steps), maxSteps)
+	}
+
+	for _, step := range pipeline.Steps {
+		if step.AgentID != "" {
+			if _, found := k.GetAIAgent(ctx, step.AgentID); !found {
+				return types.Pipeline{}, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "agent not found: %s", step.AgentID)
+			}
+			continue
+		}
+
+		nested, found := k.GetPipeline(ctx, step.PipelineID)
+		if !found {
+			return types.Pipeline{}, sdkerrors.Wrapf(types.ErrPipelineNotFound, "%s", step.PipelineID)
+		}
+		if depth := k.pipelineDepth(ctx, nested, 1); depth+1 > k.MaxPipelineDepth(ctx) {
+			return types.Pipeline{}, sdkerrors.Wrapf(types.ErrPipelineDepthExceeded, "nesting pipeline %s exceeds the maximum depth of %d", nested.ID, k.MaxPipelineDepth(ctx))
+		}
+	}
+
+	k.SetPipeline(ctx, pipeline)
+	return pipeline, nil
+}
+
+// pipelineDepth returns the nesting depth of a pipeline, counting a pipeline without
+// nested pipelines as 1. Descending stops beyond the MaxPipelineDepth param.
+func (k Keeper) pipelineDepth(ctx sdk.Context, pipeline types.Pipeline, depth uint64) uint64 {
+	if depth > k.MaxPipelineDepth(ctx) {
+		return depth
+	}
+
+	deepest := depth
+	for _, step := range pipeline.Steps {
+		if step.PipelineID == "" {
+			continue
+		}
+		nested, found := k.GetPipeline(ctx, step.PipelineID)
+		if !found {
+			continue
+		}
+		if d := k.pipelineDepth(ctx, nested, depth+1); d > deepest {
+			deepest = d
+		}
+	}
+	return deepest
+}
+
+// ExecutePipeline runs the steps of a pipeline in order within the transaction, paying
+// each step's fee to the owner of its agent, and records a single aggregated action.
+// Every agent must have an on-chain model, as results of executors only become
+// available in later blocks.
+func (k Keeper) ExecutePipeline(ctx sdk.Context, pipelineID string, sender sdk.AccAddress, data json.RawMessage, maxFee sdk.Coins) (types.AIAgentAction, error) {
+	pipeline, found := k.GetPipeline(ctx, pipelineID)
+	if !found {
+		return types.AIAgentAction{}, sdkerrors.Wrap(types.ErrPipelineNotFound, pipelineID)
+	}
+
+	actionID := fmt.Sprintf("%s-%s-%d", pipeline.ID, types.AIAgentActionTypePipeline, k.nextSequence(ctx))
+
+	run := pipelineRun{sender: sender, maxFee: maxFee}
+	output, err := k.runPipeline(ctx, pipeline, data, 1, "", &run)
+	if err != nil {
+		return types.AIAgentAction{}, err
+	}
+
+	result, err := json.Marshal(types.PipelineRun{
+		Output: output,
+		Steps:  run.steps,
+	})
+	if err != nil {
+		return types.AIAgentAction{}, err
+	}
+
+	action := types.AIAgentAction{
+		ID:         actionID,
+		PipelineID: pipeline.ID,
+		ActionType: types.AIAgentActionTypePipeline,
+		Timestamp:  ctx.BlockTime(),
+		Data:       data,
+		Result:     result,
+		Status:     types.ExecutionStatusCompleted,
+		GasUsed:    run.gasUsed,
+		Requester:  sender,
+		Fee:        run.fees,
+	}
+	k.SetAIAgentAction(ctx, action)
+
+	return action, nil
+}
+
+// pipelineRun accumulates the outcome of a pipeline execution across nested pipelines
+type pipelineRun struct {
+	sender  sdk.AccAddress
+	maxFee  sdk.Coins
+	fees    sdk.Coins
+	gasUsed uint64
+	steps   []types.PipelineStepResult
+}
+
+// runPipeline runs the steps of a pipeline at a nesting depth and returns its result
+func (k Keeper) runPipeline(ctx sdk.Context, pipeline types.Pipeline, input json.RawMessage, depth uint64, prefix string, run *pipelineRun) (json.RawMessage, error) {
+	if depth > k.MaxPipelineDepth(ctx) {
+		return nil, sdkerrors.Wrapf(types.ErrPipelineDepthExceeded, "pipeline %s at depth %d", pipeline.ID, depth)
+	}
+
+	results := make(map[string]json.RawMessage)
+	previous := input
+	for _, step := range pipeline.Steps {
+		stepInput, err := pipelineStepInput(step, input, previous, results)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(types.ErrInvalidPipeline, "step %s: %s", step.ID, err)
+		}
+
+		var result json.RawMessage
+		if step.PipelineID != "" {
+			nested, found := k.GetPipeline(ctx, step.PipelineID)
+			if !found {
+				return nil, sdkerrors.Wrapf(types.ErrPipelineNotFound, "%s in step %s", step.PipelineID, step.ID)
+			}
+			result, err = k.runPipeline(ctx, nested, stepInput, depth+1, prefix+step.ID+"/", run)
+		} else {
+			result, err = k.runPipelineStep(ctx, step, stepInput, prefix, run)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		results[step.ID] = result
+		previous = result
+	}
+
+	return results[pipeline.ResultStep()], nil
+}
+
+// runPipelineStep runs the agent of a pipeline step on its input
+func (k Keeper) runPipelineStep(ctx sdk.Context, step types.PipelineStep, input json.RawMessage, prefix string, run *pipelineRun) (json.RawMessage, error) {
+	agent, found := k.GetAIAgent(ctx, step.AgentID)
+	if !found {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", step.AgentID))
+	}
+	if agent.Status != types.AIAgentStatusActive && agent.Status != types.AIAgentStatusForRent && agent.Status != types.AIAgentStatusForSale {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "agent %s is not active", agent.ID)
+	}
+	fee, err := k.ChargeAgentCall(ctx, agent, run.sender, step.ActionType, step.Fee)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "step %s", step.ID)
+	}
+	if err := k.AuthorizeAgentCall(ctx, agent, run.sender, step.ActionType, fee); err != nil {
+		return nil, err
+	}
+
+	model, found := k.GetOnChainModel(ctx, agent)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidPipeline, "agent %s of step %s has no on-chain model", agent.ID, step.ID)
+	}
+
+	run.fees = run.fees.Add(fee...)
+	if !run.fees.IsAllLTE(run.maxFee) {
+		return nil, sdkerrors.Wrapf(types.ErrFeeExceedsMaximum, "pipeline fees %s exceed %s", run.fees, run.maxFee)
+	}
+
+	result, gasUsed, err := k.runOnChainInference(ctx, agent, model, run.sender, input, fee)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "step %s", step.ID)
+	}
+
+	run.gasUsed += gasUsed
+	run.steps = append(run.steps, types.PipelineStepResult{
+		StepID:  prefix + step.ID,
+		AgentID: agent.ID,
+		Result:  result,
+		GasUsed: gasUsed,
+		Fee:     fee,
+	})
+
+	return result, nil
+}
+
+// pipelineStepInput builds the input of a step. Without input mappings a step takes the
+// previous result as is; otherwise the selected values of the pipeline input and of
+// earlier results are concatenated into an inference input.
+func pipelineStepInput(step types.PipelineStep, input, previous json.RawMessage, results map[string]json.RawMessage) (json.RawMessage, error) {
+	if len(step.Inputs) == 0 {
+		return previous, nil
+	}
+
+	var values []sdk.Dec
+	for _, mapping := range step.Inputs {
+		var source []sdk.Dec
+		if mapping.Step == "" {
+			var in inference.Input
+			if err := json.Unmarshal(input, &in); err != nil {
+				return nil, fmt.Errorf("invalid pipeline input: %w", err)
+			}
+			source = in.Inputs
+		} else {
+			var out inference.Output
+			if err := json.Unmarshal(results[mapping.Step], &out); err != nil {
+				return nil, fmt.Errorf("invalid result of step %s: %w", mapping.Step, err)
+			}
+			source = out.Outputs
+		}
+
+		if len(mapping.Indices) == 0 {
+			values = append(values, source...)
+			continue
+		}
+		for _, i := range mapping.Indices {
+			if i >= uint64(len(source)) {
+				return nil, fmt.Errorf("index %d out of range of %d values", i, len(source))
+			}
+			values = append(values, source[i])
+		}
+	}
+
+	return json.Marshal(inference.Input{Inputs: values})
+}
\ No newline at end of file
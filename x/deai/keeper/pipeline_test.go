@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+func TestPipelineStepInput(t *testing.T) {
+	input := json.RawMessage(`{"inputs": ["1", "2", "3"]}`)
+	previous := json.RawMessage(`{"outputs": ["0.5"]}`)
+	results := map[string]json.RawMessage{
+		"first":  json.RawMessage(`{"outputs": ["0.25", "0.75"]}`),
+		"second": previous,
+	}
+
+	tests := []struct {
+		name     string
+		inputs   []types.PipelineInput
+		expected string
+	}{
+		{
+			name:     "previous result as is",
+			expected: `{"outputs": ["0.5"]}`,
+		},
+		{
+			name:     "whole pipeline input",
+			inputs:   []types.PipelineInput{{}},
+			expected: `{"inputs":["1.000000000000000000","2.000000000000000000","3.000000000000000000"]}`,
+		},
+		{
+			name:     "selected values in order",
+			inputs:   []types.PipelineInput{{Step: "first", Indices: []uint64{1, 0}}, {Indices: []uint64{2}}},
+			expected: `{"inputs":["0.750000000000000000","0.250000000000000000","3.000000000000000000"]}`,
+		},
+		{
+			name:     "several steps",
+			inputs:   []types.PipelineInput{{Step: "second"}, {Step: "first"}},
+			expected: `{"inputs":["0.500000000000000000","0.250000000000000000","0.750000000000000000"]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			step := types.PipelineStep{ID: "step", Inputs: tc.inputs}
+			result, err := pipelineStepInput(step, input, previous, results)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(result))
+		})
+	}
+
+	errors := []struct {
+		name   string
+		input  json.RawMessage
+		inputs []types.PipelineInput
+	}{
+		{"index out of range", input, []types.PipelineInput{{Step: "first", Indices: []uint64{2}}}},
+		{"missing step result", input, []types.PipelineInput{{Step: "missing"}}},
+		{"pipeline input is not an inference input", json.RawMessage(`"text"`), []types.PipelineInput{{Indices: []uint64{0}}}},
+	}
+
+	for _, tc := range errors {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := pipelineStepInput(types.PipelineStep{ID: "step", Inputs: tc.inputs}, tc.input, previous, results)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetAgentPricing stores the price list of an agent
+func (k Keeper) SetAgentPricing(ctx sdk.Context, pricing types.AgentPricing) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentPricingKey(pricing.AgentID), k.cdc.MustMarshal(&pricing))
+}
+
+// GetAgentPricing returns the price list of an agent
+func (k Keeper) GetAgentPricing(ctx sdk.Context, agentID string) (types.AgentPricing, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAgentPricingKey(agentID))
+	if value == nil {
+		return types.AgentPricing{}, false
+	}
+
+	var pricing types.AgentPricing
+	k.cdc.MustUnmarshal(value, &pricing)
+	return pricing, true
+}
+
+// GetAllAgentPricings returns the price lists of all agents
+func (k Keeper) GetAllAgentPricings(ctx sdk.Context) []types.AgentPricing {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AgentPricingKey)
+	defer iterator.Close()
+
+	var pricings []types.AgentPricing
+	for ; iterator.Valid(); iterator.Next() {
+		var pricing types.AgentPricing
+		k.cdc.MustUnmarshal(iterator.Value(), &pricing)
+		pricings = append(pricings, pricing)
+	}
+
+	return pricings
+}
+
+// UpdateAgentPricing replaces the price list of an agent on behalf of its owner. The
+// calls callers made in the current period keep counting against the new price list.
+func (k Keeper) UpdateAgentPricing(ctx sdk.Context, owner sdk.AccAddress, pricing types.AgentPricing) error {
+	agent, found := k.GetAIAgent(ctx, pricing.AgentID)
+	if !found {
+		return sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", pricing.AgentID))
+	}
+	if !agent.Owner.Equals(owner) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can set the agent pricing")
+	}
+	if err := pricing.Validate(); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidPricing, err.Error())
+	}
+
+	k.SetAgentPricing(ctx, pricing)
+	return nil
+}
+
+// QuoteAgentCall returns the fee a caller would be charged for a call of an action
+// type. It returns false if the call is not priced, in which case the caller chooses
+// the fee.
+func (k Keeper) QuoteAgentCall(ctx sdk.Context, agent types.AIAgent, caller sdk.AccAddress, actionType string) (types.AgentQuote, bool) {
+	if agent.Owner.Equals(caller) || k.HasActiveAIAgentRental(ctx, agent.ID, caller) {
+		return types.AgentQuote{}, false
+	}
+
+	pricing, found := k.GetAgentPricing(ctx, agent.ID)
+	if !found {
+		return types.AgentQuote{}, false
+	}
+
+	usage := k.getAgentCallUsage(ctx, pricing, caller)
+	return pricing.Quote(actionType, usage.Calls), true
+}
+
+// ChargeAgentCall returns the fee to charge for a call of an agent given the fee the
+// caller offered. Calls covered by a subscription of the caller are free and count
+// against its quota. Priced calls are charged the quoted fee and rejected if the
+// offered fee does not cover it; the rest of the offered fee is never collected.
+// Unpriced calls are charged the offered fee.
+func (k Keeper) ChargeAgentCall(ctx sdk.Context, agent types.AIAgent, caller sdk.AccAddress, actionType string, offered sdk.Coins) (sdk.Coins, error) {
+	if k.useAgentSubscription(ctx, agent, caller) {
+		return sdk.NewCoins(), nil
+	}
+
+	quote, priced := k.QuoteAgentCall(ctx, agent, caller, actionType)
+	if !priced {
+		return offered, nil
+	}
+
+	fee := sdk.NewCoins(quote.Fee)
+	if !fee.IsAllLTE(offered) {
+		return nil, sdkerrors.Wrapf(types.ErrFeeBelowPrice, "offered %s, the price is %s", offered, quote.Fee)
+	}
+
+	pricing, _ := k.GetAgentPricing(ctx, agent.ID)
+	usage := k.getAgentCallUsage(ctx, pricing, caller)
+	usage.Calls++
+	k.setAgentCallUsage(ctx, usage)
+
+	return fee, nil
+}
+
+// getAgentCallUsage returns the caller's usage of a priced agent in the current period,
+// starting a new period if the previous one has elapsed
+func (k Keeper) getAgentCallUsage(ctx sdk.Context, pricing types.AgentPricing, caller sdk.AccAddress) types.AgentCallUsage {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAgentCallUsageKey(pricing.AgentID, caller))
+
+	var usage types.AgentCallUsage
+	if value != nil {
+		k.cdc.MustUnmarshal(value, &usage)
+	}
+
+	if value == nil || (pricing.Period > 0 && !ctx.BlockTime().Before(usage.PeriodStart.Add(pricing.Period))) {
+		usage = types.AgentCallUsage{
+			AgentID:     pricing.AgentID,
+			Caller:      caller,
+			PeriodStart: ctx.BlockTime(),
+		}
+	}
+
+	return usage
+}
+
+// setAgentCallUsage stores a caller's usage of a priced agent
+func (k Keeper) setAgentCallUsage(ctx sdk.Context, usage types.AgentCallUsage) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentCallUsageKey(usage.AgentID, usage.Caller), k.cdc.MustMarshal(&usage))
+}
\ No newline at end of file
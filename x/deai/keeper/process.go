@@ -1,44 +0,0 @@
-package keeper
-
-import (
-	"encoding/json"
-	
-	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/nomercychain/nmxchain/x/deai/types"
-)
-
-// ProcessAIAgentActions processes pending AI agent actions
-func (k Keeper) ProcessAIAgentActions(ctx sdk.Context) {
-	// In a real implementation, this would process pending AI agent actions
-	// For now, we'll just simulate the completion of actions
-	
-	// Get all actions with "pending" status
-	store := ctx.KVStore(k.storeKey)
-	iterator := sdk.KVStorePrefixIterator(store, types.AIAgentActionKey)
-	defer iterator.Close()
-	
-	for ; iterator.Valid(); iterator.Next() {
-		var action types.AIAgentAction
-		k.cdc.MustUnmarshal(iterator.Value(), &action)
-		
-		if action.Status == "pending" {
-			// Update the action status
-			action.Status = "completed"
-			action.Result = json.RawMessage(`{"status": "success", "message": "Action completed successfully"}`)
-			
-			// Save the updated action
-			k.SetAIAgentAction(ctx, action)
-			
-			// Emit an event
-			ctx.EventManager().EmitEvent(
-				sdk.NewEvent(
-					types.EventTypeExecuteAIAgent,
-					sdk.NewAttribute(types.AttributeKeyAgentID, action.AgentID),
-					sdk.NewAttribute(types.AttributeKeyActionID, action.ID),
-					sdk.NewAttribute(types.AttributeKeyActionType, action.ActionType),
-					sdk.NewAttribute(types.AttributeKeyStatus, action.Status),
-				),
-			)
-		}
-	}
-}
\ No newline at end of file
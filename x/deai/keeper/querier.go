@@ -32,6 +32,58 @@ func NewQuerier(k Keeper, legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
 			return queryMarketplaceListings(ctx, path[1:], req, k, legacyQuerierCdc)
 		case types.QueryMarketplaceListing:
 			return queryMarketplaceListing(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAIAgentRentals:
+			return queryAIAgentRentals(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryRenterRentals:
+			return queryRenterRentals(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryExecutor:
+			return queryExecutor(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryExecutors:
+			return queryExecutors(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryExecutionRequest:
+			return queryExecutionRequest(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryPendingExecutionRequests:
+			return queryPendingExecutionRequests(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryTrainingJob:
+			return queryTrainingJob(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryTrainingJobs:
+			return queryTrainingJobs(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAIAgentStateHistory:
+			return queryAIAgentStateHistory(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAIAgentStateAt:
+			return queryAIAgentStateAt(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryPipeline:
+			return queryPipeline(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryPipelines:
+			return queryPipelines(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentWallet:
+			return queryAgentWallet(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentSchedule:
+			return queryAgentSchedule(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentSchedules:
+			return queryAgentSchedules(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentQuote:
+			return queryAgentQuote(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryGovDelegation:
+			return queryGovDelegation(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentGovVotes:
+			return queryAgentGovVotes(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentReputation:
+			return queryAgentReputation(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryCreatorReputation:
+			return queryCreatorReputation(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentRatings:
+			return queryAgentRatings(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentShares:
+			return queryAgentShares(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryAgentShareholder:
+			return queryAgentShareholder(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QuerySubscriptionPlans:
+			return querySubscriptionPlans(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QuerySubscriptions:
+			return querySubscriptions(ctx, path[1:], req, k, legacyQuerierCdc)
+		case types.QueryEncryptionKey:
+			return queryEncryptionKey(ctx, path[1:], req, k, legacyQuerierCdc)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown deai query endpoint")
 		}
@@ -228,17 +280,12 @@ func queryMarketplaceListings(ctx sdk.Context, path []string, req abci.RequestQu
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
 	}
 
-	listings := k.GetAllAIAgentMarketplaceListings(ctx)
-
 	// Filter by status if specified
+	var listings []types.AIAgentMarketplaceListing
 	if params.Status != "" {
-		var filteredListings []types.AIAgentMarketplaceListing
-		for _, listing := range listings {
-			if listing.Status == params.Status {
-				filteredListings = append(filteredListings, listing)
-			}
-		}
-		listings = filteredListings
+		listings = k.GetAIAgentMarketplaceListingsByStatus(ctx, params.Status)
+	} else {
+		listings = k.GetAllAIAgentMarketplaceListings(ctx)
 	}
 
 	// Filter by listing type if specified
@@ -252,6 +299,11 @@ func queryMarketplaceListings(ctx sdk.Context, path []string, req abci.RequestQu
 		listings = filteredListings
 	}
 
+	listings, err = k.SortMarketplaceListings(ctx, listings, params.Sort)
+	if err != nil {
+		return nil, err
+	}
+
 	res := types.QueryMarketplaceListingsResponse{
 		Listings: listings,
 	}
@@ -285,5 +337,633 @@ func queryMarketplaceListing(ctx sdk.Context, path []string, req abci.RequestQue
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
+	return bz, nil
+}
+
+func queryAIAgentRentals(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAIAgentRentalsRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	rentals := k.GetAIAgentRentalsByAgent(ctx, params.AgentID)
+
+	res := types.QueryAIAgentRentalsResponse{
+		Rentals: rentals,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryRenterRentals(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryRenterRentalsRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	renterAddr, err := sdk.AccAddressFromBech32(params.Renter)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	var rentals []types.AIAgentRental
+	for _, rental := range k.GetAIAgentRentalsByRenter(ctx, renterAddr) {
+		if params.ActiveOnly && !rental.IsActive(ctx.BlockTime()) {
+			continue
+		}
+		rentals = append(rentals, rental)
+	}
+
+	res := types.QueryRenterRentalsResponse{
+		Rentals: rentals,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryExecutor(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryExecutorRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	addr, err := sdk.AccAddressFromBech32(params.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	executor, found := k.GetExecutor(ctx, addr)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrExecutorNotFound, params.Address)
+	}
+
+	res := types.QueryExecutorResponse{
+		Executor: executor,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryExecutors(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	res := types.QueryExecutorsResponse{
+		Executors: k.GetAllExecutors(ctx),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryExecutionRequest(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryExecutionRequestRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	request, found := k.GetExecutionRequest(ctx, params.ID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrExecutionRequestNotFound, params.ID)
+	}
+
+	res := types.QueryExecutionRequestResponse{
+		Request: request,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryPendingExecutionRequests(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	res := types.QueryPendingExecutionRequestsResponse{
+		Requests: k.GetPendingExecutionRequests(ctx),
+		Height:   ctx.BlockHeight(),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryTrainingJob(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryTrainingJobRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	job, found := k.GetTrainingJob(ctx, params.ID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrTrainingJobNotFound, params.ID)
+	}
+
+	res := types.QueryTrainingJobResponse{
+		Job: job,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryTrainingJobs(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryTrainingJobsRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res := types.QueryTrainingJobsResponse{
+		Jobs: k.GetTrainingJobs(ctx, params.AgentID, params.Status),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAIAgentStateHistory(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAIAgentStateHistoryRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res := types.QueryAIAgentStateHistoryResponse{
+		States: k.GetAIAgentStateHistory(ctx, params.AgentID),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAIAgentStateAt(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAIAgentStateAtRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	state, found := k.GetAIAgentStateAt(ctx, params.AgentID, params.Version)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrStateVersionNotFound, "version %d of agent %s", params.Version, params.AgentID)
+	}
+
+	res := types.QueryAIAgentStateAtResponse{
+		State: state,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryPipeline(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryPipelineRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	pipeline, found := k.GetPipeline(ctx, params.ID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrPipelineNotFound, params.ID)
+	}
+
+	res := types.QueryPipelineResponse{
+		Pipeline: pipeline,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryPipelines(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryPipelinesRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var owner sdk.AccAddress
+	if params.Owner != "" {
+		owner, err = sdk.AccAddressFromBech32(params.Owner)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+	}
+
+	res := types.QueryPipelinesResponse{
+		Pipelines: k.GetPipelinesByOwner(ctx, owner),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentWallet(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentWalletRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if _, found := k.GetAIAgent(ctx, params.AgentID); !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "agent not found: %s", params.AgentID)
+	}
+
+	wallet := types.AgentWalletAddress(params.AgentID)
+	res := types.QueryAgentWalletResponse{
+		Address: wallet.String(),
+		Balance: k.bankKeeper.GetAllBalances(ctx, wallet),
+	}
+	if policy, found := k.GetAgentWalletPolicy(ctx, params.AgentID); found {
+		res.Policy = &policy
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentSchedule(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentScheduleRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	schedule, found := k.GetAgentSchedule(ctx, params.ID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrScheduleNotFound, params.ID)
+	}
+
+	res := types.QueryAgentScheduleResponse{
+		Schedule: schedule,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentSchedules(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentSchedulesRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var owner sdk.AccAddress
+	if params.Owner != "" {
+		owner, err = sdk.AccAddressFromBech32(params.Owner)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+	}
+
+	res := types.QueryAgentSchedulesResponse{
+		Schedules: k.GetAgentSchedules(ctx, params.AgentID, owner),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentQuote(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentQuoteRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	agent, found := k.GetAIAgent(ctx, params.AgentID)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "agent not found: %s", params.AgentID)
+	}
+
+	var caller sdk.AccAddress
+	if params.Caller != "" {
+		caller, err = sdk.AccAddressFromBech32(params.Caller)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+	}
+
+	quote, priced := k.QuoteAgentCall(ctx, agent, caller, params.ActionType)
+	res := types.QueryAgentQuoteResponse{
+		Priced: priced,
+		Quote:  quote,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryGovDelegation(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryGovDelegationRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	delegator, err := sdk.AccAddressFromBech32(params.Delegator)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	delegation, found := k.GetGovDelegation(ctx, delegator)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrGovDelegationNotFound, params.Delegator)
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, types.QueryGovDelegationResponse{Delegation: delegation})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentGovVotes(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentGovVotesRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if _, found := k.GetAIAgent(ctx, params.AgentID); !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "agent not found: %s", params.AgentID)
+	}
+
+	res := types.QueryAgentGovVotesResponse{
+		Votes:      k.GetAgentGovVotes(ctx, params.AgentID),
+		Delegators: k.getGovDelegateCount(ctx, params.AgentID),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentReputation(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentReputationRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	agent, found := k.GetAIAgent(ctx, params.AgentID)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "agent not found: %s", params.AgentID)
+	}
+
+	res := types.QueryAgentReputationResponse{
+		Agent:   k.AgentReputationScore(ctx, agent.ID),
+		Creator: k.CreatorReputationScore(ctx, agent.Creator),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryCreatorReputation(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryCreatorReputationRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	creator, err := sdk.AccAddressFromBech32(params.Creator)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	res := types.QueryCreatorReputationResponse{
+		Reputation: k.CreatorReputationScore(ctx, creator),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentRatings(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentRatingsRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if _, found := k.GetAIAgent(ctx, params.AgentID); !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "agent not found: %s", params.AgentID)
+	}
+
+	res := types.QueryAgentRatingsResponse{
+		Ratings: k.GetAgentRatings(ctx, params.AgentID),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentShares(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentSharesRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	shares, found := k.GetAgentShares(ctx, params.AgentID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrNotFractionalized, params.AgentID)
+	}
+
+	res := types.QueryAgentSharesResponse{
+		Shares:  shares,
+		Holders: k.GetAgentShareholders(ctx, params.AgentID),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryAgentShareholder(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryAgentShareholderRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	holder, err := sdk.AccAddressFromBech32(params.Holder)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	shareholder, found := k.AgentShareholderIncome(ctx, params.AgentID, holder)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrKeyNotFound, "shareholder not found: %s", params.Holder)
+	}
+	claimable, _ := shareholder.Unclaimed.TruncateDecimal()
+
+	res := types.QueryAgentShareholderResponse{
+		Shareholder: shareholder,
+		Claimable:   claimable,
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func querySubscriptionPlans(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QuerySubscriptionPlansRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res := types.QuerySubscriptionPlansResponse{
+		Plans: k.GetSubscriptionPlans(ctx, params.AgentID),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func querySubscriptions(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QuerySubscriptionsRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	subscriber, err := sdk.AccAddressFromBech32(params.Subscriber)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	res := types.QuerySubscriptionsResponse{
+		Subscriptions: k.GetAgentSubscriptions(ctx, subscriber),
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryEncryptionKey(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	var params types.QueryEncryptionKeyRequest
+	err := legacyQuerierCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	addr, err := sdk.AccAddressFromBech32(params.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	key, found := k.GetAccountEncryptionKey(ctx, addr)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrNoEncryptionKey, params.Address)
+	}
+
+	bz, err := codec.MarshalJSONIndent(legacyQuerierCdc, types.QueryEncryptionKeyResponse{Key: key})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
 	return bz, nil
 }
\ No newline at end of file
@@ -173,17 +173,12 @@ func (k queryServer) MarketplaceListings(goCtx context.Context, req *types.Query
 
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	listings := k.GetAllAIAgentMarketplaceListings(ctx)
-	
 	// Filter by status if specified
+	var listings []types.AIAgentMarketplaceListing
 	if req.Status != "" {
-		var filteredListings []types.AIAgentMarketplaceListing
-		for _, listing := range listings {
-			if listing.Status == req.Status {
-				filteredListings = append(filteredListings, listing)
-			}
-		}
-		listings = filteredListings
+		listings = k.GetAIAgentMarketplaceListingsByStatus(ctx, req.Status)
+	} else {
+		listings = k.GetAllAIAgentMarketplaceListings(ctx)
 	}
 	
 	// Filter by listing type if specified
@@ -197,6 +192,11 @@ func (k queryServer) MarketplaceListings(goCtx context.Context, req *types.Query
 		listings = filteredListings
 	}
 
+	listings, err := k.SortMarketplaceListings(ctx, listings, req.Sort)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	return &types.QueryMarketplaceListingsResponse{
 		Listings: listings,
 	}, nil
@@ -218,4 +218,502 @@ func (k queryServer) MarketplaceListing(goCtx context.Context, req *types.QueryM
 	return &types.QueryMarketplaceListingResponse{
 		Listing: listing,
 	}, nil
+}
+
+// AIAgentRentals returns all rentals of a specific AI agent
+func (k queryServer) AIAgentRentals(goCtx context.Context, req *types.QueryAIAgentRentalsRequest) (*types.QueryAIAgentRentalsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	rentals := k.GetAIAgentRentalsByAgent(ctx, req.AgentID)
+
+	return &types.QueryAIAgentRentalsResponse{
+		Rentals: rentals,
+	}, nil
+}
+
+// RenterRentals returns all rentals taken out by a specific renter
+func (k queryServer) RenterRentals(goCtx context.Context, req *types.QueryRenterRentalsRequest) (*types.QueryRenterRentalsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	renterAddr, err := sdk.AccAddressFromBech32(req.Renter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid renter address")
+	}
+
+	rentals := k.GetAIAgentRentalsByRenter(ctx, renterAddr)
+
+	// Filter out expired rentals if requested
+	if req.ActiveOnly {
+		var activeRentals []types.AIAgentRental
+		for _, rental := range rentals {
+			if rental.IsActive(ctx.BlockTime()) {
+				activeRentals = append(activeRentals, rental)
+			}
+		}
+		rentals = activeRentals
+	}
+
+	return &types.QueryRenterRentalsResponse{
+		Rentals: rentals,
+	}, nil
+}
+
+// Executor returns a specific executor
+func (k queryServer) Executor(goCtx context.Context, req *types.QueryExecutorRequest) (*types.QueryExecutorResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid executor address")
+	}
+
+	executor, found := k.GetExecutor(ctx, addr)
+	if !found {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+
+	return &types.QueryExecutorResponse{
+		Executor: executor,
+	}, nil
+}
+
+// Executors returns all executors
+func (k queryServer) Executors(goCtx context.Context, req *types.QueryExecutorsRequest) (*types.QueryExecutorsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return &types.QueryExecutorsResponse{
+		Executors: k.GetAllExecutors(ctx),
+	}, nil
+}
+
+// ExecutionRequest returns a specific execution request
+func (k queryServer) ExecutionRequest(goCtx context.Context, req *types.QueryExecutionRequestRequest) (*types.QueryExecutionRequestResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	request, found := k.GetExecutionRequest(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "execution request not found")
+	}
+
+	return &types.QueryExecutionRequestResponse{
+		Request: request,
+	}, nil
+}
+
+// PendingExecutionRequests returns all execution requests waiting for executors
+func (k queryServer) PendingExecutionRequests(goCtx context.Context, req *types.QueryPendingExecutionRequestsRequest) (*types.QueryPendingExecutionRequestsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return &types.QueryPendingExecutionRequestsResponse{
+		Requests: k.GetPendingExecutionRequests(ctx),
+		Height:   ctx.BlockHeight(),
+	}, nil
+}
+
+// TrainingJob returns a specific training job
+func (k queryServer) TrainingJob(goCtx context.Context, req *types.QueryTrainingJobRequest) (*types.QueryTrainingJobResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	job, found := k.GetTrainingJob(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "training job not found")
+	}
+
+	return &types.QueryTrainingJobResponse{
+		Job: job,
+	}, nil
+}
+
+// TrainingJobs returns training jobs, optionally filtered by agent and status
+func (k queryServer) TrainingJobs(goCtx context.Context, req *types.QueryTrainingJobsRequest) (*types.QueryTrainingJobsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return &types.QueryTrainingJobsResponse{
+		Jobs: k.GetTrainingJobs(ctx, req.AgentID, req.Status),
+	}, nil
+}
+
+// AIAgentStateHistory returns the retained versions of an AI agent's state
+func (k queryServer) AIAgentStateHistory(goCtx context.Context, req *types.QueryAIAgentStateHistoryRequest) (*types.QueryAIAgentStateHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return &types.QueryAIAgentStateHistoryResponse{
+		States: k.GetAIAgentStateHistory(ctx, req.AgentID),
+	}, nil
+}
+
+// AIAgentStateAt returns a specific version of an AI agent's state
+func (k queryServer) AIAgentStateAt(goCtx context.Context, req *types.QueryAIAgentStateAtRequest) (*types.QueryAIAgentStateAtResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	state, found := k.GetAIAgentStateAt(ctx, req.AgentID, req.Version)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent state version not found")
+	}
+
+	return &types.QueryAIAgentStateAtResponse{
+		State: state,
+	}, nil
+}
+
+// Pipeline returns a pipeline by ID
+func (k queryServer) Pipeline(goCtx context.Context, req *types.QueryPipelineRequest) (*types.QueryPipelineResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	pipeline, found := k.GetPipeline(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "pipeline not found")
+	}
+
+	return &types.QueryPipelineResponse{
+		Pipeline: pipeline,
+	}, nil
+}
+
+// Pipelines returns all pipelines, optionally filtered by owner
+func (k queryServer) Pipelines(goCtx context.Context, req *types.QueryPipelinesRequest) (*types.QueryPipelinesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	var owner sdk.AccAddress
+	if req.Owner != "" {
+		ownerAddr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid owner address")
+		}
+		owner = ownerAddr
+	}
+
+	return &types.QueryPipelinesResponse{
+		Pipelines: k.GetPipelinesByOwner(ctx, owner),
+	}, nil
+}
+
+// AgentWallet returns the wallet of an AI agent
+func (k queryServer) AgentWallet(goCtx context.Context, req *types.QueryAgentWalletRequest) (*types.QueryAgentWalletResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, found := k.GetAIAgent(ctx, req.AgentID); !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	wallet := types.AgentWalletAddress(req.AgentID)
+	res := &types.QueryAgentWalletResponse{
+		Address: wallet.String(),
+		Balance: k.bankKeeper.GetAllBalances(ctx, wallet),
+	}
+	if policy, found := k.GetAgentWalletPolicy(ctx, req.AgentID); found {
+		res.Policy = &policy
+	}
+
+	return res, nil
+}
+
+// AgentSchedule returns an agent schedule by ID
+func (k queryServer) AgentSchedule(goCtx context.Context, req *types.QueryAgentScheduleRequest) (*types.QueryAgentScheduleResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	schedule, found := k.GetAgentSchedule(ctx, req.ID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent schedule not found")
+	}
+
+	return &types.QueryAgentScheduleResponse{
+		Schedule: schedule,
+	}, nil
+}
+
+// AgentSchedules returns all agent schedules, optionally filtered by agent and owner
+func (k queryServer) AgentSchedules(goCtx context.Context, req *types.QueryAgentSchedulesRequest) (*types.QueryAgentSchedulesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	var owner sdk.AccAddress
+	if req.Owner != "" {
+		ownerAddr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid owner address")
+		}
+		owner = ownerAddr
+	}
+
+	return &types.QueryAgentSchedulesResponse{
+		Schedules: k.GetAgentSchedules(ctx, req.AgentID, owner),
+	}, nil
+}
+
+// AgentQuote returns the fee of a prospective call of an AI agent
+func (k queryServer) AgentQuote(goCtx context.Context, req *types.QueryAgentQuoteRequest) (*types.QueryAgentQuoteResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	agent, found := k.GetAIAgent(ctx, req.AgentID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	var caller sdk.AccAddress
+	if req.Caller != "" {
+		callerAddr, err := sdk.AccAddressFromBech32(req.Caller)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid caller address")
+		}
+		caller = callerAddr
+	}
+
+	quote, priced := k.QuoteAgentCall(ctx, agent, caller, req.ActionType)
+	return &types.QueryAgentQuoteResponse{
+		Priced: priced,
+		Quote:  quote,
+	}, nil
+}
+
+// GovDelegation returns the governance delegation of an account
+func (k queryServer) GovDelegation(goCtx context.Context, req *types.QueryGovDelegationRequest) (*types.QueryGovDelegationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	delegator, err := sdk.AccAddressFromBech32(req.Delegator)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid delegator address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	delegation, found := k.GetGovDelegation(ctx, delegator)
+	if !found {
+		return nil, status.Error(codes.NotFound, "governance delegation not found")
+	}
+
+	return &types.QueryGovDelegationResponse{Delegation: delegation}, nil
+}
+
+// AgentGovVotes returns the voting record of a governance agent
+func (k queryServer) AgentGovVotes(goCtx context.Context, req *types.QueryAgentGovVotesRequest) (*types.QueryAgentGovVotesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, found := k.GetAIAgent(ctx, req.AgentID); !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	return &types.QueryAgentGovVotesResponse{
+		Votes:      k.GetAgentGovVotes(ctx, req.AgentID),
+		Delegators: k.getGovDelegateCount(ctx, req.AgentID),
+	}, nil
+}
+
+// AgentReputation returns the reputation of an agent and of its creator
+func (k queryServer) AgentReputation(goCtx context.Context, req *types.QueryAgentReputationRequest) (*types.QueryAgentReputationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	agent, found := k.GetAIAgent(ctx, req.AgentID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	return &types.QueryAgentReputationResponse{
+		Agent:   k.AgentReputationScore(ctx, agent.ID),
+		Creator: k.CreatorReputationScore(ctx, agent.Creator),
+	}, nil
+}
+
+// CreatorReputation returns the reputation of a creator across their agents
+func (k queryServer) CreatorReputation(goCtx context.Context, req *types.QueryCreatorReputationRequest) (*types.QueryCreatorReputationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	creator, err := sdk.AccAddressFromBech32(req.Creator)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid creator address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return &types.QueryCreatorReputationResponse{
+		Reputation: k.CreatorReputationScore(ctx, creator),
+	}, nil
+}
+
+// AgentRatings returns the ratings of an agent
+func (k queryServer) AgentRatings(goCtx context.Context, req *types.QueryAgentRatingsRequest) (*types.QueryAgentRatingsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if _, found := k.GetAIAgent(ctx, req.AgentID); !found {
+		return nil, status.Error(codes.NotFound, "agent not found")
+	}
+
+	return &types.QueryAgentRatingsResponse{
+		Ratings: k.GetAgentRatings(ctx, req.AgentID),
+	}, nil
+}
+
+// AgentShares returns the shares and shareholders of a fractionalized agent
+func (k queryServer) AgentShares(goCtx context.Context, req *types.QueryAgentSharesRequest) (*types.QueryAgentSharesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	shares, found := k.GetAgentShares(ctx, req.AgentID)
+	if !found {
+		return nil, status.Error(codes.NotFound, "agent is not fractionalized")
+	}
+
+	return &types.QueryAgentSharesResponse{
+		Shares:  shares,
+		Holders: k.GetAgentShareholders(ctx, req.AgentID),
+	}, nil
+}
+
+// AgentShareholder returns the income a holder of shares of an agent can claim
+func (k queryServer) AgentShareholder(goCtx context.Context, req *types.QueryAgentShareholderRequest) (*types.QueryAgentShareholderResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	holder, err := sdk.AccAddressFromBech32(req.Holder)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid holder address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	shareholder, found := k.AgentShareholderIncome(ctx, req.AgentID, holder)
+	if !found {
+		return nil, status.Error(codes.NotFound, "shareholder not found")
+	}
+	claimable, _ := shareholder.Unclaimed.TruncateDecimal()
+
+	return &types.QueryAgentShareholderResponse{
+		Shareholder: shareholder,
+		Claimable:   claimable,
+	}, nil
+}
+
+// SubscriptionPlans returns the subscription plans of an agent
+func (k queryServer) SubscriptionPlans(goCtx context.Context, req *types.QuerySubscriptionPlansRequest) (*types.QuerySubscriptionPlansResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return &types.QuerySubscriptionPlansResponse{
+		Plans: k.GetSubscriptionPlans(ctx, req.AgentID),
+	}, nil
+}
+
+// Subscriptions returns the active subscriptions of a subscriber
+func (k queryServer) Subscriptions(goCtx context.Context, req *types.QuerySubscriptionsRequest) (*types.QuerySubscriptionsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	subscriber, err := sdk.AccAddressFromBech32(req.Subscriber)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscriber address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return &types.QuerySubscriptionsResponse{
+		Subscriptions: k.GetAgentSubscriptions(ctx, subscriber),
+	}, nil
+}
+
+// EncryptionKey returns the encryption key an account registered
+func (k queryServer) EncryptionKey(goCtx context.Context, req *types.QueryEncryptionKeyRequest) (*types.QueryEncryptionKeyResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	key, found := k.GetAccountEncryptionKey(ctx, addr)
+	if !found {
+		return nil, status.Error(codes.NotFound, "no encryption key registered")
+	}
+
+	return &types.QueryEncryptionKeyResponse{Key: key}, nil
 }
\ No newline at end of file
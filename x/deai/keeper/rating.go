@@ -0,0 +1,231 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetAgentRating stores an agent rating
+func (k Keeper) SetAgentRating(ctx sdk.Context, rating types.AgentRating) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentRatingKey(rating.AgentID, rating.Subject, rating.SubjectID), k.cdc.MustMarshal(&rating))
+}
+
+// HasAgentRating returns true if the action or rental of an agent was rated
+func (k Keeper) HasAgentRating(ctx sdk.Context, agentID, subject, subjectID string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetAgentRatingKey(agentID, subject, subjectID))
+}
+
+// GetAgentRatings returns the ratings of an agent
+func (k Keeper) GetAgentRatings(ctx sdk.Context, agentID string) []types.AgentRating {
+	return k.getAgentRatings(ctx, types.GetAgentRatingPrefix(agentID))
+}
+
+// GetAllAgentRatings returns the ratings of all agents
+func (k Keeper) GetAllAgentRatings(ctx sdk.Context) []types.AgentRating {
+	return k.getAgentRatings(ctx, types.AgentRatingKey)
+}
+
+func (k Keeper) getAgentRatings(ctx sdk.Context, prefix []byte) []types.AgentRating {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var ratings []types.AgentRating
+	for ; iterator.Valid(); iterator.Next() {
+		var rating types.AgentRating
+		k.cdc.MustUnmarshal(iterator.Value(), &rating)
+		ratings = append(ratings, rating)
+	}
+
+	return ratings
+}
+
+// SetAgentReputation stores the reputation of an agent
+func (k Keeper) SetAgentReputation(ctx sdk.Context, reputation types.AgentReputation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentReputationKey(reputation.Subject), k.cdc.MustMarshal(&reputation))
+}
+
+// GetAgentReputation returns the reputation of an agent as last stored
+func (k Keeper) GetAgentReputation(ctx sdk.Context, agentID string) (types.AgentReputation, bool) {
+	return k.getReputation(ctx, types.GetAgentReputationKey(agentID))
+}
+
+// GetAllAgentReputations returns the reputations of all rated agents
+func (k Keeper) GetAllAgentReputations(ctx sdk.Context) []types.AgentReputation {
+	return k.getReputations(ctx, types.AgentReputationKey)
+}
+
+// SetCreatorReputation stores the reputation of a creator
+func (k Keeper) SetCreatorReputation(ctx sdk.Context, reputation types.AgentReputation) {
+	creator, err := sdk.AccAddressFromBech32(reputation.Subject)
+	if err != nil {
+		panic(err)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetCreatorReputationKey(creator), k.cdc.MustMarshal(&reputation))
+}
+
+// GetCreatorReputation returns the reputation of a creator as last stored
+func (k Keeper) GetCreatorReputation(ctx sdk.Context, creator sdk.AccAddress) (types.AgentReputation, bool) {
+	return k.getReputation(ctx, types.GetCreatorReputationKey(creator))
+}
+
+// GetAllCreatorReputations returns the reputations of all creators of rated agents
+func (k Keeper) GetAllCreatorReputations(ctx sdk.Context) []types.AgentReputation {
+	return k.getReputations(ctx, types.CreatorReputationKey)
+}
+
+func (k Keeper) getReputation(ctx sdk.Context, key []byte) (types.AgentReputation, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(key)
+	if value == nil {
+		return types.AgentReputation{}, false
+	}
+
+	var reputation types.AgentReputation
+	k.cdc.MustUnmarshal(value, &reputation)
+	return reputation, true
+}
+
+func (k Keeper) getReputations(ctx sdk.Context, prefix []byte) []types.AgentReputation {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var reputations []types.AgentReputation
+	for ; iterator.Valid(); iterator.Next() {
+		var reputation types.AgentReputation
+		k.cdc.MustUnmarshal(iterator.Value(), &reputation)
+		reputations = append(reputations, reputation)
+	}
+
+	return reputations
+}
+
+// RateAgent records a rating of an action or rental of an agent by the account that
+// requested the action or took out the rental, and adds it to the reputation of the
+// agent and of its creator. The rating weighs by the amount paid for the rated
+// interaction in the volume-weighted score.
+func (k Keeper) RateAgent(ctx sdk.Context, rating types.AgentRating) (types.AgentRating, error) {
+	agent, found := k.GetAIAgent(ctx, rating.AgentID)
+	if !found {
+		return types.AgentRating{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", rating.AgentID))
+	}
+	if agent.Owner.Equals(rating.Rater) || agent.Creator.Equals(rating.Rater) {
+		return types.AgentRating{}, sdkerrors.Wrap(types.ErrInvalidRating, "the owner and creator cannot rate their agent")
+	}
+	if k.HasAgentRating(ctx, rating.AgentID, rating.Subject, rating.SubjectID) {
+		return types.AgentRating{}, sdkerrors.Wrapf(types.ErrAlreadyRated, "%s %s", rating.Subject, rating.SubjectID)
+	}
+
+	denom := k.MinAgentDeposit(ctx).Denom
+	switch rating.Subject {
+	case types.AgentRatingSubjectAction:
+		action, found := k.GetAIAgentAction(ctx, rating.SubjectID)
+		if !found || action.AgentID != rating.AgentID {
+			return types.AgentRating{}, sdkerrors.Wrapf(types.ErrInvalidRating, "action %s of agent %s not found", rating.SubjectID, rating.AgentID)
+		}
+		if !action.Requester.Equals(rating.Rater) {
+			return types.AgentRating{}, sdkerrors.Wrap(types.ErrInvalidRating, "only the requester of an action can rate it")
+		}
+		if action.Status == types.ExecutionStatusPending {
+			return types.AgentRating{}, sdkerrors.Wrap(types.ErrInvalidRating, "the action is still pending")
+		}
+		// The fee of a failed request was refunded
+		rating.Volume = sdk.ZeroInt()
+		if action.Status == types.ExecutionStatusCompleted {
+			rating.Volume = action.Fee.AmountOf(denom)
+		}
+	case types.AgentRatingSubjectRental:
+		rental, found := k.GetAIAgentRental(ctx, rating.SubjectID)
+		if !found || rental.AgentID != rating.AgentID {
+			return types.AgentRating{}, sdkerrors.Wrapf(types.ErrInvalidRating, "rental %s of agent %s not found", rating.SubjectID, rating.AgentID)
+		}
+		if !rental.Renter.Equals(rating.Rater) {
+			return types.AgentRating{}, sdkerrors.Wrap(types.ErrInvalidRating, "only the renter can rate a rental")
+		}
+		rating.Volume = rental.PricePaid.AmountOf(denom)
+	}
+
+	rating.CreatedAt = ctx.BlockTime()
+	if err := rating.Validate(); err != nil {
+		return types.AgentRating{}, sdkerrors.Wrap(types.ErrInvalidRating, err.Error())
+	}
+	k.SetAgentRating(ctx, rating)
+
+	halfLife := k.ratingHalfLife(ctx)
+	reputation, found := k.GetAgentReputation(ctx, agent.ID)
+	if !found {
+		reputation = types.NewAgentReputation(agent.ID, ctx.BlockTime())
+	}
+	k.SetAgentReputation(ctx, reputation.Decay(ctx.BlockTime(), halfLife).Add(rating.Score, rating.Volume))
+
+	if !agent.Creator.Empty() {
+		creatorReputation, found := k.GetCreatorReputation(ctx, agent.Creator)
+		if !found {
+			creatorReputation = types.NewAgentReputation(agent.Creator.String(), ctx.BlockTime())
+		}
+		k.SetCreatorReputation(ctx, creatorReputation.Decay(ctx.BlockTime(), halfLife).Add(rating.Score, rating.Volume))
+	}
+
+	return rating, nil
+}
+
+// AgentReputationScore returns the current reputation of an agent. Agents without
+// ratings score the prior mean.
+func (k Keeper) AgentReputationScore(ctx sdk.Context, agentID string) types.AgentReputationScore {
+	reputation, found := k.GetAgentReputation(ctx, agentID)
+	if !found {
+		reputation = types.NewAgentReputation(agentID, ctx.BlockTime())
+	}
+	return k.reputationScore(ctx, reputation)
+}
+
+// CreatorReputationScore returns the current reputation of a creator across their agents
+func (k Keeper) CreatorReputationScore(ctx sdk.Context, creator sdk.AccAddress) types.AgentReputationScore {
+	reputation, found := k.GetCreatorReputation(ctx, creator)
+	if !found {
+		reputation = types.NewAgentReputation(creator.String(), ctx.BlockTime())
+	}
+	return k.reputationScore(ctx, reputation)
+}
+
+func (k Keeper) reputationScore(ctx sdk.Context, reputation types.AgentReputation) types.AgentReputationScore {
+	reputation = reputation.Decay(ctx.BlockTime(), k.ratingHalfLife(ctx))
+	return reputation.Score(k.RatingPriorMean(ctx), k.RatingPriorWeight(ctx))
+}
+
+// ratingHalfLife returns the time after which a rating weighs half
+func (k Keeper) ratingHalfLife(ctx sdk.Context) time.Duration {
+	return time.Duration(k.RatingHalfLifeSeconds(ctx)) * time.Second
+}
+
+// SortMarketplaceListings orders marketplace listings by the given sort order. Listings
+// with equal keys keep their order.
+func (k Keeper) SortMarketplaceListings(ctx sdk.Context, listings []types.AIAgentMarketplaceListing, sortBy string) ([]types.AIAgentMarketplaceListing, error) {
+	switch sortBy {
+	case "":
+		return listings, nil
+	case types.MarketplaceSortRating:
+		scores := make(map[string]sdk.Dec)
+		for _, listing := range listings {
+			if _, found := scores[listing.AgentID]; !found {
+				scores[listing.AgentID] = k.AgentReputationScore(ctx, listing.AgentID).BayesianAverage
+			}
+		}
+		sort.SliceStable(listings, func(i, j int) bool {
+			return scores[listings[i].AgentID].GT(scores[listings[j].AgentID])
+		})
+		return listings, nil
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown sort order: %s", sortBy)
+	}
+}
\ No newline at end of file
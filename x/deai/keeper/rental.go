@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetAIAgentRental stores an AI agent rental and keeps its renter and agent indexes up to date
+func (k Keeper) SetAIAgentRental(ctx sdk.Context, rental types.AIAgentRental) {
+	store := ctx.KVStore(k.storeKey)
+	value := k.cdc.MustMarshal(&rental)
+	store.Set(types.GetAIAgentRentalKey(rental.ID), value)
+	store.Set(types.GetAIAgentRentalByRenterKey(rental.Renter, rental.ID), []byte(rental.ID))
+	store.Set(types.GetAIAgentRentalByAgentKey(rental.AgentID, rental.ID), []byte(rental.ID))
+}
+
+// GetAIAgentRental returns an AI agent rental by ID
+func (k Keeper) GetAIAgentRental(ctx sdk.Context, id string) (types.AIAgentRental, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAIAgentRentalKey(id))
+	if value == nil {
+		return types.AIAgentRental{}, false
+	}
+
+	var rental types.AIAgentRental
+	k.cdc.MustUnmarshal(value, &rental)
+	return rental, true
+}
+
+// GetAllAIAgentRentals returns all AI agent rentals
+func (k Keeper) GetAllAIAgentRentals(ctx sdk.Context) []types.AIAgentRental {
+	var rentals []types.AIAgentRental
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AIAgentRentalKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var rental types.AIAgentRental
+		k.cdc.MustUnmarshal(iterator.Value(), &rental)
+		rentals = append(rentals, rental)
+	}
+
+	return rentals
+}
+
+// GetAIAgentRentalsByAgent returns all rentals of an AI agent
+func (k Keeper) GetAIAgentRentalsByAgent(ctx sdk.Context, agentID string) []types.AIAgentRental {
+	return k.getAIAgentRentalsByIndex(ctx, types.GetAIAgentRentalByAgentPrefix(agentID))
+}
+
+// GetAIAgentRentalsByRenter returns all rentals taken out by a renter
+func (k Keeper) GetAIAgentRentalsByRenter(ctx sdk.Context, renter sdk.AccAddress) []types.AIAgentRental {
+	return k.getAIAgentRentalsByIndex(ctx, types.GetAIAgentRentalByRenterPrefix(renter))
+}
+
+// getAIAgentRentalsByIndex resolves the rental IDs stored under an index prefix
+func (k Keeper) getAIAgentRentalsByIndex(ctx sdk.Context, prefix []byte) []types.AIAgentRental {
+	var rentals []types.AIAgentRental
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		rental, found := k.GetAIAgentRental(ctx, string(iterator.Value()))
+		if found {
+			rentals = append(rentals, rental)
+		}
+	}
+
+	return rentals
+}
+
+// HasActiveAIAgentRental returns true if the renter currently holds an active rental of the agent
+func (k Keeper) HasActiveAIAgentRental(ctx sdk.Context, agentID string, renter sdk.AccAddress) bool {
+	for _, rental := range k.GetAIAgentRentalsByRenter(ctx, renter) {
+		if rental.AgentID == agentID && rental.IsActive(ctx.BlockTime()) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAIAgentRental records a new rental and schedules its expiry
+func (k Keeper) CreateAIAgentRental(ctx sdk.Context, listing types.AIAgentMarketplaceListing, renter sdk.AccAddress, duration uint64, pricePaid sdk.Coins) types.AIAgentRental {
+	startTime := ctx.BlockTime()
+	rental := types.AIAgentRental{
+		ID:        fmt.Sprintf("%s-%s-%d", listing.AgentID, renter.String(), ctx.BlockHeight()),
+		AgentID:   listing.AgentID,
+		ListingID: listing.ID,
+		Renter:    renter,
+		Owner:     listing.Seller,
+		PricePaid: pricePaid,
+		StartTime: startTime,
+		EndTime:   startTime.Add(types.RentalDurationUnit * time.Duration(duration)),
+		Status:    types.AIAgentRentalStatusActive,
+	}
+
+	k.SetAIAgentRental(ctx, rental)
+	k.insertRentalExpiryQueue(ctx, rental)
+
+	return rental
+}
+
+// insertRentalExpiryQueue adds a rental to the expiry queue keyed by its end time
+func (k Keeper) insertRentalExpiryQueue(ctx sdk.Context, rental types.AIAgentRental) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAIAgentRentalExpiryQueueKey(rental.EndTime, rental.ID), []byte(rental.ID))
+}
+
+// ExpireAIAgentRentals marks every rental whose end time has passed as expired and
+// returns the expired rentals
+func (k Keeper) ExpireAIAgentRentals(ctx sdk.Context) []types.AIAgentRental {
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.PrefixEndBytes(types.GetAIAgentRentalExpiryQueueTimePrefix(ctx.BlockTime()))
+	iterator := store.Iterator(types.AIAgentRentalExpiryQueueKey, end)
+
+	// Collect the due entries first, the store must not be written while iterating
+	var queueKeys [][]byte
+	var rentalIDs []string
+	for ; iterator.Valid(); iterator.Next() {
+		queueKeys = append(queueKeys, iterator.Key())
+		rentalIDs = append(rentalIDs, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	var expired []types.AIAgentRental
+	for i, rentalID := range rentalIDs {
+		store.Delete(queueKeys[i])
+
+		rental, found := k.GetAIAgentRental(ctx, rentalID)
+		if !found || rental.Status != types.AIAgentRentalStatusActive {
+			continue
+		}
+
+		rental.Status = types.AIAgentRentalStatusExpired
+		k.SetAIAgentRental(ctx, rental)
+		expired = append(expired, rental)
+	}
+
+	return expired
+}
\ No newline at end of file
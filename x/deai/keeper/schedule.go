@@ -0,0 +1,326 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetAgentSchedule stores an agent schedule
+func (k Keeper) SetAgentSchedule(ctx sdk.Context, schedule types.AgentSchedule) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentScheduleKey(schedule.ID), k.cdc.MustMarshal(&schedule))
+}
+
+// GetAgentSchedule returns an agent schedule by ID
+func (k Keeper) GetAgentSchedule(ctx sdk.Context, id string) (types.AgentSchedule, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAgentScheduleKey(id))
+	if value == nil {
+		return types.AgentSchedule{}, false
+	}
+
+	var schedule types.AgentSchedule
+	k.cdc.MustUnmarshal(value, &schedule)
+	return schedule, true
+}
+
+// GetAllAgentSchedules returns all agent schedules
+func (k Keeper) GetAllAgentSchedules(ctx sdk.Context) []types.AgentSchedule {
+	return k.GetAgentSchedules(ctx, "", nil)
+}
+
+// GetAgentSchedules returns the agent schedules, optionally filtered by agent and owner
+func (k Keeper) GetAgentSchedules(ctx sdk.Context, agentID string, owner sdk.AccAddress) []types.AgentSchedule {
+	var schedules []types.AgentSchedule
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AgentScheduleKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var schedule types.AgentSchedule
+		k.cdc.MustUnmarshal(iterator.Value(), &schedule)
+		if agentID != "" && schedule.AgentID != agentID {
+			continue
+		}
+		if !owner.Empty() && !schedule.Owner.Equals(owner) {
+			continue
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules
+}
+
+// GetDueAgentSchedules returns at most limit active schedules that are due, the
+// time-based ones first, each queue in the order of their next run
+func (k Keeper) GetDueAgentSchedules(ctx sdk.Context, limit uint64) []types.AgentSchedule {
+	store := ctx.KVStore(k.storeKey)
+	var scheduleIDs []string
+
+	ranges := [][2][]byte{
+		{types.AgentScheduleTimeQueueKey, sdk.PrefixEndBytes(types.GetAgentScheduleTimeQueuePrefix(ctx.BlockTime()))},
+		{types.AgentScheduleHeightQueueKey, sdk.PrefixEndBytes(types.GetAgentScheduleHeightQueuePrefix(ctx.BlockHeight()))},
+	}
+	for _, r := range ranges {
+		iterator := store.Iterator(r[0], r[1])
+		for ; iterator.Valid() && uint64(len(scheduleIDs)) < limit; iterator.Next() {
+			scheduleIDs = append(scheduleIDs, string(iterator.Value()))
+		}
+		iterator.Close()
+	}
+
+	var schedules []types.AgentSchedule
+	for _, id := range scheduleIDs {
+		schedule, found := k.GetAgentSchedule(ctx, id)
+		if found && schedule.Status == types.AgentScheduleStatusActive {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	return schedules
+}
+
+// insertAgentScheduleQueue adds a schedule to the queue keyed by its next run
+func (k Keeper) insertAgentScheduleQueue(ctx sdk.Context, schedule types.AgentSchedule) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(agentScheduleQueueKey(schedule), []byte(schedule.ID))
+}
+
+// removeAgentScheduleQueue removes a schedule from the queue
+func (k Keeper) removeAgentScheduleQueue(ctx sdk.Context, schedule types.AgentSchedule) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(agentScheduleQueueKey(schedule))
+}
+
+// agentScheduleQueueKey returns the queue key of a schedule's next run
+func agentScheduleQueueKey(schedule types.AgentSchedule) []byte {
+	if schedule.IsHeightBased() {
+		return types.GetAgentScheduleHeightQueueKey(schedule.NextRunHeight, schedule.ID)
+	}
+	return types.GetAgentScheduleTimeQueueKey(schedule.NextRunTime, schedule.ID)
+}
+
+// ScheduleAgentAction escrows the budget of a schedule in the module account and queues
+// its first run. The owner is authorized against the agent's policy at every run, as
+// the policy may change in the meantime.
+func (k Keeper) ScheduleAgentAction(ctx sdk.Context, schedule types.AgentSchedule) (types.AgentSchedule, error) {
+	agent, found := k.GetAIAgent(ctx, schedule.AgentID)
+	if !found {
+		return types.AgentSchedule{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", schedule.AgentID))
+	}
+	if !isAgentUsable(agent) {
+		return types.AgentSchedule{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent is not active")
+	}
+
+	schedule.ID = fmt.Sprintf("%s-schedule-%d", agent.ID, k.nextSequence(ctx))
+
+	schedule.Runs = 0
+	schedule.LastActionID = ""
+	schedule.Status = types.AgentScheduleStatusActive
+	schedule.CancelReason = ""
+	schedule.CreatedAt = ctx.BlockTime()
+	schedule.UpdatedAt = ctx.BlockTime()
+	if err := schedule.Validate(); err != nil {
+		return types.AgentSchedule{}, sdkerrors.Wrap(types.ErrInvalidSchedule, err.Error())
+	}
+	if schedule.IsHeightBased() && schedule.NextRunHeight <= ctx.BlockHeight() {
+		return types.AgentSchedule{}, sdkerrors.Wrap(types.ErrInvalidSchedule, "the first run must be at a later height")
+	}
+	if !schedule.IsHeightBased() && !schedule.NextRunTime.After(ctx.BlockTime()) {
+		return types.AgentSchedule{}, sdkerrors.Wrap(types.ErrInvalidSchedule, "the first run must be at a later time")
+	}
+	if !schedule.FeePerRun.IsAllLTE(schedule.Budget) {
+		return types.AgentSchedule{}, sdkerrors.Wrapf(types.ErrInvalidSchedule, "budget %s does not cover a single run at %s", schedule.Budget, schedule.FeePerRun)
+	}
+
+	if !schedule.Budget.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, schedule.Owner, types.ModuleName, schedule.Budget); err != nil {
+			return types.AgentSchedule{}, sdkerrors.Wrap(err, "failed to escrow schedule budget")
+		}
+	}
+
+	k.SetAgentSchedule(ctx, schedule)
+	k.insertAgentScheduleQueue(ctx, schedule)
+
+	return schedule, nil
+}
+
+// CancelAgentSchedule cancels an active schedule on behalf of its owner and refunds the
+// rest of its budget
+func (k Keeper) CancelAgentSchedule(ctx sdk.Context, owner sdk.AccAddress, scheduleID string) (types.AgentSchedule, sdk.Coins, error) {
+	schedule, found := k.GetAgentSchedule(ctx, scheduleID)
+	if !found {
+		return types.AgentSchedule{}, nil, sdkerrors.Wrap(types.ErrScheduleNotFound, scheduleID)
+	}
+	if !schedule.Owner.Equals(owner) {
+		return types.AgentSchedule{}, nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can cancel the schedule")
+	}
+	if schedule.Status != types.AgentScheduleStatusActive {
+		return types.AgentSchedule{}, nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "schedule is %s", schedule.Status)
+	}
+
+	k.removeAgentScheduleQueue(ctx, schedule)
+	return k.closeAgentSchedule(ctx, schedule, types.AgentScheduleStatusCancelled, types.AgentScheduleCancelledByOwner)
+}
+
+// CancelAgentScheduleOverGas cancels a due schedule whose run needs more gas than the
+// scheduled actions of a block may use, so it would otherwise block the queue forever
+func (k Keeper) CancelAgentScheduleOverGas(ctx sdk.Context, schedule types.AgentSchedule) (types.AgentSchedule, error) {
+	k.removeAgentScheduleQueue(ctx, schedule)
+	return k.endAgentSchedule(ctx, schedule, types.AgentScheduleStatusCancelled, types.AgentScheduleCancelledGasLimit)
+}
+
+// RunAgentSchedule runs a due schedule on behalf of its owner and returns the updated
+// schedule. The fee of the run is paid from the escrowed budget, after which the
+// schedule is queued for its next run or completed. Schedules whose budget no longer
+// covers a run or whose agent is no longer active are cancelled instead. A run that
+// fails counts towards the maximum number of runs without being charged; the failure
+// is reported in an event.
+func (k Keeper) RunAgentSchedule(ctx sdk.Context, schedule types.AgentSchedule) (types.AgentSchedule, error) {
+	k.removeAgentScheduleQueue(ctx, schedule)
+
+	agent, found := k.GetAIAgent(ctx, schedule.AgentID)
+	if !found || (!isAgentUsable(agent) && agent.Status != types.AIAgentStatusTraining) {
+		return k.endAgentSchedule(ctx, schedule, types.AgentScheduleStatusCancelled, types.AgentScheduleCancelledAgent)
+	}
+	if !schedule.FeePerRun.IsAllLTE(schedule.Budget) {
+		return k.endAgentSchedule(ctx, schedule, types.AgentScheduleStatusCancelled, types.AgentScheduleCancelledNoFunds)
+	}
+
+	// Run in a cached context so a failed run leaves neither the budget nor the agent changed
+	runCtx, write := ctx.CacheContext()
+	actionID, fee, err := k.runScheduledAction(runCtx, agent, schedule)
+	if err != nil {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeAgentScheduleRunFailed,
+				sdk.NewAttribute(types.AttributeKeyScheduleID, schedule.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, schedule.AgentID),
+				sdk.NewAttribute(types.AttributeKeyError, err.Error()),
+			),
+		)
+	} else {
+		write()
+		schedule.Budget = schedule.Budget.Sub(fee...)
+		schedule.LastActionID = actionID
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeAgentScheduleRun,
+				sdk.NewAttribute(types.AttributeKeyScheduleID, schedule.ID),
+				sdk.NewAttribute(types.AttributeKeyAgentID, schedule.AgentID),
+				sdk.NewAttribute(types.AttributeKeyActionID, actionID),
+				sdk.NewAttribute(types.AttributeKeyFee, fee.String()),
+				sdk.NewAttribute(types.AttributeKeyBudget, schedule.Budget.String()),
+			),
+		)
+	}
+
+	lastRun := schedule.IsLastRun()
+	schedule.Runs++
+	if lastRun {
+		return k.endAgentSchedule(ctx, schedule, types.AgentScheduleStatusCompleted, "")
+	}
+
+	schedule.Advance(ctx.BlockTime(), ctx.BlockHeight())
+	schedule.UpdatedAt = ctx.BlockTime()
+	k.SetAgentSchedule(ctx, schedule)
+	k.insertAgentScheduleQueue(ctx, schedule)
+
+	return schedule, nil
+}
+
+// runScheduledAction releases the fee of a run from the schedule's budget to its owner
+// and executes the action on the owner's behalf, like a call sent by the owner offering
+// the fee per run. It returns the ID of the recorded action and the fee charged, which
+// is below the fee per run if the agent's price is.
+func (k Keeper) runScheduledAction(ctx sdk.Context, agent types.AIAgent, schedule types.AgentSchedule) (string, sdk.Coins, error) {
+	if !isAgentUsable(agent) {
+		return "", nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "agent %s is %s", agent.ID, agent.Status)
+	}
+	fee, err := k.ChargeAgentCall(ctx, agent, schedule.Owner, schedule.ActionType, schedule.FeePerRun)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := k.AuthorizeAgentCall(ctx, agent, schedule.Owner, schedule.ActionType, fee); err != nil {
+		return "", nil, err
+	}
+	if _, found := k.GetAIAgentState(ctx, agent.ID); !found {
+		return "", nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, "agent state not found")
+	}
+
+	if !fee.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, schedule.Owner, fee); err != nil {
+			return "", nil, sdkerrors.Wrap(err, "failed to release the fee of the run")
+		}
+	}
+
+	if model, found := k.GetOnChainModel(ctx, agent); found {
+		action, err := k.ExecuteOnChainInference(ctx, agent, model, schedule.Owner, schedule.ActionType, schedule.Data, fee)
+		if err != nil {
+			return "", nil, err
+		}
+		return action.ID, fee, nil
+	}
+
+	request, err := k.RequestAIAgentExecution(ctx, agent, schedule.Owner, schedule.ActionType, schedule.Data, fee)
+	if err != nil {
+		return "", nil, err
+	}
+	return request.ID, fee, nil
+}
+
+// closeAgentSchedule completes or cancels a schedule that was removed from the queue,
+// refunding the rest of its budget to the owner. It returns the closed schedule and
+// the refund.
+func (k Keeper) closeAgentSchedule(ctx sdk.Context, schedule types.AgentSchedule, status string, reason string) (types.AgentSchedule, sdk.Coins, error) {
+	refund := schedule.Budget
+	if !refund.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, schedule.Owner, refund); err != nil {
+			return schedule, nil, sdkerrors.Wrap(err, "failed to refund schedule budget")
+		}
+	}
+
+	schedule.Budget = sdk.NewCoins()
+	schedule.Status = status
+	schedule.CancelReason = reason
+	schedule.UpdatedAt = ctx.BlockTime()
+	k.SetAgentSchedule(ctx, schedule)
+
+	return schedule, refund, nil
+}
+
+// endAgentSchedule closes a schedule the module completes or cancels by itself and
+// reports it in an event
+func (k Keeper) endAgentSchedule(ctx sdk.Context, schedule types.AgentSchedule, status string, reason string) (types.AgentSchedule, error) {
+	schedule, refund, err := k.closeAgentSchedule(ctx, schedule, status, reason)
+	if err != nil {
+		return schedule, err
+	}
+
+	eventType := types.EventTypeAgentScheduleCompleted
+	if status == types.AgentScheduleStatusCancelled {
+		eventType = types.EventTypeAgentScheduleCancelled
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			eventType,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, schedule.ID),
+			sdk.NewAttribute(types.AttributeKeyAgentID, schedule.AgentID),
+			sdk.NewAttribute(types.AttributeKeyRuns, fmt.Sprintf("%d", schedule.Runs)),
+			sdk.NewAttribute(types.AttributeKeyRefund, refund.String()),
+			sdk.NewAttribute(types.AttributeKeyReason, reason),
+		),
+	)
+
+	return schedule, nil
+}
+
+// isAgentUsable returns true if an agent can be called; listed agents remain usable by
+// their owner and renters
+func isAgentUsable(agent types.AIAgent) bool {
+	return agent.Status == types.AIAgentStatusActive || agent.Status == types.AIAgentStatusForRent || agent.Status == types.AIAgentStatusForSale
+}
\ No newline at end of file
@@ -0,0 +1,96 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SettleMarketplacePayment escrows a marketplace payment in the deai module account and
+// pays it out: the marketplace fee goes to the configured fee recipient, the creator
+// royalty goes to the agent creator unless the creator is the seller, and the remainder
+// goes to the seller. Any failed transfer fails the whole settlement.
+func (k Keeper) SettleMarketplacePayment(ctx sdk.Context, payer, seller sdk.AccAddress, agent types.AIAgent, amount sdk.Coins) (types.MarketplaceSettlement, error) {
+	// Escrow the full payment in the module account
+	if !amount.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, payer, types.ModuleName, amount); err != nil {
+			return types.MarketplaceSettlement{}, sdkerrors.Wrap(err, "failed to escrow payment")
+		}
+	}
+
+	return k.DistributeEscrowedPayment(ctx, seller, agent, amount)
+}
+
+// DistributeEscrowedPayment pays out a marketplace payment that is already held by the
+// deai module account, such as the winning bid of an auction
+func (k Keeper) DistributeEscrowedPayment(ctx sdk.Context, seller sdk.AccAddress, agent types.AIAgent, amount sdk.Coins) (types.MarketplaceSettlement, error) {
+	settlement := splitMarketplacePayment(amount, seller, agent.Creator, k.MarketplaceFeeRate(ctx), k.CreatorRoyaltyRate(ctx))
+	if amount.IsZero() {
+		return settlement, nil
+	}
+
+	if !settlement.MarketplaceFee.IsZero() {
+		if err := k.payMarketplaceFee(ctx, settlement.MarketplaceFee); err != nil {
+			return settlement, err
+		}
+	}
+
+	if !settlement.Royalty.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, settlement.RoyaltyPayee, settlement.Royalty); err != nil {
+			return settlement, sdkerrors.Wrap(err, "failed to pay creator royalty")
+		}
+	}
+
+	// A fractionalized agent can only be rented out, so its proceeds are income of its shareholders
+	if err := k.payAgentIncome(ctx, agent.ID, seller, settlement.SellerProceeds); err != nil {
+		return settlement, sdkerrors.Wrap(err, "failed to pay seller")
+	}
+
+	return settlement, nil
+}
+
+// payMarketplaceFee moves the marketplace fee from the module account to the fee recipient
+func (k Keeper) payMarketplaceFee(ctx sdk.Context, fee sdk.Coins) error {
+	switch k.MarketplaceFeeRecipient(ctx) {
+	case types.FeeRecipientFeeCollector:
+		if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, authtypes.FeeCollectorName, fee); err != nil {
+			return sdkerrors.Wrap(err, "failed to pay marketplace fee")
+		}
+	default:
+		moduleAddr := k.accountKeeper.GetModuleAddress(types.ModuleName)
+		if err := k.distrKeeper.FundCommunityPool(ctx, fee, moduleAddr); err != nil {
+			return sdkerrors.Wrap(err, "failed to pay marketplace fee")
+		}
+	}
+	return nil
+}
+
+// splitMarketplacePayment splits a payment into the marketplace fee, the royalty of the
+// creator unless the creator is the seller, and the proceeds of the seller. The fee and
+// the royalty are rounded down, so the seller receives the rounding remainder.
+func splitMarketplacePayment(amount sdk.Coins, seller, creator sdk.AccAddress, feeRate, royaltyRate sdk.Dec) types.MarketplaceSettlement {
+	settlement := types.MarketplaceSettlement{
+		Total:          amount,
+		MarketplaceFee: sdk.NewCoins(),
+		Royalty:        sdk.NewCoins(),
+		SellerProceeds: amount,
+	}
+	if amount.IsZero() {
+		return settlement
+	}
+
+	settlement.MarketplaceFee = mulCoinsTruncate(amount, feeRate)
+	if !creator.Empty() && !creator.Equals(seller) {
+		settlement.Royalty = mulCoinsTruncate(amount, royaltyRate)
+		settlement.RoyaltyPayee = creator
+	}
+	settlement.SellerProceeds = amount.Sub(settlement.MarketplaceFee...).Sub(settlement.Royalty...)
+	return settlement
+}
+
+// mulCoinsTruncate multiplies every coin amount by rate, rounding down
+func mulCoinsTruncate(coins sdk.Coins, rate sdk.Dec) sdk.Coins {
+	truncated, _ := sdk.NewDecCoinsFromCoins(coins...).MulDecTruncate(rate).TruncateDecimal()
+	return truncated
+}
\ No newline at end of file
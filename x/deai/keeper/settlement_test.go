@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitMarketplacePayment(t *testing.T) {
+	seller := sdk.AccAddress([]byte("seller______________"))
+	creator := sdk.AccAddress([]byte("creator_____________"))
+	feeRate := sdk.NewDecWithPrec(2, 2)
+	royaltyRate := sdk.NewDecWithPrec(5, 2)
+
+	tests := []struct {
+		name     string
+		amount   sdk.Coins
+		creator  sdk.AccAddress
+		fee      sdk.Coins
+		royalty  sdk.Coins
+		payee    sdk.AccAddress
+		proceeds sdk.Coins
+	}{
+		{
+			name:     "creator royalty",
+			amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)),
+			creator:  creator,
+			fee:      sdk.NewCoins(sdk.NewInt64Coin("stake", 20)),
+			royalty:  sdk.NewCoins(sdk.NewInt64Coin("stake", 50)),
+			payee:    creator,
+			proceeds: sdk.NewCoins(sdk.NewInt64Coin("stake", 930)),
+		},
+		{
+			name:     "creator is seller",
+			amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)),
+			creator:  seller,
+			fee:      sdk.NewCoins(sdk.NewInt64Coin("stake", 20)),
+			royalty:  sdk.NewCoins(),
+			proceeds: sdk.NewCoins(sdk.NewInt64Coin("stake", 980)),
+		},
+		{
+			name:     "no creator",
+			amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)),
+			fee:      sdk.NewCoins(sdk.NewInt64Coin("stake", 20)),
+			royalty:  sdk.NewCoins(),
+			proceeds: sdk.NewCoins(sdk.NewInt64Coin("stake", 980)),
+		},
+		{
+			name:     "rounding remainder goes to seller",
+			amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 1000), sdk.NewInt64Coin("uatom", 999)),
+			creator:  creator,
+			fee:      sdk.NewCoins(sdk.NewInt64Coin("stake", 20), sdk.NewInt64Coin("uatom", 19)),
+			royalty:  sdk.NewCoins(sdk.NewInt64Coin("stake", 50), sdk.NewInt64Coin("uatom", 49)),
+			payee:    creator,
+			proceeds: sdk.NewCoins(sdk.NewInt64Coin("stake", 930), sdk.NewInt64Coin("uatom", 931)),
+		},
+		{
+			name:     "dust",
+			amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 10)),
+			creator:  creator,
+			fee:      sdk.NewCoins(),
+			royalty:  sdk.NewCoins(),
+			payee:    creator,
+			proceeds: sdk.NewCoins(sdk.NewInt64Coin("stake", 10)),
+		},
+		{
+			name:     "free",
+			amount:   sdk.NewCoins(),
+			creator:  creator,
+			fee:      sdk.NewCoins(),
+			royalty:  sdk.NewCoins(),
+			proceeds: sdk.NewCoins(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			settlement := splitMarketplacePayment(tc.amount, seller, tc.creator, feeRate, royaltyRate)
+			require.Equal(t, tc.amount, settlement.Total)
+			require.True(t, tc.fee.IsEqual(settlement.MarketplaceFee), settlement.MarketplaceFee.String())
+			require.True(t, tc.royalty.IsEqual(settlement.Royalty), settlement.Royalty.String())
+			require.Equal(t, tc.payee, settlement.RoyaltyPayee)
+			require.True(t, tc.proceeds.IsEqual(settlement.SellerProceeds), settlement.SellerProceeds.String())
+
+			// Nothing is created or lost by the split
+			paid := settlement.MarketplaceFee.Add(settlement.Royalty...).Add(settlement.SellerProceeds...)
+			require.True(t, tc.amount.IsEqual(paid), paid.String())
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetAgentShares stores the shares of a fractionalized agent
+func (k Keeper) SetAgentShares(ctx sdk.Context, shares types.AgentShares) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentSharesKey(shares.AgentID), k.cdc.MustMarshal(&shares))
+}
+
+// GetAgentShares returns the shares of a fractionalized agent
+func (k Keeper) GetAgentShares(ctx sdk.Context, agentID string) (types.AgentShares, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAgentSharesKey(agentID))
+	if value == nil {
+		return types.AgentShares{}, false
+	}
+
+	var shares types.AgentShares
+	k.cdc.MustUnmarshal(value, &shares)
+	return shares, true
+}
+
+// GetAllAgentShares returns the shares of all fractionalized agents
+func (k Keeper) GetAllAgentShares(ctx sdk.Context) []types.AgentShares {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AgentSharesKey)
+	defer iterator.Close()
+
+	var allShares []types.AgentShares
+	for ; iterator.Valid(); iterator.Next() {
+		var shares types.AgentShares
+		k.cdc.MustUnmarshal(iterator.Value(), &shares)
+		allShares = append(allShares, shares)
+	}
+
+	return allShares
+}
+
+// SetAgentShareholder stores the income record of an agent shareholder
+func (k Keeper) SetAgentShareholder(ctx sdk.Context, holder types.AgentShareholder) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentShareholderKey(holder.AgentID, holder.Holder), k.cdc.MustMarshal(&holder))
+}
+
+// GetAgentShareholder returns the income record of an agent shareholder
+func (k Keeper) GetAgentShareholder(ctx sdk.Context, agentID string, holder sdk.AccAddress) (types.AgentShareholder, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAgentShareholderKey(agentID, holder))
+	if value == nil {
+		return types.AgentShareholder{}, false
+	}
+
+	var shareholder types.AgentShareholder
+	k.cdc.MustUnmarshal(value, &shareholder)
+	return shareholder, true
+}
+
+// GetAgentShareholders returns the income records of the shareholders of an agent
+func (k Keeper) GetAgentShareholders(ctx sdk.Context, agentID string) []types.AgentShareholder {
+	return k.getAgentShareholders(ctx, types.GetAgentShareholderPrefix(agentID))
+}
+
+// GetAllAgentShareholders returns the income records of the shareholders of all agents
+func (k Keeper) GetAllAgentShareholders(ctx sdk.Context) []types.AgentShareholder {
+	return k.getAgentShareholders(ctx, types.AgentShareholderKey)
+}
+
+func (k Keeper) getAgentShareholders(ctx sdk.Context, prefix []byte) []types.AgentShareholder {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var shareholders []types.AgentShareholder
+	for ; iterator.Valid(); iterator.Next() {
+		var shareholder types.AgentShareholder
+		k.cdc.MustUnmarshal(iterator.Value(), &shareholder)
+		shareholders = append(shareholders, shareholder)
+	}
+
+	return shareholders
+}
+
+func (k Keeper) deleteAgentShareholder(ctx sdk.Context, agentID string, holder sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetAgentShareholderKey(agentID, holder))
+}
+
+// FractionalizeAgent splits the ownership of an agent into a fixed supply of its share
+// denom minted to the owner. The agent NFT moves to the deai module account, which
+// holds it for the shareholders; the former owner stays on as curator. Shares are not
+// sendable through x/bank so that every transfer settles the income of both parties.
+func (k Keeper) FractionalizeAgent(ctx sdk.Context, owner sdk.AccAddress, agentID string, supply sdk.Int, reservePrice sdk.Coins) (types.AgentShares, error) {
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return types.AgentShares{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+	if !agent.Owner.Equals(owner) {
+		return types.AgentShares{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can fractionalize the agent")
+	}
+	if agent.Status == types.AIAgentStatusForSale || agent.Status == types.AIAgentStatusForRent {
+		return types.AgentShares{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent is listed on the marketplace")
+	}
+	if shares, found := k.GetAgentShares(ctx, agentID); found && shares.IsActive() {
+		return types.AgentShares{}, sdkerrors.Wrap(types.ErrAgentFractionalized, agentID)
+	}
+
+	shares := types.AgentShares{
+		AgentID:        agentID,
+		Denom:          types.AgentSharesDenom(agentID),
+		Supply:         supply,
+		Curator:        owner,
+		ReservePrice:   reservePrice,
+		IncomePerShare: sdk.NewDecCoins(),
+		Status:         types.AgentSharesStatusActive,
+		CreatedAt:      ctx.BlockTime(),
+		UpdatedAt:      ctx.BlockTime(),
+	}
+	if err := shares.Validate(); err != nil {
+		return types.AgentShares{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	// Shares of an earlier fractionalization that were not redeemed would dilute the holders
+	if !k.bankKeeper.GetSupply(ctx, shares.Denom).IsZero() {
+		return types.AgentShares{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "shares of agent %s are still outstanding", agentID)
+	}
+
+	minted := sdk.NewCoins(sdk.NewCoin(shares.Denom, supply))
+	if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, minted); err != nil {
+		return types.AgentShares{}, sdkerrors.Wrap(err, "failed to mint agent shares")
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, owner, minted); err != nil {
+		return types.AgentShares{}, sdkerrors.Wrap(err, "failed to send agent shares")
+	}
+	k.bankKeeper.SetSendEnabled(ctx, shares.Denom, false)
+
+	holder := types.NewAgentShareholder(agentID, owner, shares.IncomePerShare)
+	holder.Shares = supply
+	k.SetAgentShareholder(ctx, holder)
+
+	agent, err := k.TransferAIAgent(ctx, agent, k.accountKeeper.GetModuleAddress(types.ModuleName))
+	if err != nil {
+		return types.AgentShares{}, err
+	}
+	agent.UpdatedAt = ctx.BlockTime()
+	k.SetAIAgent(ctx, agent)
+	k.SetAgentShares(ctx, shares)
+
+	return shares, nil
+}
+
+// TransferAgentShares moves shares of an agent between accounts after settling the
+// income both accounts earned so far
+func (k Keeper) TransferAgentShares(ctx sdk.Context, sender, recipient sdk.AccAddress, agentID string, amount sdk.Int) error {
+	shares, err := k.getActiveAgentShares(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "amount must be positive")
+	}
+	if sender.Equals(recipient) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "cannot transfer shares to the sender")
+	}
+	if balance := k.bankKeeper.GetBalance(ctx, sender, shares.Denom).Amount; balance.LT(amount) {
+		return sdkerrors.Wrapf(types.ErrInsufficientShares, "%s < %s", balance, amount)
+	}
+
+	from := k.settleAgentShareholder(ctx, shares, sender)
+	to := k.settleAgentShareholder(ctx, shares, recipient)
+
+	// Keeper sends bypass the disabled x/bank send of the share denom
+	if err := k.bankKeeper.SendCoins(ctx, sender, recipient, sdk.NewCoins(sdk.NewCoin(shares.Denom, amount))); err != nil {
+		return sdkerrors.Wrap(err, "failed to transfer agent shares")
+	}
+
+	from.Shares = from.Shares.Sub(amount)
+	to.Shares = to.Shares.Add(amount)
+	k.SetAgentShareholder(ctx, from)
+	k.SetAgentShareholder(ctx, to)
+	return nil
+}
+
+// ClaimAgentIncome pays a shareholder the whole units of the income its shares earned.
+// Fractions of a unit stay unclaimed until they add up.
+func (k Keeper) ClaimAgentIncome(ctx sdk.Context, holder sdk.AccAddress, agentID string) (sdk.Coins, error) {
+	shares, found := k.GetAgentShares(ctx, agentID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrNotFractionalized, agentID)
+	}
+	if _, found := k.GetAgentShareholder(ctx, agentID, holder); !found {
+		return nil, sdkerrors.Wrapf(types.ErrInsufficientShares, "%s holds no shares of agent %s", holder, agentID)
+	}
+
+	shareholder := k.settleAgentShareholder(ctx, shares, holder)
+	claimed, err := k.payShareholderIncome(ctx, &shareholder)
+	if err != nil {
+		return nil, err
+	}
+	k.SetAgentShareholder(ctx, shareholder)
+
+	return claimed, nil
+}
+
+// BuyoutAgent lets a holder of at least BuyoutThreshold of the shares of an agent take
+// full ownership. The buyer pays every other holder the reserve price for each of their
+// shares, all shares are burned with the income they earned paid out, and the agent NFT
+// moves to the buyer. It returns the shares and the amount the buyer paid.
+func (k Keeper) BuyoutAgent(ctx sdk.Context, buyer sdk.AccAddress, agentID string) (types.AgentShares, sdk.Coins, error) {
+	shares, err := k.getActiveAgentShares(ctx, agentID)
+	if err != nil {
+		return types.AgentShares{}, nil, err
+	}
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return types.AgentShares{}, nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+
+	held := k.bankKeeper.GetBalance(ctx, buyer, shares.Denom).Amount
+	if sdk.NewDecFromInt(held).LT(k.BuyoutThreshold(ctx).MulInt(shares.Supply)) {
+		return types.AgentShares{}, nil, sdkerrors.Wrapf(types.ErrInsufficientShares, "%s of %s shares is below the buyout threshold", held, shares.Supply)
+	}
+
+	// Settle every holder before any balance changes
+	if _, found := k.GetAgentShareholder(ctx, agentID, buyer); !found {
+		k.SetAgentShareholder(ctx, types.NewAgentShareholder(agentID, buyer, shares.IncomePerShare))
+	}
+	var holders []types.AgentShareholder
+	for _, shareholder := range k.GetAgentShareholders(ctx, agentID) {
+		holders = append(holders, k.settleAgentShareholder(ctx, shares, shareholder.Holder))
+	}
+
+	outstanding := sdk.ZeroInt()
+	for _, holder := range holders {
+		if !holder.Holder.Equals(buyer) {
+			outstanding = outstanding.Add(holder.Shares)
+		}
+	}
+	cost := shares.BuyoutCost(outstanding)
+	if !cost.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, buyer, types.ModuleName, cost); err != nil {
+			return types.AgentShares{}, nil, sdkerrors.Wrap(err, "failed to escrow buyout payment")
+		}
+	}
+
+	burned := sdk.ZeroInt()
+	for i := range holders {
+		holder := &holders[i]
+		if _, err := k.payShareholderIncome(ctx, holder); err != nil {
+			return types.AgentShares{}, nil, err
+		}
+		if holder.Shares.IsPositive() {
+			if !holder.Holder.Equals(buyer) {
+				if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, holder.Holder, shares.BuyoutCost(holder.Shares)); err != nil {
+					return types.AgentShares{}, nil, sdkerrors.Wrap(err, "failed to pay shareholder")
+				}
+			}
+			redeemed := sdk.NewCoins(sdk.NewCoin(shares.Denom, holder.Shares))
+			if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, holder.Holder, types.ModuleName, redeemed); err != nil {
+				return types.AgentShares{}, nil, sdkerrors.Wrap(err, "failed to redeem agent shares")
+			}
+			burned = burned.Add(holder.Shares)
+		}
+		k.deleteAgentShareholder(ctx, agentID, holder.Holder)
+	}
+	if burned.IsPositive() {
+		if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewCoin(shares.Denom, burned))); err != nil {
+			return types.AgentShares{}, nil, sdkerrors.Wrap(err, "failed to burn agent shares")
+		}
+	}
+
+	agent, err = k.TransferAIAgent(ctx, agent, buyer)
+	if err != nil {
+		return types.AgentShares{}, nil, err
+	}
+	agent.UpdatedAt = ctx.BlockTime()
+	k.SetAIAgent(ctx, agent)
+
+	shares.Status = types.AgentSharesStatusBoughtOut
+	shares.Buyer = buyer
+	shares.UpdatedAt = ctx.BlockTime()
+	k.SetAgentShares(ctx, shares)
+
+	return shares, cost, nil
+}
+
+// AgentShareholderIncome returns the income record of a shareholder settled to the
+// current income per share, without storing it
+func (k Keeper) AgentShareholderIncome(ctx sdk.Context, agentID string, holder sdk.AccAddress) (types.AgentShareholder, bool) {
+	shares, found := k.GetAgentShares(ctx, agentID)
+	if !found {
+		return types.AgentShareholder{}, false
+	}
+	if _, found := k.GetAgentShareholder(ctx, agentID, holder); !found {
+		return types.AgentShareholder{}, false
+	}
+	return k.settleAgentShareholder(ctx, shares, holder), true
+}
+
+// AgentSeller returns the account that may list an agent on the marketplace: the owner,
+// or the curator while the agent is fractionalized
+func (k Keeper) AgentSeller(ctx sdk.Context, agent types.AIAgent) sdk.AccAddress {
+	if shares, found := k.GetAgentShares(ctx, agent.ID); found && shares.IsActive() {
+		return shares.Curator
+	}
+	return agent.Owner
+}
+
+// IsAgentFractionalized returns true if an agent is held for its shareholders
+func (k Keeper) IsAgentFractionalized(ctx sdk.Context, agentID string) bool {
+	shares, found := k.GetAgentShares(ctx, agentID)
+	return found && shares.IsActive()
+}
+
+// payAgentIncome pays income of an agent held by the deai module account to the given
+// recipient, or to the agent's shareholders while it is fractionalized
+func (k Keeper) payAgentIncome(ctx sdk.Context, agentID string, recipient sdk.AccAddress, amount sdk.Coins) error {
+	if amount.IsZero() {
+		return nil
+	}
+	if shares, found := k.GetAgentShares(ctx, agentID); found && shares.IsActive() {
+		// The income stays in the module account until the holders claim it
+		shares.IncomePerShare = shares.IncomePerShare.Add(sdk.NewDecCoinsFromCoins(amount...).QuoDecTruncate(sdk.NewDecFromInt(shares.Supply))...)
+		shares.UpdatedAt = ctx.BlockTime()
+		k.SetAgentShares(ctx, shares)
+		return nil
+	}
+	return k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, amount)
+}
+
+// getActiveAgentShares returns the shares of an agent that is currently fractionalized
+func (k Keeper) getActiveAgentShares(ctx sdk.Context, agentID string) (types.AgentShares, error) {
+	shares, found := k.GetAgentShares(ctx, agentID)
+	if !found || !shares.IsActive() {
+		return types.AgentShares{}, sdkerrors.Wrap(types.ErrNotFractionalized, agentID)
+	}
+	return shares, nil
+}
+
+// settleAgentShareholder returns the income record of a holder settled to the current
+// income per share. The caller is responsible for storing it.
+func (k Keeper) settleAgentShareholder(ctx sdk.Context, shares types.AgentShares, holder sdk.AccAddress) types.AgentShareholder {
+	shareholder, found := k.GetAgentShareholder(ctx, shares.AgentID, holder)
+	if !found {
+		shareholder = types.NewAgentShareholder(shares.AgentID, holder, shares.IncomePerShare)
+	}
+	balance := k.bankKeeper.GetBalance(ctx, holder, shares.Denom).Amount
+	return shareholder.Settle(shares.IncomePerShare, balance)
+}
+
+// payShareholderIncome pays the whole units of a settled holder's unclaimed income and
+// keeps the fractions. The caller is responsible for storing the holder.
+func (k Keeper) payShareholderIncome(ctx sdk.Context, holder *types.AgentShareholder) (sdk.Coins, error) {
+	claimed, change := holder.Unclaimed.TruncateDecimal()
+	if !claimed.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, holder.Holder, claimed); err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to pay shareholder income")
+		}
+	}
+	holder.Unclaimed = change
+	return claimed, nil
+}
\ No newline at end of file
@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// setAIAgentStateVersion stores a version of an agent's state in its history
+func (k Keeper) setAIAgentStateVersion(ctx sdk.Context, state types.AIAgentState) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAIAgentStateHistoryKey(state.AgentID, state.Version), k.cdc.MustMarshal(&state))
+}
+
+// appendAIAgentStateHistory adds a new version to an agent's state history and prunes
+// the versions beyond the StateHistoryRetention param
+func (k Keeper) appendAIAgentStateHistory(ctx sdk.Context, state types.AIAgentState) {
+	k.setAIAgentStateVersion(ctx, state)
+
+	retention := k.StateHistoryRetention(ctx)
+	if state.Version <= retention {
+		return
+	}
+	oldest := state.Version - retention + 1
+
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetAIAgentStateHistoryPrefix(state.AgentID)
+	iterator := store.Iterator(prefix, types.GetAIAgentStateHistoryKey(state.AgentID, oldest))
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetAIAgentStateAt returns a version of an agent's state from its history
+func (k Keeper) GetAIAgentStateAt(ctx sdk.Context, agentID string, version uint64) (types.AIAgentState, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAIAgentStateHistoryKey(agentID, version))
+	if value == nil {
+		return types.AIAgentState{}, false
+	}
+
+	var state types.AIAgentState
+	k.cdc.MustUnmarshal(value, &state)
+	return state, true
+}
+
+// GetAIAgentStateHistory returns the retained versions of an agent's state, oldest first
+func (k Keeper) GetAIAgentStateHistory(ctx sdk.Context, agentID string) []types.AIAgentState {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetAIAgentStateHistoryPrefix(agentID))
+	defer iterator.Close()
+
+	var states []types.AIAgentState
+	for ; iterator.Valid(); iterator.Next() {
+		var state types.AIAgentState
+		k.cdc.MustUnmarshal(iterator.Value(), &state)
+		states = append(states, state)
+	}
+
+	return states
+}
+
+// GetAllAIAgentStateHistory returns the retained state versions of all agents
+func (k Keeper) GetAllAIAgentStateHistory(ctx sdk.Context) []types.AIAgentState {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AIAgentStateHistoryKey)
+	defer iterator.Close()
+
+	var states []types.AIAgentState
+	for ; iterator.Valid(); iterator.Next() {
+		var state types.AIAgentState
+		k.cdc.MustUnmarshal(iterator.Value(), &state)
+		states = append(states, state)
+	}
+
+	return states
+}
+
+// RollbackAIAgentState restores a previous version of an agent's state. The restored
+// state becomes a new version, so the versions it replaces remain in the history, and
+// the rollback is recorded as an action of the agent.
+func (k Keeper) RollbackAIAgentState(ctx sdk.Context, agentID string, owner sdk.AccAddress, version uint64) (types.AIAgentAction, error) {
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return types.AIAgentAction{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+	if !k.AgentSeller(ctx, agent).Equals(owner) {
+		return types.AIAgentAction{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can roll back the agent state")
+	}
+
+	current, found := k.GetAIAgentState(ctx, agentID)
+	if !found {
+		return types.AIAgentAction{}, sdkerrors.Wrap(types.ErrStateVersionNotFound, "agent has no state")
+	}
+	if version == current.Version {
+		return types.AIAgentAction{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "version %d is the current state", version)
+	}
+
+	target, found := k.GetAIAgentStateAt(ctx, agentID, version)
+	if !found {
+		return types.AIAgentAction{}, sdkerrors.Wrapf(types.ErrStateVersionNotFound, "version %d of agent %s", version, agentID)
+	}
+
+	actionID := fmt.Sprintf("%s-%s-%d", agentID, types.AIAgentActionTypeStateRollback, k.nextSequence(ctx))
+
+	target.UpdatedAt = ctx.BlockTime()
+	k.SetAIAgentState(ctx, target)
+
+	rollback := types.StateRollback{
+		FromVersion: current.Version,
+		ToVersion:   version,
+		Version:     current.Version + 1,
+	}
+	data, err := json.Marshal(rollback)
+	if err != nil {
+		return types.AIAgentAction{}, err
+	}
+
+	action := types.AIAgentAction{
+		ID:         actionID,
+		AgentID:    agentID,
+		ActionType: types.AIAgentActionTypeStateRollback,
+		Timestamp:  ctx.BlockTime(),
+		Data:       data,
+		Status:     types.ExecutionStatusCompleted,
+	}
+	k.SetAIAgentAction(ctx, action)
+
+	return action, nil
+}
\ No newline at end of file
@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// UpdateAIAgentStateData replaces the state of an agent. States larger than the
+// MaxInlineStateSize param must be stored off chain, in which case only the storage
+// reference and its digest are kept on chain.
+func (k Keeper) UpdateAIAgentStateData(ctx sdk.Context, agentID string, stateData []byte, ref *types.StorageRef) (types.AIAgentState, error) {
+	ref, err := normalizeStorageRef(ref)
+	if err != nil {
+		return types.AIAgentState{}, err
+	}
+
+	state := types.AIAgentState{
+		AgentID:     agentID,
+		StateData:   stateData,
+		StorageType: types.StorageTypeChain,
+		StorageRef:  ref,
+		UpdatedAt:   ctx.BlockTime(),
+	}
+
+	if ref != nil {
+		state.StorageType = ref.Scheme
+	} else if maxSize := k.MaxInlineStateSize(ctx); uint64(len(stateData)) > maxSize {
+		return types.AIAgentState{}, sdkerrors.Wrapf(types.ErrStateDataTooLarge, "%d bytes exceeds the maximum of %d, store the state by reference", len(stateData), maxSize)
+	}
+
+	if err := state.ValidateStorage(); err != nil {
+		return types.AIAgentState{}, sdkerrors.Wrap(types.ErrInvalidStorageRef, err.Error())
+	}
+
+	k.SetAIAgentState(ctx, state)
+	return state, nil
+}
+
+// NewAIAgentTrainingData builds the record of training data submitted inline or by
+// reference. Inline data is limited to the MaxTrainingDataSize param; larger data sets
+// must be stored off chain.
+func (k Keeper) NewAIAgentTrainingData(ctx sdk.Context, id, agentID, dataType string, data []byte, source string, ref *types.StorageRef) (types.AIAgentTrainingData, error) {
+	ref, err := normalizeStorageRef(ref)
+	if err != nil {
+		return types.AIAgentTrainingData{}, err
+	}
+
+	trainingData := types.AIAgentTrainingData{
+		ID:          id,
+		AgentID:     agentID,
+		DataType:    dataType,
+		Data:        data,
+		Source:      source,
+		Timestamp:   ctx.BlockTime(),
+		StorageType: types.StorageTypeChain,
+		StorageRef:  ref,
+	}
+
+	if ref != nil {
+		trainingData.StorageType = ref.Scheme
+	} else if maxSize := k.MaxTrainingDataSize(ctx); uint64(len(data)) > maxSize {
+		return types.AIAgentTrainingData{}, sdkerrors.Wrapf(types.ErrTrainingDataTooLarge, "%d bytes exceeds the maximum of %d, store the data by reference", len(data), maxSize)
+	}
+
+	if err := trainingData.ValidateStorage(); err != nil {
+		return types.AIAgentTrainingData{}, sdkerrors.Wrap(types.ErrInvalidStorageRef, err.Error())
+	}
+
+	return trainingData, nil
+}
+
+// normalizeStorageRef validates a storage reference and stores its digest in lower case,
+// so that retrieved payloads verify against it
+func normalizeStorageRef(ref *types.StorageRef) (*types.StorageRef, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	normalized, err := ref.Normalize()
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStorageRef, err.Error())
+	}
+	return &normalized, nil
+}
\ No newline at end of file
@@ -0,0 +1,354 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetSubscriptionPlan stores a subscription plan
+func (k Keeper) SetSubscriptionPlan(ctx sdk.Context, plan types.SubscriptionPlan) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetSubscriptionPlanKey(plan.ID), k.cdc.MustMarshal(&plan))
+}
+
+// GetSubscriptionPlan returns a subscription plan by ID
+func (k Keeper) GetSubscriptionPlan(ctx sdk.Context, id string) (types.SubscriptionPlan, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetSubscriptionPlanKey(id))
+	if value == nil {
+		return types.SubscriptionPlan{}, false
+	}
+
+	var plan types.SubscriptionPlan
+	k.cdc.MustUnmarshal(value, &plan)
+	return plan, true
+}
+
+// GetAllSubscriptionPlans returns all subscription plans
+func (k Keeper) GetAllSubscriptionPlans(ctx sdk.Context) []types.SubscriptionPlan {
+	return k.GetSubscriptionPlans(ctx, "")
+}
+
+// GetSubscriptionPlans returns the subscription plans, optionally filtered by agent
+func (k Keeper) GetSubscriptionPlans(ctx sdk.Context, agentID string) []types.SubscriptionPlan {
+	var plans []types.SubscriptionPlan
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.SubscriptionPlanKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var plan types.SubscriptionPlan
+		k.cdc.MustUnmarshal(iterator.Value(), &plan)
+		if agentID != "" && plan.AgentID != agentID {
+			continue
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans
+}
+
+// SetAgentSubscription stores a subscription
+func (k Keeper) SetAgentSubscription(ctx sdk.Context, subscription types.AgentSubscription) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentSubscriptionKey(subscription.Subscriber, subscription.PlanID), k.cdc.MustMarshal(&subscription))
+}
+
+// GetAgentSubscription returns a subscriber's subscription to a plan
+func (k Keeper) GetAgentSubscription(ctx sdk.Context, subscriber sdk.AccAddress, planID string) (types.AgentSubscription, bool) {
+	return k.getAgentSubscription(ctx, types.GetAgentSubscriptionKey(subscriber, planID))
+}
+
+// GetAgentSubscriptions returns the subscriptions of a subscriber
+func (k Keeper) GetAgentSubscriptions(ctx sdk.Context, subscriber sdk.AccAddress) []types.AgentSubscription {
+	return k.getAgentSubscriptions(ctx, types.GetAgentSubscriptionPrefix(subscriber))
+}
+
+// GetAllAgentSubscriptions returns all subscriptions
+func (k Keeper) GetAllAgentSubscriptions(ctx sdk.Context) []types.AgentSubscription {
+	return k.getAgentSubscriptions(ctx, types.AgentSubscriptionKey)
+}
+
+func (k Keeper) getAgentSubscription(ctx sdk.Context, key []byte) (types.AgentSubscription, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(key)
+	if value == nil {
+		return types.AgentSubscription{}, false
+	}
+
+	var subscription types.AgentSubscription
+	k.cdc.MustUnmarshal(value, &subscription)
+	return subscription, true
+}
+
+func (k Keeper) getAgentSubscriptions(ctx sdk.Context, prefix []byte) []types.AgentSubscription {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var subscriptions []types.AgentSubscription
+	for ; iterator.Valid(); iterator.Next() {
+		var subscription types.AgentSubscription
+		k.cdc.MustUnmarshal(iterator.Value(), &subscription)
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions
+}
+
+// deleteAgentSubscription removes a subscription and its queue entry
+func (k Keeper) deleteAgentSubscription(ctx sdk.Context, subscription types.AgentSubscription) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetAgentSubscriptionKey(subscription.Subscriber, subscription.PlanID))
+	k.removeAgentSubscriptionQueue(ctx, subscription)
+}
+
+// insertAgentSubscriptionQueue adds a subscription to the queue keyed by the end of its period
+func (k Keeper) insertAgentSubscriptionQueue(ctx sdk.Context, subscription types.AgentSubscription) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetAgentSubscriptionKey(subscription.Subscriber, subscription.PlanID)
+	store.Set(types.GetAgentSubscriptionQueueKey(subscription.PeriodEnd, subscription.Subscriber, subscription.PlanID), key)
+}
+
+// removeAgentSubscriptionQueue removes a subscription from the queue
+func (k Keeper) removeAgentSubscriptionQueue(ctx sdk.Context, subscription types.AgentSubscription) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetAgentSubscriptionQueueKey(subscription.PeriodEnd, subscription.Subscriber, subscription.PlanID))
+}
+
+// GetDueAgentSubscriptions returns the subscriptions whose period has ended, in the order
+// of their period end
+func (k Keeper) GetDueAgentSubscriptions(ctx sdk.Context) []types.AgentSubscription {
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.PrefixEndBytes(types.GetAgentSubscriptionQueueTimePrefix(ctx.BlockTime()))
+	iterator := store.Iterator(types.AgentSubscriptionQueueKey, end)
+
+	// Collect the due entries first, the store must not be written while iterating
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Value())
+	}
+	iterator.Close()
+
+	var subscriptions []types.AgentSubscription
+	for _, key := range keys {
+		if subscription, found := k.getAgentSubscription(ctx, key); found {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	return subscriptions
+}
+
+// CreateSubscriptionPlan publishes a subscription plan for an agent on behalf of its
+// owner, or of the curator of a fractionalized agent
+func (k Keeper) CreateSubscriptionPlan(ctx sdk.Context, owner sdk.AccAddress, plan types.SubscriptionPlan) (types.SubscriptionPlan, error) {
+	agent, found := k.GetAIAgent(ctx, plan.AgentID)
+	if !found {
+		return types.SubscriptionPlan{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", plan.AgentID))
+	}
+	if !k.AgentSeller(ctx, agent).Equals(owner) {
+		return types.SubscriptionPlan{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can publish subscription plans of the agent")
+	}
+
+	plan.ID = fmt.Sprintf("%s-plan-%d", agent.ID, k.nextSequence(ctx))
+
+	plan.Status = types.SubscriptionPlanStatusActive
+	plan.CreatedAt = ctx.BlockTime()
+	plan.UpdatedAt = ctx.BlockTime()
+	if err := plan.Validate(); err != nil {
+		return types.SubscriptionPlan{}, sdkerrors.Wrap(types.ErrInvalidPlan, err.Error())
+	}
+
+	k.SetSubscriptionPlan(ctx, plan)
+	return plan, nil
+}
+
+// CloseSubscriptionPlan stops a plan from taking new subscribers. Its subscriptions run
+// until the end of their current period and then lapse.
+func (k Keeper) CloseSubscriptionPlan(ctx sdk.Context, owner sdk.AccAddress, planID string) (types.SubscriptionPlan, error) {
+	plan, found := k.GetSubscriptionPlan(ctx, planID)
+	if !found {
+		return types.SubscriptionPlan{}, sdkerrors.Wrap(types.ErrPlanNotFound, planID)
+	}
+	agent, found := k.GetAIAgent(ctx, plan.AgentID)
+	if !found {
+		return types.SubscriptionPlan{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", plan.AgentID))
+	}
+	if !k.AgentSeller(ctx, agent).Equals(owner) {
+		return types.SubscriptionPlan{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can close subscription plans of the agent")
+	}
+	if !plan.IsActive() {
+		return types.SubscriptionPlan{}, sdkerrors.Wrap(types.ErrInvalidPlan, "the plan is already closed")
+	}
+
+	plan.Status = types.SubscriptionPlanStatusClosed
+	plan.UpdatedAt = ctx.BlockTime()
+	k.SetSubscriptionPlan(ctx, plan)
+	return plan, nil
+}
+
+// SubscribeAgent subscribes an account to a plan. The deposit is escrowed in the module
+// account, the price of the first period is paid from it to the agent's owner, and the
+// rest is the prepaid balance renewals are paid from.
+func (k Keeper) SubscribeAgent(ctx sdk.Context, subscriber sdk.AccAddress, planID string, deposit sdk.Coins, autoRenew bool) (types.AgentSubscription, error) {
+	plan, found := k.GetSubscriptionPlan(ctx, planID)
+	if !found {
+		return types.AgentSubscription{}, sdkerrors.Wrap(types.ErrPlanNotFound, planID)
+	}
+	if !plan.IsActive() {
+		return types.AgentSubscription{}, sdkerrors.Wrap(types.ErrInvalidPlan, "the plan is closed")
+	}
+	agent, found := k.GetAIAgent(ctx, plan.AgentID)
+	if !found {
+		return types.AgentSubscription{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", plan.AgentID))
+	}
+	if !isAgentUsable(agent) {
+		return types.AgentSubscription{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent is not active")
+	}
+	if _, found := k.GetAgentSubscription(ctx, subscriber, planID); found {
+		return types.AgentSubscription{}, sdkerrors.Wrap(types.ErrAlreadySubscribed, planID)
+	}
+
+	price := sdk.NewCoins(plan.Price)
+	if !price.IsAllLTE(deposit) {
+		return types.AgentSubscription{}, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "deposit %s does not cover the price %s", deposit, plan.Price)
+	}
+	if !deposit.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, subscriber, types.ModuleName, deposit); err != nil {
+			return types.AgentSubscription{}, sdkerrors.Wrap(err, "failed to escrow subscription deposit")
+		}
+	}
+	if err := k.payAgentIncome(ctx, agent.ID, agent.Owner, price); err != nil {
+		return types.AgentSubscription{}, sdkerrors.Wrap(err, "failed to pay the subscription price")
+	}
+
+	subscription := types.AgentSubscription{
+		PlanID:      plan.ID,
+		AgentID:     plan.AgentID,
+		Subscriber:  subscriber,
+		Balance:     deposit.Sub(price...),
+		AutoRenew:   autoRenew,
+		PeriodStart: ctx.BlockTime(),
+		PeriodEnd:   ctx.BlockTime().Add(plan.Period),
+		ActionQuota: plan.ActionQuota,
+		CreatedAt:   ctx.BlockTime(),
+	}
+	k.SetAgentSubscription(ctx, subscription)
+	k.insertAgentSubscriptionQueue(ctx, subscription)
+
+	return subscription, nil
+}
+
+// FundAgentSubscription adds to the prepaid balance of a subscription
+func (k Keeper) FundAgentSubscription(ctx sdk.Context, subscriber sdk.AccAddress, planID string, amount sdk.Coins) (types.AgentSubscription, error) {
+	subscription, found := k.GetAgentSubscription(ctx, subscriber, planID)
+	if !found {
+		return types.AgentSubscription{}, sdkerrors.Wrap(types.ErrSubscriptionNotFound, planID)
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, subscriber, types.ModuleName, amount); err != nil {
+		return types.AgentSubscription{}, sdkerrors.Wrap(err, "failed to escrow subscription funds")
+	}
+
+	subscription.Balance = subscription.Balance.Add(amount...)
+	k.SetAgentSubscription(ctx, subscription)
+	return subscription, nil
+}
+
+// CancelAgentSubscription turns off the renewal of a subscription and refunds its prepaid
+// balance. The subscription keeps covering calls until the end of the paid period.
+func (k Keeper) CancelAgentSubscription(ctx sdk.Context, subscriber sdk.AccAddress, planID string) (sdk.Coins, error) {
+	subscription, found := k.GetAgentSubscription(ctx, subscriber, planID)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrSubscriptionNotFound, planID)
+	}
+
+	refund := subscription.Balance
+	if !refund.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, subscriber, refund); err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to refund subscription balance")
+		}
+	}
+
+	subscription.Balance = sdk.NewCoins()
+	subscription.AutoRenew = false
+	k.SetAgentSubscription(ctx, subscription)
+	return refund, nil
+}
+
+// RenewAgentSubscription renews a subscription whose period has ended, paying the plan's
+// price from its prepaid balance to the agent's owner. A subscription that cannot be
+// renewed lapses: it is removed and its balance refunded. It returns the renewed
+// subscription, or the reason for which the subscription lapsed.
+func (k Keeper) RenewAgentSubscription(ctx sdk.Context, subscription types.AgentSubscription) (types.AgentSubscription, string, error) {
+	plan, planFound := k.GetSubscriptionPlan(ctx, subscription.PlanID)
+	agent, agentFound := k.GetAIAgent(ctx, subscription.AgentID)
+	price := sdk.NewCoins(plan.Price)
+
+	var reason string
+	switch {
+	case !subscription.AutoRenew:
+		reason = types.SubscriptionLapsedNotRenewed
+	case !planFound || !plan.IsActive():
+		reason = types.SubscriptionLapsedPlanClosed
+	case !agentFound || !isAgentUsable(agent):
+		reason = types.SubscriptionLapsedAgent
+	case !price.IsAllLTE(subscription.Balance):
+		reason = types.SubscriptionLapsedNoFunds
+	}
+	if reason != "" {
+		if !subscription.Balance.IsZero() {
+			if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, subscription.Subscriber, subscription.Balance); err != nil {
+				return subscription, "", sdkerrors.Wrap(err, "failed to refund subscription balance")
+			}
+		}
+		k.deleteAgentSubscription(ctx, subscription)
+		return subscription, reason, nil
+	}
+
+	if err := k.payAgentIncome(ctx, agent.ID, agent.Owner, price); err != nil {
+		return subscription, "", sdkerrors.Wrap(err, "failed to pay the subscription price")
+	}
+
+	k.removeAgentSubscriptionQueue(ctx, subscription)
+	subscription = subscription.Renew(plan, ctx.BlockTime())
+	k.SetAgentSubscription(ctx, subscription)
+	k.insertAgentSubscriptionQueue(ctx, subscription)
+
+	return subscription, "", nil
+}
+
+// HasActiveAgentSubscription returns true if the subscriber currently holds an active
+// subscription to the agent, whether or not its quota is used up
+func (k Keeper) HasActiveAgentSubscription(ctx sdk.Context, agentID string, subscriber sdk.AccAddress) bool {
+	for _, subscription := range k.GetAgentSubscriptions(ctx, subscriber) {
+		if subscription.AgentID == agentID && subscription.IsActive(ctx.BlockTime()) {
+			return true
+		}
+	}
+	return false
+}
+
+// useAgentSubscription counts a call of an agent against the first of the caller's
+// subscriptions to it that covers the call. It returns false if none does. Calls of the
+// owner and of renters holding an active rental are free anyway and are not counted.
+func (k Keeper) useAgentSubscription(ctx sdk.Context, agent types.AIAgent, caller sdk.AccAddress) bool {
+	if caller.Empty() || agent.Owner.Equals(caller) || k.HasActiveAIAgentRental(ctx, agent.ID, caller) {
+		return false
+	}
+
+	for _, subscription := range k.GetAgentSubscriptions(ctx, caller) {
+		if subscription.AgentID != agent.ID || !subscription.CoversCall(ctx.BlockTime()) {
+			continue
+		}
+		subscription.ActionsUsed++
+		k.SetAgentSubscription(ctx, subscription)
+		return true
+	}
+
+	return false
+}
\ No newline at end of file
@@ -0,0 +1,358 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/inference"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetTrainingJob stores a training job
+func (k Keeper) SetTrainingJob(ctx sdk.Context, job types.TrainingJob) {
+	store := ctx.KVStore(k.storeKey)
+	value := k.cdc.MustMarshal(&job)
+	store.Set(types.GetTrainingJobKey(job.ID), value)
+}
+
+// GetTrainingJob returns a training job by ID
+func (k Keeper) GetTrainingJob(ctx sdk.Context, id string) (types.TrainingJob, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetTrainingJobKey(id))
+	if value == nil {
+		return types.TrainingJob{}, false
+	}
+
+	var job types.TrainingJob
+	k.cdc.MustUnmarshal(value, &job)
+	return job, true
+}
+
+// GetAllTrainingJobs returns all training jobs
+func (k Keeper) GetAllTrainingJobs(ctx sdk.Context) []types.TrainingJob {
+	return k.GetTrainingJobs(ctx, "", "")
+}
+
+// GetTrainingJobs returns the training jobs, optionally filtered by agent and status
+func (k Keeper) GetTrainingJobs(ctx sdk.Context, agentID string, status string) []types.TrainingJob {
+	var jobs []types.TrainingJob
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.TrainingJobKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var job types.TrainingJob
+		k.cdc.MustUnmarshal(iterator.Value(), &job)
+		if agentID != "" && job.AgentID != agentID {
+			continue
+		}
+		if status != "" && job.Status != status {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs
+}
+
+// GetExpiredTrainingJobs returns the pending training jobs whose deadline has passed
+func (k Keeper) GetExpiredTrainingJobs(ctx sdk.Context) []types.TrainingJob {
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.PrefixEndBytes(types.GetTrainingJobQueueHeightPrefix(ctx.BlockHeight() - 1))
+	iterator := store.Iterator(types.TrainingJobQueueKey, end)
+	defer iterator.Close()
+
+	var jobs []types.TrainingJob
+	for ; iterator.Valid(); iterator.Next() {
+		job, found := k.GetTrainingJob(ctx, string(iterator.Value()))
+		if found && job.IsPending() {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs
+}
+
+// insertTrainingJobQueue adds a job to the timeout queue keyed by its deadline
+func (k Keeper) insertTrainingJobQueue(ctx sdk.Context, job types.TrainingJob) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetTrainingJobQueueKey(job.Deadline, job.ID), []byte(job.ID))
+}
+
+// removeTrainingJobQueue removes a job from the timeout queue
+func (k Keeper) removeTrainingJobQueue(ctx sdk.Context, job types.TrainingJob) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetTrainingJobQueueKey(job.Deadline, job.ID))
+}
+
+// CreateTrainingJob escrows the training budget in the module account and opens a
+// training job that trainers can claim until the claim deadline. The agent stays usable
+// until a trainer claims the job. Authorization of the owner is the responsibility of
+// the caller.
+func (k Keeper) CreateTrainingJob(ctx sdk.Context, agent types.AIAgent, owner sdk.AccAddress, datasetRefs []string, hyperparameters json.RawMessage, budget sdk.Coins) (types.TrainingJob, error) {
+	if agent.Status != types.AIAgentStatusActive {
+		return types.TrainingJob{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "agent %s cannot be trained while %s", agent.ID, agent.Status)
+	}
+	if budget.IsZero() {
+		return types.TrainingJob{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "training budget cannot be zero")
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, owner, types.ModuleName, budget); err != nil {
+		return types.TrainingJob{}, sdkerrors.Wrap(err, "failed to escrow training budget")
+	}
+
+	jobID := fmt.Sprintf("%s-train-%d", agent.ID, k.nextSequence(ctx))
+
+	job := types.TrainingJob{
+		ID:              jobID,
+		AgentID:         agent.ID,
+		Owner:           owner,
+		DatasetRefs:     datasetRefs,
+		Hyperparameters: hyperparameters,
+		Budget:          budget,
+		BaseModelID:     agent.ModelID,
+		Status:          types.TrainingJobStatusOpen,
+		Deadline:        ctx.BlockHeight() + int64(k.TrainingClaimBlocks(ctx)),
+		CreatedAt:       ctx.BlockTime(),
+		UpdatedAt:       ctx.BlockTime(),
+	}
+	k.SetTrainingJob(ctx, job)
+	k.insertTrainingJobQueue(ctx, job)
+
+	return job, nil
+}
+
+// ClaimTrainingJob assigns an open job to a trainer, who then has until the submit
+// deadline to submit the trained model. The agent is in training status from the claim
+// until its claimed jobs are resolved.
+func (k Keeper) ClaimTrainingJob(ctx sdk.Context, trainer sdk.AccAddress, jobID string) (types.TrainingJob, error) {
+	job, found := k.GetTrainingJob(ctx, jobID)
+	if !found {
+		return job, sdkerrors.Wrap(types.ErrTrainingJobNotFound, jobID)
+	}
+	if job.Status != types.TrainingJobStatusOpen || ctx.BlockHeight() > job.Deadline {
+		return job, sdkerrors.Wrapf(types.ErrInvalidTrainingJobStatus, "training job %s is not open for claims", jobID)
+	}
+
+	agent, found := k.GetAIAgent(ctx, job.AgentID)
+	if !found {
+		return job, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", job.AgentID))
+	}
+	if agent.Status != types.AIAgentStatusActive && agent.Status != types.AIAgentStatusTraining {
+		return job, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "agent %s cannot be trained while %s", agent.ID, agent.Status)
+	}
+
+	k.removeTrainingJobQueue(ctx, job)
+
+	job.Status = types.TrainingJobStatusClaimed
+	job.Trainer = trainer
+	job.Deadline = ctx.BlockHeight() + int64(k.TrainingSubmitBlocks(ctx))
+	job.UpdatedAt = ctx.BlockTime()
+	k.SetTrainingJob(ctx, job)
+	k.insertTrainingJobQueue(ctx, job)
+
+	agent.Status = types.AIAgentStatusTraining
+	agent.UpdatedAt = ctx.BlockTime()
+	k.SetAIAgent(ctx, agent)
+
+	return job, nil
+}
+
+// SubmitTrainingResult records the model produced by the trainer of a claimed job for
+// review by the agent owner. Parameters are required for on-chain models, as they are
+// what the chain evaluates, and must be valid for the model type. A result that is not
+// reviewed before the new deadline expires like an unclaimed job.
+func (k Keeper) SubmitTrainingResult(ctx sdk.Context, trainer sdk.AccAddress, jobID string, modelHash string, modelURI string, parameters json.RawMessage, metrics json.RawMessage) (types.TrainingJob, error) {
+	job, found := k.GetTrainingJob(ctx, jobID)
+	if !found {
+		return job, sdkerrors.Wrap(types.ErrTrainingJobNotFound, jobID)
+	}
+	if job.Status != types.TrainingJobStatusClaimed || ctx.BlockHeight() > job.Deadline {
+		return job, sdkerrors.Wrapf(types.ErrInvalidTrainingJobStatus, "training job %s does not accept results", jobID)
+	}
+	if !job.Trainer.Equals(trainer) {
+		return job, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the trainer that claimed the job can submit its result")
+	}
+
+	if base, found := k.GetAIAgentModel(ctx, job.BaseModelID); found && base.OnChain {
+		if _, err := inference.ParseModel(base.ModelType, parameters); err != nil {
+			return job, sdkerrors.Wrap(types.ErrInvalidTrainingData, err.Error())
+		}
+	}
+
+	k.removeTrainingJobQueue(ctx, job)
+
+	job.Status = types.TrainingJobStatusSubmitted
+	job.ModelHash = modelHash
+	job.ModelURI = modelURI
+	job.Parameters = parameters
+	job.Metrics = metrics
+	job.Deadline = ctx.BlockHeight() + int64(k.TrainingReviewBlocks(ctx))
+	job.UpdatedAt = ctx.BlockTime()
+	k.SetTrainingJob(ctx, job)
+	k.insertTrainingJobQueue(ctx, job)
+
+	return job, nil
+}
+
+// ReviewTrainingResult resolves a submitted job. On acceptance the budget is paid to
+// the trainer and the agent advances to a new model version derived from its base
+// model; on rejection the budget is refunded to the account that funded the job.
+func (k Keeper) ReviewTrainingResult(ctx sdk.Context, owner sdk.AccAddress, jobID string, accept bool, reason string) (types.TrainingJob, error) {
+	job, found := k.GetTrainingJob(ctx, jobID)
+	if !found {
+		return job, sdkerrors.Wrap(types.ErrTrainingJobNotFound, jobID)
+	}
+	if job.Status != types.TrainingJobStatusSubmitted || ctx.BlockHeight() > job.Deadline {
+		return job, sdkerrors.Wrapf(types.ErrInvalidTrainingJobStatus, "training job %s has no result to review", jobID)
+	}
+
+	agent, found := k.GetAIAgent(ctx, job.AgentID)
+	if !found {
+		return job, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", job.AgentID))
+	}
+	// The curator reviews for fractionalized agents, whose owner is the module account
+	if !k.AgentSeller(ctx, agent).Equals(owner) {
+		return job, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the agent owner or curator can review a training result")
+	}
+
+	k.removeTrainingJobQueue(ctx, job)
+
+	if !accept {
+		if err := k.refundTrainingBudget(ctx, job); err != nil {
+			return job, err
+		}
+		job.Status = types.TrainingJobStatusRejected
+		job.RejectReason = reason
+		job.UpdatedAt = ctx.BlockTime()
+		k.SetTrainingJob(ctx, job)
+		k.finishAgentTraining(ctx, agent)
+		return job, nil
+	}
+
+	if !job.Budget.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, job.Trainer, job.Budget); err != nil {
+			return job, sdkerrors.Wrap(err, "failed to pay trainer")
+		}
+	}
+
+	model, err := k.newTrainedModelVersion(ctx, agent, job)
+	if err != nil {
+		return job, err
+	}
+	k.SetAIAgentModel(ctx, model)
+
+	job.Status = types.TrainingJobStatusAccepted
+	job.ResultModelID = model.ID
+	job.UpdatedAt = ctx.BlockTime()
+	k.SetTrainingJob(ctx, job)
+
+	agent.ModelID = model.ID
+	k.finishAgentTraining(ctx, agent)
+
+	return job, nil
+}
+
+// ExpireTrainingJob refunds the budget of a job whose deadline passed before it was
+// claimed, before its trainer submitted a result or before the result was reviewed
+func (k Keeper) ExpireTrainingJob(ctx sdk.Context, job types.TrainingJob) (types.TrainingJob, error) {
+	if err := k.refundTrainingBudget(ctx, job); err != nil {
+		return job, err
+	}
+
+	k.removeTrainingJobQueue(ctx, job)
+
+	job.Status = types.TrainingJobStatusExpired
+	job.UpdatedAt = ctx.BlockTime()
+	k.SetTrainingJob(ctx, job)
+
+	if agent, found := k.GetAIAgent(ctx, job.AgentID); found {
+		k.finishAgentTraining(ctx, agent)
+	}
+
+	return job, nil
+}
+
+// refundTrainingBudget returns the escrowed budget of a job to the account that funded it
+func (k Keeper) refundTrainingBudget(ctx sdk.Context, job types.TrainingJob) error {
+	if job.Budget.IsZero() {
+		return nil
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, job.Owner, job.Budget); err != nil {
+		return sdkerrors.Wrap(err, "failed to refund training budget")
+	}
+	return nil
+}
+
+// finishAgentTraining makes an agent usable again once none of its training jobs is
+// claimed or awaiting review
+func (k Keeper) finishAgentTraining(ctx sdk.Context, agent types.AIAgent) {
+	if agent.Status == types.AIAgentStatusTraining && !k.hasActiveTrainingJob(ctx, agent.ID) {
+		agent.Status = types.AIAgentStatusActive
+	}
+	agent.UpdatedAt = ctx.BlockTime()
+	k.SetAIAgent(ctx, agent)
+}
+
+// hasActiveTrainingJob returns true if a training job of the agent is claimed by a
+// trainer or has a result awaiting review
+func (k Keeper) hasActiveTrainingJob(ctx sdk.Context, agentID string) bool {
+	for _, job := range k.GetTrainingJobs(ctx, agentID, "") {
+		if job.Status == types.TrainingJobStatusClaimed || job.Status == types.TrainingJobStatusSubmitted {
+			return true
+		}
+	}
+	return false
+}
+
+// newTrainedModelVersion derives the next version of the agent's model from the base
+// model of a job and the trained model submitted for it
+func (k Keeper) newTrainedModelVersion(ctx sdk.Context, agent types.AIAgent, job types.TrainingJob) (types.AIAgentModel, error) {
+	model, err := k.newAgentModelVersion(ctx, agent, job.BaseModelID, job.Owner)
+	if err != nil {
+		return model, err
+	}
+
+	model.ModelHash = job.ModelHash
+	model.ModelURI = job.ModelURI
+	if len(job.Parameters) > 0 {
+		model.Parameters = job.Parameters
+	}
+
+	return model, nil
+}
+
+// newAgentModelVersion derives the next version of the agent's model from a base
+// model. Without a base model the version is created for the given creator.
+func (k Keeper) newAgentModelVersion(ctx sdk.Context, agent types.AIAgent, baseModelID string, creator sdk.AccAddress) (types.AIAgentModel, error) {
+	base, found := k.GetAIAgentModel(ctx, baseModelID)
+	if !found {
+		base = types.AIAgentModel{
+			Name:    agent.Name,
+			Creator: creator,
+		}
+	}
+
+	// Model versions are numbered; base models with a free-form version start over at 1
+	version, err := strconv.ParseUint(base.Version, 10, 64)
+	if err != nil {
+		version = 0
+	}
+	version++
+
+	model := base
+	model.ID = fmt.Sprintf("%s-v%d", agent.ID, version)
+	model.Version = strconv.FormatUint(version, 10)
+	model.ParentID = baseModelID
+	model.CreatedAt = ctx.BlockTime()
+	model.UpdatedAt = ctx.BlockTime()
+
+	if _, found := k.GetAIAgentModel(ctx, model.ID); found {
+		return model, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "model %s already exists", model.ID)
+	}
+
+	return model, nil
+}
\ No newline at end of file
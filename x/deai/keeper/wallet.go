@@ -0,0 +1,217 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/types"
+)
+
+// SetAgentWalletPolicy stores the wallet policy of an agent
+func (k Keeper) SetAgentWalletPolicy(ctx sdk.Context, policy types.AgentWalletPolicy) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAgentWalletPolicyKey(policy.AgentID), k.cdc.MustMarshal(&policy))
+}
+
+// GetAgentWalletPolicy returns the wallet policy of an agent
+func (k Keeper) GetAgentWalletPolicy(ctx sdk.Context, agentID string) (types.AgentWalletPolicy, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetAgentWalletPolicyKey(agentID))
+	if value == nil {
+		return types.AgentWalletPolicy{}, false
+	}
+
+	var policy types.AgentWalletPolicy
+	k.cdc.MustUnmarshal(value, &policy)
+	return policy, true
+}
+
+// GetAllAgentWalletPolicies returns the wallet policies of all agents
+func (k Keeper) GetAllAgentWalletPolicies(ctx sdk.Context) []types.AgentWalletPolicy {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AgentWalletPolicyKey)
+	defer iterator.Close()
+
+	var policies []types.AgentWalletPolicy
+	for ; iterator.Valid(); iterator.Next() {
+		var policy types.AgentWalletPolicy
+		k.cdc.MustUnmarshal(iterator.Value(), &policy)
+		policies = append(policies, policy)
+	}
+
+	return policies
+}
+
+// FundAgentWallet sends coins from an account to an agent's wallet and returns the
+// wallet address
+func (k Keeper) FundAgentWallet(ctx sdk.Context, agentID string, sender sdk.AccAddress, amount sdk.Coins) (sdk.AccAddress, error) {
+	if _, found := k.GetAIAgent(ctx, agentID); !found {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+
+	wallet := types.AgentWalletAddress(agentID)
+	if err := k.bankKeeper.SendCoins(ctx, sender, wallet, amount); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// WithdrawAgentWallet sends coins from an agent's wallet to a recipient on behalf of the
+// agent owner. Withdrawals by the owner do not count against the spend limit.
+func (k Keeper) WithdrawAgentWallet(ctx sdk.Context, agentID string, owner, recipient sdk.AccAddress, amount sdk.Coins) error {
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+	if !agent.Owner.Equals(owner) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can withdraw from the agent wallet")
+	}
+
+	return k.bankKeeper.SendCoins(ctx, types.AgentWalletAddress(agentID), recipient, amount)
+}
+
+// UpdateAgentWalletPolicy replaces the wallet policy of an agent on behalf of its owner.
+// The amount spent in the current period is carried over, so lowering the spend limit
+// takes effect immediately.
+func (k Keeper) UpdateAgentWalletPolicy(ctx sdk.Context, owner sdk.AccAddress, policy types.AgentWalletPolicy) (types.AgentWalletPolicy, error) {
+	agent, found := k.GetAIAgent(ctx, policy.AgentID)
+	if !found {
+		return types.AgentWalletPolicy{}, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", policy.AgentID))
+	}
+	if !agent.Owner.Equals(owner) {
+		return types.AgentWalletPolicy{}, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the owner can set the agent wallet policy")
+	}
+
+	policy.PeriodStart = ctx.BlockTime()
+	policy.PeriodSpent = sdk.NewCoins()
+	if current, found := k.GetAgentWalletPolicy(ctx, policy.AgentID); found {
+		policy.PeriodStart = current.PeriodStart
+		policy.PeriodSpent = current.PeriodSpent
+	}
+	if err := policy.Validate(); err != nil {
+		return types.AgentWalletPolicy{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	k.SetAgentWalletPolicy(ctx, policy)
+	return policy, nil
+}
+
+// ExecuteAgentWalletMsgs sends the messages an action result asks an agent to send from
+// its wallet, and returns the number of messages sent and the amount the wallet lost.
+// Only the results of actions requested by the owner or an allowed requester of the
+// agent's wallet policy can send messages. The messages are sent atomically: none takes
+// effect if any of them is not allowed by the policy or fails, or if the wallet loses
+// more than the rest of the spend limit of the current period. Results that ask for no
+// messages are ignored.
+func (k Keeper) ExecuteAgentWalletMsgs(ctx sdk.Context, agentID string, requester sdk.AccAddress, result json.RawMessage) (int, sdk.Coins, error) {
+	var walletResult types.AgentWalletResult
+	if err := json.Unmarshal(result, &walletResult); err != nil || len(walletResult.WalletMsgs) == 0 {
+		return 0, nil, nil
+	}
+	if maxMsgs := k.MaxAgentWalletMsgs(ctx); uint64(len(walletResult.WalletMsgs)) > maxMsgs {
+		return 0, nil, sdkerrors.Wrapf(types.ErrWalletMsgNotAllowed, "%d messages exceed the maximum of %d", len(walletResult.WalletMsgs), maxMsgs)
+	}
+
+	policy, found := k.GetAgentWalletPolicy(ctx, agentID)
+	if !found {
+		return 0, nil, sdkerrors.Wrap(types.ErrWalletMsgNotAllowed, "agent has no wallet policy")
+	}
+	agent, found := k.GetAIAgent(ctx, agentID)
+	if !found {
+		return 0, nil, sdkerrors.Wrap(sdkerrors.ErrKeyNotFound, fmt.Sprintf("agent not found: %s", agentID))
+	}
+	if !policy.AllowsRequester(agent.Owner, requester) {
+		return 0, nil, sdkerrors.Wrapf(types.ErrWalletMsgNotAllowed, "actions requested by %s cannot send messages from the agent wallet", requester)
+	}
+	if k.router == nil {
+		return 0, nil, sdkerrors.Wrap(sdkerrors.ErrLogic, "message router not set")
+	}
+
+	wallet := types.AgentWalletAddress(agentID)
+	before := k.bankKeeper.GetAllBalances(ctx, wallet)
+
+	cacheCtx, write := ctx.CacheContext()
+	for i, bz := range walletResult.WalletMsgs {
+		var msg sdk.Msg
+		if err := k.cdc.UnmarshalInterfaceJSON(bz, &msg); err != nil {
+			return 0, nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "wallet message %d: %s", i, err)
+		}
+		if typeURL := sdk.MsgTypeURL(msg); !policy.AllowsMsgType(typeURL) {
+			return 0, nil, sdkerrors.Wrapf(types.ErrWalletMsgNotAllowed, "wallet message %d: %s", i, typeURL)
+		}
+		if signers := msg.GetSigners(); len(signers) != 1 || !signers[0].Equals(wallet) {
+			return 0, nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "wallet message %d must be signed by the agent wallet alone", i)
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			return 0, nil, sdkerrors.Wrapf(err, "wallet message %d", i)
+		}
+
+		handler := k.router.Handler(msg)
+		if handler == nil {
+			return 0, nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "wallet message %d: no handler for %s", i, sdk.MsgTypeURL(msg))
+		}
+		res, err := handler(cacheCtx, msg)
+		if err != nil {
+			return 0, nil, sdkerrors.Wrapf(err, "wallet message %d", i)
+		}
+		for _, event := range res.GetEvents() {
+			cacheCtx.EventManager().EmitEvent(sdk.Event(event))
+		}
+	}
+
+	spent := walletOutflow(before, k.bankKeeper.GetAllBalances(cacheCtx, wallet))
+
+	policy, err := policy.Spend(ctx.BlockTime(), spent)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	write()
+	k.SetAgentWalletPolicy(ctx, policy)
+
+	return len(walletResult.WalletMsgs), spent, nil
+}
+
+// dispatchAgentWalletMsgs sends the wallet messages of an accepted result. The result
+// stays accepted if its messages are rejected; the rejection is reported in an event.
+func (k Keeper) dispatchAgentWalletMsgs(ctx sdk.Context, agentID, actionID string, requester sdk.AccAddress, result json.RawMessage) {
+	sent, spent, err := k.ExecuteAgentWalletMsgs(ctx, agentID, requester, result)
+	if err != nil {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeAgentWalletMsgsFailed,
+				sdk.NewAttribute(types.AttributeKeyAgentID, agentID),
+				sdk.NewAttribute(types.AttributeKeyActionID, actionID),
+				sdk.NewAttribute(types.AttributeKeyError, err.Error()),
+			),
+		)
+		return
+	}
+	if sent == 0 {
+		return
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAgentWalletMsgsSent,
+			sdk.NewAttribute(types.AttributeKeyAgentID, agentID),
+			sdk.NewAttribute(types.AttributeKeyActionID, actionID),
+			sdk.NewAttribute(types.AttributeKeyWallet, types.AgentWalletAddress(agentID).String()),
+			sdk.NewAttribute(types.AttributeKeyMsgCount, fmt.Sprintf("%d", sent)),
+			sdk.NewAttribute(types.AttributeKeySpent, spent.String()),
+		),
+	)
+}
+
+// walletOutflow returns the coins by which a balance decreased
+func walletOutflow(before, after sdk.Coins) sdk.Coins {
+	outflow := sdk.NewCoins()
+	for _, coin := range before {
+		if remaining := after.AmountOf(coin.Denom); remaining.LT(coin.Amount) {
+			outflow = outflow.Add(sdk.NewCoin(coin.Denom, coin.Amount.Sub(remaining)))
+		}
+	}
+	return outflow
+}
\ No newline at end of file
@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletOutflow(t *testing.T) {
+	before := sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("uatom", 10))
+
+	tests := []struct {
+		name     string
+		after    sdk.Coins
+		expected sdk.Coins
+	}{
+		{"unchanged", before, sdk.NewCoins()},
+		{"spent", sdk.NewCoins(sdk.NewInt64Coin("stake", 70), sdk.NewInt64Coin("uatom", 10)), sdk.NewCoins(sdk.NewInt64Coin("stake", 30))},
+		{"emptied", sdk.NewCoins(), before},
+		// Coins received while spending do not offset the spending of other denoms
+		{"received", sdk.NewCoins(sdk.NewInt64Coin("stake", 150), sdk.NewInt64Coin("uatom", 5), sdk.NewInt64Coin("ufoo", 1)), sdk.NewCoins(sdk.NewInt64Coin("uatom", 5))},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			outflow := walletOutflow(before, tc.after)
+			require.True(t, tc.expected.IsEqual(outflow), outflow.String())
+		})
+	}
+}
@@ -18,6 +18,41 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgBuyAIAgent{}, "deai/BuyAIAgent", nil)
 	cdc.RegisterConcrete(&MsgRentAIAgent{}, "deai/RentAIAgent", nil)
 	cdc.RegisterConcrete(&MsgCancelMarketListing{}, "deai/CancelMarketListing", nil)
+	cdc.RegisterConcrete(&MsgPlaceBid{}, "deai/PlaceBid", nil)
+	cdc.RegisterConcrete(&MsgRegisterExecutor{}, "deai/RegisterExecutor", nil)
+	cdc.RegisterConcrete(&MsgUnregisterExecutor{}, "deai/UnregisterExecutor", nil)
+	cdc.RegisterConcrete(&MsgCommitExecutionResult{}, "deai/CommitExecutionResult", nil)
+	cdc.RegisterConcrete(&MsgRevealExecutionResult{}, "deai/RevealExecutionResult", nil)
+	cdc.RegisterConcrete(&MsgClaimTrainingJob{}, "deai/ClaimTrainingJob", nil)
+	cdc.RegisterConcrete(&MsgSubmitTrainingResult{}, "deai/SubmitTrainingResult", nil)
+	cdc.RegisterConcrete(&MsgReviewTrainingResult{}, "deai/ReviewTrainingResult", nil)
+	cdc.RegisterConcrete(&MsgUpdateAIAgentState{}, "deai/UpdateAIAgentState", nil)
+	cdc.RegisterConcrete(&MsgRollbackAgentState{}, "deai/RollbackAgentState", nil)
+	cdc.RegisterConcrete(&MsgCreatePipeline{}, "deai/CreatePipeline", nil)
+	cdc.RegisterConcrete(&MsgDeletePipeline{}, "deai/DeletePipeline", nil)
+	cdc.RegisterConcrete(&MsgExecutePipeline{}, "deai/ExecutePipeline", nil)
+	cdc.RegisterConcrete(&MsgFundAgentWallet{}, "deai/FundAgentWallet", nil)
+	cdc.RegisterConcrete(&MsgWithdrawAgentWallet{}, "deai/WithdrawAgentWallet", nil)
+	cdc.RegisterConcrete(&MsgSetAgentWalletPolicy{}, "deai/SetAgentWalletPolicy", nil)
+	cdc.RegisterConcrete(&MsgScheduleAgentAction{}, "deai/ScheduleAgentAction", nil)
+	cdc.RegisterConcrete(&MsgCancelAgentSchedule{}, "deai/CancelAgentSchedule", nil)
+	cdc.RegisterConcrete(&MsgSetAgentPricing{}, "deai/SetAgentPricing", nil)
+	cdc.RegisterConcrete(&MsgDelegateGovVotes{}, "deai/DelegateGovVotes", nil)
+	cdc.RegisterConcrete(&MsgUndelegateGovVotes{}, "deai/UndelegateGovVotes", nil)
+	cdc.RegisterConcrete(&MsgRateAgent{}, "deai/RateAgent", nil)
+	cdc.RegisterConcrete(&MsgFractionalizeAgent{}, "deai/FractionalizeAgent", nil)
+	cdc.RegisterConcrete(&MsgTransferAgentShares{}, "deai/TransferAgentShares", nil)
+	cdc.RegisterConcrete(&MsgClaimAgentIncome{}, "deai/ClaimAgentIncome", nil)
+	cdc.RegisterConcrete(&MsgBuyoutAgent{}, "deai/BuyoutAgent", nil)
+	cdc.RegisterConcrete(&MsgCreateSubscriptionPlan{}, "deai/CreateSubscriptionPlan", nil)
+	cdc.RegisterConcrete(&MsgCloseSubscriptionPlan{}, "deai/CloseSubscriptionPlan", nil)
+	cdc.RegisterConcrete(&MsgSubscribeAgent{}, "deai/SubscribeAgent", nil)
+	cdc.RegisterConcrete(&MsgFundSubscription{}, "deai/FundSubscription", nil)
+	cdc.RegisterConcrete(&MsgCancelSubscription{}, "deai/CancelSubscription", nil)
+	cdc.RegisterConcrete(&MsgSetAgentEncryptionKey{}, "deai/SetAgentEncryptionKey", nil)
+	cdc.RegisterConcrete(&MsgRegisterEncryptionKey{}, "deai/RegisterEncryptionKey", nil)
+	cdc.RegisterConcrete(&MsgGrantTrainingDataKey{}, "deai/GrantTrainingDataKey", nil)
+	cdc.RegisterConcrete(&MsgSetAgentModel{}, "deai/SetAgentModel", nil)
 }
 
 // RegisterInterfaces registers the interfaces and implementations
@@ -31,6 +66,41 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 		&MsgBuyAIAgent{},
 		&MsgRentAIAgent{},
 		&MsgCancelMarketListing{},
+		&MsgPlaceBid{},
+		&MsgRegisterExecutor{},
+		&MsgUnregisterExecutor{},
+		&MsgCommitExecutionResult{},
+		&MsgRevealExecutionResult{},
+		&MsgClaimTrainingJob{},
+		&MsgSubmitTrainingResult{},
+		&MsgReviewTrainingResult{},
+		&MsgUpdateAIAgentState{},
+		&MsgRollbackAgentState{},
+		&MsgCreatePipeline{},
+		&MsgDeletePipeline{},
+		&MsgExecutePipeline{},
+		&MsgFundAgentWallet{},
+		&MsgWithdrawAgentWallet{},
+		&MsgSetAgentWalletPolicy{},
+		&MsgScheduleAgentAction{},
+		&MsgCancelAgentSchedule{},
+		&MsgSetAgentPricing{},
+		&MsgDelegateGovVotes{},
+		&MsgUndelegateGovVotes{},
+		&MsgRateAgent{},
+		&MsgFractionalizeAgent{},
+		&MsgTransferAgentShares{},
+		&MsgClaimAgentIncome{},
+		&MsgBuyoutAgent{},
+		&MsgCreateSubscriptionPlan{},
+		&MsgCloseSubscriptionPlan{},
+		&MsgSubscribeAgent{},
+		&MsgFundSubscription{},
+		&MsgCancelSubscription{},
+		&MsgSetAgentEncryptionKey{},
+		&MsgRegisterEncryptionKey{},
+		&MsgGrantTrainingDataKey{},
+		&MsgSetAgentModel{},
 	)
 
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
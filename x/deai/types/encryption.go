@@ -0,0 +1,122 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EncryptionSchemeX25519 encrypts a payload with a random data key using NaCl
+// secretbox, and wraps the data key for every recipient in a NaCl anonymous sealed box
+// (X25519, XSalsa20-Poly1305) opened with the recipient's X25519 private key.
+const EncryptionSchemeX25519 = "x25519-xsalsa20-poly1305"
+
+// Sizes of the keys of EncryptionSchemeX25519
+const (
+	X25519KeySize  = 32
+	DataKeySize    = 32
+	WrappedKeySize = DataKeySize + X25519KeySize + 16 // the sealed box's ephemeral key and tag
+)
+
+// TrainingDataEncryption describes how encrypted training data can be decrypted. The
+// data key is wrapped for the agent's encryption key when the data is submitted, and
+// re-wrapped by the owner for every trainer or executor granted access.
+type TrainingDataEncryption struct {
+	Scheme    string        `json:"scheme"`
+	Envelopes []KeyEnvelope `json:"envelopes"`
+}
+
+// KeyEnvelope is the data key of encrypted training data wrapped for a public key
+type KeyEnvelope struct {
+	Grantee    sdk.AccAddress `json:"grantee,omitempty"` // empty for the agent's own key
+	PublicKey  []byte         `json:"public_key"`
+	WrappedKey []byte         `json:"wrapped_key"`
+	GrantedAt  time.Time      `json:"granted_at"`
+}
+
+// AccountEncryptionKey is the X25519 public key an account registered so that data
+// keys can be wrapped for it
+type AccountEncryptionKey struct {
+	Address   sdk.AccAddress `json:"address"`
+	PublicKey []byte         `json:"public_key"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// ValidateEncryptionKey checks that a key is an X25519 public key
+func ValidateEncryptionKey(key []byte) error {
+	if len(key) != X25519KeySize {
+		return fmt.Errorf("encryption key must be a %d byte X25519 public key", X25519KeySize)
+	}
+	if bytes.Equal(key, make([]byte, X25519KeySize)) {
+		return fmt.Errorf("encryption key cannot be zero")
+	}
+	return nil
+}
+
+// ValidateWrappedKey checks that a wrapped data key has the size of a sealed data key
+func ValidateWrappedKey(wrappedKey []byte) error {
+	if len(wrappedKey) != WrappedKeySize {
+		return fmt.Errorf("wrapped key must be %d bytes", WrappedKeySize)
+	}
+	return nil
+}
+
+// Validate performs basic validation of the encryption of training data
+func (e TrainingDataEncryption) Validate() error {
+	if e.Scheme != EncryptionSchemeX25519 {
+		return fmt.Errorf("unknown encryption scheme: %s", e.Scheme)
+	}
+	if len(e.Envelopes) == 0 || !e.Envelopes[0].Grantee.Empty() {
+		return fmt.Errorf("the first key envelope must be for the agent's key")
+	}
+	for _, envelope := range e.Envelopes {
+		if err := ValidateEncryptionKey(envelope.PublicKey); err != nil {
+			return err
+		}
+		if err := ValidateWrappedKey(envelope.WrappedKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Envelope returns the key envelope for a public key
+func (e TrainingDataEncryption) Envelope(publicKey []byte) (KeyEnvelope, bool) {
+	for _, envelope := range e.Envelopes {
+		if bytes.Equal(envelope.PublicKey, publicKey) {
+			return envelope, true
+		}
+	}
+	return KeyEnvelope{}, false
+}
+
+// IsEncrypted returns true if the training data is ciphertext
+func (d AIAgentTrainingData) IsEncrypted() bool {
+	return d.Encryption != nil
+}
+
+// ValidateEncryption checks that encrypted training data is stored as ciphertext:
+// inline ciphertext is a JSON string of its base64 encoding
+func (d AIAgentTrainingData) ValidateEncryption() error {
+	if d.Encryption == nil {
+		return nil
+	}
+	if err := d.Encryption.Validate(); err != nil {
+		return err
+	}
+	if d.StorageRef != nil {
+		if !d.StorageRef.Encrypted {
+			return fmt.Errorf("the storage reference of encrypted data must be marked encrypted")
+		}
+		return nil
+	}
+
+	var ciphertext []byte
+	if err := json.Unmarshal(d.Data, &ciphertext); err != nil || len(ciphertext) == 0 {
+		return fmt.Errorf("inline ciphertext must be a base64 encoded JSON string")
+	}
+	return nil
+}
\ No newline at end of file
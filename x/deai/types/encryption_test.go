@@ -0,0 +1,61 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrainingDataValidateEncryption(t *testing.T) {
+	agentKey := bytes.Repeat([]byte{1}, X25519KeySize)
+	trainerKey := bytes.Repeat([]byte{2}, X25519KeySize)
+	wrappedKey := make([]byte, WrappedKeySize)
+	trainer := sdk.AccAddress([]byte("trainer_____________"))
+
+	valid := func() AIAgentTrainingData {
+		return AIAgentTrainingData{
+			Data: json.RawMessage(`"c2VjcmV0"`),
+			Encryption: &TrainingDataEncryption{
+				Scheme: EncryptionSchemeX25519,
+				Envelopes: []KeyEnvelope{
+					{PublicKey: agentKey, WrappedKey: wrappedKey},
+					{Grantee: trainer, PublicKey: trainerKey, WrappedKey: wrappedKey},
+				},
+			},
+		}
+	}
+	require.NoError(t, valid().ValidateEncryption())
+	require.NoError(t, AIAgentTrainingData{Data: json.RawMessage(`{"plain": true}`)}.ValidateEncryption())
+
+	envelope, found := valid().Encryption.Envelope(trainerKey)
+	require.True(t, found)
+	require.Equal(t, trainer, envelope.Grantee)
+	_, found = valid().Encryption.Envelope(bytes.Repeat([]byte{3}, X25519KeySize))
+	require.False(t, found)
+
+	tests := []struct {
+		name   string
+		modify func(d *AIAgentTrainingData)
+	}{
+		{"unknown scheme", func(d *AIAgentTrainingData) { d.Encryption.Scheme = "rot13" }},
+		{"no envelopes", func(d *AIAgentTrainingData) { d.Encryption.Envelopes = nil }},
+		{"first envelope for a grantee", func(d *AIAgentTrainingData) { d.Encryption.Envelopes = d.Encryption.Envelopes[1:] }},
+		{"zero public key", func(d *AIAgentTrainingData) { d.Encryption.Envelopes[1].PublicKey = make([]byte, X25519KeySize) }},
+		{"short public key", func(d *AIAgentTrainingData) { d.Encryption.Envelopes[1].PublicKey = trainerKey[:16] }},
+		{"short wrapped key", func(d *AIAgentTrainingData) { d.Encryption.Envelopes[1].WrappedKey = wrappedKey[:DataKeySize] }},
+		{"plaintext inline data", func(d *AIAgentTrainingData) { d.Data = json.RawMessage(`{"plain": true}`) }},
+		{"empty inline ciphertext", func(d *AIAgentTrainingData) { d.Data = json.RawMessage(`""`) }},
+		{"unencrypted storage reference", func(d *AIAgentTrainingData) { d.StorageRef = &StorageRef{} }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data := valid()
+			tc.modify(&data)
+			require.Error(t, data.ValidateEncryption())
+		})
+	}
+}
@@ -30,4 +30,46 @@ var (
 	ErrInvalidAgentDescription = sdkerrors.Register(ModuleName, 22, "invalid agent description")
 	ErrInvalidDeposit         = sdkerrors.Register(ModuleName, 23, "invalid deposit")
 	ErrInsufficientDeposit    = sdkerrors.Register(ModuleName, 24, "insufficient deposit")
+	ErrInvalidPermissions     = sdkerrors.Register(ModuleName, 25, "invalid agent permissions")
+	ErrPermissionsExpired     = sdkerrors.Register(ModuleName, 26, "agent permissions expired")
+	ErrRateLimitExceeded      = sdkerrors.Register(ModuleName, 27, "agent call rate limit exceeded")
+	ErrFeeExceedsMaximum      = sdkerrors.Register(ModuleName, 28, "fee exceeds maximum allowed by agent policy")
+	ErrBidTooLow              = sdkerrors.Register(ModuleName, 29, "bid too low")
+	ErrAuctionClosed          = sdkerrors.Register(ModuleName, 30, "auction closed")
+	ErrExecutorNotFound       = sdkerrors.Register(ModuleName, 31, "executor not found")
+	ErrExecutorNotActive      = sdkerrors.Register(ModuleName, 32, "executor not active")
+	ErrExecutorBusy           = sdkerrors.Register(ModuleName, 33, "executor has unresolved commitments")
+	ErrExecutionRequestNotFound = sdkerrors.Register(ModuleName, 34, "execution request not found")
+	ErrInvalidExecutionPhase  = sdkerrors.Register(ModuleName, 35, "execution request not in the required phase")
+	ErrAlreadyCommitted       = sdkerrors.Register(ModuleName, 36, "executor already committed a result")
+	ErrCommitmentMismatch     = sdkerrors.Register(ModuleName, 37, "revealed result does not match commitment")
+	ErrInferenceFailed        = sdkerrors.Register(ModuleName, 38, "on-chain inference failed")
+	ErrTrainingJobNotFound    = sdkerrors.Register(ModuleName, 39, "training job not found")
+	ErrInvalidTrainingJobStatus = sdkerrors.Register(ModuleName, 40, "training job not in the required status")
+	ErrInvalidStorageRef      = sdkerrors.Register(ModuleName, 41, "invalid storage reference")
+	ErrStateDataTooLarge      = sdkerrors.Register(ModuleName, 42, "state data too large to store inline")
+	ErrStateVersionNotFound   = sdkerrors.Register(ModuleName, 43, "agent state version not found")
+	ErrPipelineNotFound       = sdkerrors.Register(ModuleName, 44, "pipeline not found")
+	ErrInvalidPipeline        = sdkerrors.Register(ModuleName, 45, "invalid pipeline")
+	ErrPipelineDepthExceeded  = sdkerrors.Register(ModuleName, 46, "pipeline nesting depth exceeded")
+	ErrWalletMsgNotAllowed    = sdkerrors.Register(ModuleName, 47, "message not allowed by agent wallet policy")
+	ErrSpendLimitExceeded     = sdkerrors.Register(ModuleName, 48, "agent wallet spend limit exceeded")
+	ErrScheduleNotFound       = sdkerrors.Register(ModuleName, 49, "agent schedule not found")
+	ErrInvalidSchedule        = sdkerrors.Register(ModuleName, 50, "invalid agent schedule")
+	ErrFeeBelowPrice          = sdkerrors.Register(ModuleName, 51, "fee below the agent's price")
+	ErrInvalidPricing         = sdkerrors.Register(ModuleName, 52, "invalid agent pricing")
+	ErrNotGovernanceAgent     = sdkerrors.Register(ModuleName, 53, "agent is not a governance agent")
+	ErrGovDelegationNotFound  = sdkerrors.Register(ModuleName, 54, "governance delegation not found")
+	ErrInvalidRating          = sdkerrors.Register(ModuleName, 55, "invalid agent rating")
+	ErrAlreadyRated           = sdkerrors.Register(ModuleName, 56, "already rated")
+	ErrAgentFractionalized    = sdkerrors.Register(ModuleName, 57, "agent is fractionalized")
+	ErrNotFractionalized      = sdkerrors.Register(ModuleName, 58, "agent is not fractionalized")
+	ErrInsufficientShares     = sdkerrors.Register(ModuleName, 59, "insufficient agent shares")
+	ErrPlanNotFound           = sdkerrors.Register(ModuleName, 60, "subscription plan not found")
+	ErrInvalidPlan            = sdkerrors.Register(ModuleName, 61, "invalid subscription plan")
+	ErrSubscriptionNotFound   = sdkerrors.Register(ModuleName, 62, "subscription not found")
+	ErrAlreadySubscribed      = sdkerrors.Register(ModuleName, 63, "already subscribed")
+	ErrInvalidEncryption      = sdkerrors.Register(ModuleName, 64, "invalid training data encryption")
+	ErrNoEncryptionKey        = sdkerrors.Register(ModuleName, 65, "no encryption key registered")
+	ErrInvalidOnChainModel    = sdkerrors.Register(ModuleName, 66, "invalid on-chain model")
 )
\ No newline at end of file
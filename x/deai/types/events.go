@@ -12,6 +12,23 @@ const (
 	EventTypeCancelMarketListing  = "cancel_market_listing"
 	EventTypeTrainingCompleted    = "training_completed"
 	EventTypeMarketplaceExpired   = "marketplace_expired"
+	EventTypeRentalExpired        = "rental_expired"
+	EventTypePlaceBid             = "place_bid"
+	EventTypeAuctionSettled       = "auction_settled"
+	EventTypeExecutionCompleted   = "execution_completed"
+	EventTypeExecutionFailed      = "execution_failed"
+	EventTypeExecutorSlashed      = "executor_slashed"
+	EventTypeTrainingJobExpired   = "training_job_expired"
+	EventTypeAgentWalletMsgsSent  = "agent_wallet_msgs_sent"
+	EventTypeAgentWalletMsgsFailed = "agent_wallet_msgs_failed"
+	EventTypeAgentScheduleRun     = "agent_schedule_run"
+	EventTypeAgentScheduleRunFailed = "agent_schedule_run_failed"
+	EventTypeAgentScheduleCompleted = "agent_schedule_completed"
+	EventTypeAgentScheduleCancelled = "agent_schedule_cancelled"
+	EventTypeAgentGovVoteCast     = "agent_gov_vote_cast"
+	EventTypeAgentGovVoteFailed   = "agent_gov_vote_failed"
+	EventTypeSubscriptionRenewed  = "agent_subscription_renewed"
+	EventTypeSubscriptionLapsed   = "agent_subscription_lapsed"
 	
 	// Attribute keys
 	AttributeKeyAgentID        = "agent_id"
@@ -30,10 +47,41 @@ const (
 	AttributeKeyRentalDuration = "rental_duration"
 	AttributeKeyBuyer          = "buyer"
 	AttributeKeyRenter         = "renter"
+	AttributeKeyRentalID       = "rental_id"
 	AttributeKeyExpiresAt      = "expires_at"
+	AttributeKeyMarketplaceFee = "marketplace_fee"
+	AttributeKeyRoyalty        = "royalty"
+	AttributeKeyRoyaltyPayee   = "royalty_payee"
+	AttributeKeySellerProceeds = "seller_proceeds"
+	AttributeKeyBidder         = "bidder"
+	AttributeKeyBidAmount      = "bid_amount"
+	AttributeKeyExecutor       = "executor"
+	AttributeKeyExecutors      = "executors"
+	AttributeKeyRequestID      = "request_id"
+	AttributeKeyExecutorReward = "executor_reward"
+	AttributeKeyOwnerProceeds  = "owner_proceeds"
+	AttributeKeyAmount         = "amount"
 	AttributeKeyTrainingDataID = "training_data_id"
+	AttributeKeyJobID          = "job_id"
+	AttributeKeyTrainer        = "trainer"
 	AttributeKeyDataType       = "data_type"
 	AttributeKeyTimestamp      = "timestamp"
 	AttributeKeyStatus         = "status"
 	AttributeKeyResult         = "result"
+	AttributeKeyWallet         = "wallet"
+	AttributeKeyMsgCount       = "msg_count"
+	AttributeKeySpent          = "spent"
+	AttributeKeyError          = "error"
+	AttributeKeyScheduleID     = "schedule_id"
+	AttributeKeyRuns           = "runs"
+	AttributeKeyBudget         = "budget"
+	AttributeKeyRefund         = "refund"
+	AttributeKeyReason         = "reason"
+	AttributeKeyProposalID     = "proposal_id"
+	AttributeKeyOptions        = "options"
+	AttributeKeyDelegators     = "delegators"
+	AttributeKeyPlanID         = "plan_id"
+	AttributeKeySubscriber     = "subscriber"
+	AttributeKeyPeriodEnd      = "period_end"
+	AttributeKeyBalance        = "balance"
 )
\ No newline at end of file
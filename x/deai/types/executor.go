@@ -0,0 +1,103 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Executor status constants
+const (
+	ExecutorStatusActive   = "active"
+	ExecutorStatusInactive = "inactive" // the bond fell below the minimum executor bond
+)
+
+// Execution request status constants
+const (
+	ExecutionStatusPending   = "pending"   // waiting for executors to commit and reveal results
+	ExecutionStatusCompleted = "completed" // a quorum of executors agreed on the result
+	ExecutionStatusFailed    = "failed"    // no quorum was reached and the fee was refunded
+)
+
+// MinExecutionSaltLength is the minimum salt length of a revealed result, so that
+// commitments to results from a small result space cannot be brute forced
+const MinExecutionSaltLength = 16
+
+// Executor is a bonded off-chain worker that runs AI agent inference
+type Executor struct {
+	Address        sdk.AccAddress `json:"address"`
+	Bond           sdk.Coins      `json:"bond"`
+	Status         string         `json:"status"`
+	PendingCommits uint64         `json:"pending_commits"` // commitments on requests that are not finalized yet
+	Completed      uint64         `json:"completed"`
+	Slashed        uint64         `json:"slashed"`
+	RegisteredAt   time.Time      `json:"registered_at"`
+}
+
+// ExecutionCommit is the result commitment of a single executor
+type ExecutionCommit struct {
+	Executor   sdk.AccAddress  `json:"executor"`
+	Commitment []byte          `json:"commitment"`
+	Revealed   bool            `json:"revealed"`
+	Result     json.RawMessage `json:"result,omitempty"`
+}
+
+// ExecutionRequest is an AI agent execution waiting to be run by executors. Executors
+// commit to a result until the commit deadline and reveal it until the reveal deadline.
+type ExecutionRequest struct {
+	ID             string            `json:"id"` // equal to the ID of the recorded action
+	AgentID        string            `json:"agent_id"`
+	Requester      sdk.AccAddress    `json:"requester"`
+	ActionType     string            `json:"action_type"`
+	Data           json.RawMessage   `json:"data"`
+	Fee            sdk.Coins         `json:"fee"`
+	Status         string            `json:"status"`
+	CommitDeadline int64             `json:"commit_deadline"` // last block height accepting commitments
+	RevealDeadline int64             `json:"reveal_deadline"` // last block height accepting reveals
+	Commits        []ExecutionCommit `json:"commits"`
+	Result         json.RawMessage   `json:"result,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// InCommitPhase returns true if the request accepts commitments at the given height
+func (r ExecutionRequest) InCommitPhase(height int64) bool {
+	return r.Status == ExecutionStatusPending && height <= r.CommitDeadline
+}
+
+// InRevealPhase returns true if the request accepts reveals at the given height
+func (r ExecutionRequest) InRevealPhase(height int64) bool {
+	return r.Status == ExecutionStatusPending && height > r.CommitDeadline && height <= r.RevealDeadline
+}
+
+// FindCommit returns the index of the executor's commitment, or -1 if it has not committed
+func (r ExecutionRequest) FindCommit(executor sdk.AccAddress) int {
+	for i, commit := range r.Commits {
+		if commit.Executor.Equals(executor) {
+			return i
+		}
+	}
+	return -1
+}
+
+// AllRevealed returns true if every committed executor has revealed its result
+func (r ExecutionRequest) AllRevealed() bool {
+	for _, commit := range r.Commits {
+		if !commit.Revealed {
+			return false
+		}
+	}
+	return len(r.Commits) > 0
+}
+
+// ComputeResultCommitment returns the commitment of an executor to a result,
+// sha256(executor || salt || result). Binding the executor address keeps other
+// executors from copying a commitment they have not computed themselves.
+func ComputeResultCommitment(executor sdk.AccAddress, salt []byte, result []byte) []byte {
+	h := sha256.New()
+	h.Write(executor)
+	h.Write(salt)
+	h.Write(result)
+	return h.Sum(nil)
+}
\ No newline at end of file
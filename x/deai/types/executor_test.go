@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionRequestPhases(t *testing.T) {
+	request := ExecutionRequest{Status: ExecutionStatusPending, CommitDeadline: 10, RevealDeadline: 15}
+
+	require.True(t, request.InCommitPhase(10))
+	require.False(t, request.InRevealPhase(10))
+	require.False(t, request.InCommitPhase(11))
+	require.True(t, request.InRevealPhase(11))
+	require.True(t, request.InRevealPhase(15))
+	require.False(t, request.InRevealPhase(16))
+
+	request.Status = ExecutionStatusCompleted
+	require.False(t, request.InCommitPhase(1))
+	require.False(t, request.InRevealPhase(11))
+}
+
+func TestExecutionRequestCommits(t *testing.T) {
+	first := sdk.AccAddress([]byte("first_______________"))
+	second := sdk.AccAddress([]byte("second______________"))
+
+	request := ExecutionRequest{}
+	require.False(t, request.AllRevealed())
+	require.Equal(t, -1, request.FindCommit(first))
+
+	request.Commits = []ExecutionCommit{{Executor: first, Revealed: true}, {Executor: second}}
+	require.Equal(t, 1, request.FindCommit(second))
+	require.False(t, request.AllRevealed())
+
+	request.Commits[1].Revealed = true
+	require.True(t, request.AllRevealed())
+}
+
+func TestComputeResultCommitment(t *testing.T) {
+	first := sdk.AccAddress([]byte("first_______________"))
+	second := sdk.AccAddress([]byte("second______________"))
+	salt := []byte("0123456789abcdef")
+	result := []byte(`{"y":1}`)
+
+	commitment := ComputeResultCommitment(first, salt, result)
+	require.Len(t, commitment, 32)
+	require.Equal(t, commitment, ComputeResultCommitment(first, salt, result))
+
+	// Commitments bind the executor, the salt and the result
+	require.NotEqual(t, commitment, ComputeResultCommitment(second, salt, result))
+	require.NotEqual(t, commitment, ComputeResultCommitment(first, []byte("fedcba9876543210"), result))
+	require.NotEqual(t, commitment, ComputeResultCommitment(first, salt, []byte(`{"y":2}`)))
+}
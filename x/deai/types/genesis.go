@@ -2,6 +2,10 @@ package types
 
 import (
 	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/nomercychain/nmxchain/x/deai/inference"
 )
 
 // DefaultGenesis returns the default genesis state
@@ -13,6 +17,25 @@ func DefaultGenesis() *GenesisState {
 		Actions:             []AIAgentAction{},
 		TrainingData:        []AIAgentTrainingData{},
 		MarketplaceListings: []AIAgentMarketplaceListing{},
+		Rentals:             []AIAgentRental{},
+		Executors:           []Executor{},
+		ExecutionRequests:   []ExecutionRequest{},
+		TrainingJobs:        []TrainingJob{},
+		StateHistory:        []AIAgentState{},
+		Pipelines:           []Pipeline{},
+		WalletPolicies:      []AgentWalletPolicy{},
+		Schedules:           []AgentSchedule{},
+		Pricings:            []AgentPricing{},
+		GovDelegations:      []GovDelegation{},
+		AgentGovVotes:       []AgentGovVote{},
+		AgentRatings:        []AgentRating{},
+		AgentReputations:    []AgentReputation{},
+		CreatorReputations:  []AgentReputation{},
+		AgentShares:         []AgentShares{},
+		AgentShareholders:   []AgentShareholder{},
+		SubscriptionPlans:   []SubscriptionPlan{},
+		AgentSubscriptions:  []AgentSubscription{},
+		EncryptionKeys:      []AccountEncryptionKey{},
 		Params:              DefaultParams(),
 	}
 }
@@ -39,6 +62,238 @@ func (gs GenesisState) Validate() error {
 			return fmt.Errorf("duplicate model ID: %s", model.ID)
 		}
 		modelIDs[model.ID] = true
+
+		if model.OnChain {
+			if _, err := inference.ParseModel(model.ModelType, model.Parameters); err != nil {
+				return fmt.Errorf("invalid on-chain model %s: %w", model.ID, err)
+			}
+		}
+	}
+
+	// Validate pipelines
+	pipelineIDs := make(map[string]bool)
+	for _, pipeline := range gs.Pipelines {
+		if pipelineIDs[pipeline.ID] {
+			return fmt.Errorf("duplicate pipeline ID: %s", pipeline.ID)
+		}
+		pipelineIDs[pipeline.ID] = true
+
+		if err := pipeline.Validate(); err != nil {
+			return fmt.Errorf("invalid pipeline %s: %w", pipeline.ID, err)
+		}
+	}
+	for _, pipeline := range gs.Pipelines {
+		for _, step := range pipeline.Steps {
+			if step.AgentID != "" && !agentIDs[step.AgentID] {
+				return fmt.Errorf("pipeline %s references non-existent agent: %s", pipeline.ID, step.AgentID)
+			}
+			if step.PipelineID != "" && !pipelineIDs[step.PipelineID] {
+				return fmt.Errorf("pipeline %s references non-existent pipeline: %s", pipeline.ID, step.PipelineID)
+			}
+		}
+	}
+
+	// Validate agent wallet policies
+	policyAgents := make(map[string]bool)
+	for _, policy := range gs.WalletPolicies {
+		if policyAgents[policy.AgentID] {
+			return fmt.Errorf("duplicate wallet policy of agent: %s", policy.AgentID)
+		}
+		policyAgents[policy.AgentID] = true
+
+		if !agentIDs[policy.AgentID] {
+			return fmt.Errorf("wallet policy references non-existent agent: %s", policy.AgentID)
+		}
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("invalid wallet policy of agent %s: %w", policy.AgentID, err)
+		}
+	}
+
+	// Validate agent schedules
+	scheduleIDs := make(map[string]bool)
+	for _, schedule := range gs.Schedules {
+		if schedule.ID == "" {
+			return fmt.Errorf("agent schedule with empty ID")
+		}
+		if scheduleIDs[schedule.ID] {
+			return fmt.Errorf("duplicate agent schedule ID: %s", schedule.ID)
+		}
+		scheduleIDs[schedule.ID] = true
+
+		if !agentIDs[schedule.AgentID] {
+			return fmt.Errorf("agent schedule references non-existent agent: %s", schedule.AgentID)
+		}
+		if err := schedule.Validate(); err != nil {
+			return fmt.Errorf("invalid agent schedule %s: %w", schedule.ID, err)
+		}
+	}
+
+	// Validate agent pricings
+	pricedAgents := make(map[string]bool)
+	for _, pricing := range gs.Pricings {
+		if pricedAgents[pricing.AgentID] {
+			return fmt.Errorf("duplicate pricing of agent: %s", pricing.AgentID)
+		}
+		pricedAgents[pricing.AgentID] = true
+
+		if !agentIDs[pricing.AgentID] {
+			return fmt.Errorf("pricing references non-existent agent: %s", pricing.AgentID)
+		}
+		if err := pricing.Validate(); err != nil {
+			return fmt.Errorf("invalid pricing of agent %s: %w", pricing.AgentID, err)
+		}
+	}
+
+	// Validate governance delegations
+	delegators := make(map[string]bool)
+	for _, delegation := range gs.GovDelegations {
+		if delegation.Delegator.Empty() {
+			return fmt.Errorf("governance delegation without delegator")
+		}
+		if delegators[delegation.Delegator.String()] {
+			return fmt.Errorf("duplicate governance delegation of %s", delegation.Delegator)
+		}
+		delegators[delegation.Delegator.String()] = true
+
+		if !agentIDs[delegation.AgentID] {
+			return fmt.Errorf("governance delegation references non-existent agent: %s", delegation.AgentID)
+		}
+	}
+
+	// Validate governance agent votes
+	agentVotes := make(map[string]bool)
+	for _, vote := range gs.AgentGovVotes {
+		key := fmt.Sprintf("%s/%d", vote.AgentID, vote.ProposalID)
+		if agentVotes[key] {
+			return fmt.Errorf("duplicate vote of agent %s on proposal %d", vote.AgentID, vote.ProposalID)
+		}
+		agentVotes[key] = true
+
+		if !agentIDs[vote.AgentID] {
+			return fmt.Errorf("governance vote references non-existent agent: %s", vote.AgentID)
+		}
+		if err := vote.Validate(); err != nil {
+			return fmt.Errorf("invalid vote of agent %s on proposal %d: %w", vote.AgentID, vote.ProposalID, err)
+		}
+	}
+
+	// Validate agent ratings
+	ratings := make(map[string]bool)
+	for _, rating := range gs.AgentRatings {
+		key := fmt.Sprintf("%s/%s/%s", rating.AgentID, rating.Subject, rating.SubjectID)
+		if ratings[key] {
+			return fmt.Errorf("duplicate rating of %s %s of agent %s", rating.Subject, rating.SubjectID, rating.AgentID)
+		}
+		ratings[key] = true
+
+		if !agentIDs[rating.AgentID] {
+			return fmt.Errorf("rating references non-existent agent: %s", rating.AgentID)
+		}
+		if err := rating.Validate(); err != nil {
+			return fmt.Errorf("invalid rating of %s %s of agent %s: %w", rating.Subject, rating.SubjectID, rating.AgentID, err)
+		}
+	}
+
+	// Validate reputations
+	reputations := make(map[string]bool)
+	for _, reputation := range gs.AgentReputations {
+		if reputations[reputation.Subject] {
+			return fmt.Errorf("duplicate reputation of agent: %s", reputation.Subject)
+		}
+		reputations[reputation.Subject] = true
+
+		if !agentIDs[reputation.Subject] {
+			return fmt.Errorf("reputation references non-existent agent: %s", reputation.Subject)
+		}
+	}
+	creatorReputations := make(map[string]bool)
+	for _, reputation := range gs.CreatorReputations {
+		if creatorReputations[reputation.Subject] {
+			return fmt.Errorf("duplicate reputation of creator: %s", reputation.Subject)
+		}
+		creatorReputations[reputation.Subject] = true
+
+		if _, err := sdk.AccAddressFromBech32(reputation.Subject); err != nil {
+			return fmt.Errorf("invalid creator address %s: %w", reputation.Subject, err)
+		}
+	}
+
+	// Validate fractionalized agents and their shareholders
+	fractionalized := make(map[string]bool)
+	for _, shares := range gs.AgentShares {
+		if fractionalized[shares.AgentID] {
+			return fmt.Errorf("duplicate shares of agent: %s", shares.AgentID)
+		}
+		fractionalized[shares.AgentID] = true
+
+		if !agentIDs[shares.AgentID] {
+			return fmt.Errorf("shares reference non-existent agent: %s", shares.AgentID)
+		}
+		if err := shares.Validate(); err != nil {
+			return fmt.Errorf("invalid shares of agent %s: %w", shares.AgentID, err)
+		}
+	}
+	shareholders := make(map[string]bool)
+	for _, holder := range gs.AgentShareholders {
+		key := fmt.Sprintf("%s/%s", holder.AgentID, holder.Holder)
+		if shareholders[key] {
+			return fmt.Errorf("duplicate shareholder %s of agent %s", holder.Holder, holder.AgentID)
+		}
+		shareholders[key] = true
+
+		if !fractionalized[holder.AgentID] {
+			return fmt.Errorf("shareholder of agent %s that is not fractionalized", holder.AgentID)
+		}
+		if holder.Holder.Empty() || holder.Shares.IsNil() || holder.Shares.IsNegative() {
+			return fmt.Errorf("invalid shareholder %s of agent %s", holder.Holder, holder.AgentID)
+		}
+	}
+
+	// Validate subscription plans and subscriptions
+	planAgents := make(map[string]string)
+	for _, plan := range gs.SubscriptionPlans {
+		if _, found := planAgents[plan.ID]; found {
+			return fmt.Errorf("duplicate subscription plan ID: %s", plan.ID)
+		}
+		planAgents[plan.ID] = plan.AgentID
+
+		if !agentIDs[plan.AgentID] {
+			return fmt.Errorf("subscription plan %s references non-existent agent: %s", plan.ID, plan.AgentID)
+		}
+		if err := plan.Validate(); err != nil {
+			return fmt.Errorf("invalid subscription plan %s: %w", plan.ID, err)
+		}
+	}
+	subscriptions := make(map[string]bool)
+	for _, subscription := range gs.AgentSubscriptions {
+		key := fmt.Sprintf("%s/%s", subscription.Subscriber, subscription.PlanID)
+		if subscriptions[key] {
+			return fmt.Errorf("duplicate subscription of %s to plan %s", subscription.Subscriber, subscription.PlanID)
+		}
+		subscriptions[key] = true
+
+		if agentID, found := planAgents[subscription.PlanID]; !found || agentID != subscription.AgentID {
+			return fmt.Errorf("subscription references non-existent plan %s of agent %s", subscription.PlanID, subscription.AgentID)
+		}
+		if err := subscription.Validate(); err != nil {
+			return fmt.Errorf("invalid subscription of %s to plan %s: %w", subscription.Subscriber, subscription.PlanID, err)
+		}
+	}
+
+	// Validate registered encryption keys
+	keyAddresses := make(map[string]bool)
+	for _, key := range gs.EncryptionKeys {
+		if key.Address.Empty() {
+			return fmt.Errorf("encryption key without an address")
+		}
+		if keyAddresses[key.Address.String()] {
+			return fmt.Errorf("duplicate encryption key of %s", key.Address)
+		}
+		keyAddresses[key.Address.String()] = true
+
+		if err := ValidateEncryptionKey(key.PublicKey); err != nil {
+			return fmt.Errorf("invalid encryption key of %s: %w", key.Address, err)
+		}
 	}
 
 	// Validate states
@@ -46,6 +301,9 @@ func (gs GenesisState) Validate() error {
 		if !agentIDs[state.AgentID] {
 			return fmt.Errorf("state references non-existent agent: %s", state.AgentID)
 		}
+		if err := state.ValidateStorage(); err != nil {
+			return fmt.Errorf("invalid state of agent %s: %w", state.AgentID, err)
+		}
 	}
 
 	// Validate actions
@@ -56,7 +314,11 @@ func (gs GenesisState) Validate() error {
 		}
 		actionIDs[action.ID] = true
 
-		if !agentIDs[action.AgentID] {
+		if action.PipelineID != "" {
+			if !pipelineIDs[action.PipelineID] {
+				return fmt.Errorf("action references non-existent pipeline: %s", action.PipelineID)
+			}
+		} else if !agentIDs[action.AgentID] {
 			return fmt.Errorf("action references non-existent agent: %s", action.AgentID)
 		}
 	}
@@ -72,6 +334,9 @@ func (gs GenesisState) Validate() error {
 		if !agentIDs[data.AgentID] {
 			return fmt.Errorf("training data references non-existent agent: %s", data.AgentID)
 		}
+		if err := data.ValidateStorage(); err != nil {
+			return fmt.Errorf("invalid training data %s: %w", data.ID, err)
+		}
 	}
 
 	// Validate marketplace listings
@@ -87,6 +352,96 @@ func (gs GenesisState) Validate() error {
 		}
 	}
 
+	// Validate rentals
+	rentalIDs := make(map[string]bool)
+	for _, rental := range gs.Rentals {
+		if rentalIDs[rental.ID] {
+			return fmt.Errorf("duplicate rental ID: %s", rental.ID)
+		}
+		rentalIDs[rental.ID] = true
+
+		if !agentIDs[rental.AgentID] {
+			return fmt.Errorf("rental references non-existent agent: %s", rental.AgentID)
+		}
+		if rental.Renter.Empty() {
+			return fmt.Errorf("rental %s has an empty renter", rental.ID)
+		}
+		if !rental.EndTime.After(rental.StartTime) {
+			return fmt.Errorf("rental %s must end after it starts", rental.ID)
+		}
+	}
+
+	// Validate executors
+	executorAddrs := make(map[string]bool)
+	for _, executor := range gs.Executors {
+		if executor.Address.Empty() {
+			return fmt.Errorf("executor with empty address")
+		}
+		if executorAddrs[executor.Address.String()] {
+			return fmt.Errorf("duplicate executor: %s", executor.Address)
+		}
+		executorAddrs[executor.Address.String()] = true
+
+		if !executor.Bond.IsValid() {
+			return fmt.Errorf("executor %s has an invalid bond: %s", executor.Address, executor.Bond)
+		}
+	}
+
+	// Validate execution requests
+	requestIDs := make(map[string]bool)
+	for _, request := range gs.ExecutionRequests {
+		if requestIDs[request.ID] {
+			return fmt.Errorf("duplicate execution request ID: %s", request.ID)
+		}
+		requestIDs[request.ID] = true
+
+		if !agentIDs[request.AgentID] {
+			return fmt.Errorf("execution request references non-existent agent: %s", request.AgentID)
+		}
+		if request.RevealDeadline < request.CommitDeadline {
+			return fmt.Errorf("execution request %s reveal deadline precedes its commit deadline", request.ID)
+		}
+	}
+
+	// Validate training jobs
+	jobIDs := make(map[string]bool)
+	for _, job := range gs.TrainingJobs {
+		if jobIDs[job.ID] {
+			return fmt.Errorf("duplicate training job ID: %s", job.ID)
+		}
+		jobIDs[job.ID] = true
+
+		if !agentIDs[job.AgentID] {
+			return fmt.Errorf("training job references non-existent agent: %s", job.AgentID)
+		}
+		if !job.Budget.IsValid() {
+			return fmt.Errorf("training job %s has an invalid budget: %s", job.ID, job.Budget)
+		}
+		if (job.Status == TrainingJobStatusClaimed || job.Status == TrainingJobStatusSubmitted) && job.Trainer.Empty() {
+			return fmt.Errorf("training job %s has no trainer", job.ID)
+		}
+	}
+
+	// Validate state history
+	stateVersions := make(map[string]bool)
+	for _, state := range gs.StateHistory {
+		key := fmt.Sprintf("%s/%d", state.AgentID, state.Version)
+		if stateVersions[key] {
+			return fmt.Errorf("duplicate version %d of agent state %s", state.Version, state.AgentID)
+		}
+		stateVersions[key] = true
+
+		if !agentIDs[state.AgentID] {
+			return fmt.Errorf("state history references non-existent agent: %s", state.AgentID)
+		}
+		if state.Version == 0 {
+			return fmt.Errorf("state history of agent %s has a version 0", state.AgentID)
+		}
+		if err := state.ValidateStorage(); err != nil {
+			return fmt.Errorf("invalid version %d of agent state %s: %w", state.Version, state.AgentID, err)
+		}
+	}
+
 	return gs.Params.Validate()
 }
 
@@ -98,5 +453,25 @@ type GenesisState struct {
 	Actions             []AIAgentAction             `json:"actions"`
 	TrainingData        []AIAgentTrainingData       `json:"training_data"`
 	MarketplaceListings []AIAgentMarketplaceListing `json:"marketplace_listings"`
+	Rentals             []AIAgentRental             `json:"rentals"`
+	Executors           []Executor                  `json:"executors"`
+	ExecutionRequests   []ExecutionRequest          `json:"execution_requests"`
+	TrainingJobs        []TrainingJob               `json:"training_jobs"`
+	StateHistory        []AIAgentState              `json:"state_history"`
+	Pipelines           []Pipeline                  `json:"pipelines"`
+	WalletPolicies      []AgentWalletPolicy         `json:"wallet_policies"`
+	Schedules           []AgentSchedule             `json:"schedules"`
+	Pricings            []AgentPricing              `json:"pricings"`
+	GovDelegations      []GovDelegation             `json:"gov_delegations"`
+	AgentGovVotes       []AgentGovVote              `json:"agent_gov_votes"`
+	AgentRatings        []AgentRating               `json:"agent_ratings"`
+	AgentReputations    []AgentReputation           `json:"agent_reputations"`
+	CreatorReputations  []AgentReputation           `json:"creator_reputations"`
+	AgentShares         []AgentShares               `json:"agent_shares"`
+	AgentShareholders   []AgentShareholder          `json:"agent_shareholders"`
+	SubscriptionPlans   []SubscriptionPlan          `json:"subscription_plans"`
+	AgentSubscriptions  []AgentSubscription         `json:"agent_subscriptions"`
+	EncryptionKeys      []AccountEncryptionKey      `json:"encryption_keys"`
+	NextSequence        uint64                      `json:"next_sequence"`
 	Params              Params                      `json:"params"`
 }
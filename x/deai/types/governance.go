@@ -0,0 +1,154 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+)
+
+// AIAgentActionTypeGovVote is the action type of the decision a governance agent makes
+// on a proposal on behalf of its delegators
+const AIAgentActionTypeGovVote = "gov_vote"
+
+// Agent governance vote status constants
+const (
+	AgentGovVoteStatusPending = "pending" // the agent's decision is being computed by executors
+	AgentGovVoteStatusCast    = "cast"    // the decision was cast for the delegators
+	AgentGovVoteStatusFailed  = "failed"  // the agent made no valid decision; delegators vote themselves
+)
+
+// Vote options a governance agent can decide on
+var agentVoteOptions = map[string]govv1.VoteOption{
+	"yes":          govv1.OptionYes,
+	"no":           govv1.OptionNo,
+	"abstain":      govv1.OptionAbstain,
+	"no_with_veto": govv1.OptionNoWithVeto,
+}
+
+// GovDelegation delegates the governance voting power of an account to a governance
+// agent. An account delegates to at most one agent at a time.
+type GovDelegation struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	AgentID   string         `json:"agent_id"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// AgentGovProposal is the input of a governance agent's decision on a proposal
+type AgentGovProposal struct {
+	ProposalID    uint64    `json:"proposal_id"`
+	Title         string    `json:"title"`
+	Summary       string    `json:"summary"`
+	Metadata      string    `json:"metadata,omitempty"`
+	Proposer      string    `json:"proposer"`
+	Messages      []string  `json:"messages,omitempty"` // type URLs of the proposal messages
+	VotingEndTime time.Time `json:"voting_end_time"`
+}
+
+// AgentGovDecision is the result of a governance agent's decision on a proposal: either
+// a single option or weighted options whose weights sum to 1
+type AgentGovDecision struct {
+	Option  string            `json:"option,omitempty"`
+	Options []AgentVoteOption `json:"options,omitempty"`
+}
+
+// AgentVoteOption is a weighted option of a governance agent's vote
+type AgentVoteOption struct {
+	Option string  `json:"option"` // yes, no, abstain or no_with_veto
+	Weight sdk.Dec `json:"weight"`
+}
+
+// AgentGovVote records a governance agent's vote on a proposal and the delegators it
+// was cast for. Delegators that voted themselves keep their own vote.
+type AgentGovVote struct {
+	AgentID    string            `json:"agent_id"`
+	ProposalID uint64            `json:"proposal_id"`
+	ActionID   string            `json:"action_id,omitempty"` // the action that computed the decision
+	Status     string            `json:"status"`
+	Options    []AgentVoteOption `json:"options,omitempty"`
+	Delegators uint64            `json:"delegators"` // delegators the vote was cast for
+	Error      string            `json:"error,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// ParseAgentGovDecision parses the result of a governance agent's decision into the
+// options of its vote
+func ParseAgentGovDecision(result json.RawMessage) ([]AgentVoteOption, error) {
+	var decision AgentGovDecision
+	if err := json.Unmarshal(result, &decision); err != nil {
+		return nil, fmt.Errorf("invalid decision: %w", err)
+	}
+
+	options := decision.Options
+	if decision.Option != "" {
+		if len(options) > 0 {
+			return nil, fmt.Errorf("decision has both an option and weighted options")
+		}
+		options = []AgentVoteOption{{Option: decision.Option, Weight: sdk.OneDec()}}
+	}
+	if err := ValidateAgentVoteOptions(options); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// ValidateAgentVoteOptions checks that vote options are known, distinct and weigh 1 in total
+func ValidateAgentVoteOptions(options []AgentVoteOption) error {
+	if len(options) == 0 {
+		return fmt.Errorf("decision has no vote option")
+	}
+
+	seen := make(map[string]bool)
+	total := sdk.ZeroDec()
+	for _, option := range options {
+		if _, ok := agentVoteOptions[option.Option]; !ok {
+			return fmt.Errorf("unknown vote option: %s", option.Option)
+		}
+		if seen[option.Option] {
+			return fmt.Errorf("duplicate vote option: %s", option.Option)
+		}
+		seen[option.Option] = true
+
+		if option.Weight.IsNil() || !option.Weight.IsPositive() {
+			return fmt.Errorf("weight of vote option %s must be positive", option.Option)
+		}
+		total = total.Add(option.Weight)
+	}
+	if !total.Equal(sdk.OneDec()) {
+		return fmt.Errorf("weights of the vote options sum to %s, not 1", total)
+	}
+	return nil
+}
+
+// GovVoteOptions converts validated agent vote options to the options of an x/gov vote
+func GovVoteOptions(options []AgentVoteOption) govv1.WeightedVoteOptions {
+	weighted := make(govv1.WeightedVoteOptions, 0, len(options))
+	for _, option := range options {
+		weighted = append(weighted, govv1.NewWeightedVoteOption(agentVoteOptions[option.Option], option.Weight))
+	}
+	return weighted
+}
+
+// Validate performs basic validation of a governance agent's vote record
+func (v AgentGovVote) Validate() error {
+	if v.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	switch v.Status {
+	case AgentGovVoteStatusPending:
+		if v.ActionID == "" {
+			return fmt.Errorf("pending vote has no action")
+		}
+	case AgentGovVoteStatusCast:
+		if err := ValidateAgentVoteOptions(v.Options); err != nil {
+			return err
+		}
+	case AgentGovVoteStatusFailed:
+	default:
+		return fmt.Errorf("invalid vote status: %s", v.Status)
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAgentGovDecision(t *testing.T) {
+	options, err := ParseAgentGovDecision(json.RawMessage(`{"option": "no_with_veto"}`))
+	require.NoError(t, err)
+	require.Equal(t, []AgentVoteOption{{Option: "no_with_veto", Weight: sdk.OneDec()}}, options)
+
+	options, err = ParseAgentGovDecision(json.RawMessage(`{"options": [{"option": "yes", "weight": "0.7"}, {"option": "abstain", "weight": "0.3"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, govv1.WeightedVoteOptions{
+		govv1.NewWeightedVoteOption(govv1.OptionYes, sdk.MustNewDecFromStr("0.7")),
+		govv1.NewWeightedVoteOption(govv1.OptionAbstain, sdk.MustNewDecFromStr("0.3")),
+	}, GovVoteOptions(options))
+
+	tests := []struct {
+		name   string
+		result string
+	}{
+		{"not JSON", `yes`},
+		{"no option", `{}`},
+		{"unknown option", `{"option": "maybe"}`},
+		{"option and weighted options", `{"option": "yes", "options": [{"option": "yes", "weight": "1"}]}`},
+		{"duplicate option", `{"options": [{"option": "yes", "weight": "0.5"}, {"option": "yes", "weight": "0.5"}]}`},
+		{"zero weight", `{"options": [{"option": "yes", "weight": "1"}, {"option": "no", "weight": "0"}]}`},
+		{"missing weight", `{"options": [{"option": "yes"}]}`},
+		{"weights below 1", `{"options": [{"option": "yes", "weight": "0.5"}, {"option": "no", "weight": "0.4"}]}`},
+		{"weights above 1", `{"options": [{"option": "yes", "weight": "0.7"}, {"option": "no", "weight": "0.4"}]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseAgentGovDecision(json.RawMessage(tc.result))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAgentGovVoteValidate(t *testing.T) {
+	yes := []AgentVoteOption{{Option: "yes", Weight: sdk.OneDec()}}
+
+	require.NoError(t, AgentGovVote{AgentID: "agent", Status: AgentGovVoteStatusPending, ActionID: "action"}.Validate())
+	require.NoError(t, AgentGovVote{AgentID: "agent", Status: AgentGovVoteStatusCast, Options: yes}.Validate())
+	require.NoError(t, AgentGovVote{AgentID: "agent", Status: AgentGovVoteStatusFailed}.Validate())
+
+	require.Error(t, AgentGovVote{Status: AgentGovVoteStatusFailed}.Validate())
+	require.Error(t, AgentGovVote{AgentID: "agent", Status: AgentGovVoteStatusPending}.Validate())
+	require.Error(t, AgentGovVote{AgentID: "agent", Status: AgentGovVoteStatusCast}.Validate())
+	require.Error(t, AgentGovVote{AgentID: "agent", Status: "withdrawn"}.Validate())
+}
@@ -2,9 +2,12 @@ package types
 
 import (
 	context "context"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/cosmos-sdk/x/nft"
 )
 
 // MsgServer defines the MsgServer interface for the deai module
@@ -17,6 +20,41 @@ type MsgServer interface {
 	BuyAIAgent(context.Context, *MsgBuyAIAgent) (*MsgBuyAIAgentResponse, error)
 	RentAIAgent(context.Context, *MsgRentAIAgent) (*MsgRentAIAgentResponse, error)
 	CancelMarketListing(context.Context, *MsgCancelMarketListing) (*MsgCancelMarketListingResponse, error)
+	PlaceBid(context.Context, *MsgPlaceBid) (*MsgPlaceBidResponse, error)
+	RegisterExecutor(context.Context, *MsgRegisterExecutor) (*MsgRegisterExecutorResponse, error)
+	UnregisterExecutor(context.Context, *MsgUnregisterExecutor) (*MsgUnregisterExecutorResponse, error)
+	CommitExecutionResult(context.Context, *MsgCommitExecutionResult) (*MsgCommitExecutionResultResponse, error)
+	RevealExecutionResult(context.Context, *MsgRevealExecutionResult) (*MsgRevealExecutionResultResponse, error)
+	ClaimTrainingJob(context.Context, *MsgClaimTrainingJob) (*MsgClaimTrainingJobResponse, error)
+	SubmitTrainingResult(context.Context, *MsgSubmitTrainingResult) (*MsgSubmitTrainingResultResponse, error)
+	ReviewTrainingResult(context.Context, *MsgReviewTrainingResult) (*MsgReviewTrainingResultResponse, error)
+	UpdateAIAgentState(context.Context, *MsgUpdateAIAgentState) (*MsgUpdateAIAgentStateResponse, error)
+	RollbackAgentState(context.Context, *MsgRollbackAgentState) (*MsgRollbackAgentStateResponse, error)
+	CreatePipeline(context.Context, *MsgCreatePipeline) (*MsgCreatePipelineResponse, error)
+	DeletePipeline(context.Context, *MsgDeletePipeline) (*MsgDeletePipelineResponse, error)
+	ExecutePipeline(context.Context, *MsgExecutePipeline) (*MsgExecutePipelineResponse, error)
+	FundAgentWallet(context.Context, *MsgFundAgentWallet) (*MsgFundAgentWalletResponse, error)
+	WithdrawAgentWallet(context.Context, *MsgWithdrawAgentWallet) (*MsgWithdrawAgentWalletResponse, error)
+	SetAgentWalletPolicy(context.Context, *MsgSetAgentWalletPolicy) (*MsgSetAgentWalletPolicyResponse, error)
+	ScheduleAgentAction(context.Context, *MsgScheduleAgentAction) (*MsgScheduleAgentActionResponse, error)
+	CancelAgentSchedule(context.Context, *MsgCancelAgentSchedule) (*MsgCancelAgentScheduleResponse, error)
+	SetAgentPricing(context.Context, *MsgSetAgentPricing) (*MsgSetAgentPricingResponse, error)
+	DelegateGovVotes(context.Context, *MsgDelegateGovVotes) (*MsgDelegateGovVotesResponse, error)
+	UndelegateGovVotes(context.Context, *MsgUndelegateGovVotes) (*MsgUndelegateGovVotesResponse, error)
+	RateAgent(context.Context, *MsgRateAgent) (*MsgRateAgentResponse, error)
+	FractionalizeAgent(context.Context, *MsgFractionalizeAgent) (*MsgFractionalizeAgentResponse, error)
+	TransferAgentShares(context.Context, *MsgTransferAgentShares) (*MsgTransferAgentSharesResponse, error)
+	ClaimAgentIncome(context.Context, *MsgClaimAgentIncome) (*MsgClaimAgentIncomeResponse, error)
+	BuyoutAgent(context.Context, *MsgBuyoutAgent) (*MsgBuyoutAgentResponse, error)
+	CreateSubscriptionPlan(context.Context, *MsgCreateSubscriptionPlan) (*MsgCreateSubscriptionPlanResponse, error)
+	CloseSubscriptionPlan(context.Context, *MsgCloseSubscriptionPlan) (*MsgCloseSubscriptionPlanResponse, error)
+	SubscribeAgent(context.Context, *MsgSubscribeAgent) (*MsgSubscribeAgentResponse, error)
+	FundSubscription(context.Context, *MsgFundSubscription) (*MsgFundSubscriptionResponse, error)
+	CancelSubscription(context.Context, *MsgCancelSubscription) (*MsgCancelSubscriptionResponse, error)
+	SetAgentEncryptionKey(context.Context, *MsgSetAgentEncryptionKey) (*MsgSetAgentEncryptionKeyResponse, error)
+	RegisterEncryptionKey(context.Context, *MsgRegisterEncryptionKey) (*MsgRegisterEncryptionKeyResponse, error)
+	GrantTrainingDataKey(context.Context, *MsgGrantTrainingDataKey) (*MsgGrantTrainingDataKeyResponse, error)
+	SetAgentModel(context.Context, *MsgSetAgentModel) (*MsgSetAgentModelResponse, error)
 }
 
 // QueryServer defines the QueryServer interface for the deai module
@@ -31,11 +69,38 @@ type QueryServer interface {
 	AIAgentTrainingData(context.Context, *QueryAIAgentTrainingDataRequest) (*QueryAIAgentTrainingDataResponse, error)
 	MarketplaceListings(context.Context, *QueryMarketplaceListingsRequest) (*QueryMarketplaceListingsResponse, error)
 	MarketplaceListing(context.Context, *QueryMarketplaceListingRequest) (*QueryMarketplaceListingResponse, error)
+	AIAgentRentals(context.Context, *QueryAIAgentRentalsRequest) (*QueryAIAgentRentalsResponse, error)
+	RenterRentals(context.Context, *QueryRenterRentalsRequest) (*QueryRenterRentalsResponse, error)
+	Executor(context.Context, *QueryExecutorRequest) (*QueryExecutorResponse, error)
+	Executors(context.Context, *QueryExecutorsRequest) (*QueryExecutorsResponse, error)
+	ExecutionRequest(context.Context, *QueryExecutionRequestRequest) (*QueryExecutionRequestResponse, error)
+	PendingExecutionRequests(context.Context, *QueryPendingExecutionRequestsRequest) (*QueryPendingExecutionRequestsResponse, error)
+	TrainingJob(context.Context, *QueryTrainingJobRequest) (*QueryTrainingJobResponse, error)
+	TrainingJobs(context.Context, *QueryTrainingJobsRequest) (*QueryTrainingJobsResponse, error)
+	AIAgentStateHistory(context.Context, *QueryAIAgentStateHistoryRequest) (*QueryAIAgentStateHistoryResponse, error)
+	AIAgentStateAt(context.Context, *QueryAIAgentStateAtRequest) (*QueryAIAgentStateAtResponse, error)
+	Pipeline(context.Context, *QueryPipelineRequest) (*QueryPipelineResponse, error)
+	Pipelines(context.Context, *QueryPipelinesRequest) (*QueryPipelinesResponse, error)
+	AgentWallet(context.Context, *QueryAgentWalletRequest) (*QueryAgentWalletResponse, error)
+	AgentSchedule(context.Context, *QueryAgentScheduleRequest) (*QueryAgentScheduleResponse, error)
+	AgentSchedules(context.Context, *QueryAgentSchedulesRequest) (*QueryAgentSchedulesResponse, error)
+	AgentQuote(context.Context, *QueryAgentQuoteRequest) (*QueryAgentQuoteResponse, error)
+	GovDelegation(context.Context, *QueryGovDelegationRequest) (*QueryGovDelegationResponse, error)
+	AgentGovVotes(context.Context, *QueryAgentGovVotesRequest) (*QueryAgentGovVotesResponse, error)
+	AgentReputation(context.Context, *QueryAgentReputationRequest) (*QueryAgentReputationResponse, error)
+	CreatorReputation(context.Context, *QueryCreatorReputationRequest) (*QueryCreatorReputationResponse, error)
+	AgentRatings(context.Context, *QueryAgentRatingsRequest) (*QueryAgentRatingsResponse, error)
+	AgentShares(context.Context, *QueryAgentSharesRequest) (*QueryAgentSharesResponse, error)
+	AgentShareholder(context.Context, *QueryAgentShareholderRequest) (*QueryAgentShareholderResponse, error)
+	SubscriptionPlans(context.Context, *QuerySubscriptionPlansRequest) (*QuerySubscriptionPlansResponse, error)
+	Subscriptions(context.Context, *QuerySubscriptionsRequest) (*QuerySubscriptionsResponse, error)
+	EncryptionKey(context.Context, *QueryEncryptionKeyRequest) (*QueryEncryptionKeyResponse, error)
 }
 
 // AccountKeeper defines the expected account keeper
 type AccountKeeper interface {
 	GetAccount(ctx sdk.Context, addr sdk.AccAddress) types.AccountI
+	GetModuleAddress(moduleName string) sdk.AccAddress
 	// other methods from the interface you are implementing
 }
 
@@ -43,11 +108,37 @@ type AccountKeeper interface {
 type BankKeeper interface {
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
-	SendCoinsFromAccountToAccount(ctx sdk.Context, senderAddr sdk.AccAddress, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
+	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+	GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin
+	GetSupply(ctx sdk.Context, denom string) sdk.Coin
+	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	SetSendEnabled(ctx sdk.Context, denom string, value bool)
 	// other methods from the interface you are implementing
 }
 
+// DistributionKeeper defines the expected distribution keeper
+type DistributionKeeper interface {
+	FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) error
+}
+
 // NFTKeeper defines the expected NFT keeper
 type NFTKeeper interface {
-	// Methods for NFT operations
+	SaveClass(ctx sdk.Context, class nft.Class) error
+	HasClass(ctx sdk.Context, classID string) bool
+	Mint(ctx sdk.Context, token nft.NFT, receiver sdk.AccAddress) error
+	Transfer(ctx sdk.Context, classID string, nftID string, receiver sdk.AccAddress) error
+	HasNFT(ctx sdk.Context, classID, id string) bool
+	GetOwner(ctx sdk.Context, classID string, nftID string) sdk.AccAddress
+	GetNFTsOfClassByOwner(ctx sdk.Context, classID string, owner sdk.AccAddress) []nft.NFT
+}
+
+// GovKeeper defines the expected gov keeper
+type GovKeeper interface {
+	GetProposal(ctx sdk.Context, proposalID uint64) (govv1.Proposal, bool)
+	IterateActiveProposalsQueue(ctx sdk.Context, endTime time.Time, cb func(proposal govv1.Proposal) (stop bool))
+	GetVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress) (govv1.Vote, bool)
+	AddVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, options govv1.WeightedVoteOptions, metadata string) error
 }
\ No newline at end of file
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 // Module name and store keys
 const (
 	ModuleName   = "deai"
@@ -9,6 +15,9 @@ const (
 	MemStoreKey  = "mem_" + ModuleName
 )
 
+// KeySeparator separates variable-length IDs from the rest of a composite store key
+var KeySeparator = []byte("/")
+
 // Key prefixes for store keys
 var (
 	AIAgentKey                    = []byte{0x01} // prefix for AI agents
@@ -22,6 +31,40 @@ var (
 	AIAgentMarketplaceKey         = []byte{0x09} // prefix for AI agent marketplace listings
 	AIAgentMarketplaceByAgentKey  = []byte{0x0A} // prefix for AI agent marketplace listings by agent
 	AIAgentMarketplaceBySellerKey = []byte{0x0B} // prefix for AI agent marketplace listings by seller
+	AIAgentRentalKey              = []byte{0x0C} // prefix for AI agent rentals
+	AIAgentRentalByRenterKey      = []byte{0x0D} // prefix for AI agent rentals by renter
+	AIAgentRentalByAgentKey       = []byte{0x0E} // prefix for AI agent rentals by agent
+	AIAgentRentalExpiryQueueKey   = []byte{0x0F} // prefix for AI agent rentals by end time
+	AIAgentCallCounterKey         = []byte{0x10} // prefix for per-caller AI agent call counters
+	ExecutorKey                   = []byte{0x11} // prefix for executors
+	ExecutionRequestKey           = []byte{0x12} // prefix for execution requests
+	ExecutionRevealQueueKey       = []byte{0x13} // prefix for execution requests by reveal deadline
+	TrainingJobKey                = []byte{0x14} // prefix for training jobs
+	TrainingJobQueueKey           = []byte{0x15} // prefix for pending training jobs by deadline
+	AIAgentStateHistoryKey        = []byte{0x16} // prefix for AI agent state versions
+	PipelineKey                   = []byte{0x17} // prefix for pipelines
+	AgentWalletPolicyKey          = []byte{0x18} // prefix for agent wallet policies
+	AgentScheduleKey              = []byte{0x19} // prefix for agent schedules
+	AgentScheduleTimeQueueKey     = []byte{0x1A} // prefix for time-based agent schedules by next run time
+	AgentScheduleHeightQueueKey   = []byte{0x1B} // prefix for height-based agent schedules by next run height
+	AgentPricingKey               = []byte{0x1C} // prefix for agent price lists
+	AgentCallUsageKey             = []byte{0x1D} // prefix for per-caller usage of priced agents
+	GovDelegationKey              = []byte{0x1E} // prefix for governance delegations by delegator
+	GovDelegationByAgentKey       = []byte{0x1F} // prefix for governance delegations by agent
+	GovDelegateKey                = []byte{0x20} // prefix for the delegator counts of governance agents
+	AgentGovVoteKey               = []byte{0x21} // prefix for governance agent votes
+	AgentGovVoteByActionKey       = []byte{0x22} // prefix for pending governance agent votes by action
+	AgentRatingKey                = []byte{0x23} // prefix for agent ratings
+	AgentReputationKey            = []byte{0x24} // prefix for agent reputations
+	CreatorReputationKey          = []byte{0x25} // prefix for creator reputations
+	AgentSharesKey                = []byte{0x26} // prefix for fractionalized agents
+	AgentShareholderKey           = []byte{0x27} // prefix for the income records of agent shareholders
+	SubscriptionPlanKey           = []byte{0x28} // prefix for agent subscription plans
+	AgentSubscriptionKey          = []byte{0x29} // prefix for agent subscriptions by subscriber
+	AgentSubscriptionQueueKey     = []byte{0x2A} // prefix for agent subscriptions by period end
+	AccountEncryptionKeyKey       = []byte{0x2B} // prefix for the registered encryption keys of accounts
+	AIAgentMarketplaceByStatusKey = []byte{0x2C} // prefix for AI agent marketplace listings by status and expiry
+	NextSequenceKey               = []byte{0x2D} // key for the next number of the module sequence
 )
 
 // GetAIAgentKey returns the store key to retrieve an AI agent by ID
@@ -44,9 +87,15 @@ func GetAIAgentActionKey(id string) []byte {
 	return append(AIAgentActionKey, []byte(id)...)
 }
 
-// GetAIAgentActionByAgentKey returns the store key to retrieve AI agent actions by agent ID
-func GetAIAgentActionByAgentKey(agentID string, actionID string) []byte {
-	return append(append(AIAgentActionByAgentKey, []byte(agentID)...), []byte(actionID)...)
+// GetAIAgentActionByAgentPrefix returns the store prefix for all actions of an agent
+func GetAIAgentActionByAgentPrefix(agentID string) []byte {
+	return append(append(AIAgentActionByAgentKey, []byte(agentID)...), KeySeparator...)
+}
+
+// GetAIAgentActionByAgentKey returns the store key of an AI agent action in the index of
+// its agent, ordered by the time of the action
+func GetAIAgentActionByAgentKey(agentID string, timestamp time.Time, actionID string) []byte {
+	return append(append(GetAIAgentActionByAgentPrefix(agentID), sdk.FormatTimeBytes(timestamp)...), []byte(actionID)...)
 }
 
 // GetAIAgentModelKey returns the store key to retrieve an AI agent model by ID
@@ -78,3 +127,252 @@ func GetAIAgentMarketplaceByAgentKey(agentID string, listingID string) []byte {
 func GetAIAgentMarketplaceBySellerKey(seller []byte, listingID string) []byte {
 	return append(append(AIAgentMarketplaceBySellerKey, seller...), []byte(listingID)...)
 }
+
+// GetAIAgentMarketplaceByStatusPrefix returns the store prefix for all marketplace listings with a status
+func GetAIAgentMarketplaceByStatusPrefix(status string) []byte {
+	return append(append(AIAgentMarketplaceByStatusKey, []byte(status)...), KeySeparator...)
+}
+
+// GetAIAgentMarketplaceByStatusTimePrefix returns the store prefix for the marketplace listings with a
+// status that expire at the given time
+func GetAIAgentMarketplaceByStatusTimePrefix(status string, expiresAt time.Time) []byte {
+	return append(GetAIAgentMarketplaceByStatusPrefix(status), sdk.FormatTimeBytes(expiresAt)...)
+}
+
+// GetAIAgentMarketplaceByStatusKey returns the store key of a marketplace listing in the status index
+func GetAIAgentMarketplaceByStatusKey(status string, expiresAt time.Time, listingID string) []byte {
+	return append(GetAIAgentMarketplaceByStatusTimePrefix(status, expiresAt), []byte(listingID)...)
+}
+
+// GetAIAgentRentalKey returns the store key to retrieve an AI agent rental by ID
+func GetAIAgentRentalKey(id string) []byte {
+	return append(AIAgentRentalKey, []byte(id)...)
+}
+
+// GetAIAgentRentalByRenterPrefix returns the store prefix for all rentals of a renter
+func GetAIAgentRentalByRenterPrefix(renter []byte) []byte {
+	return append(AIAgentRentalByRenterKey, renter...)
+}
+
+// GetAIAgentRentalByRenterKey returns the store key to retrieve AI agent rentals by renter
+func GetAIAgentRentalByRenterKey(renter []byte, rentalID string) []byte {
+	return append(GetAIAgentRentalByRenterPrefix(renter), []byte(rentalID)...)
+}
+
+// GetAIAgentRentalByAgentPrefix returns the store prefix for all rentals of an agent
+func GetAIAgentRentalByAgentPrefix(agentID string) []byte {
+	return append(append(AIAgentRentalByAgentKey, []byte(agentID)...), KeySeparator...)
+}
+
+// GetAIAgentRentalByAgentKey returns the store key to retrieve AI agent rentals by agent ID
+func GetAIAgentRentalByAgentKey(agentID string, rentalID string) []byte {
+	return append(GetAIAgentRentalByAgentPrefix(agentID), []byte(rentalID)...)
+}
+
+// GetAIAgentRentalExpiryQueueTimePrefix returns the expiry queue prefix for rentals ending at the given time
+func GetAIAgentRentalExpiryQueueTimePrefix(endTime time.Time) []byte {
+	return append(AIAgentRentalExpiryQueueKey, sdk.FormatTimeBytes(endTime)...)
+}
+
+// GetAIAgentRentalExpiryQueueKey returns the store key of a rental in the expiry queue
+func GetAIAgentRentalExpiryQueueKey(endTime time.Time, rentalID string) []byte {
+	return append(GetAIAgentRentalExpiryQueueTimePrefix(endTime), []byte(rentalID)...)
+}
+
+// GetAIAgentCallCounterKey returns the store key of the call counter of a caller for an agent and scope
+func GetAIAgentCallCounterKey(agentID string, caller []byte, scope string) []byte {
+	key := append(append(AIAgentCallCounterKey, []byte(agentID)...), KeySeparator...)
+	return append(append(append(key, caller...), KeySeparator...), []byte(scope)...)
+}
+
+// GetExecutorKey returns the store key to retrieve an executor by address
+func GetExecutorKey(addr []byte) []byte {
+	return append(ExecutorKey, addr...)
+}
+
+// GetExecutionRequestKey returns the store key to retrieve an execution request by ID
+func GetExecutionRequestKey(id string) []byte {
+	return append(ExecutionRequestKey, []byte(id)...)
+}
+
+// GetExecutionRevealQueueHeightPrefix returns the reveal queue prefix for requests whose reveal deadline is the given height
+func GetExecutionRevealQueueHeightPrefix(height int64) []byte {
+	return append(ExecutionRevealQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetExecutionRevealQueueKey returns the store key of an execution request in the reveal queue
+func GetExecutionRevealQueueKey(height int64, requestID string) []byte {
+	return append(GetExecutionRevealQueueHeightPrefix(height), []byte(requestID)...)
+}
+
+// GetTrainingJobKey returns the store key to retrieve a training job by ID
+func GetTrainingJobKey(id string) []byte {
+	return append(TrainingJobKey, []byte(id)...)
+}
+
+// GetTrainingJobQueueHeightPrefix returns the timeout queue prefix for training jobs whose deadline is the given height
+func GetTrainingJobQueueHeightPrefix(height int64) []byte {
+	return append(TrainingJobQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetTrainingJobQueueKey returns the store key of a training job in the timeout queue
+func GetTrainingJobQueueKey(height int64, jobID string) []byte {
+	return append(GetTrainingJobQueueHeightPrefix(height), []byte(jobID)...)
+}
+
+// GetAIAgentStateHistoryPrefix returns the store prefix for all state versions of an agent
+func GetAIAgentStateHistoryPrefix(agentID string) []byte {
+	return append(append(AIAgentStateHistoryKey, []byte(agentID)...), KeySeparator...)
+}
+
+// GetAIAgentStateHistoryKey returns the store key of a version of an agent's state
+func GetAIAgentStateHistoryKey(agentID string, version uint64) []byte {
+	return append(GetAIAgentStateHistoryPrefix(agentID), sdk.Uint64ToBigEndian(version)...)
+}
+
+// GetPipelineKey returns the store key to retrieve a pipeline by ID
+func GetPipelineKey(id string) []byte {
+	return append(PipelineKey, []byte(id)...)
+}
+
+// GetAgentWalletPolicyKey returns the store key to retrieve the wallet policy of an agent
+func GetAgentWalletPolicyKey(agentID string) []byte {
+	return append(AgentWalletPolicyKey, []byte(agentID)...)
+}
+
+// GetAgentScheduleKey returns the store key to retrieve an agent schedule by ID
+func GetAgentScheduleKey(id string) []byte {
+	return append(AgentScheduleKey, []byte(id)...)
+}
+
+// GetAgentScheduleTimeQueuePrefix returns the queue prefix for time-based schedules due at the given time
+func GetAgentScheduleTimeQueuePrefix(runTime time.Time) []byte {
+	return append(AgentScheduleTimeQueueKey, sdk.FormatTimeBytes(runTime)...)
+}
+
+// GetAgentScheduleTimeQueueKey returns the store key of a time-based schedule in the queue
+func GetAgentScheduleTimeQueueKey(runTime time.Time, scheduleID string) []byte {
+	return append(GetAgentScheduleTimeQueuePrefix(runTime), []byte(scheduleID)...)
+}
+
+// GetAgentScheduleHeightQueuePrefix returns the queue prefix for height-based schedules due at the given height
+func GetAgentScheduleHeightQueuePrefix(height int64) []byte {
+	return append(AgentScheduleHeightQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetAgentScheduleHeightQueueKey returns the store key of a height-based schedule in the queue
+func GetAgentScheduleHeightQueueKey(height int64, scheduleID string) []byte {
+	return append(GetAgentScheduleHeightQueuePrefix(height), []byte(scheduleID)...)
+}
+
+// GetAgentPricingKey returns the store key to retrieve the price list of an agent
+func GetAgentPricingKey(agentID string) []byte {
+	return append(AgentPricingKey, []byte(agentID)...)
+}
+
+// GetAgentCallUsageKey returns the store key of a caller's usage of a priced agent
+func GetAgentCallUsageKey(agentID string, caller sdk.AccAddress) []byte {
+	key := append(append(AgentCallUsageKey, []byte(agentID)...), KeySeparator...)
+	return append(key, caller...)
+}
+
+// GetGovDelegationKey returns the store key to retrieve the governance delegation of a delegator
+func GetGovDelegationKey(delegator sdk.AccAddress) []byte {
+	return append(GovDelegationKey, delegator...)
+}
+
+// GetGovDelegationByAgentPrefix returns the store prefix for all delegators of a governance agent
+func GetGovDelegationByAgentPrefix(agentID string) []byte {
+	return append(append(GovDelegationByAgentKey, []byte(agentID)...), KeySeparator...)
+}
+
+// GetGovDelegationByAgentKey returns the store key of a delegator in the index of a governance agent
+func GetGovDelegationByAgentKey(agentID string, delegator sdk.AccAddress) []byte {
+	return append(GetGovDelegationByAgentPrefix(agentID), delegator...)
+}
+
+// GetGovDelegateKey returns the store key of the delegator count of a governance agent
+func GetGovDelegateKey(agentID string) []byte {
+	return append(GovDelegateKey, []byte(agentID)...)
+}
+
+// GetAgentGovVotePrefix returns the store prefix for all votes of a governance agent
+func GetAgentGovVotePrefix(agentID string) []byte {
+	return append(append(AgentGovVoteKey, []byte(agentID)...), KeySeparator...)
+}
+
+// GetAgentGovVoteKey returns the store key to retrieve a governance agent's vote on a proposal
+func GetAgentGovVoteKey(agentID string, proposalID uint64) []byte {
+	return append(GetAgentGovVotePrefix(agentID), sdk.Uint64ToBigEndian(proposalID)...)
+}
+
+// GetAgentGovVoteByActionKey returns the store key of a pending governance agent vote by action ID
+func GetAgentGovVoteByActionKey(actionID string) []byte {
+	return append(AgentGovVoteByActionKey, []byte(actionID)...)
+}
+
+// GetAgentRatingPrefix returns the store prefix for all ratings of an agent
+func GetAgentRatingPrefix(agentID string) []byte {
+	return append(append(AgentRatingKey, []byte(agentID)...), KeySeparator...)
+}
+
+// GetAgentRatingKey returns the store key of the rating of an action or rental of an agent
+func GetAgentRatingKey(agentID, subject, subjectID string) []byte {
+	key := append(append(GetAgentRatingPrefix(agentID), []byte(subject)...), KeySeparator...)
+	return append(key, []byte(subjectID)...)
+}
+
+// GetAgentReputationKey returns the store key to retrieve the reputation of an agent
+func GetAgentReputationKey(agentID string) []byte {
+	return append(AgentReputationKey, []byte(agentID)...)
+}
+
+// GetCreatorReputationKey returns the store key to retrieve the reputation of a creator
+func GetCreatorReputationKey(creator sdk.AccAddress) []byte {
+	return append(CreatorReputationKey, creator...)
+}
+
+// GetAgentSharesKey returns the store key to retrieve the shares of a fractionalized agent
+func GetAgentSharesKey(agentID string) []byte {
+	return append(AgentSharesKey, []byte(agentID)...)
+}
+
+// GetAgentShareholderPrefix returns the store prefix for all shareholders of an agent
+func GetAgentShareholderPrefix(agentID string) []byte {
+	return append(append(AgentShareholderKey, []byte(agentID)...), KeySeparator...)
+}
+
+// GetAgentShareholderKey returns the store key of the income record of an agent shareholder
+func GetAgentShareholderKey(agentID string, holder sdk.AccAddress) []byte {
+	return append(GetAgentShareholderPrefix(agentID), holder...)
+}
+
+// GetSubscriptionPlanKey returns the store key to retrieve a subscription plan by ID
+func GetSubscriptionPlanKey(id string) []byte {
+	return append(SubscriptionPlanKey, []byte(id)...)
+}
+
+// GetAgentSubscriptionPrefix returns the store prefix for all subscriptions of a subscriber
+func GetAgentSubscriptionPrefix(subscriber sdk.AccAddress) []byte {
+	return append(append(AgentSubscriptionKey, subscriber...), KeySeparator...)
+}
+
+// GetAgentSubscriptionKey returns the store key of a subscriber's subscription to a plan
+func GetAgentSubscriptionKey(subscriber sdk.AccAddress, planID string) []byte {
+	return append(GetAgentSubscriptionPrefix(subscriber), []byte(planID)...)
+}
+
+// GetAgentSubscriptionQueueTimePrefix returns the queue prefix for subscriptions whose period ends at the given time
+func GetAgentSubscriptionQueueTimePrefix(periodEnd time.Time) []byte {
+	return append(AgentSubscriptionQueueKey, sdk.FormatTimeBytes(periodEnd)...)
+}
+
+// GetAgentSubscriptionQueueKey returns the store key of a subscription in the queue
+func GetAgentSubscriptionQueueKey(periodEnd time.Time, subscriber sdk.AccAddress, planID string) []byte {
+	return append(GetAgentSubscriptionQueueTimePrefix(periodEnd), GetAgentSubscriptionKey(subscriber, planID)...)
+}
+
+// GetAccountEncryptionKeyKey returns the store key to retrieve the encryption key of an account
+func GetAccountEncryptionKeyKey(addr sdk.AccAddress) []byte {
+	return append(AccountEncryptionKeyKey, addr...)
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAIAgentActionByAgentKey(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Actions of an agent are indexed in time order, whatever their IDs
+	earlier := GetAIAgentActionByAgentKey("agent", now, "b")
+	later := GetAIAgentActionByAgentKey("agent", now.Add(time.Nanosecond), "a")
+	require.Equal(t, -1, bytes.Compare(earlier, later))
+
+	// The actions of an agent do not include the actions of agents sharing its ID as a prefix
+	prefix := GetAIAgentActionByAgentPrefix("agent")
+	require.True(t, bytes.HasPrefix(earlier, prefix))
+	require.False(t, bytes.HasPrefix(GetAIAgentActionByAgentKey("agent1", now, "a"), prefix))
+}
+
+func TestAIAgentMarketplaceByStatusKey(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The range the EndBlocker iterates for listings that expired before the block time
+	start := sdk.PrefixEndBytes(GetAIAgentMarketplaceByStatusTimePrefix("active", time.Time{}))
+	end := GetAIAgentMarketplaceByStatusTimePrefix("active", now)
+	inRange := func(key []byte) bool {
+		return bytes.Compare(key, start) >= 0 && bytes.Compare(key, end) < 0
+	}
+
+	require.True(t, inRange(GetAIAgentMarketplaceByStatusKey("active", now.Add(-time.Second), "listing")))
+	require.False(t, inRange(GetAIAgentMarketplaceByStatusKey("active", now, "listing")))
+	require.False(t, inRange(GetAIAgentMarketplaceByStatusKey("active", now.Add(time.Second), "listing")))
+	require.False(t, inRange(GetAIAgentMarketplaceByStatusKey("active", time.Time{}, "listing")))
+	require.False(t, inRange(GetAIAgentMarketplaceByStatusKey("sold", now.Add(-time.Second), "listing")))
+
+	// Listings of a status do not include the listings of statuses sharing it as a prefix
+	prefix := GetAIAgentMarketplaceByStatusPrefix("active")
+	require.False(t, bytes.HasPrefix(GetAIAgentMarketplaceByStatusKey("active2", now, "listing"), prefix))
+}
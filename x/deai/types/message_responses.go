@@ -2,6 +2,9 @@ package types
 
 import (
 	"encoding/json"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // Message response types
@@ -12,12 +15,15 @@ type MsgCreateAIAgentResponse struct {
 type MsgUpdateAIAgentResponse struct {}
 
 type MsgTrainAIAgentResponse struct {
-	TrainingDataID string `json:"training_data_id"`
+	TrainingDataID string `json:"training_data_id,omitempty"` // set if training data was submitted inline
+	JobID          string `json:"job_id"`
 }
 
 type MsgExecuteAIAgentResponse struct {
 	ActionID string          `json:"action_id"`
 	Result   json.RawMessage `json:"result"`
+	Fee      sdk.Coins       `json:"fee"`
+	Refund   sdk.Coins       `json:"refund"` // part of the offered fee that was not collected
 }
 
 type MsgListAIAgentForSaleResponse struct {
@@ -31,6 +37,129 @@ type MsgBuyAIAgentResponse struct {
 type MsgRentAIAgentResponse struct {
 	AgentID  string `json:"agent_id"`
 	Duration uint64 `json:"duration"`
+	RentalID string `json:"rental_id"`
+}
+
+type MsgCancelMarketListingResponse struct {}
+
+type MsgPlaceBidResponse struct {
+	ListingID string `json:"listing_id"`
+	Sold      bool   `json:"sold"`
+}
+
+type MsgRegisterExecutorResponse struct {
+	Bond sdk.Coins `json:"bond"`
+}
+
+type MsgUnregisterExecutorResponse struct {
+	Refunded sdk.Coins `json:"refunded"`
+}
+
+type MsgCommitExecutionResultResponse struct {}
+
+type MsgRevealExecutionResultResponse struct {
+	// Finalized is true if this reveal was the last outstanding one and the request was resolved
+	Finalized bool   `json:"finalized"`
+	Status    string `json:"status"`
+}
+
+type MsgClaimTrainingJobResponse struct {
+	Deadline int64 `json:"deadline"` // last block height to submit the result
+}
+
+type MsgSubmitTrainingResultResponse struct {
+	Deadline int64 `json:"deadline"` // last block height to review the result
+}
+
+type MsgReviewTrainingResultResponse struct {
+	ModelID string `json:"model_id,omitempty"` // the new model version if the result was accepted
+}
+
+type MsgUpdateAIAgentStateResponse struct{}
+
+type MsgRollbackAgentStateResponse struct {
+	ActionID string `json:"action_id"`
+	Version  uint64 `json:"version"` // the new version holding the restored state
+}
+
+type MsgCreatePipelineResponse struct {
+	PipelineID string `json:"pipeline_id"`
+}
+
+type MsgDeletePipelineResponse struct{}
+
+type MsgExecutePipelineResponse struct {
+	ActionID string          `json:"action_id"`
+	Result   json.RawMessage `json:"result"`
+}
+
+type MsgFundAgentWalletResponse struct {
+	Wallet string `json:"wallet"`
+}
+
+type MsgWithdrawAgentWalletResponse struct{}
+
+type MsgSetAgentWalletPolicyResponse struct{}
+
+type MsgScheduleAgentActionResponse struct {
+	ScheduleID string `json:"schedule_id"`
+}
+
+type MsgCancelAgentScheduleResponse struct {
+	Refund sdk.Coins `json:"refund"`
+}
+
+type MsgSetAgentPricingResponse struct{}
+
+type MsgDelegateGovVotesResponse struct{}
+
+type MsgUndelegateGovVotesResponse struct {
+	AgentID string `json:"agent_id"`
+}
+
+type MsgRateAgentResponse struct {
+	Volume sdk.Int `json:"volume"`
 }
 
-type MsgCancelMarketListingResponse struct {}
\ No newline at end of file
+type MsgFractionalizeAgentResponse struct {
+	Denom string `json:"denom"`
+}
+
+type MsgTransferAgentSharesResponse struct{}
+
+type MsgClaimAgentIncomeResponse struct {
+	Amount sdk.Coins `json:"amount"`
+}
+
+type MsgBuyoutAgentResponse struct {
+	Cost sdk.Coins `json:"cost"`
+}
+
+type MsgCreateSubscriptionPlanResponse struct {
+	PlanID string `json:"plan_id"`
+}
+
+type MsgCloseSubscriptionPlanResponse struct{}
+
+type MsgSubscribeAgentResponse struct {
+	PeriodEnd time.Time `json:"period_end"`
+	Balance   sdk.Coins `json:"balance"`
+}
+
+type MsgFundSubscriptionResponse struct {
+	Balance sdk.Coins `json:"balance"`
+}
+
+type MsgCancelSubscriptionResponse struct {
+	Refund sdk.Coins `json:"refund"`
+}
+
+type MsgSetAgentEncryptionKeyResponse struct{}
+
+type MsgRegisterEncryptionKeyResponse struct{}
+
+type MsgGrantTrainingDataKeyResponse struct{}
+
+type MsgSetAgentModelResponse struct {
+	ModelID string `json:"model_id"`
+}
\ No newline at end of file
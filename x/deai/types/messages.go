@@ -1,22 +1,60 @@
 package types
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/deai/inference"
 )
 
 // Message types
 const (
-	TypeMsgCreateAIAgent        = "create_ai_agent"
-	TypeMsgUpdateAIAgent        = "update_ai_agent"
-	TypeMsgTrainAIAgent         = "train_ai_agent"
-	TypeMsgExecuteAIAgent       = "execute_ai_agent"
-	TypeMsgListAIAgentForSale   = "list_ai_agent_for_sale"
-	TypeMsgBuyAIAgent           = "buy_ai_agent"
-	TypeMsgRentAIAgent          = "rent_ai_agent"
-	TypeMsgCancelMarketListing  = "cancel_market_listing"
+	TypeMsgCreateAIAgent          = "create_ai_agent"
+	TypeMsgUpdateAIAgent          = "update_ai_agent"
+	TypeMsgTrainAIAgent           = "train_ai_agent"
+	TypeMsgExecuteAIAgent         = "execute_ai_agent"
+	TypeMsgListAIAgentForSale     = "list_ai_agent_for_sale"
+	TypeMsgBuyAIAgent             = "buy_ai_agent"
+	TypeMsgRentAIAgent            = "rent_ai_agent"
+	TypeMsgCancelMarketListing    = "cancel_market_listing"
+	TypeMsgPlaceBid               = "place_bid"
+	TypeMsgRegisterExecutor       = "register_executor"
+	TypeMsgUnregisterExecutor     = "unregister_executor"
+	TypeMsgCommitExecutionResult  = "commit_execution_result"
+	TypeMsgRevealExecutionResult  = "reveal_execution_result"
+	TypeMsgClaimTrainingJob       = "claim_training_job"
+	TypeMsgSubmitTrainingResult   = "submit_training_result"
+	TypeMsgReviewTrainingResult   = "review_training_result"
+	TypeMsgUpdateAIAgentState     = "update_ai_agent_state"
+	TypeMsgRollbackAgentState     = "rollback_agent_state"
+	TypeMsgCreatePipeline         = "create_pipeline"
+	TypeMsgDeletePipeline         = "delete_pipeline"
+	TypeMsgExecutePipeline        = "execute_pipeline"
+	TypeMsgFundAgentWallet        = "fund_agent_wallet"
+	TypeMsgWithdrawAgentWallet    = "withdraw_agent_wallet"
+	TypeMsgSetAgentWalletPolicy   = "set_agent_wallet_policy"
+	TypeMsgScheduleAgentAction    = "schedule_agent_action"
+	TypeMsgCancelAgentSchedule    = "cancel_agent_schedule"
+	TypeMsgSetAgentPricing        = "set_agent_pricing"
+	TypeMsgDelegateGovVotes       = "delegate_gov_votes"
+	TypeMsgUndelegateGovVotes     = "undelegate_gov_votes"
+	TypeMsgRateAgent              = "rate_agent"
+	TypeMsgFractionalizeAgent     = "fractionalize_agent"
+	TypeMsgTransferAgentShares    = "transfer_agent_shares"
+	TypeMsgClaimAgentIncome       = "claim_agent_income"
+	TypeMsgBuyoutAgent            = "buyout_agent"
+	TypeMsgCreateSubscriptionPlan = "create_subscription_plan"
+	TypeMsgCloseSubscriptionPlan  = "close_subscription_plan"
+	TypeMsgSubscribeAgent         = "subscribe_agent"
+	TypeMsgFundSubscription       = "fund_subscription"
+	TypeMsgCancelSubscription     = "cancel_subscription"
+	TypeMsgSetAgentEncryptionKey  = "set_agent_encryption_key"
+	TypeMsgRegisterEncryptionKey  = "register_encryption_key"
+	TypeMsgGrantTrainingDataKey   = "grant_training_data_key"
+	TypeMsgSetAgentModel          = "set_agent_model"
 )
 
 var (
@@ -28,6 +66,41 @@ var (
 	_ sdk.Msg = &MsgBuyAIAgent{}
 	_ sdk.Msg = &MsgRentAIAgent{}
 	_ sdk.Msg = &MsgCancelMarketListing{}
+	_ sdk.Msg = &MsgPlaceBid{}
+	_ sdk.Msg = &MsgRegisterExecutor{}
+	_ sdk.Msg = &MsgUnregisterExecutor{}
+	_ sdk.Msg = &MsgCommitExecutionResult{}
+	_ sdk.Msg = &MsgRevealExecutionResult{}
+	_ sdk.Msg = &MsgClaimTrainingJob{}
+	_ sdk.Msg = &MsgSubmitTrainingResult{}
+	_ sdk.Msg = &MsgReviewTrainingResult{}
+	_ sdk.Msg = &MsgUpdateAIAgentState{}
+	_ sdk.Msg = &MsgRollbackAgentState{}
+	_ sdk.Msg = &MsgCreatePipeline{}
+	_ sdk.Msg = &MsgDeletePipeline{}
+	_ sdk.Msg = &MsgExecutePipeline{}
+	_ sdk.Msg = &MsgFundAgentWallet{}
+	_ sdk.Msg = &MsgWithdrawAgentWallet{}
+	_ sdk.Msg = &MsgSetAgentWalletPolicy{}
+	_ sdk.Msg = &MsgScheduleAgentAction{}
+	_ sdk.Msg = &MsgCancelAgentSchedule{}
+	_ sdk.Msg = &MsgSetAgentPricing{}
+	_ sdk.Msg = &MsgDelegateGovVotes{}
+	_ sdk.Msg = &MsgUndelegateGovVotes{}
+	_ sdk.Msg = &MsgRateAgent{}
+	_ sdk.Msg = &MsgFractionalizeAgent{}
+	_ sdk.Msg = &MsgTransferAgentShares{}
+	_ sdk.Msg = &MsgClaimAgentIncome{}
+	_ sdk.Msg = &MsgBuyoutAgent{}
+	_ sdk.Msg = &MsgCreateSubscriptionPlan{}
+	_ sdk.Msg = &MsgCloseSubscriptionPlan{}
+	_ sdk.Msg = &MsgSubscribeAgent{}
+	_ sdk.Msg = &MsgFundSubscription{}
+	_ sdk.Msg = &MsgCancelSubscription{}
+	_ sdk.Msg = &MsgSetAgentEncryptionKey{}
+	_ sdk.Msg = &MsgRegisterEncryptionKey{}
+	_ sdk.Msg = &MsgGrantTrainingDataKey{}
+	_ sdk.Msg = &MsgSetAgentModel{}
 )
 
 // MsgCreateAIAgent defines a message to create a new AI agent
@@ -83,6 +156,9 @@ func (msg MsgCreateAIAgent) ValidateBasic() error {
 	if msg.ModelID == "" {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "model ID cannot be empty")
 	}
+	if _, err := ParseAgentPolicy(msg.Permissions); err != nil {
+		return sdkerrors.Wrap(ErrInvalidPermissions, err.Error())
+	}
 	return nil
 }
 
@@ -147,6 +223,9 @@ func (msg MsgUpdateAIAgent) ValidateBasic() error {
 	if msg.Name == "" {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "name cannot be empty")
 	}
+	if _, err := ParseAgentPolicy(msg.Permissions); err != nil {
+		return sdkerrors.Wrap(ErrInvalidPermissions, err.Error())
+	}
 	return nil
 }
 
@@ -161,13 +240,20 @@ func (msg MsgUpdateAIAgent) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
 }
 
-// MsgTrainAIAgent defines a message to train an AI agent
+// MsgTrainAIAgent defines a message to open a training job for an AI agent. Training
+// data can be submitted inline or by a StorageRef to off-chain storage, in which case
+// it is stored and referenced by the job, or referenced through DatasetRefs.
 type MsgTrainAIAgent struct {
-	Owner     sdk.AccAddress  `json:"owner"`
-	AgentID   string          `json:"agent_id"`
-	DataType  string          `json:"data_type"`
-	Data      json.RawMessage `json:"data"`
-	Source    string          `json:"source"`
+	Owner           sdk.AccAddress  `json:"owner"`
+	AgentID         string          `json:"agent_id"`
+	DataType        string          `json:"data_type"`
+	Data            json.RawMessage `json:"data"`
+	Source          string          `json:"source"`
+	DatasetRefs     []string        `json:"dataset_refs"`
+	Hyperparameters json.RawMessage `json:"hyperparameters"`
+	Budget          sdk.Coins       `json:"budget"`
+	StorageRef      *StorageRef     `json:"storage_ref,omitempty"`
+	WrappedKey      []byte          `json:"wrapped_key,omitempty"` // set if the data is encrypted to the agent's encryption key
 }
 
 // NewMsgTrainAIAgent creates a new MsgTrainAIAgent instance
@@ -177,13 +263,21 @@ func NewMsgTrainAIAgent(
 	dataType string,
 	data json.RawMessage,
 	source string,
+	datasetRefs []string,
+	hyperparameters json.RawMessage,
+	budget sdk.Coins,
+	storageRef *StorageRef,
 ) *MsgTrainAIAgent {
 	return &MsgTrainAIAgent{
-		Owner:     owner,
-		AgentID:   agentID,
-		DataType:  dataType,
-		Data:      data,
-		Source:    source,
+		Owner:           owner,
+		AgentID:         agentID,
+		DataType:        dataType,
+		Data:            data,
+		Source:          source,
+		DatasetRefs:     datasetRefs,
+		Hyperparameters: hyperparameters,
+		Budget:          budget,
+		StorageRef:      storageRef,
 	}
 }
 
@@ -205,11 +299,38 @@ func (msg MsgTrainAIAgent) ValidateBasic() error {
 	if msg.AgentID == "" {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
 	}
-	if msg.DataType == "" {
+	if len(msg.Data) == 0 && msg.StorageRef == nil && len(msg.DatasetRefs) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "training data or dataset references are required")
+	}
+	if (len(msg.Data) > 0 || msg.StorageRef != nil) && msg.DataType == "" {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "data type cannot be empty")
 	}
-	if len(msg.Data) == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "data cannot be empty")
+	if msg.StorageRef != nil {
+		if len(msg.Data) > 0 {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "training data cannot be submitted both inline and by reference")
+		}
+		if err := msg.StorageRef.Validate(); err != nil {
+			return sdkerrors.Wrap(ErrInvalidStorageRef, err.Error())
+		}
+	}
+	if len(msg.WrappedKey) > 0 {
+		if len(msg.Data) == 0 && msg.StorageRef == nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "a wrapped key requires training data")
+		}
+		if err := ValidateWrappedKey(msg.WrappedKey); err != nil {
+			return sdkerrors.Wrap(ErrInvalidEncryption, err.Error())
+		}
+	}
+	for _, ref := range msg.DatasetRefs {
+		if ref == "" {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "dataset reference cannot be empty")
+		}
+	}
+	if len(msg.Hyperparameters) > 0 && !json.Valid(msg.Hyperparameters) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "hyperparameters must be valid JSON")
+	}
+	if !msg.Budget.IsValid() || msg.Budget.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "training budget must be positive")
 	}
 	return nil
 }
@@ -272,8 +393,8 @@ func (msg MsgExecuteAIAgent) ValidateBasic() error {
 	if msg.ActionType == "" {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "action type cannot be empty")
 	}
-	if msg.Fee.IsZero() || !msg.Fee.IsValid() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "fee must be valid and non-zero")
+	if !msg.Fee.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "fee must be valid")
 	}
 	return nil
 }
@@ -296,8 +417,9 @@ type MsgListAIAgentForSale struct {
 	Price           sdk.Coins      `json:"price"`
 	RentalPrice     sdk.Coins      `json:"rental_price,omitempty"`
 	RentalDuration  uint64         `json:"rental_duration,omitempty"`
-	ListingType     string         `json:"listing_type"` // "sale", "rent", "both"
+	ListingType     string         `json:"listing_type"` // "sale", "rent", "both", "english_auction", "dutch_auction"
 	ExpirationDays  uint64         `json:"expiration_days"`
+	ReservePrice    sdk.Coins      `json:"reserve_price,omitempty"`
 }
 
 // NewMsgListAIAgentForSale creates a new MsgListAIAgentForSale instance
@@ -309,6 +431,7 @@ func NewMsgListAIAgentForSale(
 	rentalDuration uint64,
 	listingType string,
 	expirationDays uint64,
+	reservePrice sdk.Coins,
 ) *MsgListAIAgentForSale {
 	return &MsgListAIAgentForSale{
 		Seller:          seller,
@@ -318,6 +441,7 @@ func NewMsgListAIAgentForSale(
 		RentalDuration:  rentalDuration,
 		ListingType:     listingType,
 		ExpirationDays:  expirationDays,
+		ReservePrice:    reservePrice,
 	}
 }
 
@@ -339,8 +463,14 @@ func (msg MsgListAIAgentForSale) ValidateBasic() error {
 	if msg.AgentID == "" {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
 	}
-	if msg.ListingType != "sale" && msg.ListingType != "rent" && msg.ListingType != "both" {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "listing type must be 'sale', 'rent', or 'both'")
+	switch msg.ListingType {
+	case ListingTypeSale, ListingTypeRent, ListingTypeBoth:
+	case ListingTypeEnglishAuction, ListingTypeDutchAuction:
+		if err := validateAuctionPrices(msg.ListingType, msg.Price, msg.ReservePrice); err != nil {
+			return err
+		}
+	default:
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "listing type must be 'sale', 'rent', 'both', 'english_auction' or 'dutch_auction'")
 	}
 	if (msg.ListingType == "sale" || msg.ListingType == "both") && !msg.Price.IsValid() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "price must be valid for sale listings")
@@ -365,6 +495,23 @@ func (msg MsgListAIAgentForSale) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Seller}
 }
 
+// validateAuctionPrices checks that an auction is priced in a single denomination
+func validateAuctionPrices(listingType string, price sdk.Coins, reservePrice sdk.Coins) error {
+	if len(price) != 1 || !price.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "auctions require a starting price in a single denomination")
+	}
+	if reservePrice.Empty() {
+		return nil
+	}
+	if len(reservePrice) != 1 || !reservePrice.IsValid() || reservePrice[0].Denom != price[0].Denom {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "reserve price must be in the starting price denomination")
+	}
+	if listingType == ListingTypeDutchAuction && reservePrice.IsAllGTE(price) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "dutch auction reserve price must be below the starting price")
+	}
+	return nil
+}
+
 // MsgBuyAIAgent defines a message to buy an AI agent
 type MsgBuyAIAgent struct {
 	Buyer     sdk.AccAddress `json:"buyer"`
@@ -516,4 +663,1937 @@ func (msg MsgCancelMarketListing) GetSignBytes() []byte {
 // GetSigners returns the signers
 func (msg MsgCancelMarketListing) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgPlaceBid defines a message to bid on an auction listing
+type MsgPlaceBid struct {
+	Bidder    sdk.AccAddress `json:"bidder"`
+	ListingID string         `json:"listing_id"`
+	Amount    sdk.Coins      `json:"amount"`
+}
+
+// NewMsgPlaceBid creates a new MsgPlaceBid instance
+func NewMsgPlaceBid(
+	bidder sdk.AccAddress,
+	listingID string,
+	amount sdk.Coins,
+) *MsgPlaceBid {
+	return &MsgPlaceBid{
+		Bidder:    bidder,
+		ListingID: listingID,
+		Amount:    amount,
+	}
+}
+
+// Route returns the message route
+func (msg MsgPlaceBid) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgPlaceBid) Type() string {
+	return TypeMsgPlaceBid
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgPlaceBid) ValidateBasic() error {
+	if msg.Bidder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "bidder address cannot be empty")
+	}
+	if msg.ListingID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "listing ID cannot be empty")
+	}
+	if len(msg.Amount) != 1 || !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "bid must be a positive amount in a single denomination")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgPlaceBid) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgPlaceBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgRegisterExecutor defines a message to register as an executor, or to top up the
+// bond of an existing executor
+type MsgRegisterExecutor struct {
+	Executor sdk.AccAddress `json:"executor"`
+	Bond     sdk.Coins      `json:"bond"`
+}
+
+// NewMsgRegisterExecutor creates a new MsgRegisterExecutor instance
+func NewMsgRegisterExecutor(
+	executor sdk.AccAddress,
+	bond sdk.Coins,
+) *MsgRegisterExecutor {
+	return &MsgRegisterExecutor{
+		Executor: executor,
+		Bond:     bond,
+	}
+}
+
+// Route returns the message route
+func (msg MsgRegisterExecutor) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgRegisterExecutor) Type() string {
+	return TypeMsgRegisterExecutor
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgRegisterExecutor) ValidateBasic() error {
+	if msg.Executor.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "executor address cannot be empty")
+	}
+	if !msg.Bond.IsValid() || msg.Bond.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "bond must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgRegisterExecutor) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgRegisterExecutor) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Executor}
+}
+
+// MsgUnregisterExecutor defines a message to unregister an executor and withdraw its bond
+type MsgUnregisterExecutor struct {
+	Executor sdk.AccAddress `json:"executor"`
+}
+
+// NewMsgUnregisterExecutor creates a new MsgUnregisterExecutor instance
+func NewMsgUnregisterExecutor(executor sdk.AccAddress) *MsgUnregisterExecutor {
+	return &MsgUnregisterExecutor{
+		Executor: executor,
+	}
+}
+
+// Route returns the message route
+func (msg MsgUnregisterExecutor) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgUnregisterExecutor) Type() string {
+	return TypeMsgUnregisterExecutor
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgUnregisterExecutor) ValidateBasic() error {
+	if msg.Executor.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "executor address cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgUnregisterExecutor) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgUnregisterExecutor) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Executor}
+}
+
+// MsgCommitExecutionResult defines a message to commit to the result of an execution request
+type MsgCommitExecutionResult struct {
+	Executor   sdk.AccAddress `json:"executor"`
+	RequestID  string         `json:"request_id"`
+	Commitment []byte         `json:"commitment"`
+}
+
+// NewMsgCommitExecutionResult creates a new MsgCommitExecutionResult instance
+func NewMsgCommitExecutionResult(
+	executor sdk.AccAddress,
+	requestID string,
+	commitment []byte,
+) *MsgCommitExecutionResult {
+	return &MsgCommitExecutionResult{
+		Executor:   executor,
+		RequestID:  requestID,
+		Commitment: commitment,
+	}
+}
+
+// Route returns the message route
+func (msg MsgCommitExecutionResult) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgCommitExecutionResult) Type() string {
+	return TypeMsgCommitExecutionResult
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgCommitExecutionResult) ValidateBasic() error {
+	if msg.Executor.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "executor address cannot be empty")
+	}
+	if msg.RequestID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "request ID cannot be empty")
+	}
+	if len(msg.Commitment) != sha256.Size {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "commitment must be %d bytes", sha256.Size)
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgCommitExecutionResult) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgCommitExecutionResult) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Executor}
+}
+
+// MsgRevealExecutionResult defines a message to reveal a previously committed result
+type MsgRevealExecutionResult struct {
+	Executor  sdk.AccAddress  `json:"executor"`
+	RequestID string          `json:"request_id"`
+	Result    json.RawMessage `json:"result"`
+	Salt      []byte          `json:"salt"`
+}
+
+// NewMsgRevealExecutionResult creates a new MsgRevealExecutionResult instance
+func NewMsgRevealExecutionResult(
+	executor sdk.AccAddress,
+	requestID string,
+	result json.RawMessage,
+	salt []byte,
+) *MsgRevealExecutionResult {
+	return &MsgRevealExecutionResult{
+		Executor:  executor,
+		RequestID: requestID,
+		Result:    result,
+		Salt:      salt,
+	}
+}
+
+// Route returns the message route
+func (msg MsgRevealExecutionResult) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgRevealExecutionResult) Type() string {
+	return TypeMsgRevealExecutionResult
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgRevealExecutionResult) ValidateBasic() error {
+	if msg.Executor.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "executor address cannot be empty")
+	}
+	if msg.RequestID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "request ID cannot be empty")
+	}
+	if len(msg.Result) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "result cannot be empty")
+	}
+	if len(msg.Salt) < MinExecutionSaltLength {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "salt must be at least %d bytes", MinExecutionSaltLength)
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgRevealExecutionResult) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgRevealExecutionResult) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Executor}
+}
+
+// MsgClaimTrainingJob defines a message to claim an open training job
+type MsgClaimTrainingJob struct {
+	Trainer sdk.AccAddress `json:"trainer"`
+	JobID   string         `json:"job_id"`
+}
+
+// NewMsgClaimTrainingJob creates a new MsgClaimTrainingJob instance
+func NewMsgClaimTrainingJob(trainer sdk.AccAddress, jobID string) *MsgClaimTrainingJob {
+	return &MsgClaimTrainingJob{
+		Trainer: trainer,
+		JobID:   jobID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgClaimTrainingJob) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgClaimTrainingJob) Type() string {
+	return TypeMsgClaimTrainingJob
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgClaimTrainingJob) ValidateBasic() error {
+	if msg.Trainer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "trainer address cannot be empty")
+	}
+	if msg.JobID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "job ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgClaimTrainingJob) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgClaimTrainingJob) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Trainer}
+}
+
+// MsgSubmitTrainingResult defines a message to submit the model trained for a claimed job
+type MsgSubmitTrainingResult struct {
+	Trainer    sdk.AccAddress  `json:"trainer"`
+	JobID      string          `json:"job_id"`
+	ModelHash  string          `json:"model_hash"`
+	ModelURI   string          `json:"model_uri"`
+	Parameters json.RawMessage `json:"parameters"`
+	Metrics    json.RawMessage `json:"metrics"`
+}
+
+// NewMsgSubmitTrainingResult creates a new MsgSubmitTrainingResult instance
+func NewMsgSubmitTrainingResult(
+	trainer sdk.AccAddress,
+	jobID string,
+	modelHash string,
+	modelURI string,
+	parameters json.RawMessage,
+	metrics json.RawMessage,
+) *MsgSubmitTrainingResult {
+	return &MsgSubmitTrainingResult{
+		Trainer:    trainer,
+		JobID:      jobID,
+		ModelHash:  modelHash,
+		ModelURI:   modelURI,
+		Parameters: parameters,
+		Metrics:    metrics,
+	}
+}
+
+// Route returns the message route
+func (msg MsgSubmitTrainingResult) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgSubmitTrainingResult) Type() string {
+	return TypeMsgSubmitTrainingResult
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgSubmitTrainingResult) ValidateBasic() error {
+	if msg.Trainer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "trainer address cannot be empty")
+	}
+	if msg.JobID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "job ID cannot be empty")
+	}
+	if err := ValidateModelHash(msg.ModelHash); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	if len(msg.Parameters) > 0 && !json.Valid(msg.Parameters) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "parameters must be valid JSON")
+	}
+	if len(msg.Metrics) > 0 && !json.Valid(msg.Metrics) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "metrics must be valid JSON")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgSubmitTrainingResult) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgSubmitTrainingResult) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Trainer}
+}
+
+// MsgReviewTrainingResult defines a message for the agent owner to accept or reject a trained model
+type MsgReviewTrainingResult struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	JobID  string         `json:"job_id"`
+	Accept bool           `json:"accept"`
+	Reason string         `json:"reason"`
+}
+
+// NewMsgReviewTrainingResult creates a new MsgReviewTrainingResult instance
+func NewMsgReviewTrainingResult(owner sdk.AccAddress, jobID string, accept bool, reason string) *MsgReviewTrainingResult {
+	return &MsgReviewTrainingResult{
+		Owner:  owner,
+		JobID:  jobID,
+		Accept: accept,
+		Reason: reason,
+	}
+}
+
+// Route returns the message route
+func (msg MsgReviewTrainingResult) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgReviewTrainingResult) Type() string {
+	return TypeMsgReviewTrainingResult
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgReviewTrainingResult) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.JobID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "job ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgReviewTrainingResult) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgReviewTrainingResult) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgUpdateAIAgentState defines a message for the agent owner to replace the agent's
+// state, either inline or by a StorageRef to off-chain storage
+type MsgUpdateAIAgentState struct {
+	Owner      sdk.AccAddress `json:"owner"`
+	AgentID    string         `json:"agent_id"`
+	StateData  []byte         `json:"state_data"`
+	StorageRef *StorageRef    `json:"storage_ref,omitempty"`
+}
+
+// NewMsgUpdateAIAgentState creates a new MsgUpdateAIAgentState instance
+func NewMsgUpdateAIAgentState(owner sdk.AccAddress, agentID string, stateData []byte, storageRef *StorageRef) *MsgUpdateAIAgentState {
+	return &MsgUpdateAIAgentState{
+		Owner:      owner,
+		AgentID:    agentID,
+		StateData:  stateData,
+		StorageRef: storageRef,
+	}
+}
+
+// Route returns the message route
+func (msg MsgUpdateAIAgentState) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgUpdateAIAgentState) Type() string {
+	return TypeMsgUpdateAIAgentState
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgUpdateAIAgentState) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if len(msg.StateData) == 0 && msg.StorageRef == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "state data or a storage reference is required")
+	}
+	if msg.StorageRef != nil {
+		if len(msg.StateData) > 0 {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "state cannot be submitted both inline and by reference")
+		}
+		if err := msg.StorageRef.Validate(); err != nil {
+			return sdkerrors.Wrap(ErrInvalidStorageRef, err.Error())
+		}
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgUpdateAIAgentState) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgUpdateAIAgentState) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgRollbackAgentState defines a message for the agent owner to restore a previous
+// version of the agent's state
+type MsgRollbackAgentState struct {
+	Owner   sdk.AccAddress `json:"owner"`
+	AgentID string         `json:"agent_id"`
+	Version uint64         `json:"version"`
+}
+
+// NewMsgRollbackAgentState creates a new MsgRollbackAgentState instance
+func NewMsgRollbackAgentState(owner sdk.AccAddress, agentID string, version uint64) *MsgRollbackAgentState {
+	return &MsgRollbackAgentState{
+		Owner:   owner,
+		AgentID: agentID,
+		Version: version,
+	}
+}
+
+// Route returns the message route
+func (msg MsgRollbackAgentState) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgRollbackAgentState) Type() string {
+	return TypeMsgRollbackAgentState
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgRollbackAgentState) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if msg.Version == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "version must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgRollbackAgentState) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgRollbackAgentState) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCreatePipeline defines a message to compose AI agents into a pipeline
+type MsgCreatePipeline struct {
+	Owner       sdk.AccAddress `json:"owner"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Steps       []PipelineStep `json:"steps"`
+	OutputStep  string         `json:"output_step,omitempty"`
+}
+
+// NewMsgCreatePipeline creates a new MsgCreatePipeline instance
+func NewMsgCreatePipeline(owner sdk.AccAddress, name, description string, steps []PipelineStep, outputStep string) *MsgCreatePipeline {
+	return &MsgCreatePipeline{
+		Owner:       owner,
+		Name:        name,
+		Description: description,
+		Steps:       steps,
+		OutputStep:  outputStep,
+	}
+}
+
+// Route returns the message route
+func (msg MsgCreatePipeline) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgCreatePipeline) Type() string {
+	return TypeMsgCreatePipeline
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgCreatePipeline) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	pipeline := Pipeline{
+		Owner:       msg.Owner,
+		Name:        msg.Name,
+		Description: msg.Description,
+		Steps:       msg.Steps,
+		OutputStep:  msg.OutputStep,
+	}
+	if err := pipeline.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidPipeline, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgCreatePipeline) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgCreatePipeline) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgDeletePipeline defines a message for the pipeline owner to delete a pipeline
+type MsgDeletePipeline struct {
+	Owner      sdk.AccAddress `json:"owner"`
+	PipelineID string         `json:"pipeline_id"`
+}
+
+// NewMsgDeletePipeline creates a new MsgDeletePipeline instance
+func NewMsgDeletePipeline(owner sdk.AccAddress, pipelineID string) *MsgDeletePipeline {
+	return &MsgDeletePipeline{
+		Owner:      owner,
+		PipelineID: pipelineID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgDeletePipeline) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgDeletePipeline) Type() string {
+	return TypeMsgDeletePipeline
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgDeletePipeline) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.PipelineID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "pipeline ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgDeletePipeline) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgDeletePipeline) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgExecutePipeline defines a message to run a pipeline on an input. The fees of all
+// agent steps together may not exceed MaxFee.
+type MsgExecutePipeline struct {
+	Sender     sdk.AccAddress  `json:"sender"`
+	PipelineID string          `json:"pipeline_id"`
+	Data       json.RawMessage `json:"data"`
+	MaxFee     sdk.Coins       `json:"max_fee"`
+}
+
+// NewMsgExecutePipeline creates a new MsgExecutePipeline instance
+func NewMsgExecutePipeline(sender sdk.AccAddress, pipelineID string, data json.RawMessage, maxFee sdk.Coins) *MsgExecutePipeline {
+	return &MsgExecutePipeline{
+		Sender:     sender,
+		PipelineID: pipelineID,
+		Data:       data,
+		MaxFee:     maxFee,
+	}
+}
+
+// Route returns the message route
+func (msg MsgExecutePipeline) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgExecutePipeline) Type() string {
+	return TypeMsgExecutePipeline
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgExecutePipeline) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender address cannot be empty")
+	}
+	if msg.PipelineID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "pipeline ID cannot be empty")
+	}
+	if len(msg.Data) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "data cannot be empty")
+	}
+	if !msg.MaxFee.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.MaxFee.String())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgExecutePipeline) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgExecutePipeline) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgFundAgentWallet defines a message to send coins to the wallet of an AI agent
+type MsgFundAgentWallet struct {
+	Sender  sdk.AccAddress `json:"sender"`
+	AgentID string         `json:"agent_id"`
+	Amount  sdk.Coins      `json:"amount"`
+}
+
+// NewMsgFundAgentWallet creates a new MsgFundAgentWallet instance
+func NewMsgFundAgentWallet(sender sdk.AccAddress, agentID string, amount sdk.Coins) *MsgFundAgentWallet {
+	return &MsgFundAgentWallet{
+		Sender:  sender,
+		AgentID: agentID,
+		Amount:  amount,
+	}
+}
+
+// Route returns the message route
+func (msg MsgFundAgentWallet) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgFundAgentWallet) Type() string {
+	return TypeMsgFundAgentWallet
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgFundAgentWallet) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgFundAgentWallet) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgFundAgentWallet) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgWithdrawAgentWallet defines a message for the agent owner to send coins out of the
+// wallet of an AI agent
+type MsgWithdrawAgentWallet struct {
+	Owner     sdk.AccAddress `json:"owner"`
+	AgentID   string         `json:"agent_id"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	Amount    sdk.Coins      `json:"amount"`
+}
+
+// NewMsgWithdrawAgentWallet creates a new MsgWithdrawAgentWallet instance
+func NewMsgWithdrawAgentWallet(owner sdk.AccAddress, agentID string, recipient sdk.AccAddress, amount sdk.Coins) *MsgWithdrawAgentWallet {
+	return &MsgWithdrawAgentWallet{
+		Owner:     owner,
+		AgentID:   agentID,
+		Recipient: recipient,
+		Amount:    amount,
+	}
+}
+
+// Route returns the message route
+func (msg MsgWithdrawAgentWallet) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgWithdrawAgentWallet) Type() string {
+	return TypeMsgWithdrawAgentWallet
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgWithdrawAgentWallet) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.Recipient.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "recipient address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgWithdrawAgentWallet) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgWithdrawAgentWallet) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetAgentWalletPolicy defines a message for the agent owner to set the messages an
+// AI agent may send from its wallet, whose requests may send them and how much it may
+// spend per period
+type MsgSetAgentWalletPolicy struct {
+	Owner             sdk.AccAddress   `json:"owner"`
+	AgentID           string           `json:"agent_id"`
+	SpendLimit        sdk.Coins        `json:"spend_limit"`
+	Period            time.Duration    `json:"period"`
+	AllowedMsgTypes   []string         `json:"allowed_msg_types"`
+	AllowedRequesters []sdk.AccAddress `json:"allowed_requesters"`
+}
+
+// NewMsgSetAgentWalletPolicy creates a new MsgSetAgentWalletPolicy instance
+func NewMsgSetAgentWalletPolicy(owner sdk.AccAddress, agentID string, spendLimit sdk.Coins, period time.Duration, allowedMsgTypes []string, allowedRequesters []sdk.AccAddress) *MsgSetAgentWalletPolicy {
+	return &MsgSetAgentWalletPolicy{
+		Owner:             owner,
+		AgentID:           agentID,
+		SpendLimit:        spendLimit,
+		Period:            period,
+		AllowedMsgTypes:   allowedMsgTypes,
+		AllowedRequesters: allowedRequesters,
+	}
+}
+
+// Route returns the message route
+func (msg MsgSetAgentWalletPolicy) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgSetAgentWalletPolicy) Type() string {
+	return TypeMsgSetAgentWalletPolicy
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgSetAgentWalletPolicy) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if err := msg.Policy().Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	return nil
+}
+
+// Policy returns the wallet policy the message sets
+func (msg MsgSetAgentWalletPolicy) Policy() AgentWalletPolicy {
+	return AgentWalletPolicy{
+		AgentID:           msg.AgentID,
+		SpendLimit:        msg.SpendLimit,
+		Period:            msg.Period,
+		AllowedMsgTypes:   msg.AllowedMsgTypes,
+		AllowedRequesters: msg.AllowedRequesters,
+	}
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgSetAgentWalletPolicy) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgSetAgentWalletPolicy) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgScheduleAgentAction defines a message to run an action of an AI agent at a block
+// time or height, optionally repeating every interval, paid from a prepaid budget
+type MsgScheduleAgentAction struct {
+	Owner          sdk.AccAddress  `json:"owner"`
+	AgentID        string          `json:"agent_id"`
+	ActionType     string          `json:"action_type"`
+	Data           json.RawMessage `json:"data"`
+	FeePerRun      sdk.Coins       `json:"fee_per_run"`
+	Budget         sdk.Coins       `json:"budget"`
+	StartTime      time.Time       `json:"start_time,omitempty"`
+	StartHeight    int64           `json:"start_height,omitempty"`
+	Interval       time.Duration   `json:"interval,omitempty"`
+	IntervalBlocks uint64          `json:"interval_blocks,omitempty"`
+	MaxRuns        uint64          `json:"max_runs,omitempty"`
+}
+
+// NewMsgScheduleAgentAction creates a new MsgScheduleAgentAction instance
+func NewMsgScheduleAgentAction(
+	owner sdk.AccAddress,
+	agentID string,
+	actionType string,
+	data json.RawMessage,
+	feePerRun sdk.Coins,
+	budget sdk.Coins,
+	startTime time.Time,
+	startHeight int64,
+	interval time.Duration,
+	intervalBlocks uint64,
+	maxRuns uint64,
+) *MsgScheduleAgentAction {
+	return &MsgScheduleAgentAction{
+		Owner:          owner,
+		AgentID:        agentID,
+		ActionType:     actionType,
+		Data:           data,
+		FeePerRun:      feePerRun,
+		Budget:         budget,
+		StartTime:      startTime,
+		StartHeight:    startHeight,
+		Interval:       interval,
+		IntervalBlocks: intervalBlocks,
+		MaxRuns:        maxRuns,
+	}
+}
+
+// Route returns the message route
+func (msg MsgScheduleAgentAction) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgScheduleAgentAction) Type() string {
+	return TypeMsgScheduleAgentAction
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgScheduleAgentAction) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if err := msg.Schedule().Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidSchedule, err.Error())
+	}
+	if !msg.FeePerRun.IsAllLTE(msg.Budget) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "budget must cover at least one run")
+	}
+	return nil
+}
+
+// Schedule returns the schedule the message creates
+func (msg MsgScheduleAgentAction) Schedule() AgentSchedule {
+	return AgentSchedule{
+		AgentID:        msg.AgentID,
+		Owner:          msg.Owner,
+		ActionType:     msg.ActionType,
+		Data:           msg.Data,
+		FeePerRun:      msg.FeePerRun,
+		Budget:         msg.Budget,
+		Interval:       msg.Interval,
+		IntervalBlocks: msg.IntervalBlocks,
+		NextRunTime:    msg.StartTime,
+		NextRunHeight:  msg.StartHeight,
+		MaxRuns:        msg.MaxRuns,
+		Status:         AgentScheduleStatusActive,
+	}
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgScheduleAgentAction) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgScheduleAgentAction) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCancelAgentSchedule defines a message to cancel an agent schedule and refund the
+// rest of its budget
+type MsgCancelAgentSchedule struct {
+	Owner      sdk.AccAddress `json:"owner"`
+	ScheduleID string         `json:"schedule_id"`
+}
+
+// NewMsgCancelAgentSchedule creates a new MsgCancelAgentSchedule instance
+func NewMsgCancelAgentSchedule(owner sdk.AccAddress, scheduleID string) *MsgCancelAgentSchedule {
+	return &MsgCancelAgentSchedule{
+		Owner:      owner,
+		ScheduleID: scheduleID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgCancelAgentSchedule) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgCancelAgentSchedule) Type() string {
+	return TypeMsgCancelAgentSchedule
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgCancelAgentSchedule) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.ScheduleID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "schedule ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgCancelAgentSchedule) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgCancelAgentSchedule) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetAgentPricing defines a message for the agent owner to set the price of calls of
+// an AI agent
+type MsgSetAgentPricing struct {
+	Owner           sdk.AccAddress        `json:"owner"`
+	AgentID         string                `json:"agent_id"`
+	Denom           string                `json:"denom"`
+	DefaultPrice    sdk.Int               `json:"default_price"`
+	ActionPrices    []AgentActionPrice    `json:"action_prices"`
+	FreeCalls       uint64                `json:"free_calls"`
+	Period          time.Duration         `json:"period"`
+	VolumeDiscounts []AgentVolumeDiscount `json:"volume_discounts"`
+}
+
+// NewMsgSetAgentPricing creates a new MsgSetAgentPricing instance
+func NewMsgSetAgentPricing(owner sdk.AccAddress, pricing AgentPricing) *MsgSetAgentPricing {
+	return &MsgSetAgentPricing{
+		Owner:           owner,
+		AgentID:         pricing.AgentID,
+		Denom:           pricing.Denom,
+		DefaultPrice:    pricing.DefaultPrice,
+		ActionPrices:    pricing.ActionPrices,
+		FreeCalls:       pricing.FreeCalls,
+		Period:          pricing.Period,
+		VolumeDiscounts: pricing.VolumeDiscounts,
+	}
+}
+
+// Route returns the message route
+func (msg MsgSetAgentPricing) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgSetAgentPricing) Type() string {
+	return TypeMsgSetAgentPricing
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgSetAgentPricing) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if err := msg.Pricing().Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidPricing, err.Error())
+	}
+	return nil
+}
+
+// Pricing returns the price list the message sets
+func (msg MsgSetAgentPricing) Pricing() AgentPricing {
+	return AgentPricing{
+		AgentID:         msg.AgentID,
+		Denom:           msg.Denom,
+		DefaultPrice:    msg.DefaultPrice,
+		ActionPrices:    msg.ActionPrices,
+		FreeCalls:       msg.FreeCalls,
+		Period:          msg.Period,
+		VolumeDiscounts: msg.VolumeDiscounts,
+	}
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgSetAgentPricing) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgSetAgentPricing) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgDelegateGovVotes defines a message to delegate governance voting power to a governance agent
+type MsgDelegateGovVotes struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	AgentID   string         `json:"agent_id"`
+}
+
+// NewMsgDelegateGovVotes creates a new MsgDelegateGovVotes instance
+func NewMsgDelegateGovVotes(delegator sdk.AccAddress, agentID string) *MsgDelegateGovVotes {
+	return &MsgDelegateGovVotes{
+		Delegator: delegator,
+		AgentID:   agentID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgDelegateGovVotes) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgDelegateGovVotes) Type() string {
+	return TypeMsgDelegateGovVotes
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgDelegateGovVotes) ValidateBasic() error {
+	if msg.Delegator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "delegator address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgDelegateGovVotes) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgDelegateGovVotes) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+// MsgUndelegateGovVotes defines a message to end a governance delegation
+type MsgUndelegateGovVotes struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+}
+
+// NewMsgUndelegateGovVotes creates a new MsgUndelegateGovVotes instance
+func NewMsgUndelegateGovVotes(delegator sdk.AccAddress) *MsgUndelegateGovVotes {
+	return &MsgUndelegateGovVotes{
+		Delegator: delegator,
+	}
+}
+
+// Route returns the message route
+func (msg MsgUndelegateGovVotes) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgUndelegateGovVotes) Type() string {
+	return TypeMsgUndelegateGovVotes
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgUndelegateGovVotes) ValidateBasic() error {
+	if msg.Delegator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "delegator address cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgUndelegateGovVotes) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgUndelegateGovVotes) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+// MsgRateAgent defines a message to rate an action or rental of an AI agent
+type MsgRateAgent struct {
+	Rater       sdk.AccAddress `json:"rater"`
+	AgentID     string         `json:"agent_id"`
+	ActionID    string         `json:"action_id,omitempty"`
+	RentalID    string         `json:"rental_id,omitempty"`
+	Score       uint32         `json:"score"`
+	CommentHash string         `json:"comment_hash,omitempty"`
+}
+
+// NewMsgRateAgent creates a new MsgRateAgent instance
+func NewMsgRateAgent(rater sdk.AccAddress, agentID, actionID, rentalID string, score uint32, commentHash string) *MsgRateAgent {
+	return &MsgRateAgent{
+		Rater:       rater,
+		AgentID:     agentID,
+		ActionID:    actionID,
+		RentalID:    rentalID,
+		Score:       score,
+		CommentHash: commentHash,
+	}
+}
+
+// Route returns the message route
+func (msg MsgRateAgent) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgRateAgent) Type() string {
+	return TypeMsgRateAgent
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgRateAgent) ValidateBasic() error {
+	if msg.Rater.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "rater address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if (msg.ActionID == "") == (msg.RentalID == "") {
+		return sdkerrors.Wrap(ErrInvalidRating, "exactly one of action ID and rental ID must be set")
+	}
+	if msg.Score < MinAgentRatingScore || msg.Score > MaxAgentRatingScore {
+		return sdkerrors.Wrapf(ErrInvalidRating, "score must be between %d and %d", MinAgentRatingScore, MaxAgentRatingScore)
+	}
+	if err := ValidateCommentHash(msg.CommentHash); err != nil {
+		return sdkerrors.Wrap(ErrInvalidRating, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgRateAgent) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgRateAgent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Rater}
+}
+
+// Rating returns the agent rating the message submits
+func (msg MsgRateAgent) Rating() AgentRating {
+	rating := AgentRating{
+		AgentID:     msg.AgentID,
+		Rater:       msg.Rater,
+		Subject:     AgentRatingSubjectAction,
+		SubjectID:   msg.ActionID,
+		Score:       msg.Score,
+		CommentHash: msg.CommentHash,
+	}
+	if msg.RentalID != "" {
+		rating.Subject = AgentRatingSubjectRental
+		rating.SubjectID = msg.RentalID
+	}
+	return rating
+}
+
+// MsgFractionalizeAgent defines a message to split the ownership of an AI agent into shares
+type MsgFractionalizeAgent struct {
+	Owner        sdk.AccAddress `json:"owner"`
+	AgentID      string         `json:"agent_id"`
+	Supply       sdk.Int        `json:"supply"`
+	ReservePrice sdk.Coins      `json:"reserve_price"` // buyout price per share
+}
+
+// NewMsgFractionalizeAgent creates a new MsgFractionalizeAgent instance
+func NewMsgFractionalizeAgent(owner sdk.AccAddress, agentID string, supply sdk.Int, reservePrice sdk.Coins) *MsgFractionalizeAgent {
+	return &MsgFractionalizeAgent{
+		Owner:        owner,
+		AgentID:      agentID,
+		Supply:       supply,
+		ReservePrice: reservePrice,
+	}
+}
+
+// Route returns the message route
+func (msg MsgFractionalizeAgent) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgFractionalizeAgent) Type() string {
+	return TypeMsgFractionalizeAgent
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgFractionalizeAgent) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if msg.Supply.IsNil() || !msg.Supply.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "share supply must be positive")
+	}
+	if !msg.ReservePrice.IsValid() || msg.ReservePrice.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "reserve price must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgFractionalizeAgent) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgFractionalizeAgent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgTransferAgentShares defines a message to transfer shares of a fractionalized AI agent
+type MsgTransferAgentShares struct {
+	Sender    sdk.AccAddress `json:"sender"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	AgentID   string         `json:"agent_id"`
+	Amount    sdk.Int        `json:"amount"`
+}
+
+// NewMsgTransferAgentShares creates a new MsgTransferAgentShares instance
+func NewMsgTransferAgentShares(sender, recipient sdk.AccAddress, agentID string, amount sdk.Int) *MsgTransferAgentShares {
+	return &MsgTransferAgentShares{
+		Sender:    sender,
+		Recipient: recipient,
+		AgentID:   agentID,
+		Amount:    amount,
+	}
+}
+
+// Route returns the message route
+func (msg MsgTransferAgentShares) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgTransferAgentShares) Type() string {
+	return TypeMsgTransferAgentShares
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgTransferAgentShares) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender address cannot be empty")
+	}
+	if msg.Recipient.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "recipient address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if msg.Amount.IsNil() || !msg.Amount.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "amount must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgTransferAgentShares) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgTransferAgentShares) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgClaimAgentIncome defines a message to claim the income earned by shares of an AI agent
+type MsgClaimAgentIncome struct {
+	Holder  sdk.AccAddress `json:"holder"`
+	AgentID string         `json:"agent_id"`
+}
+
+// NewMsgClaimAgentIncome creates a new MsgClaimAgentIncome instance
+func NewMsgClaimAgentIncome(holder sdk.AccAddress, agentID string) *MsgClaimAgentIncome {
+	return &MsgClaimAgentIncome{
+		Holder:  holder,
+		AgentID: agentID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgClaimAgentIncome) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgClaimAgentIncome) Type() string {
+	return TypeMsgClaimAgentIncome
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgClaimAgentIncome) ValidateBasic() error {
+	if msg.Holder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "holder address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgClaimAgentIncome) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgClaimAgentIncome) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Holder}
+}
+
+// MsgBuyoutAgent defines a message to redeem full ownership of a fractionalized AI agent
+type MsgBuyoutAgent struct {
+	Buyer   sdk.AccAddress `json:"buyer"`
+	AgentID string         `json:"agent_id"`
+}
+
+// NewMsgBuyoutAgent creates a new MsgBuyoutAgent instance
+func NewMsgBuyoutAgent(buyer sdk.AccAddress, agentID string) *MsgBuyoutAgent {
+	return &MsgBuyoutAgent{
+		Buyer:   buyer,
+		AgentID: agentID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgBuyoutAgent) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgBuyoutAgent) Type() string {
+	return TypeMsgBuyoutAgent
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgBuyoutAgent) ValidateBasic() error {
+	if msg.Buyer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "buyer address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgBuyoutAgent) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgBuyoutAgent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Buyer}
+}
+
+// MsgCreateSubscriptionPlan defines a message to publish a subscription plan for an AI agent
+type MsgCreateSubscriptionPlan struct {
+	Owner       sdk.AccAddress `json:"owner"`
+	AgentID     string         `json:"agent_id"`
+	Name        string         `json:"name"`
+	Period      time.Duration  `json:"period"`
+	Price       sdk.Coin       `json:"price"`                  // paid upfront for every period
+	ActionQuota uint64         `json:"action_quota,omitempty"` // calls per period; 0 is unlimited
+}
+
+// NewMsgCreateSubscriptionPlan creates a new MsgCreateSubscriptionPlan instance
+func NewMsgCreateSubscriptionPlan(owner sdk.AccAddress, agentID, name string, period time.Duration, price sdk.Coin, actionQuota uint64) *MsgCreateSubscriptionPlan {
+	return &MsgCreateSubscriptionPlan{
+		Owner:       owner,
+		AgentID:     agentID,
+		Name:        name,
+		Period:      period,
+		Price:       price,
+		ActionQuota: actionQuota,
+	}
+}
+
+// Route returns the message route
+func (msg MsgCreateSubscriptionPlan) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgCreateSubscriptionPlan) Type() string {
+	return TypeMsgCreateSubscriptionPlan
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgCreateSubscriptionPlan) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if msg.Name == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "plan name cannot be empty")
+	}
+	if msg.Period <= 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "period must be positive")
+	}
+	if !msg.Price.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "invalid price")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgCreateSubscriptionPlan) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgCreateSubscriptionPlan) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCloseSubscriptionPlan defines a message to stop a subscription plan from taking new subscribers
+type MsgCloseSubscriptionPlan struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	PlanID string         `json:"plan_id"`
+}
+
+// NewMsgCloseSubscriptionPlan creates a new MsgCloseSubscriptionPlan instance
+func NewMsgCloseSubscriptionPlan(owner sdk.AccAddress, planID string) *MsgCloseSubscriptionPlan {
+	return &MsgCloseSubscriptionPlan{
+		Owner:  owner,
+		PlanID: planID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgCloseSubscriptionPlan) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgCloseSubscriptionPlan) Type() string {
+	return TypeMsgCloseSubscriptionPlan
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgCloseSubscriptionPlan) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.PlanID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "plan ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgCloseSubscriptionPlan) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgCloseSubscriptionPlan) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSubscribeAgent defines a message to subscribe to a subscription plan of an AI agent
+type MsgSubscribeAgent struct {
+	Subscriber sdk.AccAddress `json:"subscriber"`
+	PlanID     string         `json:"plan_id"`
+	Deposit    sdk.Coins      `json:"deposit"` // pays the first period; the rest is prepaid for renewals
+	AutoRenew  bool           `json:"auto_renew"`
+}
+
+// NewMsgSubscribeAgent creates a new MsgSubscribeAgent instance
+func NewMsgSubscribeAgent(subscriber sdk.AccAddress, planID string, deposit sdk.Coins, autoRenew bool) *MsgSubscribeAgent {
+	return &MsgSubscribeAgent{
+		Subscriber: subscriber,
+		PlanID:     planID,
+		Deposit:    deposit,
+		AutoRenew:  autoRenew,
+	}
+}
+
+// Route returns the message route
+func (msg MsgSubscribeAgent) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgSubscribeAgent) Type() string {
+	return TypeMsgSubscribeAgent
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgSubscribeAgent) ValidateBasic() error {
+	if msg.Subscriber.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "subscriber address cannot be empty")
+	}
+	if msg.PlanID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "plan ID cannot be empty")
+	}
+	if !msg.Deposit.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "invalid deposit")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgSubscribeAgent) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgSubscribeAgent) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Subscriber}
+}
+
+// MsgFundSubscription defines a message to add to the prepaid balance of a subscription
+type MsgFundSubscription struct {
+	Subscriber sdk.AccAddress `json:"subscriber"`
+	PlanID     string         `json:"plan_id"`
+	Amount     sdk.Coins      `json:"amount"`
+}
+
+// NewMsgFundSubscription creates a new MsgFundSubscription instance
+func NewMsgFundSubscription(subscriber sdk.AccAddress, planID string, amount sdk.Coins) *MsgFundSubscription {
+	return &MsgFundSubscription{
+		Subscriber: subscriber,
+		PlanID:     planID,
+		Amount:     amount,
+	}
+}
+
+// Route returns the message route
+func (msg MsgFundSubscription) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgFundSubscription) Type() string {
+	return TypeMsgFundSubscription
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgFundSubscription) ValidateBasic() error {
+	if msg.Subscriber.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "subscriber address cannot be empty")
+	}
+	if msg.PlanID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "plan ID cannot be empty")
+	}
+	if !msg.Amount.IsValid() || msg.Amount.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgFundSubscription) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgFundSubscription) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Subscriber}
+}
+
+// MsgCancelSubscription defines a message to stop the renewal of a subscription and refund its balance
+type MsgCancelSubscription struct {
+	Subscriber sdk.AccAddress `json:"subscriber"`
+	PlanID     string         `json:"plan_id"`
+}
+
+// NewMsgCancelSubscription creates a new MsgCancelSubscription instance
+func NewMsgCancelSubscription(subscriber sdk.AccAddress, planID string) *MsgCancelSubscription {
+	return &MsgCancelSubscription{
+		Subscriber: subscriber,
+		PlanID:     planID,
+	}
+}
+
+// Route returns the message route
+func (msg MsgCancelSubscription) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgCancelSubscription) Type() string {
+	return TypeMsgCancelSubscription
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgCancelSubscription) ValidateBasic() error {
+	if msg.Subscriber.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "subscriber address cannot be empty")
+	}
+	if msg.PlanID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "plan ID cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgCancelSubscription) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgCancelSubscription) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Subscriber}
+}
+
+// MsgSetAgentEncryptionKey defines a message to set the key training data of an AI agent is encrypted to
+type MsgSetAgentEncryptionKey struct {
+	Owner     sdk.AccAddress `json:"owner"`
+	AgentID   string         `json:"agent_id"`
+	PublicKey []byte         `json:"public_key"` // X25519 public key
+}
+
+// NewMsgSetAgentEncryptionKey creates a new MsgSetAgentEncryptionKey instance
+func NewMsgSetAgentEncryptionKey(owner sdk.AccAddress, agentID string, publicKey []byte) *MsgSetAgentEncryptionKey {
+	return &MsgSetAgentEncryptionKey{
+		Owner:     owner,
+		AgentID:   agentID,
+		PublicKey: publicKey,
+	}
+}
+
+// Route returns the message route
+func (msg MsgSetAgentEncryptionKey) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgSetAgentEncryptionKey) Type() string {
+	return TypeMsgSetAgentEncryptionKey
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgSetAgentEncryptionKey) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if err := ValidateEncryptionKey(msg.PublicKey); err != nil {
+		return sdkerrors.Wrap(ErrInvalidEncryption, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgSetAgentEncryptionKey) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgSetAgentEncryptionKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgRegisterEncryptionKey defines a message to register the key data keys are wrapped for when an account is granted access
+type MsgRegisterEncryptionKey struct {
+	Address   sdk.AccAddress `json:"address"`
+	PublicKey []byte         `json:"public_key"` // X25519 public key
+}
+
+// NewMsgRegisterEncryptionKey creates a new MsgRegisterEncryptionKey instance
+func NewMsgRegisterEncryptionKey(address sdk.AccAddress, publicKey []byte) *MsgRegisterEncryptionKey {
+	return &MsgRegisterEncryptionKey{
+		Address:   address,
+		PublicKey: publicKey,
+	}
+}
+
+// Route returns the message route
+func (msg MsgRegisterEncryptionKey) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgRegisterEncryptionKey) Type() string {
+	return TypeMsgRegisterEncryptionKey
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgRegisterEncryptionKey) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "address cannot be empty")
+	}
+	if err := ValidateEncryptionKey(msg.PublicKey); err != nil {
+		return sdkerrors.Wrap(ErrInvalidEncryption, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgRegisterEncryptionKey) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgRegisterEncryptionKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+// MsgGrantTrainingDataKey defines a message to grant a trainer or executor access to encrypted training data
+type MsgGrantTrainingDataKey struct {
+	Owner          sdk.AccAddress `json:"owner"`
+	TrainingDataID string         `json:"training_data_id"`
+	Grantee        sdk.AccAddress `json:"grantee"`
+	WrappedKey     []byte         `json:"wrapped_key"` // the data key wrapped for the grantee's registered key
+}
+
+// NewMsgGrantTrainingDataKey creates a new MsgGrantTrainingDataKey instance
+func NewMsgGrantTrainingDataKey(owner sdk.AccAddress, trainingDataID string, grantee sdk.AccAddress, wrappedKey []byte) *MsgGrantTrainingDataKey {
+	return &MsgGrantTrainingDataKey{
+		Owner:          owner,
+		TrainingDataID: trainingDataID,
+		Grantee:        grantee,
+		WrappedKey:     wrappedKey,
+	}
+}
+
+// Route returns the message route
+func (msg MsgGrantTrainingDataKey) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgGrantTrainingDataKey) Type() string {
+	return TypeMsgGrantTrainingDataKey
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgGrantTrainingDataKey) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.TrainingDataID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "training data ID cannot be empty")
+	}
+	if msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "grantee address cannot be empty")
+	}
+	if err := ValidateWrappedKey(msg.WrappedKey); err != nil {
+		return sdkerrors.Wrap(ErrInvalidEncryption, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgGrantTrainingDataKey) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgGrantTrainingDataKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetAgentModel defines a message for the agent owner to attach a model evaluated
+// on-chain to an AI agent
+type MsgSetAgentModel struct {
+	Owner      sdk.AccAddress  `json:"owner"`
+	AgentID    string          `json:"agent_id"`
+	ModelType  string          `json:"model_type"`
+	Parameters json.RawMessage `json:"parameters"`
+}
+
+// NewMsgSetAgentModel creates a new MsgSetAgentModel instance
+func NewMsgSetAgentModel(owner sdk.AccAddress, agentID string, modelType string, parameters json.RawMessage) *MsgSetAgentModel {
+	return &MsgSetAgentModel{
+		Owner:      owner,
+		AgentID:    agentID,
+		ModelType:  modelType,
+		Parameters: parameters,
+	}
+}
+
+// Route returns the message route
+func (msg MsgSetAgentModel) Route() string {
+	return RouterKey
+}
+
+// Type returns the message type
+func (msg MsgSetAgentModel) Type() string {
+	return TypeMsgSetAgentModel
+}
+
+// ValidateBasic performs basic validation
+func (msg MsgSetAgentModel) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address cannot be empty")
+	}
+	if msg.AgentID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "agent ID cannot be empty")
+	}
+	if _, err := inference.ParseModel(msg.ModelType, msg.Parameters); err != nil {
+		return sdkerrors.Wrap(ErrInvalidOnChainModel, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes returns the bytes to sign
+func (msg MsgSetAgentModel) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the signers
+func (msg MsgSetAgentModel) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
 }
\ No newline at end of file
@@ -45,8 +45,17 @@ type AIAgent struct {
 	UpdatedAt   time.Time       `json:"updated_at"`
 	Permissions json.RawMessage `json:"permissions,omitempty"`
 	Metadata    json.RawMessage `json:"metadata,omitempty"`
+	NFTID       string          `json:"nft_id,omitempty"`
+	Wallet      sdk.AccAddress  `json:"wallet"` // see AgentWalletAddress
+
+	// EncryptionKey is the X25519 public key training data of the agent is encrypted to.
+	// The owner holds the private key and re-wraps data keys for the parties it grants.
+	EncryptionKey []byte `json:"encryption_key,omitempty"`
 }
 
+// AgentNFTClassID is the x/nft class under which AI agents are minted
+const AgentNFTClassID = "deai"
+
 // Validate performs basic validation of the AI agent
 func (a AIAgent) Validate() error {
 	if a.ID == "" {
@@ -64,6 +73,14 @@ func (a AIAgent) Validate() error {
 	if a.ModelID == "" {
 		return fmt.Errorf("model ID cannot be empty")
 	}
+	if len(a.EncryptionKey) > 0 {
+		if err := ValidateEncryptionKey(a.EncryptionKey); err != nil {
+			return err
+		}
+	}
+	if !a.Wallet.Empty() && !a.Wallet.Equals(AgentWalletAddress(a.ID)) {
+		return fmt.Errorf("agent wallet %s is not derived from the agent ID", a.Wallet)
+	}
 
 	// Validate status
 	validStatus := map[string]bool{
@@ -96,10 +113,12 @@ func (a AIAgent) Validate() error {
 
 // AIAgentState defines the state of an AI agent
 type AIAgentState struct {
-	AgentID     string    `json:"agent_id"`
-	StateData   []byte    `json:"state_data"`
-	StorageType string    `json:"storage_type"` // "chain", "ipfs", "arweave", etc.
-	UpdatedAt   time.Time `json:"updated_at"`
+	AgentID     string      `json:"agent_id"`
+	StateData   []byte      `json:"state_data"`
+	StorageType string      `json:"storage_type"`          // "chain", "ipfs", "arweave", etc.
+	StorageRef  *StorageRef `json:"storage_ref,omitempty"` // set when the state is stored off chain
+	UpdatedAt   time.Time   `json:"updated_at"`
+	Version     uint64      `json:"version"` // incremented on every update, starting at 1
 }
 
 // AIAgentAction defines an action performed by an AI agent
@@ -112,6 +131,9 @@ type AIAgentAction struct {
 	Result     json.RawMessage `json:"result,omitempty"`
 	Status     string          `json:"status"` // "pending", "processing", "completed", "failed"
 	GasUsed    uint64          `json:"gas_used"`
+	PipelineID string          `json:"pipeline_id,omitempty"` // set on the aggregated action of a pipeline execution
+	Requester  sdk.AccAddress  `json:"requester,omitempty"`   // the account that called the agent
+	Fee        sdk.Coins       `json:"fee,omitempty"`         // the fee the requester paid
 }
 
 // AIAgentTrainingData defines training data for an AI agent
@@ -122,7 +144,11 @@ type AIAgentTrainingData struct {
 	Data        json.RawMessage `json:"data"`
 	Source      string          `json:"source,omitempty"`
 	Timestamp   time.Time       `json:"timestamp"`
-	StorageType string          `json:"storage_type"` // "chain", "ipfs", "arweave", etc.
+	StorageType string          `json:"storage_type"`          // "chain", "ipfs", "arweave", etc.
+	StorageRef  *StorageRef     `json:"storage_ref,omitempty"` // set when the data is stored off chain
+
+	// Encryption is set when the data is ciphertext, see TrainingDataEncryption
+	Encryption *TrainingDataEncryption `json:"encryption,omitempty"`
 }
 
 // AIAgentModel defines an AI model that can be used by agents
@@ -138,6 +164,10 @@ type AIAgentModel struct {
 	Metadata     json.RawMessage `json:"metadata,omitempty"`
 	CreatedAt    time.Time       `json:"created_at"`
 	UpdatedAt    time.Time       `json:"updated_at"`
+	OnChain      bool            `json:"on_chain,omitempty"` // evaluated inside consensus by x/deai/inference
+	ModelHash    string          `json:"model_hash,omitempty"`
+	ModelURI     string          `json:"model_uri,omitempty"`
+	ParentID     string          `json:"parent_id,omitempty"` // the model this version was trained from
 }
 
 // AIAgentMarketplaceListing defines a marketplace listing for an AI agent
@@ -148,8 +178,118 @@ type AIAgentMarketplaceListing struct {
 	Price          sdk.Coins      `json:"price,omitempty"`
 	RentalPrice    sdk.Coins      `json:"rental_price,omitempty"`
 	RentalDuration uint64         `json:"rental_duration,omitempty"`
-	ListingType    string         `json:"listing_type"` // "sale", "rent", "both"
+	ListingType    string         `json:"listing_type"` // "sale", "rent", "both", "english_auction", "dutch_auction"
 	Status         string         `json:"status"`       // "active", "completed", "cancelled", "expired"
 	CreatedAt      time.Time      `json:"created_at"`
 	ExpiresAt      time.Time      `json:"expires_at"`
+	ReservePrice   sdk.Coins      `json:"reserve_price,omitempty"` // English: minimum winning bid, Dutch: floor price
+	HighestBid     sdk.Coins      `json:"highest_bid,omitempty"`
+	HighestBidder  sdk.AccAddress `json:"highest_bidder,omitempty"`
+	BidCount       uint64         `json:"bid_count,omitempty"`
+}
+
+// Marketplace listing types
+const (
+	ListingTypeSale           = "sale"
+	ListingTypeRent           = "rent"
+	ListingTypeBoth           = "both"
+	ListingTypeEnglishAuction = "english_auction" // ascending bids escrowed in the module account
+	ListingTypeDutchAuction   = "dutch_auction"   // price decays linearly from Price to ReservePrice
+)
+
+// IsAuction returns true if the listing is an English or Dutch auction
+func (l AIAgentMarketplaceListing) IsAuction() bool {
+	return l.ListingType == ListingTypeEnglishAuction || l.ListingType == ListingTypeDutchAuction
+}
+
+// RentalPriceFor returns the price of renting the agent for the given duration, which
+// is the rental price prorated over the listed rental duration. Prorated amounts are
+// rounded up, so a rental never costs less than one unit of each price denom.
+func (l AIAgentMarketplaceListing) RentalPriceFor(duration uint64) sdk.Coins {
+	if duration >= l.RentalDuration {
+		return sdk.NewCoins(l.RentalPrice...)
+	}
+
+	price := make(sdk.Coins, 0, len(l.RentalPrice))
+	for _, coin := range l.RentalPrice {
+		amount := coin.Amount.Mul(sdk.NewIntFromUint64(duration))
+		listed := sdk.NewIntFromUint64(l.RentalDuration)
+		prorated := amount.Quo(listed)
+		if !amount.Mod(listed).IsZero() {
+			prorated = prorated.AddRaw(1)
+		}
+		price = append(price, sdk.NewCoin(coin.Denom, prorated))
+	}
+	return sdk.NewCoins(price...)
+}
+
+// DutchAuctionPrice returns the asking price of a Dutch auction at the given time.
+// The price decays linearly from Price at creation to ReservePrice at expiry.
+func (l AIAgentMarketplaceListing) DutchAuctionPrice(blockTime time.Time) sdk.Coins {
+	if len(l.Price) == 0 || !blockTime.After(l.CreatedAt) {
+		return l.Price
+	}
+
+	start := l.Price[0]
+	floor := l.ReservePrice.AmountOf(start.Denom)
+	if !blockTime.Before(l.ExpiresAt) {
+		return sdk.NewCoins(sdk.NewCoin(start.Denom, floor))
+	}
+
+	elapsed := sdk.NewInt(int64(blockTime.Sub(l.CreatedAt)))
+	total := sdk.NewInt(int64(l.ExpiresAt.Sub(l.CreatedAt)))
+	decay := start.Amount.Sub(floor).Mul(elapsed).Quo(total)
+	return sdk.NewCoins(sdk.NewCoin(start.Denom, start.Amount.Sub(decay)))
+}
+
+// MinNextBid returns the lowest bid an English auction accepts. The first bid must
+// meet the starting price, later bids must raise the highest bid by minIncrementRate
+// and by at least one unit.
+func (l AIAgentMarketplaceListing) MinNextBid(minIncrementRate sdk.Dec) sdk.Coins {
+	if l.HighestBid.IsZero() {
+		return l.Price
+	}
+
+	bid := l.HighestBid[0]
+	increment := minIncrementRate.MulInt(bid.Amount).Ceil().TruncateInt()
+	if !increment.IsPositive() {
+		increment = sdk.OneInt()
+	}
+	return sdk.NewCoins(sdk.NewCoin(bid.Denom, bid.Amount.Add(increment)))
+}
+
+// MarketplaceSettlement describes how a marketplace payment was split
+type MarketplaceSettlement struct {
+	Total          sdk.Coins      `json:"total"`
+	MarketplaceFee sdk.Coins      `json:"marketplace_fee"`
+	Royalty        sdk.Coins      `json:"royalty"`
+	RoyaltyPayee   sdk.AccAddress `json:"royalty_payee,omitempty"`
+	SellerProceeds sdk.Coins      `json:"seller_proceeds"`
+}
+
+// AI agent rental status constants
+const (
+	AIAgentRentalStatusActive  = "active"
+	AIAgentRentalStatusExpired = "expired"
+)
+
+// RentalDurationUnit is the unit in which marketplace rental durations are expressed
+const RentalDurationUnit = time.Hour
+
+// AIAgentRental defines a time-bound access grant for a renter of an AI agent
+type AIAgentRental struct {
+	ID        string         `json:"id"`
+	AgentID   string         `json:"agent_id"`
+	ListingID string         `json:"listing_id"`
+	Renter    sdk.AccAddress `json:"renter"`
+	Owner     sdk.AccAddress `json:"owner"`
+	PricePaid sdk.Coins      `json:"price_paid"`
+	StartTime time.Time      `json:"start_time"`
+	EndTime   time.Time      `json:"end_time"`
+	Status    string         `json:"status"` // "active", "expired"
+}
+
+// IsActive returns true if the rental grants access at the given block time
+func (r AIAgentRental) IsActive(blockTime time.Time) bool {
+	return r.Status == AIAgentRentalStatusActive && blockTime.Before(r.EndTime)
 }
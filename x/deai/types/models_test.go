@@ -0,0 +1,108 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRentalPriceFor(t *testing.T) {
+	listing := AIAgentMarketplaceListing{
+		RentalPrice:    sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("uatom", 7)),
+		RentalDuration: 30,
+	}
+
+	tests := []struct {
+		name     string
+		duration uint64
+		expected sdk.Coins
+	}{
+		{"listed duration", 30, sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("uatom", 7))},
+		{"longer than listed", 90, sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("uatom", 7))},
+		{"exact proration", 15, sdk.NewCoins(sdk.NewInt64Coin("stake", 50), sdk.NewInt64Coin("uatom", 4))},
+		{"rounded up", 1, sdk.NewCoins(sdk.NewInt64Coin("stake", 4), sdk.NewInt64Coin("uatom", 1))},
+		{"rounded up near listed", 29, sdk.NewCoins(sdk.NewInt64Coin("stake", 97), sdk.NewInt64Coin("uatom", 7))},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, listing.RentalPriceFor(tc.duration))
+		})
+	}
+}
+
+func TestRentalPriceForIsNeverFree(t *testing.T) {
+	listing := AIAgentMarketplaceListing{
+		RentalPrice:    sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+		RentalDuration: 1_000_000,
+	}
+
+	for _, duration := range []uint64{1, 2, 999_999} {
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1)), listing.RentalPriceFor(duration))
+	}
+}
+
+func TestDutchAuctionPrice(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	listing := AIAgentMarketplaceListing{
+		Price:        sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)),
+		ReservePrice: sdk.NewCoins(sdk.NewInt64Coin("stake", 200)),
+		CreatedAt:    createdAt,
+		ExpiresAt:    createdAt.Add(100 * time.Second),
+	}
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		expected int64
+	}{
+		{"before creation", -time.Second, 1000},
+		{"at creation", 0, 1000},
+		{"decay rounds toward the start price", time.Nanosecond, 1000},
+		{"quarter", 25 * time.Second, 800},
+		{"rounded", 33 * time.Second, 736},
+		{"at expiry", 100 * time.Second, 200},
+		{"after expiry", time.Hour, 200},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			price := listing.DutchAuctionPrice(createdAt.Add(tc.elapsed))
+			require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", tc.expected)), price)
+		})
+	}
+
+	// Without a reserve price the price decays to zero
+	listing.ReservePrice = nil
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 500)), listing.DutchAuctionPrice(createdAt.Add(50*time.Second)))
+	require.True(t, listing.DutchAuctionPrice(listing.ExpiresAt).IsZero())
+}
+
+func TestMinNextBid(t *testing.T) {
+	listing := AIAgentMarketplaceListing{Price: sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))}
+	rate := sdk.NewDecWithPrec(5, 2)
+
+	// The first bid must meet the starting price
+	require.Equal(t, listing.Price, listing.MinNextBid(rate))
+
+	tests := []struct {
+		name     string
+		highest  int64
+		rate     sdk.Dec
+		expected int64
+	}{
+		{"increment", 1000, rate, 1050},
+		{"increment rounds up", 1001, rate, 1052},
+		{"at least one unit", 10, rate, 11},
+		{"zero rate", 1000, sdk.ZeroDec(), 1001},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			listing.HighestBid = sdk.NewCoins(sdk.NewInt64Coin("stake", tc.highest))
+			require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", tc.expected)), listing.MinNextBid(tc.rate))
+		})
+	}
+}
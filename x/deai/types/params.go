@@ -9,12 +9,43 @@ import (
 
 // Parameter store keys
 var (
-	KeyMinAgentDeposit        = []byte("MinAgentDeposit")
-	KeyMaxAgentNameLength     = []byte("MaxAgentNameLength")
-	KeyMaxAgentDescLength     = []byte("MaxAgentDescLength")
-	KeyMaxTrainingDataSize    = []byte("MaxTrainingDataSize")
-	KeyMaxMarketplaceListings = []byte("MaxMarketplaceListings")
-	KeyMarketplaceFeeRate     = []byte("MarketplaceFeeRate")
+	KeyMinAgentDeposit         = []byte("MinAgentDeposit")
+	KeyMaxAgentNameLength      = []byte("MaxAgentNameLength")
+	KeyMaxAgentDescLength      = []byte("MaxAgentDescLength")
+	KeyMaxTrainingDataSize     = []byte("MaxTrainingDataSize")
+	KeyMaxMarketplaceListings  = []byte("MaxMarketplaceListings")
+	KeyMarketplaceFeeRate      = []byte("MarketplaceFeeRate")
+	KeyMarketplaceFeeRecipient = []byte("MarketplaceFeeRecipient")
+	KeyCreatorRoyaltyRate      = []byte("CreatorRoyaltyRate")
+	KeyAuctionExtensionSeconds = []byte("AuctionExtensionSeconds")
+	KeyMinBidIncrementRate     = []byte("MinBidIncrementRate")
+	KeyMinExecutorBond         = []byte("MinExecutorBond")
+	KeyExecutionQuorum         = []byte("ExecutionQuorum")
+	KeyExecutionCommitBlocks   = []byte("ExecutionCommitBlocks")
+	KeyExecutionRevealBlocks   = []byte("ExecutionRevealBlocks")
+	KeyExecutorFeeRate         = []byte("ExecutorFeeRate")
+	KeyExecutorSlashRate       = []byte("ExecutorSlashRate")
+	KeyTrainingClaimBlocks     = []byte("TrainingClaimBlocks")
+	KeyTrainingSubmitBlocks    = []byte("TrainingSubmitBlocks")
+	KeyTrainingReviewBlocks    = []byte("TrainingReviewBlocks")
+	KeyMaxInlineStateSize      = []byte("MaxInlineStateSize")
+	KeyStateHistoryRetention   = []byte("StateHistoryRetention")
+	KeyMaxPipelineDepth        = []byte("MaxPipelineDepth")
+	KeyMaxPipelineSteps        = []byte("MaxPipelineSteps")
+	KeyMaxAgentWalletMsgs      = []byte("MaxAgentWalletMsgs")
+	KeyMaxScheduledRuns        = []byte("MaxScheduledRuns")
+	KeyScheduledRunsGasLimit   = []byte("ScheduledRunsGasLimit")
+	KeyGovVoteGasLimit         = []byte("GovVoteGasLimit")
+	KeyRatingPriorMean         = []byte("RatingPriorMean")
+	KeyRatingPriorWeight       = []byte("RatingPriorWeight")
+	KeyRatingHalfLifeSeconds   = []byte("RatingHalfLifeSeconds")
+	KeyBuyoutThreshold         = []byte("BuyoutThreshold")
+)
+
+// Marketplace fee recipients
+const (
+	FeeRecipientCommunityPool = "community_pool"
+	FeeRecipientFeeCollector  = "fee_collector"
 )
 
 // ParamKeyTable returns the parameter key table
@@ -25,12 +56,37 @@ func ParamKeyTable() paramtypes.KeyTable {
 // DefaultParams returns default parameters
 func DefaultParams() Params {
 	return Params{
-		MinAgentDeposit:        sdk.NewCoin("unmx", sdk.NewInt(100000000)), // 100 NMX
-		MaxAgentNameLength:     50,
-		MaxAgentDescLength:     500,
-		MaxTrainingDataSize:    1048576, // 1MB
-		MaxMarketplaceListings: 100,     // Per account
-		MarketplaceFeeRate:     sdk.NewDecWithPrec(25, 3), // 2.5%
+		MinAgentDeposit:         sdk.NewCoin("unmx", sdk.NewInt(100000000)), // 100 NMX
+		MaxAgentNameLength:      50,
+		MaxAgentDescLength:      500,
+		MaxTrainingDataSize:     1048576,                   // 1MB
+		MaxMarketplaceListings:  100,                       // Per account
+		MarketplaceFeeRate:      sdk.NewDecWithPrec(25, 3), // 2.5%
+		MarketplaceFeeRecipient: FeeRecipientCommunityPool,
+		CreatorRoyaltyRate:      sdk.NewDecWithPrec(5, 2),                    // 5%
+		AuctionExtensionSeconds: 600,                                         // 10 minutes
+		MinBidIncrementRate:     sdk.NewDecWithPrec(5, 2),                    // 5%
+		MinExecutorBond:         sdk.NewCoin("unmx", sdk.NewInt(1000000000)), // 1000 NMX
+		ExecutionQuorum:         2,
+		ExecutionCommitBlocks:   20,
+		ExecutionRevealBlocks:   20,
+		ExecutorFeeRate:         sdk.NewDecWithPrec(3, 1), // 30%
+		ExecutorSlashRate:       sdk.NewDecWithPrec(5, 2), // 5%
+		TrainingClaimBlocks:     1000,
+		TrainingSubmitBlocks:    10000,
+		TrainingReviewBlocks:    10000,
+		MaxInlineStateSize:      65536, // 64KB
+		StateHistoryRetention:   10,
+		MaxPipelineDepth:        3,
+		MaxPipelineSteps:        16,
+		MaxAgentWalletMsgs:      4,
+		MaxScheduledRuns:        50,
+		ScheduledRunsGasLimit:   10000000,
+		GovVoteGasLimit:         5000000,
+		RatingPriorMean:         sdk.NewDec(3),
+		RatingPriorWeight:       sdk.NewDec(5),
+		RatingHalfLifeSeconds:   7776000,                    // 90 days
+		BuyoutThreshold:         sdk.NewDecWithPrec(667, 3), // 66.7%
 	}
 }
 
@@ -43,6 +99,31 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(KeyMaxTrainingDataSize, &p.MaxTrainingDataSize, validateUint64),
 		paramtypes.NewParamSetPair(KeyMaxMarketplaceListings, &p.MaxMarketplaceListings, validateUint64),
 		paramtypes.NewParamSetPair(KeyMarketplaceFeeRate, &p.MarketplaceFeeRate, validateMarketplaceFeeRate),
+		paramtypes.NewParamSetPair(KeyMarketplaceFeeRecipient, &p.MarketplaceFeeRecipient, validateMarketplaceFeeRecipient),
+		paramtypes.NewParamSetPair(KeyCreatorRoyaltyRate, &p.CreatorRoyaltyRate, validateCreatorRoyaltyRate),
+		paramtypes.NewParamSetPair(KeyAuctionExtensionSeconds, &p.AuctionExtensionSeconds, validateUint64),
+		paramtypes.NewParamSetPair(KeyMinBidIncrementRate, &p.MinBidIncrementRate, validateMinBidIncrementRate),
+		paramtypes.NewParamSetPair(KeyMinExecutorBond, &p.MinExecutorBond, validateMinExecutorBond),
+		paramtypes.NewParamSetPair(KeyExecutionQuorum, &p.ExecutionQuorum, validateUint64),
+		paramtypes.NewParamSetPair(KeyExecutionCommitBlocks, &p.ExecutionCommitBlocks, validateUint64),
+		paramtypes.NewParamSetPair(KeyExecutionRevealBlocks, &p.ExecutionRevealBlocks, validateUint64),
+		paramtypes.NewParamSetPair(KeyExecutorFeeRate, &p.ExecutorFeeRate, validateExecutorFeeRate),
+		paramtypes.NewParamSetPair(KeyExecutorSlashRate, &p.ExecutorSlashRate, validateExecutorSlashRate),
+		paramtypes.NewParamSetPair(KeyTrainingClaimBlocks, &p.TrainingClaimBlocks, validateUint64),
+		paramtypes.NewParamSetPair(KeyTrainingSubmitBlocks, &p.TrainingSubmitBlocks, validateUint64),
+		paramtypes.NewParamSetPair(KeyTrainingReviewBlocks, &p.TrainingReviewBlocks, validateUint64),
+		paramtypes.NewParamSetPair(KeyMaxInlineStateSize, &p.MaxInlineStateSize, validateUint64),
+		paramtypes.NewParamSetPair(KeyStateHistoryRetention, &p.StateHistoryRetention, validateUint64),
+		paramtypes.NewParamSetPair(KeyMaxPipelineDepth, &p.MaxPipelineDepth, validateUint64),
+		paramtypes.NewParamSetPair(KeyMaxPipelineSteps, &p.MaxPipelineSteps, validateUint64),
+		paramtypes.NewParamSetPair(KeyMaxAgentWalletMsgs, &p.MaxAgentWalletMsgs, validateUint64),
+		paramtypes.NewParamSetPair(KeyMaxScheduledRuns, &p.MaxScheduledRuns, validateUint64),
+		paramtypes.NewParamSetPair(KeyScheduledRunsGasLimit, &p.ScheduledRunsGasLimit, validateUint64),
+		paramtypes.NewParamSetPair(KeyGovVoteGasLimit, &p.GovVoteGasLimit, validateUint64),
+		paramtypes.NewParamSetPair(KeyRatingPriorMean, &p.RatingPriorMean, validateRatingPriorMean),
+		paramtypes.NewParamSetPair(KeyRatingPriorWeight, &p.RatingPriorWeight, validateRatingPriorWeight),
+		paramtypes.NewParamSetPair(KeyRatingHalfLifeSeconds, &p.RatingHalfLifeSeconds, validateUint64),
+		paramtypes.NewParamSetPair(KeyBuyoutThreshold, &p.BuyoutThreshold, validateBuyoutThreshold),
 	}
 }
 
@@ -66,6 +147,84 @@ func (p Params) Validate() error {
 	if err := validateMarketplaceFeeRate(p.MarketplaceFeeRate); err != nil {
 		return err
 	}
+	if err := validateMarketplaceFeeRecipient(p.MarketplaceFeeRecipient); err != nil {
+		return err
+	}
+	if err := validateCreatorRoyaltyRate(p.CreatorRoyaltyRate); err != nil {
+		return err
+	}
+	if err := validateUint64(p.AuctionExtensionSeconds); err != nil {
+		return err
+	}
+	if err := validateMinBidIncrementRate(p.MinBidIncrementRate); err != nil {
+		return err
+	}
+	if err := validateMinExecutorBond(p.MinExecutorBond); err != nil {
+		return err
+	}
+	if err := validateUint64(p.ExecutionQuorum); err != nil {
+		return err
+	}
+	if err := validateUint64(p.ExecutionCommitBlocks); err != nil {
+		return err
+	}
+	if err := validateUint64(p.ExecutionRevealBlocks); err != nil {
+		return err
+	}
+	if err := validateExecutorFeeRate(p.ExecutorFeeRate); err != nil {
+		return err
+	}
+	if err := validateExecutorSlashRate(p.ExecutorSlashRate); err != nil {
+		return err
+	}
+	if err := validateUint64(p.TrainingClaimBlocks); err != nil {
+		return err
+	}
+	if err := validateUint64(p.TrainingSubmitBlocks); err != nil {
+		return err
+	}
+	if err := validateUint64(p.TrainingReviewBlocks); err != nil {
+		return err
+	}
+	if err := validateUint64(p.MaxInlineStateSize); err != nil {
+		return err
+	}
+	if err := validateUint64(p.StateHistoryRetention); err != nil {
+		return err
+	}
+	if err := validateUint64(p.MaxPipelineDepth); err != nil {
+		return err
+	}
+	if err := validateUint64(p.MaxPipelineSteps); err != nil {
+		return err
+	}
+	if err := validateUint64(p.MaxAgentWalletMsgs); err != nil {
+		return err
+	}
+	if err := validateUint64(p.MaxScheduledRuns); err != nil {
+		return err
+	}
+	if err := validateUint64(p.ScheduledRunsGasLimit); err != nil {
+		return err
+	}
+	if err := validateUint64(p.GovVoteGasLimit); err != nil {
+		return err
+	}
+	if err := validateRatingPriorMean(p.RatingPriorMean); err != nil {
+		return err
+	}
+	if err := validateRatingPriorWeight(p.RatingPriorWeight); err != nil {
+		return err
+	}
+	if err := validateUint64(p.RatingHalfLifeSeconds); err != nil {
+		return err
+	}
+	if err := validateBuyoutThreshold(p.BuyoutThreshold); err != nil {
+		return err
+	}
+	if p.MarketplaceFeeRate.Add(p.CreatorRoyaltyRate).GT(sdk.OneDec()) {
+		return fmt.Errorf("marketplace fee rate and creator royalty rate cannot exceed 1 combined")
+	}
 	return nil
 }
 
@@ -116,12 +275,175 @@ func validateMarketplaceFeeRate(i interface{}) error {
 	return nil
 }
 
+func validateMarketplaceFeeRecipient(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	switch v {
+	case FeeRecipientCommunityPool, FeeRecipientFeeCollector:
+		return nil
+	default:
+		return fmt.Errorf("invalid marketplace fee recipient: %s", v)
+	}
+}
+
+func validateCreatorRoyaltyRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if v.IsNegative() {
+		return fmt.Errorf("creator royalty rate cannot be negative")
+	}
+	
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("creator royalty rate cannot be greater than 1")
+	}
+	
+	return nil
+}
+
+func validateMinBidIncrementRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if v.IsNegative() {
+		return fmt.Errorf("min bid increment rate cannot be negative")
+	}
+	
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("min bid increment rate cannot be greater than 1")
+	}
+	
+	return nil
+}
+
+func validateMinExecutorBond(i interface{}) error {
+	v, ok := i.(sdk.Coin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if !v.IsValid() {
+		return fmt.Errorf("invalid min executor bond: %s", v)
+	}
+	
+	if v.IsZero() {
+		return fmt.Errorf("min executor bond cannot be zero")
+	}
+	
+	return nil
+}
+
+func validateExecutorFeeRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if v.IsNegative() {
+		return fmt.Errorf("executor fee rate cannot be negative")
+	}
+	
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("executor fee rate cannot be greater than 1")
+	}
+	
+	return nil
+}
+
+func validateExecutorSlashRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if v.IsNegative() {
+		return fmt.Errorf("executor slash rate cannot be negative")
+	}
+	
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("executor slash rate cannot be greater than 1")
+	}
+	
+	return nil
+}
+
+func validateRatingPriorMean(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if v.IsNil() || v.LT(sdk.NewDec(MinAgentRatingScore)) || v.GT(sdk.NewDec(MaxAgentRatingScore)) {
+		return fmt.Errorf("rating prior mean must be between %d and %d", MinAgentRatingScore, MaxAgentRatingScore)
+	}
+	
+	return nil
+}
+
+func validateRatingPriorWeight(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("rating prior weight cannot be negative")
+	}
+	
+	return nil
+}
+
+func validateBuyoutThreshold(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	
+	if v.IsNil() || v.LTE(sdk.NewDecWithPrec(5, 1)) || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("buyout threshold must be above 0.5 and at most 1: %s", v)
+	}
+	
+	return nil
+}
+
 // Params defines the parameters for the deai module
 type Params struct {
-	MinAgentDeposit        sdk.Coin `json:"min_agent_deposit"`
-	MaxAgentNameLength     uint64   `json:"max_agent_name_length"`
-	MaxAgentDescLength     uint64   `json:"max_agent_desc_length"`
-	MaxTrainingDataSize    uint64   `json:"max_training_data_size"`
-	MaxMarketplaceListings uint64   `json:"max_marketplace_listings"`
-	MarketplaceFeeRate     sdk.Dec  `json:"marketplace_fee_rate"`
+	MinAgentDeposit         sdk.Coin `json:"min_agent_deposit"`
+	MaxAgentNameLength      uint64   `json:"max_agent_name_length"`
+	MaxAgentDescLength      uint64   `json:"max_agent_desc_length"`
+	MaxTrainingDataSize     uint64   `json:"max_training_data_size"`
+	MaxMarketplaceListings  uint64   `json:"max_marketplace_listings"`
+	MarketplaceFeeRate      sdk.Dec  `json:"marketplace_fee_rate"`
+	MarketplaceFeeRecipient string   `json:"marketplace_fee_recipient"`
+	CreatorRoyaltyRate      sdk.Dec  `json:"creator_royalty_rate"`
+	AuctionExtensionSeconds uint64   `json:"auction_extension_seconds"`
+	MinBidIncrementRate     sdk.Dec  `json:"min_bid_increment_rate"`
+	MinExecutorBond         sdk.Coin `json:"min_executor_bond"`
+	ExecutionQuorum         uint64   `json:"execution_quorum"`
+	ExecutionCommitBlocks   uint64   `json:"execution_commit_blocks"`
+	ExecutionRevealBlocks   uint64   `json:"execution_reveal_blocks"`
+	ExecutorFeeRate         sdk.Dec  `json:"executor_fee_rate"`
+	ExecutorSlashRate       sdk.Dec  `json:"executor_slash_rate"`
+	TrainingClaimBlocks     uint64   `json:"training_claim_blocks"`
+	TrainingSubmitBlocks    uint64   `json:"training_submit_blocks"`
+	TrainingReviewBlocks    uint64   `json:"training_review_blocks"`
+	MaxInlineStateSize      uint64   `json:"max_inline_state_size"`
+	StateHistoryRetention   uint64   `json:"state_history_retention"`
+	MaxPipelineDepth        uint64   `json:"max_pipeline_depth"`
+	MaxPipelineSteps        uint64   `json:"max_pipeline_steps"`
+	MaxAgentWalletMsgs      uint64   `json:"max_agent_wallet_msgs"`
+	MaxScheduledRuns        uint64   `json:"max_scheduled_runs"`
+	ScheduledRunsGasLimit   uint64   `json:"scheduled_runs_gas_limit"`
+	GovVoteGasLimit         uint64   `json:"gov_vote_gas_limit"`
+	RatingPriorMean         sdk.Dec  `json:"rating_prior_mean"`
+	RatingPriorWeight       sdk.Dec  `json:"rating_prior_weight"`
+	RatingHalfLifeSeconds   uint64   `json:"rating_half_life_seconds"`
+	BuyoutThreshold         sdk.Dec  `json:"buyout_threshold"`
 }
\ No newline at end of file
@@ -0,0 +1,235 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AgentPolicyVersion is the current version of the agent permission policy schema
+const AgentPolicyVersion uint32 = 1
+
+// Agent policy visibility constants
+const (
+	AgentVisibilityPrivate   = "private"   // only the owner (and active renters) may call the agent
+	AgentVisibilityPublic    = "public"    // anyone not on the denylist may call the agent
+	AgentVisibilityAllowlist = "allowlist" // only addresses on the allowlist may call the agent
+)
+
+// Action types used when authorizing non-execution calls against an agent. Training
+// is only open to callers other than the owner through an explicit train action rule.
+const (
+	AgentActionTypeTrain        = "train"
+	AgentActionTypeDynaContract = "dynacontract"
+)
+
+// AgentRateLimit limits how many calls a single caller may make within a fixed window
+type AgentRateLimit struct {
+	MaxCalls      uint64 `json:"max_calls"`
+	WindowSeconds uint64 `json:"window_seconds"`
+}
+
+// Window returns the rate limit window as a duration
+func (r AgentRateLimit) Window() time.Duration {
+	return time.Duration(r.WindowSeconds) * time.Second
+}
+
+// Validate performs basic validation of the rate limit
+func (r AgentRateLimit) Validate() error {
+	if r.MaxCalls == 0 {
+		return fmt.Errorf("rate limit max calls must be positive")
+	}
+	if r.WindowSeconds == 0 {
+		return fmt.Errorf("rate limit window must be positive")
+	}
+	return nil
+}
+
+// AgentActionRule restricts calls of a single action type
+type AgentActionRule struct {
+	Disabled  bool            `json:"disabled,omitempty"`
+	Allowlist []string        `json:"allowlist,omitempty"`
+	MaxFee    sdk.Coins       `json:"max_fee,omitempty"`
+	RateLimit *AgentRateLimit `json:"rate_limit,omitempty"`
+}
+
+// IsAllowlisted returns true if the caller is on the action allowlist
+func (r AgentActionRule) IsAllowlisted(caller sdk.AccAddress) bool {
+	return containsAddress(r.Allowlist, caller)
+}
+
+// AgentPolicy is the declarative permission policy stored in AIAgent.Permissions.
+//
+// Example:
+//
+//	{
+//	  "version": 1,
+//	  "visibility": "allowlist",
+//	  "allowlist": ["nmx1..."],
+//	  "denylist": [],
+//	  "actions": {"predict": {"max_fee": [{"denom": "unmx", "amount": "1000"}]}},
+//	  "rate_limit": {"max_calls": 10, "window_seconds": 3600},
+//	  "expires_at": "2027-01-01T00:00:00Z"
+//	}
+type AgentPolicy struct {
+	Version    uint32                     `json:"version"`
+	Visibility string                     `json:"visibility"`
+	Allowlist  []string                   `json:"allowlist,omitempty"`
+	Denylist   []string                   `json:"denylist,omitempty"`
+	Actions    map[string]AgentActionRule `json:"actions,omitempty"`
+	RateLimit  *AgentRateLimit            `json:"rate_limit,omitempty"`
+	MaxFee     sdk.Coins                  `json:"max_fee,omitempty"`
+	ExpiresAt  *time.Time                 `json:"expires_at,omitempty"`
+}
+
+// DefaultAgentPolicy returns the policy applied to agents without explicit permissions
+func DefaultAgentPolicy() AgentPolicy {
+	return AgentPolicy{
+		Version:    AgentPolicyVersion,
+		Visibility: AgentVisibilityPrivate,
+	}
+}
+
+// ParseAgentPolicy decodes and validates the permission policy of an agent.
+// Empty permissions, and a bare "{}", resolve to the default private policy.
+func ParseAgentPolicy(permissions json.RawMessage) (AgentPolicy, error) {
+	if len(permissions) == 0 || string(permissions) == "null" {
+		return DefaultAgentPolicy(), nil
+	}
+
+	var policy AgentPolicy
+	if err := json.Unmarshal(permissions, &policy); err != nil {
+		return AgentPolicy{}, fmt.Errorf("invalid permissions JSON: %w", err)
+	}
+
+	// Legacy unversioned permissions are treated as the default policy
+	if policy.Version == 0 && policy.Visibility == "" {
+		return DefaultAgentPolicy(), nil
+	}
+
+	if err := policy.Validate(); err != nil {
+		return AgentPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// Validate performs basic validation of the policy
+func (p AgentPolicy) Validate() error {
+	if p.Version != AgentPolicyVersion {
+		return fmt.Errorf("unsupported permission policy version: %d", p.Version)
+	}
+
+	switch p.Visibility {
+	case AgentVisibilityPrivate, AgentVisibilityPublic, AgentVisibilityAllowlist:
+	default:
+		return fmt.Errorf("invalid visibility: %s", p.Visibility)
+	}
+
+	if p.Visibility == AgentVisibilityAllowlist && len(p.Allowlist) == 0 {
+		return fmt.Errorf("allowlist visibility requires a non-empty allowlist")
+	}
+
+	if err := validateAddressList("allowlist", p.Allowlist); err != nil {
+		return err
+	}
+	if err := validateAddressList("denylist", p.Denylist); err != nil {
+		return err
+	}
+
+	if p.RateLimit != nil {
+		if err := p.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if p.MaxFee != nil && !p.MaxFee.IsValid() {
+		return fmt.Errorf("invalid max fee: %s", p.MaxFee)
+	}
+
+	for actionType, rule := range p.Actions {
+		if actionType == "" {
+			return fmt.Errorf("action rule with empty action type")
+		}
+		if err := validateAddressList(fmt.Sprintf("%s allowlist", actionType), rule.Allowlist); err != nil {
+			return err
+		}
+		if rule.MaxFee != nil && !rule.MaxFee.IsValid() {
+			return fmt.Errorf("invalid max fee for action %s: %s", actionType, rule.MaxFee)
+		}
+		if rule.RateLimit != nil {
+			if err := rule.RateLimit.Validate(); err != nil {
+				return fmt.Errorf("action %s: %w", actionType, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the policy no longer grants access at the given block time
+func (p AgentPolicy) IsExpired(blockTime time.Time) bool {
+	return p.ExpiresAt != nil && !blockTime.Before(*p.ExpiresAt)
+}
+
+// IsDenied returns true if the caller is on the denylist
+func (p AgentPolicy) IsDenied(caller sdk.AccAddress) bool {
+	return containsAddress(p.Denylist, caller)
+}
+
+// IsVisibleTo returns true if the visibility settings admit the caller
+func (p AgentPolicy) IsVisibleTo(caller sdk.AccAddress) bool {
+	switch p.Visibility {
+	case AgentVisibilityPublic:
+		return true
+	case AgentVisibilityAllowlist:
+		return containsAddress(p.Allowlist, caller)
+	default:
+		return false
+	}
+}
+
+// ActionRule returns the rule for an action type. When the policy declares action
+// rules, action types without a rule are not allowed.
+func (p AgentPolicy) ActionRule(actionType string) (AgentActionRule, bool) {
+	if len(p.Actions) == 0 {
+		return AgentActionRule{}, true
+	}
+	rule, found := p.Actions[actionType]
+	return rule, found
+}
+
+// HasActionRule returns true if the policy declares a rule for the action type
+func (p AgentPolicy) HasActionRule(actionType string) bool {
+	_, found := p.Actions[actionType]
+	return found
+}
+
+// AgentCallCounter tracks the calls of a caller against an agent within the current rate limit window
+type AgentCallCounter struct {
+	AgentID     string         `json:"agent_id"`
+	Caller      sdk.AccAddress `json:"caller"`
+	Scope       string         `json:"scope"` // "*" for the agent-wide limit, otherwise "action/" and the action type
+	WindowStart time.Time      `json:"window_start"`
+	Count       uint64         `json:"count"`
+}
+
+func validateAddressList(name string, addrs []string) error {
+	for _, addr := range addrs {
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return fmt.Errorf("invalid address in %s: %s", name, addr)
+		}
+	}
+	return nil
+}
+
+func containsAddress(addrs []string, addr sdk.AccAddress) bool {
+	for _, a := range addrs {
+		if a == addr.String() {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
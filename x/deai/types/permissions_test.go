@@ -0,0 +1,110 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAgentPolicy(t *testing.T) {
+	caller := sdk.AccAddress([]byte("caller______________"))
+
+	for _, permissions := range []string{"", "null", "{}", `{"foo": 1}`} {
+		policy, err := ParseAgentPolicy(json.RawMessage(permissions))
+		require.NoError(t, err, permissions)
+		require.Equal(t, DefaultAgentPolicy(), policy, permissions)
+	}
+
+	policy, err := ParseAgentPolicy(json.RawMessage(fmt.Sprintf(`{
+		"version": 1,
+		"visibility": "allowlist",
+		"allowlist": [%q],
+		"actions": {"predict": {"max_fee": [{"denom": "stake", "amount": "1000"}], "rate_limit": {"max_calls": 2, "window_seconds": 60}}},
+		"expires_at": "2027-01-01T00:00:00Z"
+	}`, caller.String())))
+	require.NoError(t, err)
+	require.Equal(t, AgentVisibilityAllowlist, policy.Visibility)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)), policy.Actions["predict"].MaxFee)
+	require.Equal(t, time.Minute, policy.Actions["predict"].RateLimit.Window())
+
+	tests := []struct {
+		name        string
+		permissions string
+	}{
+		{"invalid JSON", `{"version":`},
+		{"unsupported version", `{"version": 2, "visibility": "public"}`},
+		{"invalid visibility", `{"version": 1, "visibility": "friends"}`},
+		{"empty allowlist", `{"version": 1, "visibility": "allowlist"}`},
+		{"invalid allowlist address", `{"version": 1, "visibility": "allowlist", "allowlist": ["nobody"]}`},
+		{"invalid denylist address", `{"version": 1, "visibility": "public", "denylist": ["nobody"]}`},
+		{"zero rate limit", `{"version": 1, "visibility": "public", "rate_limit": {"max_calls": 0, "window_seconds": 60}}`},
+		{"empty action type", `{"version": 1, "visibility": "public", "actions": {"": {}}}`},
+		{"invalid action rate limit", `{"version": 1, "visibility": "public", "actions": {"predict": {"rate_limit": {"max_calls": 1}}}}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseAgentPolicy(json.RawMessage(tc.permissions))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAgentPolicyAccess(t *testing.T) {
+	allowed := sdk.AccAddress([]byte("allowed_____________"))
+	denied := sdk.AccAddress([]byte("denied______________"))
+	other := sdk.AccAddress([]byte("other_______________"))
+	expiry := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	policy := AgentPolicy{
+		Version:    AgentPolicyVersion,
+		Visibility: AgentVisibilityAllowlist,
+		Allowlist:  []string{allowed.String()},
+		Denylist:   []string{denied.String()},
+		ExpiresAt:  &expiry,
+	}
+	require.NoError(t, policy.Validate())
+
+	require.True(t, policy.IsVisibleTo(allowed))
+	require.False(t, policy.IsVisibleTo(other))
+	require.True(t, policy.IsDenied(denied))
+	require.False(t, policy.IsDenied(allowed))
+
+	require.False(t, policy.IsExpired(expiry.Add(-time.Second)))
+	require.True(t, policy.IsExpired(expiry))
+
+	policy.Visibility = AgentVisibilityPublic
+	require.True(t, policy.IsVisibleTo(other))
+	policy.Visibility = AgentVisibilityPrivate
+	require.False(t, policy.IsVisibleTo(allowed))
+}
+
+func TestAgentPolicyActionRule(t *testing.T) {
+	// Without action rules every action type is allowed
+	policy := DefaultAgentPolicy()
+	_, found := policy.ActionRule("predict")
+	require.True(t, found)
+
+	// With action rules only the declared action types are allowed
+	policy.Actions = map[string]AgentActionRule{"predict": {Disabled: true}}
+	rule, found := policy.ActionRule("predict")
+	require.True(t, found)
+	require.True(t, rule.Disabled)
+	_, found = policy.ActionRule("classify")
+	require.False(t, found)
+}
+
+func TestAgentPolicyHasActionRule(t *testing.T) {
+	// Training is only open to others by an explicit rule, even though a policy
+	// without action rules allows every action type
+	policy := DefaultAgentPolicy()
+	require.False(t, policy.HasActionRule(AgentActionTypeTrain))
+
+	policy.Actions = map[string]AgentActionRule{AgentActionTypeTrain: {}}
+	require.True(t, policy.HasActionRule(AgentActionTypeTrain))
+	require.False(t, policy.HasActionRule("predict"))
+}
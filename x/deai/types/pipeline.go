@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AIAgentActionTypePipeline is the action type of the aggregated action recorded for a
+// pipeline execution
+const AIAgentActionTypePipeline = "pipeline"
+
+// Pipeline composes AI agents: each step runs an agent, or another pipeline, on the
+// pipeline input or on the results of earlier steps. Steps are listed in execution
+// order and may only take inputs from steps before them, so the steps form a DAG.
+type Pipeline struct {
+	ID          string         `json:"id"`
+	Owner       sdk.AccAddress `json:"owner"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Steps       []PipelineStep `json:"steps"`
+	OutputStep  string         `json:"output_step,omitempty"` // the step whose result is the pipeline result; the last step if empty
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// PipelineStep is a step of a pipeline. It runs either an agent, whose owner is paid
+// the step fee, or a nested pipeline. The step fee is the most the step pays for calls
+// of a priced agent, which are charged the agent's price.
+type PipelineStep struct {
+	ID         string          `json:"id"`
+	AgentID    string          `json:"agent_id,omitempty"`
+	PipelineID string          `json:"pipeline_id,omitempty"`
+	ActionType string          `json:"action_type,omitempty"`
+	Inputs     []PipelineInput `json:"inputs,omitempty"` // the result of the previous step, or the pipeline input for the first step, if empty
+	Fee        sdk.Coins       `json:"fee,omitempty"`
+}
+
+// PipelineInput selects the values a step takes from the pipeline input or the result
+// of an earlier step. The values of all inputs of a step are concatenated in order.
+type PipelineInput struct {
+	Step    string   `json:"step,omitempty"`    // the earlier step to take values from; the pipeline input if empty
+	Indices []uint64 `json:"indices,omitempty"` // the values to take, in order; all values if empty
+}
+
+// PipelineStepResult is the outcome of one step in the record of a pipeline execution
+type PipelineStepResult struct {
+	StepID  string          `json:"step_id"` // nested steps are prefixed with the ID of their pipeline step
+	AgentID string          `json:"agent_id"`
+	Result  json.RawMessage `json:"result"`
+	GasUsed uint64          `json:"gas_used"`
+	Fee     sdk.Coins       `json:"fee,omitempty"`
+}
+
+// PipelineRun is the result of the aggregated action recorded for a pipeline execution
+type PipelineRun struct {
+	Output json.RawMessage      `json:"output"`
+	Steps  []PipelineStepResult `json:"steps"`
+}
+
+// ResultStep returns the ID of the step whose result is the pipeline result
+func (p Pipeline) ResultStep() string {
+	if p.OutputStep != "" {
+		return p.OutputStep
+	}
+	return p.Steps[len(p.Steps)-1].ID
+}
+
+// Validate performs basic validation of a pipeline
+func (p Pipeline) Validate() error {
+	if p.Owner.Empty() {
+		return fmt.Errorf("pipeline owner cannot be empty")
+	}
+	if p.Name == "" {
+		return fmt.Errorf("pipeline name cannot be empty")
+	}
+	if len(p.Steps) == 0 {
+		return fmt.Errorf("pipeline has no steps")
+	}
+
+	stepIDs := make(map[string]bool)
+	for i, step := range p.Steps {
+		if step.ID == "" {
+			return fmt.Errorf("step %d has no ID", i)
+		}
+		if stepIDs[step.ID] {
+			return fmt.Errorf("duplicate step ID: %s", step.ID)
+		}
+
+		switch {
+		case step.AgentID != "" && step.PipelineID != "":
+			return fmt.Errorf("step %s cannot run both an agent and a pipeline", step.ID)
+		case step.AgentID != "":
+			if step.ActionType == "" {
+				return fmt.Errorf("step %s has no action type", step.ID)
+			}
+		case step.PipelineID != "":
+			if step.PipelineID == p.ID {
+				return fmt.Errorf("step %s runs its own pipeline", step.ID)
+			}
+			if !step.Fee.IsZero() {
+				return fmt.Errorf("step %s runs a pipeline, which charges its own step fees", step.ID)
+			}
+		default:
+			return fmt.Errorf("step %s runs neither an agent nor a pipeline", step.ID)
+		}
+
+		if !step.Fee.IsValid() {
+			return fmt.Errorf("step %s has an invalid fee: %s", step.ID, step.Fee)
+		}
+		for _, input := range step.Inputs {
+			if input.Step != "" && !stepIDs[input.Step] {
+				return fmt.Errorf("step %s takes input from %s, which is not an earlier step", step.ID, input.Step)
+			}
+		}
+
+		stepIDs[step.ID] = true
+	}
+
+	if p.OutputStep != "" && !stepIDs[p.OutputStep] {
+		return fmt.Errorf("output step %s not found", p.OutputStep)
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineValidate(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner_______________"))
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+
+	valid := func() Pipeline {
+		return Pipeline{
+			ID:    "pipeline",
+			Owner: owner,
+			Name:  "classify",
+			Steps: []PipelineStep{
+				{ID: "embed", AgentID: "embedder", ActionType: "predict", Fee: fee},
+				{ID: "score", PipelineID: "scoring", Inputs: []PipelineInput{{Step: "embed"}, {Indices: []uint64{0}}}},
+			},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(p *Pipeline)
+	}{
+		{"no owner", func(p *Pipeline) { p.Owner = nil }},
+		{"no name", func(p *Pipeline) { p.Name = "" }},
+		{"no steps", func(p *Pipeline) { p.Steps = nil }},
+		{"step without ID", func(p *Pipeline) { p.Steps[1].ID = "" }},
+		{"duplicate step ID", func(p *Pipeline) { p.Steps[1].ID = "embed" }},
+		{"agent and pipeline", func(p *Pipeline) { p.Steps[0].PipelineID = "scoring" }},
+		{"neither agent nor pipeline", func(p *Pipeline) { p.Steps[1].PipelineID = "" }},
+		{"agent without action type", func(p *Pipeline) { p.Steps[0].ActionType = "" }},
+		{"runs itself", func(p *Pipeline) { p.Steps[1].PipelineID = p.ID }},
+		{"fee on a nested pipeline", func(p *Pipeline) { p.Steps[1].Fee = fee }},
+		{"invalid fee", func(p *Pipeline) { p.Steps[0].Fee = sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.NewInt(-1)}} }},
+		{"input from a later step", func(p *Pipeline) { p.Steps[0].Inputs = []PipelineInput{{Step: "score"}} }},
+		{"input from itself", func(p *Pipeline) { p.Steps[1].Inputs = []PipelineInput{{Step: "score"}} }},
+		{"unknown output step", func(p *Pipeline) { p.OutputStep = "missing" }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pipeline := valid()
+			tc.modify(&pipeline)
+			require.Error(t, pipeline.Validate())
+		})
+	}
+}
+
+func TestPipelineResultStep(t *testing.T) {
+	pipeline := Pipeline{Steps: []PipelineStep{{ID: "first"}, {ID: "second"}}}
+	require.Equal(t, "second", pipeline.ResultStep())
+
+	pipeline.OutputStep = "first"
+	require.Equal(t, "first", pipeline.ResultStep())
+}
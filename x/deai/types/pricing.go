@@ -0,0 +1,126 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AgentPricing is the price list an owner sets for calls of their agent. Calls of an
+// agent with a price list must pay at least the quoted fee, and only the quoted fee is
+// charged. The owner and renters holding an active rental are not charged by it. The
+// price list only sets what callers pay once the agent's permission policy admits them.
+type AgentPricing struct {
+	AgentID         string                `json:"agent_id"`
+	Denom           string                `json:"denom"`
+	DefaultPrice    sdk.Int               `json:"default_price"` // price of action types without a price of their own
+	ActionPrices    []AgentActionPrice    `json:"action_prices,omitempty"`
+	FreeCalls       uint64                `json:"free_calls,omitempty"`       // calls per caller and period that are free
+	Period          time.Duration         `json:"period,omitempty"`           // period over which calls are counted; 0 counts calls forever
+	VolumeDiscounts []AgentVolumeDiscount `json:"volume_discounts,omitempty"` // ordered by MinCalls
+}
+
+// AgentActionPrice is the price of calls of a single action type
+type AgentActionPrice struct {
+	ActionType string  `json:"action_type"`
+	Price      sdk.Int `json:"price"`
+}
+
+// AgentVolumeDiscount lowers the price for callers that made at least MinCalls calls
+// in the current period
+type AgentVolumeDiscount struct {
+	MinCalls uint64  `json:"min_calls"`
+	Discount sdk.Dec `json:"discount"`
+}
+
+// AgentQuote is the fee of a prospective call of an agent
+type AgentQuote struct {
+	Fee                sdk.Coin `json:"fee"`
+	ListPrice          sdk.Coin `json:"list_price"` // price before the free tier and volume discounts
+	Discount           sdk.Dec  `json:"discount"`
+	FreeCallsRemaining uint64   `json:"free_calls_remaining"`
+}
+
+// AgentCallUsage counts the calls a caller made against a priced agent in the current period
+type AgentCallUsage struct {
+	AgentID     string         `json:"agent_id"`
+	Caller      sdk.AccAddress `json:"caller"`
+	PeriodStart time.Time      `json:"period_start"`
+	Calls       uint64         `json:"calls"`
+}
+
+// Validate performs basic validation of an agent price list
+func (p AgentPricing) Validate() error {
+	if p.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	if err := sdk.ValidateDenom(p.Denom); err != nil {
+		return fmt.Errorf("invalid price denom: %w", err)
+	}
+	if p.DefaultPrice.IsNil() || p.DefaultPrice.IsNegative() {
+		return fmt.Errorf("default price cannot be negative")
+	}
+	if p.Period < 0 {
+		return fmt.Errorf("period cannot be negative")
+	}
+
+	seen := make(map[string]bool)
+	for _, price := range p.ActionPrices {
+		if price.ActionType == "" {
+			return fmt.Errorf("action price with empty action type")
+		}
+		if seen[price.ActionType] {
+			return fmt.Errorf("duplicate price of action %s", price.ActionType)
+		}
+		seen[price.ActionType] = true
+
+		if price.Price.IsNil() || price.Price.IsNegative() {
+			return fmt.Errorf("price of action %s cannot be negative", price.ActionType)
+		}
+	}
+
+	for i, discount := range p.VolumeDiscounts {
+		if discount.Discount.IsNil() || !discount.Discount.IsPositive() || discount.Discount.GT(sdk.OneDec()) {
+			return fmt.Errorf("volume discount must be positive and at most 1")
+		}
+		if i > 0 && discount.MinCalls <= p.VolumeDiscounts[i-1].MinCalls {
+			return fmt.Errorf("volume discounts must be ordered by increasing min calls")
+		}
+	}
+	return nil
+}
+
+// Price returns the list price of an action type
+func (p AgentPricing) Price(actionType string) sdk.Coin {
+	for _, price := range p.ActionPrices {
+		if price.ActionType == actionType {
+			return sdk.NewCoin(p.Denom, price.Price)
+		}
+	}
+	return sdk.NewCoin(p.Denom, p.DefaultPrice)
+}
+
+// Quote returns the fee of a call of an action type by a caller that already made the
+// given number of calls in the current period
+func (p AgentPricing) Quote(actionType string, calls uint64) AgentQuote {
+	quote := AgentQuote{
+		ListPrice: p.Price(actionType),
+		Discount:  sdk.ZeroDec(),
+	}
+
+	if calls < p.FreeCalls {
+		quote.Fee = sdk.NewCoin(p.Denom, sdk.ZeroInt())
+		quote.FreeCallsRemaining = p.FreeCalls - calls
+		return quote
+	}
+
+	for _, discount := range p.VolumeDiscounts {
+		if calls >= discount.MinCalls {
+			quote.Discount = discount.Discount
+		}
+	}
+	amount := sdk.OneDec().Sub(quote.Discount).MulInt(quote.ListPrice.Amount).TruncateInt()
+	quote.Fee = sdk.NewCoin(p.Denom, amount)
+	return quote
+}
\ No newline at end of file
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testAgentPricing() AgentPricing {
+	return AgentPricing{
+		AgentID:      "agent",
+		Denom:        "stake",
+		DefaultPrice: sdk.NewInt(100),
+		ActionPrices: []AgentActionPrice{{ActionType: "train", Price: sdk.NewInt(250)}},
+		FreeCalls:    3,
+		VolumeDiscounts: []AgentVolumeDiscount{
+			{MinCalls: 10, Discount: sdk.NewDecWithPrec(1, 1)},
+			{MinCalls: 100, Discount: sdk.NewDecWithPrec(25, 2)},
+		},
+	}
+}
+
+func TestAgentPricingQuote(t *testing.T) {
+	pricing := testAgentPricing()
+
+	tests := []struct {
+		name       string
+		actionType string
+		calls      uint64
+		fee        int64
+		listPrice  int64
+		discount   sdk.Dec
+		free       uint64
+	}{
+		{"first call is free", "train", 0, 0, 250, sdk.ZeroDec(), 3},
+		{"last free call", "query", 2, 0, 100, sdk.ZeroDec(), 1},
+		{"list price", "query", 3, 100, 100, sdk.ZeroDec(), 0},
+		{"action price", "train", 9, 250, 250, sdk.ZeroDec(), 0},
+		{"first discount", "query", 10, 90, 100, sdk.NewDecWithPrec(1, 1), 0},
+		{"first discount on action price", "train", 99, 225, 250, sdk.NewDecWithPrec(1, 1), 0},
+		{"discounted fee rounds down", "train", 100, 187, 250, sdk.NewDecWithPrec(25, 2), 0},
+		{"highest discount", "query", 1000, 75, 100, sdk.NewDecWithPrec(25, 2), 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quote := pricing.Quote(tc.actionType, tc.calls)
+			require.Equal(t, sdk.NewInt64Coin("stake", tc.fee), quote.Fee)
+			require.Equal(t, sdk.NewInt64Coin("stake", tc.listPrice), quote.ListPrice)
+			require.True(t, tc.discount.Equal(quote.Discount), quote.Discount.String())
+			require.Equal(t, tc.free, quote.FreeCallsRemaining)
+		})
+	}
+}
+
+func TestAgentPricingValidate(t *testing.T) {
+	require.NoError(t, testAgentPricing().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*AgentPricing)
+	}{
+		{"invalid denom", func(p *AgentPricing) { p.Denom = "1" }},
+		{"negative default price", func(p *AgentPricing) { p.DefaultPrice = sdk.NewInt(-1) }},
+		{"negative action price", func(p *AgentPricing) { p.ActionPrices[0].Price = sdk.NewInt(-1) }},
+		{"duplicate action price", func(p *AgentPricing) {
+			p.ActionPrices = append(p.ActionPrices, AgentActionPrice{ActionType: "train", Price: sdk.NewInt(1)})
+		}},
+		{"discount above 1", func(p *AgentPricing) { p.VolumeDiscounts[1].Discount = sdk.NewDecWithPrec(11, 1) }},
+		{"zero discount", func(p *AgentPricing) { p.VolumeDiscounts[0].Discount = sdk.ZeroDec() }},
+		{"unordered discounts", func(p *AgentPricing) { p.VolumeDiscounts[1].MinCalls = 10 }},
+		{"negative period", func(p *AgentPricing) { p.Period = -1 }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pricing := testAgentPricing()
+			tc.modify(&pricing)
+			require.Error(t, pricing.Validate())
+		})
+	}
+}
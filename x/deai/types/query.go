@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 // Query endpoints
 const (
 	QueryAIAgent            = "ai_agent"
@@ -12,6 +16,32 @@ const (
 	QueryAIAgentTrainingData = "ai_agent_training_data"
 	QueryMarketplaceListings = "marketplace_listings"
 	QueryMarketplaceListing  = "marketplace_listing"
+	QueryAIAgentRentals      = "ai_agent_rentals"
+	QueryRenterRentals       = "renter_rentals"
+	QueryExecutor            = "executor"
+	QueryExecutors           = "executors"
+	QueryExecutionRequest    = "execution_request"
+	QueryPendingExecutionRequests = "pending_execution_requests"
+	QueryTrainingJob              = "training_job"
+	QueryTrainingJobs             = "training_jobs"
+	QueryAIAgentStateHistory      = "ai_agent_state_history"
+	QueryAIAgentStateAt           = "ai_agent_state_at"
+	QueryPipeline                 = "pipeline"
+	QueryPipelines                = "pipelines"
+	QueryAgentWallet              = "agent_wallet"
+	QueryAgentSchedule            = "agent_schedule"
+	QueryAgentSchedules           = "agent_schedules"
+	QueryAgentQuote               = "agent_quote"
+	QueryGovDelegation            = "gov_delegation"
+	QueryAgentGovVotes            = "agent_gov_votes"
+	QueryAgentReputation          = "agent_reputation"
+	QueryCreatorReputation        = "creator_reputation"
+	QueryAgentRatings             = "agent_ratings"
+	QueryAgentShares              = "agent_shares"
+	QueryAgentShareholder         = "agent_shareholder"
+	QuerySubscriptionPlans        = "subscription_plans"
+	QuerySubscriptions            = "subscriptions"
+	QueryEncryptionKey            = "encryption_key"
 )
 
 // QueryAIAgentRequest is the request type for the Query/AIAgent RPC method
@@ -96,6 +126,7 @@ type QueryAIAgentTrainingDataResponse struct {
 type QueryMarketplaceListingsRequest struct {
 	Status      string `json:"status,omitempty"`
 	ListingType string `json:"listing_type,omitempty"`
+	Sort        string `json:"sort,omitempty"` // see MarketplaceSortRating
 }
 
 // QueryMarketplaceListingsResponse is the response type for the Query/MarketplaceListings RPC method
@@ -111,4 +142,277 @@ type QueryMarketplaceListingRequest struct {
 // QueryMarketplaceListingResponse is the response type for the Query/MarketplaceListing RPC method
 type QueryMarketplaceListingResponse struct {
 	Listing AIAgentMarketplaceListing `json:"listing"`
+}
+
+// QueryAIAgentRentalsRequest is the request type for the Query/AIAgentRentals RPC method
+type QueryAIAgentRentalsRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QueryAIAgentRentalsResponse is the response type for the Query/AIAgentRentals RPC method
+type QueryAIAgentRentalsResponse struct {
+	Rentals []AIAgentRental `json:"rentals"`
+}
+
+// QueryRenterRentalsRequest is the request type for the Query/RenterRentals RPC method
+type QueryRenterRentalsRequest struct {
+	Renter     string `json:"renter"`
+	ActiveOnly bool   `json:"active_only,omitempty"`
+}
+
+// QueryRenterRentalsResponse is the response type for the Query/RenterRentals RPC method
+type QueryRenterRentalsResponse struct {
+	Rentals []AIAgentRental `json:"rentals"`
+}
+
+// QueryExecutorRequest is the request type for the Query/Executor RPC method
+type QueryExecutorRequest struct {
+	Address string `json:"address"`
+}
+
+// QueryExecutorResponse is the response type for the Query/Executor RPC method
+type QueryExecutorResponse struct {
+	Executor Executor `json:"executor"`
+}
+
+// QueryExecutorsRequest is the request type for the Query/Executors RPC method
+type QueryExecutorsRequest struct{}
+
+// QueryExecutorsResponse is the response type for the Query/Executors RPC method
+type QueryExecutorsResponse struct {
+	Executors []Executor `json:"executors"`
+}
+
+// QueryExecutionRequestRequest is the request type for the Query/ExecutionRequest RPC method
+type QueryExecutionRequestRequest struct {
+	ID string `json:"id"`
+}
+
+// QueryExecutionRequestResponse is the response type for the Query/ExecutionRequest RPC method
+type QueryExecutionRequestResponse struct {
+	Request ExecutionRequest `json:"request"`
+}
+
+// QueryPendingExecutionRequestsRequest is the request type for the Query/PendingExecutionRequests RPC method
+type QueryPendingExecutionRequestsRequest struct{}
+
+// QueryPendingExecutionRequestsResponse is the response type for the Query/PendingExecutionRequests RPC method
+type QueryPendingExecutionRequestsResponse struct {
+	Requests []ExecutionRequest `json:"requests"`
+	// Height is the block height the requests were queried at, so that executors can
+	// tell which phase each request is in
+	Height int64 `json:"height"`
+}
+
+// QueryTrainingJobRequest is the request type for the Query/TrainingJob RPC method
+type QueryTrainingJobRequest struct {
+	ID string `json:"id"`
+}
+
+// QueryTrainingJobResponse is the response type for the Query/TrainingJob RPC method
+type QueryTrainingJobResponse struct {
+	Job TrainingJob `json:"job"`
+}
+
+// QueryTrainingJobsRequest is the request type for the Query/TrainingJobs RPC method
+type QueryTrainingJobsRequest struct {
+	AgentID string `json:"agent_id,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+// QueryTrainingJobsResponse is the response type for the Query/TrainingJobs RPC method
+type QueryTrainingJobsResponse struct {
+	Jobs []TrainingJob `json:"jobs"`
+}
+
+// QueryAIAgentStateHistoryRequest is the request type for the Query/AIAgentStateHistory RPC method
+type QueryAIAgentStateHistoryRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QueryAIAgentStateHistoryResponse is the response type for the Query/AIAgentStateHistory RPC method
+type QueryAIAgentStateHistoryResponse struct {
+	States []AIAgentState `json:"states"`
+}
+
+// QueryAIAgentStateAtRequest is the request type for the Query/AIAgentStateAt RPC method
+type QueryAIAgentStateAtRequest struct {
+	AgentID string `json:"agent_id"`
+	Version uint64 `json:"version"`
+}
+
+// QueryAIAgentStateAtResponse is the response type for the Query/AIAgentStateAt RPC method
+type QueryAIAgentStateAtResponse struct {
+	State AIAgentState `json:"state"`
+}
+
+// QueryPipelineRequest is the request type for the Query/Pipeline RPC method
+type QueryPipelineRequest struct {
+	ID string `json:"id"`
+}
+
+// QueryPipelineResponse is the response type for the Query/Pipeline RPC method
+type QueryPipelineResponse struct {
+	Pipeline Pipeline `json:"pipeline"`
+}
+
+// QueryPipelinesRequest is the request type for the Query/Pipelines RPC method
+type QueryPipelinesRequest struct {
+	Owner string `json:"owner,omitempty"`
+}
+
+// QueryPipelinesResponse is the response type for the Query/Pipelines RPC method
+type QueryPipelinesResponse struct {
+	Pipelines []Pipeline `json:"pipelines"`
+}
+
+// QueryAgentWalletRequest is the request type for the Query/AgentWallet RPC method
+type QueryAgentWalletRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QueryAgentWalletResponse is the response type for the Query/AgentWallet RPC method
+type QueryAgentWalletResponse struct {
+	Address string             `json:"address"`
+	Balance sdk.Coins          `json:"balance"`
+	Policy  *AgentWalletPolicy `json:"policy,omitempty"` // nil if the agent cannot spend from its wallet
+}
+
+// QueryAgentScheduleRequest is the request type for the Query/AgentSchedule RPC method
+type QueryAgentScheduleRequest struct {
+	ID string `json:"id"`
+}
+
+// QueryAgentScheduleResponse is the response type for the Query/AgentSchedule RPC method
+type QueryAgentScheduleResponse struct {
+	Schedule AgentSchedule `json:"schedule"`
+}
+
+// QueryAgentSchedulesRequest is the request type for the Query/AgentSchedules RPC method
+type QueryAgentSchedulesRequest struct {
+	AgentID string `json:"agent_id,omitempty"`
+	Owner   string `json:"owner,omitempty"`
+}
+
+// QueryAgentSchedulesResponse is the response type for the Query/AgentSchedules RPC method
+type QueryAgentSchedulesResponse struct {
+	Schedules []AgentSchedule `json:"schedules"`
+}
+
+// QueryAgentQuoteRequest is the request type for the Query/AgentQuote RPC method
+type QueryAgentQuoteRequest struct {
+	AgentID    string `json:"agent_id"`
+	Caller     string `json:"caller,omitempty"`
+	ActionType string `json:"action_type"`
+}
+
+// QueryAgentQuoteResponse is the response type for the Query/AgentQuote RPC method
+type QueryAgentQuoteResponse struct {
+	Priced bool       `json:"priced"` // false if the caller chooses the fee
+	Quote  AgentQuote `json:"quote"`
+}
+
+// QueryGovDelegationRequest is the request type for the Query/GovDelegation RPC method
+type QueryGovDelegationRequest struct {
+	Delegator string `json:"delegator"`
+}
+
+// QueryGovDelegationResponse is the response type for the Query/GovDelegation RPC method
+type QueryGovDelegationResponse struct {
+	Delegation GovDelegation `json:"delegation"`
+}
+
+// QueryAgentGovVotesRequest is the request type for the Query/AgentGovVotes RPC method
+type QueryAgentGovVotesRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QueryAgentGovVotesResponse is the response type for the Query/AgentGovVotes RPC method
+type QueryAgentGovVotesResponse struct {
+	Votes      []AgentGovVote `json:"votes"`
+	Delegators uint64         `json:"delegators"` // current number of delegators of the agent
+}
+
+// QueryAgentReputationRequest is the request type for the Query/AgentReputation RPC method
+type QueryAgentReputationRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QueryAgentReputationResponse is the response type for the Query/AgentReputation RPC method
+type QueryAgentReputationResponse struct {
+	Agent   AgentReputationScore `json:"agent"`
+	Creator AgentReputationScore `json:"creator"` // across all agents of the agent's creator
+}
+
+// QueryCreatorReputationRequest is the request type for the Query/CreatorReputation RPC method
+type QueryCreatorReputationRequest struct {
+	Creator string `json:"creator"`
+}
+
+// QueryCreatorReputationResponse is the response type for the Query/CreatorReputation RPC method
+type QueryCreatorReputationResponse struct {
+	Reputation AgentReputationScore `json:"reputation"`
+}
+
+// QueryAgentRatingsRequest is the request type for the Query/AgentRatings RPC method
+type QueryAgentRatingsRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QueryAgentRatingsResponse is the response type for the Query/AgentRatings RPC method
+type QueryAgentRatingsResponse struct {
+	Ratings []AgentRating `json:"ratings"`
+}
+
+// QueryAgentSharesRequest is the request type for the Query/AgentShares RPC method
+type QueryAgentSharesRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QueryAgentSharesResponse is the response type for the Query/AgentShares RPC method
+type QueryAgentSharesResponse struct {
+	Shares  AgentShares        `json:"shares"`
+	Holders []AgentShareholder `json:"holders"`
+}
+
+// QueryAgentShareholderRequest is the request type for the Query/AgentShareholder RPC method
+type QueryAgentShareholderRequest struct {
+	AgentID string `json:"agent_id"`
+	Holder  string `json:"holder"`
+}
+
+// QueryAgentShareholderResponse is the response type for the Query/AgentShareholder RPC method
+type QueryAgentShareholderResponse struct {
+	Shareholder AgentShareholder `json:"shareholder"` // settled to the current income per share
+	Claimable   sdk.Coins        `json:"claimable"`
+}
+
+// QuerySubscriptionPlansRequest is the request type for the Query/SubscriptionPlans RPC method
+type QuerySubscriptionPlansRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// QuerySubscriptionPlansResponse is the response type for the Query/SubscriptionPlans RPC method
+type QuerySubscriptionPlansResponse struct {
+	Plans []SubscriptionPlan `json:"plans"`
+}
+
+// QuerySubscriptionsRequest is the request type for the Query/Subscriptions RPC method
+type QuerySubscriptionsRequest struct {
+	Subscriber string `json:"subscriber"`
+}
+
+// QuerySubscriptionsResponse is the response type for the Query/Subscriptions RPC method
+type QuerySubscriptionsResponse struct {
+	Subscriptions []AgentSubscription `json:"subscriptions"`
+}
+
+// QueryEncryptionKeyRequest is the request type for the Query/EncryptionKey RPC method
+type QueryEncryptionKeyRequest struct {
+	Address string `json:"address"`
+}
+
+// QueryEncryptionKeyResponse is the response type for the Query/EncryptionKey RPC method
+type QueryEncryptionKeyResponse struct {
+	Key AccountEncryptionKey `json:"key"`
 }
\ No newline at end of file
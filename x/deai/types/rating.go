@@ -0,0 +1,174 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Range of agent rating scores
+const (
+	MinAgentRatingScore = 1
+	MaxAgentRatingScore = 5
+)
+
+// Interactions an agent rating can be submitted for
+const (
+	AgentRatingSubjectAction = "action"
+	AgentRatingSubjectRental = "rental"
+)
+
+// Sort orders of marketplace listings
+const (
+	MarketplaceSortRating = "rating" // by the Bayesian average rating of the listed agent, best first
+)
+
+// AgentRating is a rating of an agent by an account that called or rented it. An
+// account rates each of its actions and rentals of an agent at most once.
+type AgentRating struct {
+	AgentID     string         `json:"agent_id"`
+	Rater       sdk.AccAddress `json:"rater"`
+	Subject     string         `json:"subject"`    // action or rental
+	SubjectID   string         `json:"subject_id"` // the ID of the rated action or rental
+	Score       uint32         `json:"score"`
+	CommentHash string         `json:"comment_hash,omitempty"` // hex SHA-256 of an off-chain comment
+	Volume      sdk.Int        `json:"volume"`                 // amount the rater paid for the rated interaction
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// AgentReputation aggregates the ratings of an agent, or of all agents of a creator.
+// The sums are decayed to UpdatedAt: every rating loses half its weight each half-life.
+type AgentReputation struct {
+	Subject        string    `json:"subject"` // the agent ID or creator address
+	RatingCount    uint64    `json:"rating_count"`
+	ScoreSum       sdk.Dec   `json:"score_sum"`        // sum of decayed scores
+	WeightSum      sdk.Dec   `json:"weight_sum"`       // sum of decay factors
+	VolumeScoreSum sdk.Dec   `json:"volume_score_sum"` // sum of decayed scores weighted by volume
+	VolumeSum      sdk.Dec   `json:"volume_sum"`       // sum of decayed volumes
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// AgentReputationScore is the reputation of an agent or creator at a point in time
+type AgentReputationScore struct {
+	Subject             string  `json:"subject"`
+	RatingCount         uint64  `json:"rating_count"`
+	BayesianAverage     sdk.Dec `json:"bayesian_average"`      // average pulled towards the prior for few ratings
+	VolumeWeightedScore sdk.Dec `json:"volume_weighted_score"` // average weighted by the amount raters paid
+}
+
+// Validate performs basic validation of an agent rating
+func (r AgentRating) Validate() error {
+	if r.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	if r.Rater.Empty() {
+		return fmt.Errorf("rater cannot be empty")
+	}
+	if r.Subject != AgentRatingSubjectAction && r.Subject != AgentRatingSubjectRental {
+		return fmt.Errorf("invalid rating subject: %s", r.Subject)
+	}
+	if r.SubjectID == "" {
+		return fmt.Errorf("rated %s cannot be empty", r.Subject)
+	}
+	if r.Score < MinAgentRatingScore || r.Score > MaxAgentRatingScore {
+		return fmt.Errorf("score must be between %d and %d", MinAgentRatingScore, MaxAgentRatingScore)
+	}
+	if err := ValidateCommentHash(r.CommentHash); err != nil {
+		return err
+	}
+	if r.Volume.IsNil() || r.Volume.IsNegative() {
+		return fmt.Errorf("volume cannot be negative")
+	}
+	return nil
+}
+
+// ValidateCommentHash checks that a comment hash is empty or a hex SHA-256 digest
+func ValidateCommentHash(commentHash string) error {
+	if commentHash == "" {
+		return nil
+	}
+	bz, err := hex.DecodeString(commentHash)
+	if err != nil || len(bz) != 32 {
+		return fmt.Errorf("comment hash must be a hex SHA-256 digest")
+	}
+	return nil
+}
+
+// NewAgentReputation returns the reputation of a subject without ratings
+func NewAgentReputation(subject string, now time.Time) AgentReputation {
+	return AgentReputation{
+		Subject:        subject,
+		ScoreSum:       sdk.ZeroDec(),
+		WeightSum:      sdk.ZeroDec(),
+		VolumeScoreSum: sdk.ZeroDec(),
+		VolumeSum:      sdk.ZeroDec(),
+		UpdatedAt:      now,
+	}
+}
+
+// Decay returns the reputation with its sums decayed to the given time
+func (r AgentReputation) Decay(now time.Time, halfLife time.Duration) AgentReputation {
+	if halfLife <= 0 || !now.After(r.UpdatedAt) {
+		return r
+	}
+
+	factor := DecayFactor(now.Sub(r.UpdatedAt), halfLife)
+	r.ScoreSum = r.ScoreSum.Mul(factor)
+	r.WeightSum = r.WeightSum.Mul(factor)
+	r.VolumeScoreSum = r.VolumeScoreSum.Mul(factor)
+	r.VolumeSum = r.VolumeSum.Mul(factor)
+	r.UpdatedAt = now
+	return r
+}
+
+// Add returns the reputation with a new rating added. The reputation must have been
+// decayed to the time of the rating.
+func (r AgentReputation) Add(score uint32, volume sdk.Int) AgentReputation {
+	scoreDec := sdk.NewDec(int64(score))
+	volumeDec := sdk.NewDecFromInt(volume)
+
+	r.RatingCount++
+	r.ScoreSum = r.ScoreSum.Add(scoreDec)
+	r.WeightSum = r.WeightSum.Add(sdk.OneDec())
+	r.VolumeScoreSum = r.VolumeScoreSum.Add(scoreDec.Mul(volumeDec))
+	r.VolumeSum = r.VolumeSum.Add(volumeDec)
+	return r
+}
+
+// Score returns the Bayesian average and volume-weighted score of the reputation. The
+// Bayesian average weighs the prior mean as priorWeight ratings; the volume-weighted
+// score falls back to the plain average if no rater paid anything.
+func (r AgentReputation) Score(priorMean, priorWeight sdk.Dec) AgentReputationScore {
+	score := AgentReputationScore{
+		Subject:             r.Subject,
+		RatingCount:         r.RatingCount,
+		BayesianAverage:     priorMean,
+		VolumeWeightedScore: sdk.ZeroDec(),
+	}
+
+	if total := priorWeight.Add(r.WeightSum); total.IsPositive() {
+		score.BayesianAverage = priorMean.Mul(priorWeight).Add(r.ScoreSum).Quo(total)
+	}
+	switch {
+	case r.VolumeSum.IsPositive():
+		score.VolumeWeightedScore = r.VolumeScoreSum.Quo(r.VolumeSum)
+	case r.WeightSum.IsPositive():
+		score.VolumeWeightedScore = r.ScoreSum.Quo(r.WeightSum)
+	}
+	return score
+}
+
+// DecayFactor returns the weight left to a rating after the given age: it halves every
+// half-life and falls linearly in between
+func DecayFactor(age, halfLife time.Duration) sdk.Dec {
+	halvings := uint64(age / halfLife)
+	if halvings >= 64 {
+		return sdk.ZeroDec()
+	}
+
+	factor := sdk.OneDec().Quo(sdk.NewDec(2).Power(halvings))
+	remainder := sdk.NewDec(int64(age % halfLife)).QuoInt64(int64(halfLife))
+	return factor.Mul(sdk.OneDec().Sub(remainder.QuoInt64(2)))
+}
\ No newline at end of file
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecayFactor(t *testing.T) {
+	halfLife := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		expected string
+	}{
+		{"new", 0, "1"},
+		{"half a half-life", halfLife / 2, "0.75"},
+		{"one half-life", halfLife, "0.5"},
+		{"one and a half half-lives", halfLife * 3 / 2, "0.375"},
+		{"two half-lives", 2 * halfLife, "0.25"},
+		{"forgotten", 64 * halfLife, "0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, sdk.MustNewDecFromStr(tc.expected), DecayFactor(tc.age, halfLife))
+		})
+	}
+}
+
+func TestAgentReputationScore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	priorMean, priorWeight := sdk.NewDec(3), sdk.NewDec(2)
+
+	// Without ratings the score is the prior
+	reputation := NewAgentReputation("agent", now)
+	score := reputation.Score(priorMean, priorWeight)
+	require.Equal(t, priorMean, score.BayesianAverage)
+	require.True(t, score.VolumeWeightedScore.IsZero())
+
+	reputation = reputation.Add(5, sdk.NewInt(100)).Add(1, sdk.ZeroInt())
+	score = reputation.Score(priorMean, priorWeight)
+	require.Equal(t, uint64(2), score.RatingCount)
+	require.Equal(t, sdk.MustNewDecFromStr("3"), score.BayesianAverage)
+	require.Equal(t, sdk.NewDec(5), score.VolumeWeightedScore)
+
+	// Without paid ratings the volume-weighted score is the plain average
+	unpaid := NewAgentReputation("agent", now).Add(5, sdk.ZeroInt()).Add(4, sdk.ZeroInt())
+	require.Equal(t, sdk.MustNewDecFromStr("4.5"), unpaid.Score(priorMean, priorWeight).VolumeWeightedScore)
+}
+
+func TestAgentReputationDecay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	halfLife := time.Hour
+	priorMean, priorWeight := sdk.NewDec(3), sdk.ZeroDec()
+
+	// An old 1 weighs half as much as a new 5
+	reputation := NewAgentReputation("agent", now).Add(1, sdk.NewInt(10))
+	reputation = reputation.Decay(now.Add(halfLife), halfLife).Add(5, sdk.NewInt(10))
+	require.Equal(t, now.Add(halfLife), reputation.UpdatedAt)
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), reputation.WeightSum)
+
+	score := reputation.Score(priorMean, priorWeight)
+	require.Equal(t, uint64(2), score.RatingCount)
+	require.Equal(t, sdk.MustNewDecFromStr("5.5").Quo(sdk.MustNewDecFromStr("1.5")), score.BayesianAverage)
+	require.Equal(t, sdk.MustNewDecFromStr("55").Quo(sdk.MustNewDecFromStr("15")), score.VolumeWeightedScore)
+
+	// Decaying to the past or without a half-life changes nothing
+	require.Equal(t, reputation, reputation.Decay(now, halfLife))
+	require.Equal(t, reputation, reputation.Decay(now.Add(10*halfLife), 0))
+}
+
+func TestAgentRatingValidate(t *testing.T) {
+	rater := sdk.AccAddress([]byte("rater_______________"))
+
+	valid := func() AgentRating {
+		return AgentRating{
+			AgentID:     "agent",
+			Rater:       rater,
+			Subject:     AgentRatingSubjectRental,
+			SubjectID:   "rental",
+			Score:       MaxAgentRatingScore,
+			CommentHash: strings.Repeat("0f", 32),
+			Volume:      sdk.NewInt(100),
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(r *AgentRating)
+	}{
+		{"no agent", func(r *AgentRating) { r.AgentID = "" }},
+		{"no rater", func(r *AgentRating) { r.Rater = nil }},
+		{"unknown subject", func(r *AgentRating) { r.Subject = "listing" }},
+		{"no subject ID", func(r *AgentRating) { r.SubjectID = "" }},
+		{"score too low", func(r *AgentRating) { r.Score = MinAgentRatingScore - 1 }},
+		{"score too high", func(r *AgentRating) { r.Score = MaxAgentRatingScore + 1 }},
+		{"comment hash not hex", func(r *AgentRating) { r.CommentHash = strings.Repeat("zz", 32) }},
+		{"comment hash too short", func(r *AgentRating) { r.CommentHash = "0f" }},
+		{"no volume", func(r *AgentRating) { r.Volume = sdk.Int{} }},
+		{"negative volume", func(r *AgentRating) { r.Volume = sdk.NewInt(-1) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rating := valid()
+			tc.modify(&rating)
+			require.Error(t, rating.Validate())
+		})
+	}
+}
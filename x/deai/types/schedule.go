@@ -0,0 +1,123 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Agent schedule status constants
+const (
+	AgentScheduleStatusActive    = "active"    // the schedule runs when it is due
+	AgentScheduleStatusCompleted = "completed" // the schedule reached its last run and the rest of the budget was refunded
+	AgentScheduleStatusCancelled = "cancelled" // the schedule was cancelled and the rest of the budget was refunded
+)
+
+// Reasons for which a schedule is cancelled
+const (
+	AgentScheduleCancelledByOwner  = "cancelled by owner"
+	AgentScheduleCancelledNoFunds  = "budget exhausted"
+	AgentScheduleCancelledAgent    = "agent no longer active"
+	AgentScheduleCancelledGasLimit = "run exceeds the scheduled gas limit"
+)
+
+// AgentSchedule runs an action of an AI agent on behalf of its owner at a given block
+// time or height, and optionally again every interval. The fee of every run is paid
+// from a budget escrowed in the module account when the schedule was created.
+type AgentSchedule struct {
+	ID             string          `json:"id"`
+	AgentID        string          `json:"agent_id"`
+	Owner          sdk.AccAddress  `json:"owner"` // the account that funded the budget and on whose behalf the action runs
+	ActionType     string          `json:"action_type"`
+	Data           json.RawMessage `json:"data"`
+	FeePerRun      sdk.Coins       `json:"fee_per_run"`
+	Budget         sdk.Coins       `json:"budget"`                    // the remaining escrowed budget
+	Interval       time.Duration   `json:"interval,omitempty"`        // time between runs of time-based schedules
+	IntervalBlocks uint64          `json:"interval_blocks,omitempty"` // blocks between runs of height-based schedules
+	NextRunTime    time.Time       `json:"next_run_time,omitempty"`   // set for time-based schedules
+	NextRunHeight  int64           `json:"next_run_height,omitempty"` // set for height-based schedules
+	MaxRuns        uint64          `json:"max_runs,omitempty"`        // 0 runs until the budget is exhausted
+	Runs           uint64          `json:"runs"`
+	LastActionID   string          `json:"last_action_id,omitempty"`
+	Status         string          `json:"status"`
+	CancelReason   string          `json:"cancel_reason,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// IsHeightBased returns true if the schedule runs at block heights rather than block times
+func (s AgentSchedule) IsHeightBased() bool {
+	return s.NextRunHeight > 0
+}
+
+// IsRecurring returns true if the schedule runs more than once
+func (s AgentSchedule) IsRecurring() bool {
+	return s.Interval > 0 || s.IntervalBlocks > 0
+}
+
+// IsLastRun returns true if the next run of the schedule is its last one
+func (s AgentSchedule) IsLastRun() bool {
+	return !s.IsRecurring() || (s.MaxRuns > 0 && s.Runs+1 >= s.MaxRuns)
+}
+
+// Advance moves the schedule to the run that follows the current one. Runs that were
+// missed because the schedule fell behind are skipped.
+func (s *AgentSchedule) Advance(blockTime time.Time, height int64) {
+	if s.IsHeightBased() {
+		s.NextRunHeight += int64(s.IntervalBlocks)
+		if s.NextRunHeight <= height {
+			s.NextRunHeight = height + int64(s.IntervalBlocks)
+		}
+		return
+	}
+
+	s.NextRunTime = s.NextRunTime.Add(s.Interval)
+	if !s.NextRunTime.After(blockTime) {
+		s.NextRunTime = blockTime.Add(s.Interval)
+	}
+}
+
+// Validate performs basic validation of an agent schedule
+func (s AgentSchedule) Validate() error {
+	if s.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	if s.Owner.Empty() {
+		return fmt.Errorf("owner cannot be empty")
+	}
+	if s.ActionType == "" {
+		return fmt.Errorf("action type cannot be empty")
+	}
+	if !s.FeePerRun.IsValid() {
+		return fmt.Errorf("invalid fee per run: %s", s.FeePerRun)
+	}
+	if !s.Budget.IsValid() {
+		return fmt.Errorf("invalid budget: %s", s.Budget)
+	}
+
+	heightBased := s.NextRunHeight > 0
+	if heightBased == !s.NextRunTime.IsZero() {
+		return fmt.Errorf("exactly one of the next run time and height must be set")
+	}
+	if heightBased && s.Interval != 0 {
+		return fmt.Errorf("height-based schedules repeat every number of blocks, not every duration")
+	}
+	if !heightBased && s.IntervalBlocks != 0 {
+		return fmt.Errorf("time-based schedules repeat every duration, not every number of blocks")
+	}
+	if s.Interval < 0 {
+		return fmt.Errorf("interval cannot be negative")
+	}
+	if !s.IsRecurring() && s.MaxRuns > 1 {
+		return fmt.Errorf("one-off schedules cannot have more than one run")
+	}
+
+	switch s.Status {
+	case AgentScheduleStatusActive, AgentScheduleStatusCompleted, AgentScheduleStatusCancelled:
+	default:
+		return fmt.Errorf("invalid schedule status: %s", s.Status)
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentScheduleAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	timeBased := AgentSchedule{NextRunTime: start, Interval: time.Hour}
+	timeBased.Advance(start.Add(time.Minute), 100)
+	require.Equal(t, start.Add(time.Hour), timeBased.NextRunTime)
+
+	// Missed runs are skipped rather than fired back to back
+	timeBased.Advance(start.Add(5*time.Hour+time.Minute), 200)
+	require.Equal(t, start.Add(6*time.Hour+time.Minute), timeBased.NextRunTime)
+
+	heightBased := AgentSchedule{NextRunHeight: 100, IntervalBlocks: 10}
+	heightBased.Advance(start, 100)
+	require.Equal(t, int64(110), heightBased.NextRunHeight)
+
+	heightBased.Advance(start, 135)
+	require.Equal(t, int64(145), heightBased.NextRunHeight)
+	require.True(t, heightBased.IsHeightBased())
+	require.False(t, timeBased.IsHeightBased())
+}
+
+func TestAgentScheduleIsLastRun(t *testing.T) {
+	require.True(t, AgentSchedule{NextRunHeight: 10}.IsLastRun())
+	require.False(t, AgentSchedule{NextRunHeight: 10, IntervalBlocks: 5}.IsLastRun())
+	require.False(t, AgentSchedule{NextRunHeight: 10, IntervalBlocks: 5, MaxRuns: 3, Runs: 1}.IsLastRun())
+	require.True(t, AgentSchedule{NextRunHeight: 10, IntervalBlocks: 5, MaxRuns: 3, Runs: 2}.IsLastRun())
+}
+
+func TestAgentScheduleValidate(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner_______________"))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	valid := func() AgentSchedule {
+		return AgentSchedule{
+			AgentID:     "agent",
+			Owner:       owner,
+			ActionType:  "trade",
+			FeePerRun:   sdk.NewCoins(sdk.NewInt64Coin("stake", 10)),
+			Budget:      sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+			NextRunTime: start,
+			Interval:    time.Hour,
+			Status:      AgentScheduleStatusActive,
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	heightBased := valid()
+	heightBased.NextRunTime = time.Time{}
+	heightBased.Interval = 0
+	heightBased.NextRunHeight = 10
+	heightBased.IntervalBlocks = 5
+	require.NoError(t, heightBased.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(s *AgentSchedule)
+	}{
+		{"no agent", func(s *AgentSchedule) { s.AgentID = "" }},
+		{"no owner", func(s *AgentSchedule) { s.Owner = nil }},
+		{"no action type", func(s *AgentSchedule) { s.ActionType = "" }},
+		{"invalid fee", func(s *AgentSchedule) { s.FeePerRun = sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.NewInt(-1)}} }},
+		{"invalid budget", func(s *AgentSchedule) { s.Budget = sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.NewInt(-1)}} }},
+		{"both time and height", func(s *AgentSchedule) { s.NextRunHeight = 10 }},
+		{"neither time nor height", func(s *AgentSchedule) { s.NextRunTime = time.Time{} }},
+		{"time-based with block interval", func(s *AgentSchedule) { s.IntervalBlocks = 5 }},
+		{"negative interval", func(s *AgentSchedule) { s.Interval = -time.Hour }},
+		{"one-off with several runs", func(s *AgentSchedule) { s.Interval = 0; s.MaxRuns = 2 }},
+		{"invalid status", func(s *AgentSchedule) { s.Status = "paused" }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule := valid()
+			tc.modify(&schedule)
+			require.Error(t, schedule.Validate())
+		})
+	}
+
+	heightBased.Interval = time.Hour
+	require.Error(t, heightBased.Validate())
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AgentSharesDenomPrefix prefixes the bank denom of the ownership shares of an agent
+const AgentSharesDenomPrefix = "deai/agent/"
+
+// Agent shares status constants
+const (
+	AgentSharesStatusActive    = "active"     // the agent is held by the module for its shareholders
+	AgentSharesStatusBoughtOut = "bought_out" // a shareholder redeemed full ownership
+)
+
+// AgentSharesDenom returns the bank denom of the ownership shares of an agent
+func AgentSharesDenom(agentID string) string {
+	return AgentSharesDenomPrefix + agentID
+}
+
+// AgentShares describes a fractionalized agent. While the shares are active the agent
+// NFT is held by the deai module account and the agent's execution fees and rental
+// income are distributed pro rata to the holders of its share denom.
+type AgentShares struct {
+	AgentID        string         `json:"agent_id"`
+	Denom          string         `json:"denom"`
+	Supply         sdk.Int        `json:"supply"`
+	Curator        sdk.AccAddress `json:"curator"`       // the fractionalizer; may list the agent for rent
+	ReservePrice   sdk.Coins      `json:"reserve_price"` // price per share paid to the other holders on buyout
+	IncomePerShare sdk.DecCoins   `json:"income_per_share,omitempty"`
+	Status         string         `json:"status"`
+	Buyer          sdk.AccAddress `json:"buyer,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// AgentShareholder tracks the income of a holder of agent shares. The holder earns
+// IncomePerShare minus the checkpoint on the shares held since the last settlement.
+type AgentShareholder struct {
+	AgentID    string         `json:"agent_id"`
+	Holder     sdk.AccAddress `json:"holder"`
+	Shares     sdk.Int        `json:"shares"`     // the holder's balance at the last settlement
+	Checkpoint sdk.DecCoins   `json:"checkpoint"` // IncomePerShare at the last settlement
+	Unclaimed  sdk.DecCoins   `json:"unclaimed,omitempty"`
+}
+
+// Validate performs basic validation of a fractionalized agent
+func (s AgentShares) Validate() error {
+	if s.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	if s.Denom != AgentSharesDenom(s.AgentID) {
+		return fmt.Errorf("denom %s is not the share denom of agent %s", s.Denom, s.AgentID)
+	}
+	if err := sdk.ValidateDenom(s.Denom); err != nil {
+		return err
+	}
+	if s.Supply.IsNil() || !s.Supply.IsPositive() {
+		return fmt.Errorf("share supply must be positive")
+	}
+	if s.Curator.Empty() {
+		return fmt.Errorf("curator cannot be empty")
+	}
+	if !s.ReservePrice.IsValid() || s.ReservePrice.IsZero() {
+		return fmt.Errorf("invalid reserve price: %s", s.ReservePrice)
+	}
+	if !s.IncomePerShare.IsValid() {
+		return fmt.Errorf("invalid income per share: %s", s.IncomePerShare)
+	}
+	switch s.Status {
+	case AgentSharesStatusActive:
+	case AgentSharesStatusBoughtOut:
+		if s.Buyer.Empty() {
+			return fmt.Errorf("bought out shares have no buyer")
+		}
+	default:
+		return fmt.Errorf("invalid shares status: %s", s.Status)
+	}
+	return nil
+}
+
+// IsActive returns true if the agent is held for its shareholders
+func (s AgentShares) IsActive() bool {
+	return s.Status == AgentSharesStatusActive
+}
+
+// BuyoutCost returns the amount a buyer pays the other holders for the given number of shares
+func (s AgentShares) BuyoutCost(shares sdk.Int) sdk.Coins {
+	cost := sdk.NewCoins()
+	for _, coin := range s.ReservePrice {
+		cost = cost.Add(sdk.NewCoin(coin.Denom, coin.Amount.Mul(shares)))
+	}
+	return cost
+}
+
+// NewAgentShareholder returns the income record of a holder that starts earning at
+// the given income per share
+func NewAgentShareholder(agentID string, holder sdk.AccAddress, incomePerShare sdk.DecCoins) AgentShareholder {
+	return AgentShareholder{
+		AgentID:    agentID,
+		Holder:     holder,
+		Shares:     sdk.ZeroInt(),
+		Checkpoint: incomePerShare,
+		Unclaimed:  sdk.NewDecCoins(),
+	}
+}
+
+// Settle adds the income earned since the last settlement to the unclaimed income and
+// records the current balance. Shares received outside the module only earn from the
+// next settlement on, so shares never earn twice for the same period.
+func (h AgentShareholder) Settle(incomePerShare sdk.DecCoins, balance sdk.Int) AgentShareholder {
+	earning := h.Shares
+	if balance.LT(earning) {
+		earning = balance
+	}
+	if earning.IsPositive() {
+		accrued := incomePerShare.Sub(h.Checkpoint).MulDecTruncate(sdk.NewDecFromInt(earning))
+		h.Unclaimed = h.Unclaimed.Add(accrued...)
+	}
+	h.Shares = balance
+	h.Checkpoint = incomePerShare
+	return h
+}
\ No newline at end of file
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentSharesBuyoutCost(t *testing.T) {
+	shares := AgentShares{
+		ReservePrice: sdk.NewCoins(sdk.NewInt64Coin("stake", 5), sdk.NewInt64Coin("uatom", 2)),
+	}
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 35), sdk.NewInt64Coin("uatom", 14)), shares.BuyoutCost(sdk.NewInt(7)))
+	require.True(t, shares.BuyoutCost(sdk.ZeroInt()).IsZero())
+}
+
+func TestAgentShareholderSettle(t *testing.T) {
+	holder := sdk.AccAddress([]byte("holder______________"))
+
+	tests := []struct {
+		name      string
+		shares    int64
+		balance   int64
+		unclaimed string
+	}{
+		{"held shares earn", 10, 10, "15"},
+		{"received shares earn from the next settlement", 10, 30, "15"},
+		{"sent shares stop earning", 10, 4, "6"},
+		{"no shares", 0, 10, "0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			shareholder := NewAgentShareholder("agent", holder, stakeDecCoins("0.5"))
+			shareholder.Shares = sdk.NewInt(tc.shares)
+
+			settled := shareholder.Settle(stakeDecCoins("2"), sdk.NewInt(tc.balance))
+			require.True(t, stakeDecCoins(tc.unclaimed).IsEqual(settled.Unclaimed), settled.Unclaimed.String())
+			require.Equal(t, sdk.NewInt(tc.balance), settled.Shares)
+			require.True(t, stakeDecCoins("2").IsEqual(settled.Checkpoint))
+		})
+	}
+}
+
+func TestAgentShareholderSettleTwice(t *testing.T) {
+	holder := sdk.AccAddress([]byte("holder______________"))
+
+	shareholder := NewAgentShareholder("agent", holder, stakeDecCoins("0"))
+	shareholder = shareholder.Settle(stakeDecCoins("0"), sdk.NewInt(10))
+	require.True(t, shareholder.Unclaimed.IsZero())
+
+	shareholder = shareholder.Settle(stakeDecCoins("1.5"), sdk.NewInt(30))
+	require.True(t, stakeDecCoins("15").IsEqual(shareholder.Unclaimed), shareholder.Unclaimed.String())
+
+	// Settling again at the same income per share earns nothing
+	shareholder = shareholder.Settle(stakeDecCoins("1.5"), sdk.NewInt(30))
+	require.True(t, stakeDecCoins("15").IsEqual(shareholder.Unclaimed), shareholder.Unclaimed.String())
+
+	shareholder = shareholder.Settle(stakeDecCoins("2"), sdk.NewInt(30))
+	require.True(t, stakeDecCoins("30").IsEqual(shareholder.Unclaimed), shareholder.Unclaimed.String())
+}
+
+// stakeDecCoins returns the given amount of stake as dec coins, which are empty for zero
+func stakeDecCoins(amount string) sdk.DecCoins {
+	return sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", sdk.MustNewDecFromStr(amount)))
+}
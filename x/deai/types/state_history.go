@@ -0,0 +1,12 @@
+package types
+
+// AIAgentActionTypeStateRollback is the action type recorded when an owner rolls back
+// the state of an agent
+const AIAgentActionTypeStateRollback = "state_rollback"
+
+// StateRollback describes a state rollback in the data of its AIAgentAction
+type StateRollback struct {
+	FromVersion uint64 `json:"from_version"` // the version that was current before the rollback
+	ToVersion   uint64 `json:"to_version"`   // the version that was restored
+	Version     uint64 `json:"version"`      // the new version holding the restored state
+}
\ No newline at end of file
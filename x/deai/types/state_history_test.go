@@ -0,0 +1,30 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAIAgentStateHistoryKey(t *testing.T) {
+	// Versions are iterated in order, which pruning relies on
+	for _, versions := range [][2]uint64{{1, 2}, {9, 10}, {255, 256}, {1 << 32, 1<<32 + 1}} {
+		require.Equal(t, -1, bytes.Compare(GetAIAgentStateHistoryKey("agent", versions[0]), GetAIAgentStateHistoryKey("agent", versions[1])), versions)
+	}
+
+	// The history of an agent does not include the history of agents sharing its ID as a prefix
+	prefix := GetAIAgentStateHistoryPrefix("agent")
+	require.True(t, bytes.HasPrefix(GetAIAgentStateHistoryKey("agent", 1), prefix))
+	require.False(t, bytes.HasPrefix(GetAIAgentStateHistoryKey("agent1", 1), prefix))
+}
+
+func TestMsgRollbackAgentStateValidateBasic(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner_______________"))
+
+	require.NoError(t, MsgRollbackAgentState{Owner: owner, AgentID: "agent", Version: 1}.ValidateBasic())
+	require.Error(t, MsgRollbackAgentState{AgentID: "agent", Version: 1}.ValidateBasic())
+	require.Error(t, MsgRollbackAgentState{Owner: owner, Version: 1}.ValidateBasic())
+	require.Error(t, MsgRollbackAgentState{Owner: owner, AgentID: "agent"}.ValidateBasic())
+}
@@ -0,0 +1,218 @@
+package types
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Storage type constants. Payloads stored on chain use StorageTypeChain; all other
+// storage types name the scheme of the payload's StorageRef.
+const (
+	StorageTypeChain   = "chain"
+	StorageTypeIPFS    = "ipfs"
+	StorageTypeArweave = "arweave"
+	StorageTypeFile    = "file" // a content-addressed blob store shared out of band
+)
+
+// StorageRef references a payload stored off chain. The payload itself is fetched from
+// the storage network by its CID, and checked against the sha256 Digest and Size
+// recorded on chain before it is used.
+type StorageRef struct {
+	Scheme    string `json:"scheme"`
+	CID       string `json:"cid"`    // the IPFS CID, Arweave transaction ID or blob store key
+	Digest    string `json:"digest"` // lower case hex encoded sha256 digest of the stored bytes
+	Size      uint64 `json:"size"`
+	Encrypted bool   `json:"encrypted,omitempty"` // the stored bytes are ciphertext
+}
+
+// IsValidStorageScheme returns true if payloads can be stored by reference with the scheme
+func IsValidStorageScheme(scheme string) bool {
+	switch scheme {
+	case StorageTypeIPFS, StorageTypeArweave, StorageTypeFile:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate performs basic validation of a storage reference. The CID must be valid for
+// the scheme: an IPFS CID with a well-formed multihash, an Arweave transaction ID, or
+// the digest itself for blobs in a blob store, which keys blobs by their digest.
+func (r StorageRef) Validate() error {
+	if !IsValidStorageScheme(r.Scheme) {
+		return fmt.Errorf("invalid storage scheme: %s", r.Scheme)
+	}
+	if r.CID == "" {
+		return fmt.Errorf("storage reference has no CID")
+	}
+	digest, err := NormalizeDigest(r.Digest)
+	if err != nil {
+		return err
+	}
+	if err := validateCID(r.Scheme, r.CID, digest); err != nil {
+		return err
+	}
+	if r.Size == 0 {
+		return fmt.Errorf("storage reference has no size")
+	}
+	return nil
+}
+
+// Normalize validates the storage reference and returns it with its digest in lower
+// case, the form payloads are verified against after they are retrieved
+func (r StorageRef) Normalize() (StorageRef, error) {
+	if err := r.Validate(); err != nil {
+		return r, err
+	}
+	r.Digest, _ = NormalizeDigest(r.Digest)
+	if r.Scheme == StorageTypeFile {
+		r.CID = r.Digest
+	}
+	return r, nil
+}
+
+// ValidateDigest checks that a digest is a hex encoded sha256 digest
+func ValidateDigest(digest string) error {
+	_, err := NormalizeDigest(digest)
+	return err
+}
+
+// NormalizeDigest checks that a digest is a hex encoded sha256 digest and returns it in
+// lower case
+func NormalizeDigest(digest string) (string, error) {
+	bz, err := hex.DecodeString(digest)
+	if err != nil {
+		return "", fmt.Errorf("digest must be hex encoded: %w", err)
+	}
+	if len(bz) != 32 {
+		return "", fmt.Errorf("digest must be a sha256 digest")
+	}
+	return hex.EncodeToString(bz), nil
+}
+
+// validateCID checks that a CID identifies a payload with the given digest under a
+// storage scheme
+func validateCID(scheme, cid, digest string) error {
+	switch scheme {
+	case StorageTypeIPFS:
+		return validateIPFSCID(cid)
+	case StorageTypeArweave:
+		// Arweave transaction IDs are unpadded base64url encoded 32-byte hashes
+		bz, err := base64.RawURLEncoding.DecodeString(cid)
+		if err != nil || len(bz) != 32 {
+			return fmt.Errorf("invalid arweave transaction ID: %s", cid)
+		}
+		return nil
+	case StorageTypeFile:
+		if !strings.EqualFold(cid, digest) {
+			return fmt.Errorf("blob store key %s does not match digest %s", cid, digest)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid storage scheme: %s", scheme)
+	}
+}
+
+// validateIPFSCID checks that a CID is a base58 CIDv0 or a base32 CIDv1 and that its
+// multihash is well-formed
+func validateIPFSCID(cid string) error {
+	// CIDv0 is the base58btc encoding of a sha2-256 multihash
+	if len(cid) == 46 && strings.HasPrefix(cid, "Qm") {
+		bz, err := decodeBase58(cid)
+		if err != nil {
+			return fmt.Errorf("invalid CID %s: %w", cid, err)
+		}
+		if len(bz) != 34 || bz[0] != 0x12 || bz[1] != 0x20 {
+			return fmt.Errorf("invalid CID %s: CIDv0 must hold a sha2-256 multihash", cid)
+		}
+		return nil
+	}
+
+	// CIDv1 carries a multibase prefix; only the default base32 encoding is accepted
+	if !strings.HasPrefix(cid, "b") {
+		return fmt.Errorf("invalid CID %s: CIDv1 must be base32 encoded", cid)
+	}
+	bz, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(cid[1:]))
+	if err != nil {
+		return fmt.Errorf("invalid CID %s: %w", cid, err)
+	}
+
+	// <version><codec><multihash code><digest length><digest>
+	var fields [4]uint64
+	for i := range fields {
+		value, n := binary.Uvarint(bz)
+		if n <= 0 {
+			return fmt.Errorf("invalid CID %s: truncated", cid)
+		}
+		fields[i] = value
+		bz = bz[n:]
+	}
+	if fields[0] != 1 {
+		return fmt.Errorf("invalid CID %s: unsupported version %d", cid, fields[0])
+	}
+	if fields[3] == 0 || uint64(len(bz)) != fields[3] {
+		return fmt.Errorf("invalid CID %s: multihash digest length does not match", cid)
+	}
+	return nil
+}
+
+// base58Alphabet is the bitcoin base58 alphabet IPFS encodes CIDv0 with
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes a base58btc string
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	// Leading ones encode leading zero bytes
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// validateStoredPayload checks that a payload is either stored inline or by a valid
+// reference, and that its storage type matches
+func validateStoredPayload(data []byte, ref *StorageRef, storageType string) error {
+	if ref == nil {
+		if storageType != "" && storageType != StorageTypeChain {
+			return fmt.Errorf("storage type %s requires a storage reference", storageType)
+		}
+		return nil
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("payload cannot be stored both inline and by reference")
+	}
+	if err := ref.Validate(); err != nil {
+		return err
+	}
+	if storageType != ref.Scheme {
+		return fmt.Errorf("storage type %s does not match storage reference scheme %s", storageType, ref.Scheme)
+	}
+	return nil
+}
+
+// ValidateStorage checks that the state data is stored as its storage type advertises
+func (s AIAgentState) ValidateStorage() error {
+	return validateStoredPayload(s.StateData, s.StorageRef, s.StorageType)
+}
+
+// ValidateStorage checks that the training data is stored as its storage type advertises
+func (d AIAgentTrainingData) ValidateStorage() error {
+	return validateStoredPayload(d.Data, d.StorageRef, d.StorageType)
+}
\ No newline at end of file
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// helloDigest is the sha256 digest of "hello"
+const helloDigest = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestNormalizeDigest(t *testing.T) {
+	digest, err := NormalizeDigest(strings.ToUpper(helloDigest))
+	require.NoError(t, err)
+	require.Equal(t, helloDigest, digest)
+
+	_, err = NormalizeDigest("not hex")
+	require.Error(t, err)
+	_, err = NormalizeDigest(helloDigest[:62])
+	require.Error(t, err)
+	_, err = NormalizeDigest(helloDigest + "00")
+	require.Error(t, err)
+}
+
+func TestValidateCID(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme string
+		cid    string
+		valid  bool
+	}{
+		{"ipfs v0", StorageTypeIPFS, "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", true},
+		{"ipfs v1", StorageTypeIPFS, "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi", true},
+		{"ipfs v0 not base58", StorageTypeIPFS, "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbd0", false},
+		{"ipfs v0 not sha2-256", StorageTypeIPFS, "Qm11111111111111111111111111111111111111111111", false},
+		{"ipfs v1 not base32", StorageTypeIPFS, "zdj7WWeQ43G6JJvLWQWZpyHuAMq6uYWRjkBXFad11vE2LHhQ7", false},
+		{"ipfs v1 truncated", StorageTypeIPFS, "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbz", false},
+		{"ipfs v1 bad version", StorageTypeIPFS, "bciqa", false},
+		{"arweave", StorageTypeArweave, "bNbA3TEQVL60xlgCcqdz4ZPHFZ711cZ3hmkpGttDt_U", true},
+		{"arweave padded", StorageTypeArweave, "bNbA3TEQVL60xlgCcqdz4ZPHFZ711cZ3hmkpGttDt_U=", false},
+		{"arweave too short", StorageTypeArweave, "bNbA3TEQVL60xlgCcqdz4ZPHFZ711cZ3hmkpGttD", false},
+		{"file", StorageTypeFile, helloDigest, true},
+		{"file upper case", StorageTypeFile, strings.ToUpper(helloDigest), true},
+		{"file other key", StorageTypeFile, "blob", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCID(tc.scheme, tc.cid, helloDigest)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestStorageRefNormalize(t *testing.T) {
+	ref, err := StorageRef{
+		Scheme: StorageTypeFile,
+		CID:    strings.ToUpper(helloDigest),
+		Digest: strings.ToUpper(helloDigest),
+		Size:   5,
+	}.Normalize()
+	require.NoError(t, err)
+	require.Equal(t, helloDigest, ref.Digest)
+	require.Equal(t, helloDigest, ref.CID)
+
+	ref, err = StorageRef{
+		Scheme: StorageTypeIPFS,
+		CID:    "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG",
+		Digest: strings.ToUpper(helloDigest),
+		Size:   5,
+	}.Normalize()
+	require.NoError(t, err)
+	require.Equal(t, helloDigest, ref.Digest)
+	require.Equal(t, "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", ref.CID)
+
+	_, err = StorageRef{Scheme: StorageTypeIPFS, CID: "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", Digest: helloDigest}.Normalize()
+	require.Error(t, err)
+}
+
+func TestDecodeBase58(t *testing.T) {
+	bz, err := decodeBase58("11")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0}, bz)
+
+	bz, err = decodeBase58("15Q")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0xff}, bz)
+
+	_, err = decodeBase58("0OIl")
+	require.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Subscription plan status constants
+const (
+	SubscriptionPlanStatusActive = "active" // users can subscribe and subscriptions renew
+	SubscriptionPlanStatusClosed = "closed" // subscriptions run until the end of their period and lapse
+)
+
+// Reasons for which a subscription lapses
+const (
+	SubscriptionLapsedNotRenewed = "auto-renewal off"
+	SubscriptionLapsedNoFunds    = "prepaid balance exhausted"
+	SubscriptionLapsedPlanClosed = "plan closed"
+	SubscriptionLapsedAgent      = "agent no longer active"
+)
+
+// SubscriptionPlan is a plan an agent owner publishes for access to their agent over
+// a period. Subscribers pay the price upfront for every period, and their calls of the
+// agent within the period are free up to the action quota.
+type SubscriptionPlan struct {
+	ID          string        `json:"id"`
+	AgentID     string        `json:"agent_id"`
+	Name        string        `json:"name"`
+	Period      time.Duration `json:"period"`
+	Price       sdk.Coin      `json:"price"`
+	ActionQuota uint64        `json:"action_quota,omitempty"` // calls per period; 0 is unlimited
+	Status      string        `json:"status"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// AgentSubscription is a subscriber's access to an agent under a plan. Renewals are
+// paid from a balance the subscriber prepaid into the module account; a subscription
+// that is not renewed at the end of its period lapses and the balance is refunded.
+type AgentSubscription struct {
+	PlanID      string         `json:"plan_id"`
+	AgentID     string         `json:"agent_id"`
+	Subscriber  sdk.AccAddress `json:"subscriber"`
+	Balance     sdk.Coins      `json:"balance"` // the prepaid balance left for renewals
+	AutoRenew   bool           `json:"auto_renew"`
+	PeriodStart time.Time      `json:"period_start"`
+	PeriodEnd   time.Time      `json:"period_end"`
+	ActionQuota uint64         `json:"action_quota,omitempty"` // the plan's quota when the period started
+	ActionsUsed uint64         `json:"actions_used"`
+	Renewals    uint64         `json:"renewals"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// Validate performs basic validation of a subscription plan
+func (p SubscriptionPlan) Validate() error {
+	if p.ID == "" {
+		return fmt.Errorf("plan ID cannot be empty")
+	}
+	if p.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	if p.Name == "" {
+		return fmt.Errorf("plan name cannot be empty")
+	}
+	if p.Period <= 0 {
+		return fmt.Errorf("period must be positive")
+	}
+	if !p.Price.IsValid() {
+		return fmt.Errorf("invalid price: %s", p.Price)
+	}
+	if p.Status != SubscriptionPlanStatusActive && p.Status != SubscriptionPlanStatusClosed {
+		return fmt.Errorf("invalid plan status: %s", p.Status)
+	}
+	return nil
+}
+
+// IsActive returns true if users can subscribe to the plan
+func (p SubscriptionPlan) IsActive() bool {
+	return p.Status == SubscriptionPlanStatusActive
+}
+
+// Validate performs basic validation of a subscription
+func (s AgentSubscription) Validate() error {
+	if s.PlanID == "" {
+		return fmt.Errorf("plan ID cannot be empty")
+	}
+	if s.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	if s.Subscriber.Empty() {
+		return fmt.Errorf("subscriber cannot be empty")
+	}
+	if !s.Balance.IsValid() {
+		return fmt.Errorf("invalid balance: %s", s.Balance)
+	}
+	if !s.PeriodEnd.After(s.PeriodStart) {
+		return fmt.Errorf("period must end after it starts")
+	}
+	return nil
+}
+
+// IsActive returns true if the subscription's current period has not ended at the given time
+func (s AgentSubscription) IsActive(blockTime time.Time) bool {
+	return blockTime.Before(s.PeriodEnd)
+}
+
+// CoversCall returns true if the subscription covers a call of its agent at the given time
+func (s AgentSubscription) CoversCall(blockTime time.Time) bool {
+	return s.IsActive(blockTime) && (s.ActionQuota == 0 || s.ActionsUsed < s.ActionQuota)
+}
+
+// Renew returns the subscription moved to its next period under the plan, with the
+// plan's price paid from its balance, which must cover it. Periods missed because the
+// subscription fell behind are not charged; the next period starts at the block time.
+func (s AgentSubscription) Renew(plan SubscriptionPlan, blockTime time.Time) AgentSubscription {
+	s.PeriodStart = s.PeriodEnd
+	if !s.PeriodStart.Add(plan.Period).After(blockTime) {
+		s.PeriodStart = blockTime
+	}
+	s.PeriodEnd = s.PeriodStart.Add(plan.Period)
+	s.Balance = s.Balance.Sub(plan.Price)
+	s.ActionQuota = plan.ActionQuota
+	s.ActionsUsed = 0
+	s.Renewals++
+	return s
+}
\ No newline at end of file
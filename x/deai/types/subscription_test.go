@@ -0,0 +1,94 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentSubscriptionRenew(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	month := 30 * 24 * time.Hour
+	plan := SubscriptionPlan{Period: month, Price: sdk.NewInt64Coin("stake", 100), ActionQuota: 50}
+
+	subscription := AgentSubscription{
+		Balance:     sdk.NewCoins(sdk.NewInt64Coin("stake", 250), sdk.NewInt64Coin("uatom", 5)),
+		PeriodStart: start,
+		PeriodEnd:   start.Add(month),
+		ActionQuota: 10,
+		ActionsUsed: 10,
+	}
+
+	// A renewal on time continues from the end of the period
+	renewed := subscription.Renew(plan, start.Add(month))
+	require.Equal(t, start.Add(month), renewed.PeriodStart)
+	require.Equal(t, start.Add(2*month), renewed.PeriodEnd)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 150), sdk.NewInt64Coin("uatom", 5)), renewed.Balance)
+	require.Equal(t, uint64(50), renewed.ActionQuota)
+	require.Zero(t, renewed.ActionsUsed)
+	require.Equal(t, uint64(1), renewed.Renewals)
+
+	// A late renewal within the next period keeps the period boundaries
+	renewed = subscription.Renew(plan, start.Add(month+time.Hour))
+	require.Equal(t, start.Add(month), renewed.PeriodStart)
+
+	// Missed periods are not charged: the next period starts at the block time
+	late := start.Add(3*month + time.Hour)
+	renewed = subscription.Renew(plan, late)
+	require.Equal(t, late, renewed.PeriodStart)
+	require.Equal(t, late.Add(month), renewed.PeriodEnd)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 150), sdk.NewInt64Coin("uatom", 5)), renewed.Balance)
+}
+
+func TestAgentSubscriptionCoversCall(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	subscription := AgentSubscription{PeriodStart: start, PeriodEnd: start.Add(time.Hour), ActionQuota: 2, ActionsUsed: 1}
+
+	require.True(t, subscription.CoversCall(start))
+	require.False(t, subscription.CoversCall(start.Add(time.Hour)))
+	require.True(t, subscription.IsActive(start.Add(time.Hour-time.Nanosecond)))
+
+	subscription.ActionsUsed = 2
+	require.False(t, subscription.CoversCall(start))
+	require.True(t, subscription.IsActive(start))
+
+	// A zero quota is unlimited
+	subscription.ActionQuota = 0
+	require.True(t, subscription.CoversCall(start))
+}
+
+func TestSubscriptionPlanValidate(t *testing.T) {
+	valid := func() SubscriptionPlan {
+		return SubscriptionPlan{
+			ID:      "plan",
+			AgentID: "agent",
+			Name:    "monthly",
+			Period:  time.Hour,
+			Price:   sdk.NewInt64Coin("stake", 100),
+			Status:  SubscriptionPlanStatusActive,
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(p *SubscriptionPlan)
+	}{
+		{"no ID", func(p *SubscriptionPlan) { p.ID = "" }},
+		{"no agent", func(p *SubscriptionPlan) { p.AgentID = "" }},
+		{"no name", func(p *SubscriptionPlan) { p.Name = "" }},
+		{"no period", func(p *SubscriptionPlan) { p.Period = 0 }},
+		{"invalid price", func(p *SubscriptionPlan) { p.Price = sdk.Coin{Denom: "stake", Amount: sdk.NewInt(-1)} }},
+		{"invalid status", func(p *SubscriptionPlan) { p.Status = "paused" }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := valid()
+			tc.modify(&plan)
+			require.Error(t, plan.Validate())
+		})
+	}
+}
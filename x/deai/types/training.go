@@ -0,0 +1,66 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Training job status constants
+const (
+	TrainingJobStatusOpen      = "open"      // waiting for a trainer to claim the job
+	TrainingJobStatusClaimed   = "claimed"   // a trainer is producing the model
+	TrainingJobStatusSubmitted = "submitted" // the trained model waits for the owner's review
+	TrainingJobStatusAccepted  = "accepted"  // the agent moved to the trained model and the trainer was paid
+	TrainingJobStatusRejected  = "rejected"  // the owner rejected the trained model and the budget was refunded
+	TrainingJobStatusExpired   = "expired"   // the job timed out and the budget was refunded
+)
+
+// TrainingJob is a request to train a new model version for an AI agent. Off-chain
+// trainers claim the job and submit the resulting model, which only replaces the
+// agent's model once the owner accepts it.
+type TrainingJob struct {
+	ID              string          `json:"id"`
+	AgentID         string          `json:"agent_id"`
+	Owner           sdk.AccAddress  `json:"owner"` // the account that funded the job
+	DatasetRefs     []string        `json:"dataset_refs"`
+	Hyperparameters json.RawMessage `json:"hyperparameters,omitempty"`
+	Budget          sdk.Coins       `json:"budget"`
+	BaseModelID     string          `json:"base_model_id"`
+	Status          string          `json:"status"`
+	Trainer         sdk.AccAddress  `json:"trainer,omitempty"`
+	Deadline        int64           `json:"deadline"` // last block height to claim an open job, submit a claimed one or review a submitted one
+	ModelHash       string          `json:"model_hash,omitempty"`
+	ModelURI        string          `json:"model_uri,omitempty"`
+	Parameters      json.RawMessage `json:"parameters,omitempty"` // parameters of on-chain models
+	Metrics         json.RawMessage `json:"metrics,omitempty"`
+	ResultModelID   string          `json:"result_model_id,omitempty"`
+	RejectReason    string          `json:"reject_reason,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// IsPending returns true if the job has not been resolved yet
+func (j TrainingJob) IsPending() bool {
+	switch j.Status {
+	case TrainingJobStatusOpen, TrainingJobStatusClaimed, TrainingJobStatusSubmitted:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateModelHash checks that a model hash is a hex encoded sha256 digest
+func ValidateModelHash(modelHash string) error {
+	bz, err := hex.DecodeString(modelHash)
+	if err != nil {
+		return fmt.Errorf("model hash must be hex encoded: %w", err)
+	}
+	if len(bz) != 32 {
+		return fmt.Errorf("model hash must be a sha256 digest")
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrainingJobIsPending(t *testing.T) {
+	for status, pending := range map[string]bool{
+		TrainingJobStatusOpen:      true,
+		TrainingJobStatusClaimed:   true,
+		TrainingJobStatusSubmitted: true,
+		TrainingJobStatusAccepted:  false,
+		TrainingJobStatusRejected:  false,
+		TrainingJobStatusExpired:   false,
+	} {
+		require.Equal(t, pending, TrainingJob{Status: status}.IsPending(), status)
+	}
+}
+
+func TestValidateModelHash(t *testing.T) {
+	require.NoError(t, ValidateModelHash(strings.Repeat("ab", 32)))
+	require.Error(t, ValidateModelHash(""))
+	require.Error(t, ValidateModelHash(strings.Repeat("ab", 31)))
+	require.Error(t, ValidateModelHash(strings.Repeat("zz", 32)))
+}
+
+func TestMsgTrainAIAgentValidateBasic(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner_______________"))
+	budget := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	tests := []struct {
+		name  string
+		msg   MsgTrainAIAgent
+		valid bool
+	}{
+		{"inline data", MsgTrainAIAgent{Owner: owner, AgentID: "agent", DataType: "samples", Data: json.RawMessage(`[]`), Budget: budget}, true},
+		{"dataset references", MsgTrainAIAgent{Owner: owner, AgentID: "agent", DatasetRefs: []string{"ipfs://data"}, Hyperparameters: json.RawMessage(`{"lr": 0.1}`), Budget: budget}, true},
+		{"no owner", MsgTrainAIAgent{AgentID: "agent", DatasetRefs: []string{"ipfs://data"}, Budget: budget}, false},
+		{"no agent", MsgTrainAIAgent{Owner: owner, DatasetRefs: []string{"ipfs://data"}, Budget: budget}, false},
+		{"no data", MsgTrainAIAgent{Owner: owner, AgentID: "agent", Budget: budget}, false},
+		{"inline data without type", MsgTrainAIAgent{Owner: owner, AgentID: "agent", Data: json.RawMessage(`[]`), Budget: budget}, false},
+		{"empty dataset reference", MsgTrainAIAgent{Owner: owner, AgentID: "agent", DatasetRefs: []string{""}, Budget: budget}, false},
+		{"invalid hyperparameters", MsgTrainAIAgent{Owner: owner, AgentID: "agent", DatasetRefs: []string{"ipfs://data"}, Hyperparameters: json.RawMessage(`{`), Budget: budget}, false},
+		{"no budget", MsgTrainAIAgent{Owner: owner, AgentID: "agent", DatasetRefs: []string{"ipfs://data"}}, false},
+		{"wrapped key without data", MsgTrainAIAgent{Owner: owner, AgentID: "agent", DatasetRefs: []string{"ipfs://data"}, Budget: budget, WrappedKey: []byte{1}}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMsgSubmitTrainingResultValidateBasic(t *testing.T) {
+	trainer := sdk.AccAddress([]byte("trainer_____________"))
+	hash := strings.Repeat("ab", 32)
+
+	require.NoError(t, NewMsgSubmitTrainingResult(trainer, "job", hash, "ipfs://model", nil, json.RawMessage(`{"accuracy": 0.9}`)).ValidateBasic())
+	require.Error(t, NewMsgSubmitTrainingResult(nil, "job", hash, "", nil, nil).ValidateBasic())
+	require.Error(t, NewMsgSubmitTrainingResult(trainer, "", hash, "", nil, nil).ValidateBasic())
+	require.Error(t, NewMsgSubmitTrainingResult(trainer, "job", "model", "", nil, nil).ValidateBasic())
+	require.Error(t, NewMsgSubmitTrainingResult(trainer, "job", hash, "", json.RawMessage(`{`), nil).ValidateBasic())
+	require.Error(t, NewMsgSubmitTrainingResult(trainer, "job", hash, "", nil, json.RawMessage(`{`)).ValidateBasic())
+}
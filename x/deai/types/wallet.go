@@ -0,0 +1,138 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Type URLs of the messages an agent wallet can be allowed to send
+const (
+	AgentWalletMsgBankSend        = "/cosmos.bank.v1beta1.MsgSend"
+	AgentWalletMsgDelegate        = "/nomercychain.nmxchain.neuropos.MsgDelegate"
+	AgentWalletMsgExecuteContract = "/nomercychain.nmxchain.dynacontract.MsgExecuteDynaContract"
+)
+
+// IsAgentWalletMsgType returns true if agent wallets can be allowed to send messages of the type
+func IsAgentWalletMsgType(typeURL string) bool {
+	switch typeURL {
+	case AgentWalletMsgBankSend, AgentWalletMsgDelegate, AgentWalletMsgExecuteContract:
+		return true
+	default:
+		return false
+	}
+}
+
+// AgentWalletAddress returns the account address of an agent's wallet. The address is
+// derived from the module name and the agent ID, so no private key exists for it and
+// only the module can sign for it.
+func AgentWalletAddress(agentID string) sdk.AccAddress {
+	return address.Module(ModuleName, []byte("agent/"+agentID))
+}
+
+// AgentWalletPolicy constrains what an agent can do with its wallet. Messages are only
+// sent from the wallet if their type is allowed and the action was requested by the
+// owner or one of the allowed requesters, and the wallet may lose at most SpendLimit
+// per Period. An agent without a policy cannot spend from its wallet.
+type AgentWalletPolicy struct {
+	AgentID           string           `json:"agent_id"`
+	SpendLimit        sdk.Coins        `json:"spend_limit"`
+	Period            time.Duration    `json:"period"`
+	AllowedMsgTypes   []string         `json:"allowed_msg_types"`
+	PeriodStart       time.Time        `json:"period_start"`
+	PeriodSpent       sdk.Coins        `json:"period_spent"` // spent since PeriodStart
+	AllowedRequesters []sdk.AccAddress `json:"allowed_requesters"`
+}
+
+// Validate performs basic validation of an agent wallet policy
+func (p AgentWalletPolicy) Validate() error {
+	if p.AgentID == "" {
+		return fmt.Errorf("agent ID cannot be empty")
+	}
+	if !p.SpendLimit.IsValid() {
+		return fmt.Errorf("invalid spend limit: %s", p.SpendLimit)
+	}
+	if p.Period <= 0 {
+		return fmt.Errorf("spend limit period must be positive")
+	}
+	if !p.PeriodSpent.IsValid() {
+		return fmt.Errorf("invalid spent amount: %s", p.PeriodSpent)
+	}
+
+	seen := make(map[string]bool)
+	for _, typeURL := range p.AllowedMsgTypes {
+		if !IsAgentWalletMsgType(typeURL) {
+			return fmt.Errorf("agent wallets cannot send %s messages", typeURL)
+		}
+		if seen[typeURL] {
+			return fmt.Errorf("duplicate allowed message type: %s", typeURL)
+		}
+		seen[typeURL] = true
+	}
+
+	requesters := make(map[string]bool)
+	for _, requester := range p.AllowedRequesters {
+		if requester.Empty() {
+			return fmt.Errorf("allowed requester cannot be empty")
+		}
+		if requesters[requester.String()] {
+			return fmt.Errorf("duplicate allowed requester: %s", requester)
+		}
+		requesters[requester.String()] = true
+	}
+	return nil
+}
+
+// AllowsMsgType returns true if the policy allows the agent to send messages of the type
+func (p AgentWalletPolicy) AllowsMsgType(typeURL string) bool {
+	for _, allowed := range p.AllowedMsgTypes {
+		if allowed == typeURL {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRequester returns true if the policy lets actions requested by the account send
+// messages from the wallet. Requests of the owner are always allowed.
+func (p AgentWalletPolicy) AllowsRequester(owner, requester sdk.AccAddress) bool {
+	if requester.Equals(owner) {
+		return true
+	}
+	for _, allowed := range p.AllowedRequesters {
+		if allowed.Equals(requester) {
+			return true
+		}
+	}
+	return false
+}
+
+// Spend records the coins the wallet lost at the given block time against the spend
+// limit. A new period starts once Period has passed since PeriodStart. It returns an
+// error if the spending would bring the period total above the spend limit; denoms
+// missing from the spend limit cannot be spent.
+func (p AgentWalletPolicy) Spend(blockTime time.Time, spent sdk.Coins) (AgentWalletPolicy, error) {
+	if blockTime.Sub(p.PeriodStart) >= p.Period {
+		p.PeriodStart = blockTime
+		p.PeriodSpent = sdk.NewCoins()
+	}
+
+	total := p.PeriodSpent.Add(spent...)
+	if !total.IsAllLTE(p.SpendLimit) {
+		return p, sdkerrors.Wrapf(ErrSpendLimitExceeded, "spending %s would bring the period total to %s, above %s", spent, total, p.SpendLimit)
+	}
+
+	p.PeriodSpent = total
+	return p, nil
+}
+
+// AgentWalletResult is the part of an action result that asks for messages to be sent
+// from the agent's wallet. Each message is JSON encoded with its "@type" URL, and all
+// messages of a result are sent atomically once the result is accepted.
+type AgentWalletResult struct {
+	WalletMsgs []json.RawMessage `json:"wallet_msgs,omitempty"`
+}
\ No newline at end of file
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentWalletPolicySpend(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := AgentWalletPolicy{
+		AgentID:     "agent",
+		SpendLimit:  sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		Period:      time.Hour,
+		PeriodStart: start,
+		PeriodSpent: sdk.NewCoins(),
+	}
+
+	// Spending up to the limit within a period is allowed
+	policy, err := policy.Spend(start.Add(time.Minute), sdk.NewCoins(sdk.NewInt64Coin("stake", 60)))
+	require.NoError(t, err)
+	policy, err = policy.Spend(start.Add(2*time.Minute), sdk.NewCoins(sdk.NewInt64Coin("stake", 40)))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), policy.PeriodSpent)
+	require.Equal(t, start, policy.PeriodStart)
+
+	// The limit is exhausted until the period ends
+	_, err = policy.Spend(start.Add(59*time.Minute), sdk.NewCoins(sdk.NewInt64Coin("stake", 1)))
+	require.ErrorIs(t, err, ErrSpendLimitExceeded)
+
+	// A new period starts once the period has passed
+	next := start.Add(time.Hour)
+	policy, err = policy.Spend(next, sdk.NewCoins(sdk.NewInt64Coin("stake", 30)))
+	require.NoError(t, err)
+	require.Equal(t, next, policy.PeriodStart)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 30)), policy.PeriodSpent)
+}
+
+func TestAgentWalletPolicySpendRejected(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := AgentWalletPolicy{
+		AgentID:     "agent",
+		SpendLimit:  sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		Period:      time.Hour,
+		PeriodStart: start,
+		PeriodSpent: sdk.NewCoins(sdk.NewInt64Coin("stake", 50)),
+	}
+
+	tests := []struct {
+		name  string
+		spent sdk.Coins
+	}{
+		{"above the rest of the limit", sdk.NewCoins(sdk.NewInt64Coin("stake", 51))},
+		{"denom without a limit", sdk.NewCoins(sdk.NewInt64Coin("uatom", 1))},
+		{"one denom above the limit", sdk.NewCoins(sdk.NewInt64Coin("stake", 10), sdk.NewInt64Coin("uatom", 1))},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			updated, err := policy.Spend(start.Add(time.Minute), tc.spent)
+			require.ErrorIs(t, err, ErrSpendLimitExceeded)
+			require.Equal(t, policy.PeriodSpent, updated.PeriodSpent)
+		})
+	}
+}
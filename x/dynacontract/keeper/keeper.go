@@ -10,7 +10,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/nomercychain/nmxchain/x/dynacontract/types"
-	"github.com/nomercychain/nmxchain/x/deai/types" // For AI agent integration
+	deaitypes "github.com/nomercychain/nmxchain/x/deai/types" // For AI agent integration
 )
 
 // Keeper of the dynacontract store
@@ -393,6 +393,15 @@ func (k Keeper) ExecuteDynaContract(ctx sdk.Context, contractID string, caller s
 		if agent.Status != deaitypes.AIAgentStatusActive {
 			return nil, 0, types.ErrAgentNotActive
 		}
+
+		// The contract owner attached the agent, so the agent's policy must admit the owner
+		contractOwner, err := sdk.AccAddressFromBech32(contract.Owner)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := k.deaiKeeper.AuthorizeAgentCall(ctx, agent, contractOwner, deaitypes.AgentActionTypeDynaContract, nil); err != nil {
+			return nil, 0, err
+		}
 		
 		// Execute the contract using the AI agent
 		// This is a simplified implementation
@@ -22,4 +22,5 @@ type DeAIKeeper interface {
 	GetAIAgentState(ctx sdk.Context, agentID string) (state deaitypes.AIAgentState, found bool)
 	SetAIAgentState(ctx sdk.Context, state deaitypes.AIAgentState)
 	ExecuteAIAgent(ctx sdk.Context, agentID string, sender sdk.AccAddress, actionType string, data []byte, fee sdk.Coin) (actionID string, result []byte, err error)
+	AuthorizeAgentCall(ctx sdk.Context, agent deaitypes.AIAgent, caller sdk.AccAddress, actionType string, fee sdk.Coins) error
 }
\ No newline at end of file
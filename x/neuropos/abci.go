@@ -1,64 +1,75 @@
 package neuropos
 
 import (
+	"fmt"
 	"time"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/nomercychain/nmxchain/x/neuropos/keeper"
 	"github.com/nomercychain/nmxchain/x/neuropos/types"
-	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // BeginBlocker is called at the beginning of every block
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
-	// Update network state metrics
-	k.UpdateNetworkState(ctx)
-
 	// Process missed blocks for validators
 	processMissedBlocks(ctx, req, k)
 
 	// Update neural networks periodically
 	updateNeuralNetworks(ctx, k)
 
-	// Detect anomalies using AI models
+	// Count the votes and proposals of validators for the anomaly detectors
+	k.RecordValidatorActivity(ctx, req)
+
+	// Detect anomalies in the network state history and validator activity
 	anomalyReports := k.DetectAnomalies(ctx)
 
 	// Process anomaly reports
+	params := k.GetParams(ctx)
 	for _, report := range anomalyReports {
+		// If the anomaly of a validator is severe enough, queue a penalty. The validator
+		// can dispute it until the end of its challenge period. In dry-run mode reports
+		// are only stored, so the detectors can be tuned without penalties.
+		if !params.AnomalyDryRun && len(report.ValidatorAddress) > 0 && report.Confidence.GTE(params.AnomalySlashConfidence) {
+			if _, found := k.StakingKeeper.GetValidator(ctx, sdk.ValAddress(report.ValidatorAddress)); found {
+				report.PenaltyID = k.QueuePenalty(ctx, report)
+			}
+		}
+
 		// Store the anomaly report
 		k.SetAnomalyReport(ctx, report)
 
-		// If the anomaly is severe enough, take action
-		if report.Confidence.GT(sdk.NewDecWithPrec(9, 1)) { // 90% confidence
-			// Example: slash the validator that caused the anomaly
-			// This is a simplified example - real implementation would be more nuanced
-			validator, found := k.StakingKeeper.GetValidator(ctx, sdk.ValAddress(report.ValidatorAddress))
-			if found {
-				consAddr, err := validator.GetConsAddr()
-				if err == nil {
-					// Slash a small amount for detected anomalies
-					k.StakingKeeper.Slash(ctx, consAddr, ctx.BlockHeight(), validator.ConsensusPower(sdk.DefaultPowerReduction), sdk.NewDecWithPrec(1, 2)) // 1%
-					
-					// Update validator reputation
-					k.UpdateValidatorReputation(ctx, validator.GetOperator().String(), sdk.NewDecWithPrec(-5, 2), "anomaly detected") // -0.05
-				}
-			}
-		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeAnomalyDetected,
+				sdk.NewAttribute(types.AttributeKeyAnomalyID, fmt.Sprintf("%d", report.ID)),
+				sdk.NewAttribute(types.AttributeKeyAnomalyType, report.AnomalyType),
+				sdk.NewAttribute(types.AttributeKeyAnomalyConfidence, report.Confidence.String()),
+				sdk.NewAttribute(types.AttributeKeyValidator, report.ValidatorAddress.String()),
+				sdk.NewAttribute(types.AttributeKeyDryRun, fmt.Sprintf("%t", params.AnomalyDryRun)),
+			),
+		)
 	}
+
+	// Execute, reduce or cancel the penalties whose challenge period has ended, before
+	// staking computes the validator set of this block
+	k.ResolvePenalties(ctx)
 }
 
 // EndBlocker is called at the end of every block
 func EndBlocker(ctx sdk.Context, req abci.RequestEndBlock, k keeper.Keeper) []abci.ValidatorUpdate {
+	// Update network state metrics at the end of the block, when its gas usage is known
+	k.UpdateNetworkState(ctx)
+
 	// Adjust block parameters based on network state
 	k.AdjustBlockParameters(ctx)
 
 	// Update validator performances
 	updateValidatorPerformances(ctx, req, k)
 
-	// Return validator updates
-	// In a real implementation, this might include AI-based validator scoring
-	return []abci.ValidatorUpdate{}
+	// Send the reputation-weighted voting powers to consensus
+	return k.ApplyValidatorPowerUpdates(ctx)
 }
 
 // processSigningInfo processes the signing info for validators
@@ -96,7 +107,7 @@ func processMissedBlocks(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.K
 		k.UpdateValidatorPerformance(ctx, validator.GetOperator().String(), false, false, true)
 
 		// If double signing, slash severely
-		if evidence.Type == abci.EvidenceType_DUPLICATE_VOTE {
+		if evidence.Type == abci.MisbehaviorType_DUPLICATE_VOTE {
 			// Get slash fraction for double signing
 			slashFraction := k.SlashFractionDoubleSign(ctx)
 
@@ -228,34 +239,11 @@ func updateNeuralNetworks(ctx sdk.Context, k keeper.Keeper) {
 
 	// If it's time to update
 	if ctx.BlockTime().Sub(lastUpdate) >= params.NeuralNetworkUpdateInterval {
-		// Get all neural networks
-		networks := k.GetAllNeuralNetworks(ctx)
+		// Train the networks for an epoch on their recent training data and measure
+		// their accuracy and loss, for as many networks as the block's update gas covers
+		networks, done := k.RetrainNeuralNetworks(ctx)
 
-		// Update each network
 		for _, network := range networks {
-			// Skip networks that are already being updated or trained
-			if network.Status == types.NeuralNetworkStatusUpdating || network.Status == types.NeuralNetworkStatusTraining {
-				continue
-			}
-
-			// Get training data for this network
-			trainingData := k.GetTrainingDataByNetwork(ctx, network.ID)
-			if len(trainingData) == 0 {
-				continue
-			}
-
-			// Set network status to updating
-			network.Status = types.NeuralNetworkStatusUpdating
-			k.SetNeuralNetwork(ctx, network)
-
-			// In a real implementation, this would trigger the actual neural network update
-			// For now, we'll simulate an update by improving accuracy slightly
-			network.Accuracy = sdk.MinDec(sdk.OneDec(), network.Accuracy.Add(sdk.NewDecWithPrec(1, 2))) // +0.01
-			network.Loss = sdk.MaxDec(sdk.ZeroDec(), network.Loss.Sub(sdk.NewDecWithPrec(1, 2)))       // -0.01
-			network.Status = types.NeuralNetworkStatusActive
-			network.LastUpdatedTime = ctx.BlockTime()
-			k.SetNeuralNetwork(ctx, network)
-
 			// Emit event
 			ctx.EventManager().EmitEvent(
 				sdk.NewEvent(
@@ -267,8 +255,10 @@ func updateNeuralNetworks(ctx sdk.Context, k keeper.Keeper) {
 			)
 		}
 
-		// Update last update time
-		nowBytes, _ := ctx.BlockTime().MarshalBinary()
-		store.Set(lastUpdateKey, nowBytes)
+		// Update last update time once every network had its turn
+		if done {
+			nowBytes, _ := ctx.BlockTime().MarshalBinary()
+			store.Set(lastUpdateKey, nowBytes)
+		}
 	}
 }
\ No newline at end of file
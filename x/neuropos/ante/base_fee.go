@@ -0,0 +1,66 @@
+package ante
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/keeper"
+)
+
+// BaseFeeDecorator rejects transactions whose fee in the base fee denom does not cover
+// the base fee for their gas limit, and burns the base fee share of the fees once the
+// rest of the ante chain has deducted them. It records the fees and gas limits of the
+// transactions of every block for its average fee rate, also when the base fee is
+// disabled.
+type BaseFeeDecorator struct {
+	keeper keeper.Keeper
+}
+
+// NewBaseFeeDecorator returns a new BaseFeeDecorator
+func NewBaseFeeDecorator(k keeper.Keeper) BaseFeeDecorator {
+	return BaseFeeDecorator{keeper: k}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (d BaseFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	// Genesis transactions pay no fees
+	if ctx.BlockHeight() == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "transaction must be a FeeTx")
+	}
+
+	enabled := d.keeper.BaseFeeEnabled(ctx)
+	required := d.keeper.RequiredBaseFee(ctx, feeTx.GetGas())
+	if enabled && !simulate {
+		if paid := feeTx.GetFee().AmountOf(required.Denom); paid.LT(required.Amount) {
+			return ctx, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "insufficient fees; got: %s%s required base fee: %s", paid, required.Denom, required)
+		}
+	}
+
+	newCtx, err := next(ctx, tx, simulate)
+	if err != nil || simulate {
+		return newCtx, err
+	}
+
+	if enabled {
+		if err := d.keeper.BurnBaseFee(newCtx, required); err != nil {
+			return newCtx, sdkerrors.Wrapf(err, "failed to burn base fee %s", required)
+		}
+	}
+
+	d.keeper.AddBlockFees(newCtx, feeTx.GetFee().AmountOf(required.Denom), feeTx.GetGas())
+	return newCtx, nil
+}
+
+// NewAnteHandler wraps an ante handler, typically the default auth one, with the
+// BaseFeeDecorator so that the base fee is checked before and burned after it runs
+func NewAnteHandler(k keeper.Keeper, next sdk.AnteHandler) sdk.AnteHandler {
+	decorator := NewBaseFeeDecorator(k)
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return decorator.AnteHandle(ctx, tx, simulate, next)
+	}
+}
\ No newline at end of file
@@ -48,6 +48,7 @@ func GetQueryCmd() *cobra.Command {
 		NewQueryValidatorSlashEventsCmd(),
 		NewQueryNetworkStateCmd(),
 		NewQueryAnomalyReportsCmd(),
+		NewQueryBaseFeeCmd(),
 	)
 
 	return neuroposQueryCmd
@@ -832,3 +833,31 @@ func NewQueryAnomalyReportsCmd() *cobra.Command {
 
 	return cmd
 }
+
+// NewQueryBaseFeeCmd returns a CLI command handler for querying the base fee
+func NewQueryBaseFeeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "base-fee",
+		Short: "Query the current base fee and the base fees projected after the next block",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.BaseFee(cmd.Context(), &types.QueryBaseFeeRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
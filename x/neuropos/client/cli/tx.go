@@ -37,6 +37,8 @@ func GetTxCmd() *cobra.Command {
 		NewTrainNeuralNetworkCmd(),
 		NewSubmitNeuralPredictionCmd(),
 		NewUpdateValidatorReputationCmd(),
+		NewDisputePenaltyCmd(),
+		NewVotePenaltyCmd(),
 	)
 
 	return neuroposTxCmd
@@ -587,5 +589,80 @@ The reason is a string describing the reason for the reputation change.`,
 
 	flags.AddTxFlagsToCmd(cmd)
 
+	return cmd
+}
+
+// NewDisputePenaltyCmd returns a CLI command handler for disputing an anomaly penalty
+func NewDisputePenaltyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dispute-penalty [penalty-id] [bond] [counter-evidence]",
+		Short: "Dispute the pending anomaly penalty of your validator",
+		Long: `Dispute the pending anomaly penalty of your validator during its challenge period.
+
+The bond is escrowed until the challenge period ends. It is refunded if the other validators
+vote to reduce or cancel the penalty, and burned if the penalty is upheld.
+
+The counter-evidence is a string explaining why the anomaly report is a false positive.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			penaltyID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid penalty ID: %w", err)
+			}
+
+			bond, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid bond: %w", err)
+			}
+
+			valAddr := sdk.ValAddress(clientCtx.GetFromAddress())
+
+			msg := types.NewMsgDisputePenalty(valAddr, penaltyID, bond, args[2])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewVotePenaltyCmd returns a CLI command handler for voting on a disputed anomaly penalty
+func NewVotePenaltyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-penalty [penalty-id] [option]",
+		Short: "Vote on a disputed anomaly penalty of another validator",
+		Long: fmt.Sprintf(`Vote on a disputed anomaly penalty of another validator with your validator.
+
+The option is one of %s, %s or %s. Votes are weighted by voting power when the
+challenge period ends.`, types.PenaltyVoteUphold, types.PenaltyVoteReduce, types.PenaltyVoteCancel),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			penaltyID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid penalty ID: %w", err)
+			}
+
+			valAddr := sdk.ValAddress(clientCtx.GetFromAddress())
+
+			msg := types.NewMsgVotePenalty(valAddr, penaltyID, args[1])
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
 	return cmd
 }
\ No newline at end of file
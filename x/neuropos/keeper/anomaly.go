@@ -0,0 +1,401 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// Minimum standard deviations of the metrics, so that a metric that has been constant
+// is not reported for the smallest change
+var (
+	// minRateStdDev is one percentage point for miss rates, proposer skews and congestion
+	minRateStdDev = sdk.NewDecWithPrec(1, 2)
+
+	// minRelativeStdDev is one percent of the mean for fee rates
+	minRelativeStdDev = sdk.NewDecWithPrec(1, 2)
+)
+
+// SetNetworkStateHistory adds the network state of a block to the history and drops the
+// states that fell out of the anomaly history window
+func (k Keeper) SetNetworkStateHistory(ctx sdk.Context, state types.NetworkState) {
+	store := ctx.KVStore(k.storeKey)
+	value := k.cdc.MustMarshal(&state)
+	store.Set(types.NetworkStateHistoryKey(state.BlockHeight), value)
+
+	// Collect the expired states first, the store cannot be written while iterated
+	cutoff := state.BlockHeight - int64(k.AnomalyHistoryWindow(ctx))
+	if cutoff <= 0 {
+		return
+	}
+
+	var expired [][]byte
+	iterator := store.Iterator(types.NetworkStateHistoryKeyPrefix, types.NetworkStateHistoryKey(cutoff+1))
+	for ; iterator.Valid(); iterator.Next() {
+		expired = append(expired, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range expired {
+		store.Delete(key)
+	}
+}
+
+// GetNetworkStateHistory returns the network states of the last blocks, oldest first
+func (k Keeper) GetNetworkStateHistory(ctx sdk.Context) []types.NetworkState {
+	var states []types.NetworkState
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.NetworkStateHistoryKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var state types.NetworkState
+		k.cdc.MustUnmarshal(iterator.Value(), &state)
+		states = append(states, state)
+	}
+
+	return states
+}
+
+// SetValidatorAnomalyStats sets a validator's anomaly statistics
+func (k Keeper) SetValidatorAnomalyStats(ctx sdk.Context, stats types.ValidatorAnomalyStats) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.ValidatorAnomalyStatsKey(stats.ValidatorAddress)
+	value := k.cdc.MustMarshal(&stats)
+	store.Set(key, value)
+}
+
+// GetValidatorAnomalyStats returns a validator's anomaly statistics
+func (k Keeper) GetValidatorAnomalyStats(ctx sdk.Context, validatorAddr string) (types.ValidatorAnomalyStats, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.ValidatorAnomalyStatsKey(validatorAddr)
+	value := store.Get(key)
+	if value == nil {
+		return types.ValidatorAnomalyStats{}, false
+	}
+
+	var stats types.ValidatorAnomalyStats
+	k.cdc.MustUnmarshal(value, &stats)
+	return stats, true
+}
+
+// DeleteValidatorAnomalyStats deletes a validator's anomaly statistics
+func (k Keeper) DeleteValidatorAnomalyStats(ctx sdk.Context, validatorAddr string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.ValidatorAnomalyStatsKey(validatorAddr))
+}
+
+// GetAllValidatorAnomalyStats returns the anomaly statistics of all validators
+func (k Keeper) GetAllValidatorAnomalyStats(ctx sdk.Context) []types.ValidatorAnomalyStats {
+	var stats []types.ValidatorAnomalyStats
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ValidatorAnomalyStatsKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var s types.ValidatorAnomalyStats
+		k.cdc.MustUnmarshal(iterator.Value(), &s)
+		stats = append(stats, s)
+	}
+
+	return stats
+}
+
+// nextAnomalyReportID returns the ID of the next anomaly report and increments it
+func (k Keeper) nextAnomalyReportID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	id := uint64(1)
+	if bz := store.Get(types.NextAnomalyReportIDKey); bz != nil {
+		id = sdk.BigEndianToUint64(bz)
+	}
+	store.Set(types.NextAnomalyReportIDKey, sdk.Uint64ToBigEndian(id+1))
+	return id
+}
+
+// RecordValidatorActivity counts for the current detection window which validators
+// signed the last block, which one proposed this block and how many proposals each
+// validator could expect from its share of the voting power
+func (k Keeper) RecordValidatorActivity(ctx sdk.Context, req abci.RequestBeginBlock) {
+	votes := req.LastCommitInfo.GetVotes()
+
+	totalPower := int64(0)
+	for _, vote := range votes {
+		totalPower += vote.Validator.Power
+	}
+
+	proposer := sdk.ConsAddress(req.Header.ProposerAddress)
+	for _, vote := range votes {
+		consAddr := sdk.ConsAddress(vote.Validator.Address)
+		validator, found := k.stakingKeeper.GetValidatorByConsAddr(ctx, consAddr)
+		if !found {
+			continue
+		}
+
+		valAddr := validator.GetOperator().String()
+		stats, found := k.GetValidatorAnomalyStats(ctx, valAddr)
+		if !found {
+			stats = types.ValidatorAnomalyStats{
+				ValidatorAddress: valAddr,
+				WindowExpected:   sdk.ZeroDec(),
+				MissRate:         newEWMAStat(),
+				ProposerSkew:     newEWMAStat(),
+			}
+		}
+
+		if vote.SignedLastBlock {
+			stats.WindowSigned++
+		} else {
+			stats.WindowMissed++
+		}
+		if consAddr.Equals(proposer) {
+			stats.WindowProposed++
+		}
+		if totalPower > 0 {
+			stats.WindowExpected = stats.WindowExpected.Add(sdk.NewDec(vote.Validator.Power).QuoInt64(totalPower))
+		}
+
+		k.SetValidatorAnomalyStats(ctx, stats)
+	}
+}
+
+// DetectAnomalies runs the anomaly detectors enabled in the params. The network
+// detectors compare the last block with the blocks of the anomaly history window; the
+// validator detectors compare the activity of every validator in the window that ends
+// at this block with its baselines over past windows. A metric is reported when its
+// z-score exceeds the threshold, with a confidence of 1 - 1/z^2, the lower bound
+// Chebyshev's inequality gives for the deviation not being chance whatever the
+// distribution of the metric.
+func (k Keeper) DetectAnomalies(ctx sdk.Context) []types.AnomalyReport {
+	params := k.GetParams(ctx)
+	enabled := make(map[string]bool)
+	for _, detector := range params.AnomalyDetectors {
+		enabled[detector] = true
+	}
+
+	reports := []types.AnomalyReport{}
+	if enabled[types.AnomalyTypeCongestionSpike] || enabled[types.AnomalyTypeFeeRateShift] {
+		reports = append(reports, k.detectNetworkAnomalies(ctx, params, enabled)...)
+	}
+
+	// Windows are closed even when the validator detectors are disabled, so that the
+	// baselines are ready when they are enabled
+	if uint64(ctx.BlockHeight())%params.AnomalyValidatorWindow == 0 {
+		reports = append(reports, k.detectValidatorAnomalies(ctx, params, enabled)...)
+	}
+
+	for i := range reports {
+		reports[i].ID = k.nextAnomalyReportID(ctx)
+	}
+
+	return reports
+}
+
+// detectNetworkAnomalies compares the last block in the network state history with the
+// blocks before it
+func (k Keeper) detectNetworkAnomalies(ctx sdk.Context, params types.Params, enabled map[string]bool) []types.AnomalyReport {
+	history := k.GetNetworkStateHistory(ctx)
+	if uint64(len(history)) <= params.AnomalyMinSamples {
+		return nil
+	}
+
+	latest := history[len(history)-1]
+	past := history[:len(history)-1]
+	var reports []types.AnomalyReport
+
+	if enabled[types.AnomalyTypeCongestionSpike] {
+		values := make([]sdk.Dec, len(past))
+		for i, state := range past {
+			values[i] = state.NetworkCongestion
+		}
+		mean, stdDev := meanStdDev(values)
+
+		// Only spikes are anomalous; quiet blocks are not
+		z := zScore(latest.NetworkCongestion, mean, stdDev, minRateStdDev)
+		if z.GT(params.AnomalyZScoreThreshold) {
+			reports = append(reports, newAnomalyReport(ctx, types.AnomalyTypeCongestionSpike, nil, latest.BlockHeight,
+				fmt.Sprintf("block %d used %s of the block gas, %s standard deviations above the last %d blocks", latest.BlockHeight, latest.NetworkCongestion, z, len(past)),
+				types.AnomalyEvidence{Metric: "network_congestion", Value: latest.NetworkCongestion, Mean: mean, StdDev: stdDev, ZScore: z, Samples: uint64(len(past))},
+			))
+		}
+	}
+
+	if enabled[types.AnomalyTypeFeeRateShift] {
+		values := make([]sdk.Dec, len(past))
+		for i, state := range past {
+			values[i] = state.AverageFeeRate
+		}
+		mean, stdDev := meanStdDev(values)
+
+		z := zScore(latest.AverageFeeRate, mean, stdDev, minRelativeStdDev.Mul(mean.Abs()))
+		if z.Abs().GT(params.AnomalyZScoreThreshold) {
+			reports = append(reports, newAnomalyReport(ctx, types.AnomalyTypeFeeRateShift, nil, latest.BlockHeight,
+				fmt.Sprintf("average fee rate of block %d is %s, %s standard deviations from the last %d blocks", latest.BlockHeight, latest.AverageFeeRate, z, len(past)),
+				types.AnomalyEvidence{Metric: "average_fee_rate", Value: latest.AverageFeeRate, Mean: mean, StdDev: stdDev, ZScore: z, Samples: uint64(len(past))},
+			))
+		}
+	}
+
+	return reports
+}
+
+// detectValidatorAnomalies closes the detection window of every validator: it compares
+// the miss rate and proposer skew of the window with the baselines of the validator,
+// folds them into the baselines and starts a new window
+func (k Keeper) detectValidatorAnomalies(ctx sdk.Context, params types.Params, enabled map[string]bool) []types.AnomalyReport {
+	var reports []types.AnomalyReport
+
+	for _, stats := range k.GetAllValidatorAnomalyStats(ctx) {
+		blocks := stats.WindowSigned + stats.WindowMissed
+		if blocks == 0 {
+			// Drop the baselines of validators staking no longer knows
+			valAddr, err := sdk.ValAddressFromBech32(stats.ValidatorAddress)
+			if err == nil {
+				if _, found := k.stakingKeeper.GetValidator(ctx, valAddr); !found {
+					k.DeleteValidatorAnomalyStats(ctx, stats.ValidatorAddress)
+				}
+			}
+			continue
+		}
+
+		valAddr, err := sdk.ValAddressFromBech32(stats.ValidatorAddress)
+		if err != nil {
+			continue
+		}
+
+		missRate := sdk.NewDec(int64(stats.WindowMissed)).QuoInt64(int64(blocks))
+		if enabled[types.AnomalyTypeMissedBlocks] && stats.MissRate.Samples >= params.AnomalyMinSamples {
+			stdDev := ewmaStdDev(stats.MissRate)
+
+			// Only missing more blocks is anomalous
+			z := zScore(missRate, stats.MissRate.Mean, stdDev, minRateStdDev)
+			if z.GT(params.AnomalyZScoreThreshold) {
+				reports = append(reports, newAnomalyReport(ctx, types.AnomalyTypeMissedBlocks, valAddr, ctx.BlockHeight(),
+					fmt.Sprintf("validator %s missed %d of the last %d blocks, %s standard deviations above its baseline", stats.ValidatorAddress, stats.WindowMissed, blocks, z),
+					types.AnomalyEvidence{Metric: "miss_rate", Value: missRate, Mean: stats.MissRate.Mean, StdDev: stdDev, ZScore: z, Samples: stats.MissRate.Samples},
+				))
+			}
+		}
+
+		skew := sdk.NewDec(int64(stats.WindowProposed)).Sub(stats.WindowExpected).QuoInt64(int64(blocks))
+		if enabled[types.AnomalyTypeProposerSkew] && stats.ProposerSkew.Samples >= params.AnomalyMinSamples {
+			stdDev := ewmaStdDev(stats.ProposerSkew)
+
+			z := zScore(skew, stats.ProposerSkew.Mean, stdDev, minRateStdDev)
+			if z.Abs().GT(params.AnomalyZScoreThreshold) {
+				reports = append(reports, newAnomalyReport(ctx, types.AnomalyTypeProposerSkew, valAddr, ctx.BlockHeight(),
+					fmt.Sprintf("validator %s proposed %d blocks for %s expected from its voting power, %s standard deviations from its baseline", stats.ValidatorAddress, stats.WindowProposed, stats.WindowExpected, z),
+					types.AnomalyEvidence{Metric: "proposer_skew", Value: skew, Mean: stats.ProposerSkew.Mean, StdDev: stdDev, ZScore: z, Samples: stats.ProposerSkew.Samples},
+				))
+			}
+		}
+
+		stats.MissRate = updateEWMA(stats.MissRate, missRate, params.AnomalyEWMAAlpha)
+		stats.ProposerSkew = updateEWMA(stats.ProposerSkew, skew, params.AnomalyEWMAAlpha)
+		stats.WindowSigned = 0
+		stats.WindowMissed = 0
+		stats.WindowProposed = 0
+		stats.WindowExpected = sdk.ZeroDec()
+		k.SetValidatorAnomalyStats(ctx, stats)
+	}
+
+	return reports
+}
+
+// newAnomalyReport returns an anomaly report with its evidence
+func newAnomalyReport(ctx sdk.Context, anomalyType string, validatorAddr sdk.ValAddress, height int64, description string, evidence types.AnomalyEvidence) types.AnomalyReport {
+	bz, err := json.Marshal(evidence)
+	if err != nil {
+		panic(err)
+	}
+
+	return types.AnomalyReport{
+		ValidatorAddress: validatorAddr,
+		BlockHeight:      height,
+		AnomalyType:      anomalyType,
+		Confidence:       anomalyConfidence(evidence.ZScore),
+		Description:      description,
+		Evidence:         string(bz),
+		Timestamp:        ctx.BlockTime().Unix(),
+	}
+}
+
+// anomalyConfidence returns 1 - 1/z^2, the lower bound of Chebyshev's inequality on the
+// probability that a value z standard deviations from the mean is not chance
+func anomalyConfidence(z sdk.Dec) sdk.Dec {
+	z2 := z.Mul(z)
+	if z2.LTE(sdk.OneDec()) {
+		return sdk.ZeroDec()
+	}
+	return sdk.OneDec().Sub(sdk.OneDec().Quo(z2))
+}
+
+// zScore returns the number of standard deviations a value is from the mean, using a
+// standard deviation of at least minStdDev
+func zScore(value, mean, stdDev, minStdDev sdk.Dec) sdk.Dec {
+	if stdDev.LT(minStdDev) {
+		stdDev = minStdDev
+	}
+	if !stdDev.IsPositive() {
+		stdDev = sdk.SmallestDec()
+	}
+	return value.Sub(mean).Quo(stdDev)
+}
+
+// meanStdDev returns the mean and the population standard deviation of values
+func meanStdDev(values []sdk.Dec) (sdk.Dec, sdk.Dec) {
+	if len(values) == 0 {
+		return sdk.ZeroDec(), sdk.ZeroDec()
+	}
+
+	sum := sdk.ZeroDec()
+	for _, v := range values {
+		sum = sum.Add(v)
+	}
+	mean := sum.QuoInt64(int64(len(values)))
+
+	squares := sdk.ZeroDec()
+	for _, v := range values {
+		diff := v.Sub(mean)
+		squares = squares.Add(diff.Mul(diff))
+	}
+
+	stdDev, err := squares.QuoInt64(int64(len(values))).ApproxSqrt()
+	if err != nil {
+		return mean, sdk.ZeroDec()
+	}
+	return mean, stdDev
+}
+
+// newEWMAStat returns an empty moving average
+func newEWMAStat() types.EWMAStat {
+	return types.EWMAStat{Mean: sdk.ZeroDec(), Variance: sdk.ZeroDec()}
+}
+
+// updateEWMA folds a value into an exponentially weighted moving average and variance
+// with weight alpha
+func updateEWMA(stat types.EWMAStat, value, alpha sdk.Dec) types.EWMAStat {
+	if stat.Samples == 0 {
+		return types.EWMAStat{Mean: value, Variance: sdk.ZeroDec(), Samples: 1}
+	}
+
+	diff := value.Sub(stat.Mean)
+	increment := alpha.Mul(diff)
+	return types.EWMAStat{
+		Mean:     stat.Mean.Add(increment),
+		Variance: sdk.OneDec().Sub(alpha).Mul(stat.Variance.Add(diff.Mul(increment))),
+		Samples:  stat.Samples + 1,
+	}
+}
+
+// ewmaStdDev returns the standard deviation of a moving average
+func ewmaStdDev(stat types.EWMAStat) sdk.Dec {
+	stdDev, err := stat.Variance.ApproxSqrt()
+	if err != nil {
+		return sdk.ZeroDec()
+	}
+	return stdDev
+}
\ No newline at end of file
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func decs(values ...int64) []sdk.Dec {
+	result := make([]sdk.Dec, len(values))
+	for i, v := range values {
+		result[i] = sdk.NewDec(v)
+	}
+	return result
+}
+
+func TestMeanStdDev(t *testing.T) {
+	mean, stdDev := meanStdDev(decs(2, 4, 4, 4, 5, 5, 7, 9))
+	require.True(t, sdk.NewDec(5).Equal(mean), mean.String())
+	require.True(t, sdk.NewDec(2).Equal(stdDev), stdDev.String())
+
+	mean, stdDev = meanStdDev(decs(3, 3, 3))
+	require.True(t, sdk.NewDec(3).Equal(mean), mean.String())
+	require.True(t, stdDev.IsZero(), stdDev.String())
+
+	mean, stdDev = meanStdDev(nil)
+	require.True(t, mean.IsZero())
+	require.True(t, stdDev.IsZero())
+}
+
+func TestZScore(t *testing.T) {
+	require.True(t, sdk.NewDec(2).Equal(zScore(sdk.NewDec(9), sdk.NewDec(5), sdk.NewDec(2), sdk.ZeroDec())))
+	require.True(t, sdk.NewDec(-2).Equal(zScore(sdk.NewDec(1), sdk.NewDec(5), sdk.NewDec(2), sdk.ZeroDec())))
+
+	// The standard deviation is raised to its minimum
+	require.True(t, sdk.OneDec().Equal(zScore(sdk.NewDec(9), sdk.NewDec(5), sdk.NewDec(2), sdk.NewDec(4))))
+
+	// A constant history makes any change an anomaly
+	require.True(t, zScore(sdk.NewDec(6), sdk.NewDec(5), sdk.ZeroDec(), sdk.ZeroDec()).GT(sdk.NewDec(1_000_000)))
+	require.True(t, zScore(sdk.NewDec(5), sdk.NewDec(5), sdk.ZeroDec(), sdk.ZeroDec()).IsZero())
+}
+
+func TestAnomalyConfidence(t *testing.T) {
+	require.True(t, sdk.NewDecWithPrec(75, 2).Equal(anomalyConfidence(sdk.NewDec(2))))
+	require.True(t, sdk.MustNewDecFromStr("0.888888888888888889").Equal(anomalyConfidence(sdk.NewDec(-3))))
+	require.True(t, anomalyConfidence(sdk.OneDec()).IsZero())
+	require.True(t, anomalyConfidence(sdk.NewDecWithPrec(5, 1)).IsZero())
+}
+
+func TestUpdateEWMA(t *testing.T) {
+	alpha := sdk.NewDecWithPrec(5, 1)
+
+	stat := updateEWMA(newEWMAStat(), sdk.ZeroDec(), alpha)
+	require.Equal(t, uint64(1), stat.Samples)
+	require.True(t, stat.Mean.IsZero())
+	require.True(t, stat.Variance.IsZero())
+
+	stat = updateEWMA(stat, sdk.NewDec(4), alpha)
+	require.Equal(t, uint64(2), stat.Samples)
+	require.True(t, sdk.NewDec(2).Equal(stat.Mean), stat.Mean.String())
+	require.True(t, sdk.NewDec(4).Equal(stat.Variance), stat.Variance.String())
+	require.True(t, sdk.NewDec(2).Equal(ewmaStdDev(stat)))
+}
+
+func TestBlockFeeRate(t *testing.T) {
+	rate, found := blockFeeRate(sdk.NewInt(1000), 400)
+	require.True(t, found)
+	require.True(t, sdk.NewDecWithPrec(25, 1).Equal(rate), rate.String())
+
+	rate, found = blockFeeRate(sdk.ZeroInt(), 400)
+	require.True(t, found)
+	require.True(t, rate.IsZero())
+
+	_, found = blockFeeRate(sdk.ZeroInt(), 0)
+	require.False(t, found)
+}
@@ -0,0 +1,196 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// GetBaseFee returns the base fee, the lowest gas price in the base fee denom that the
+// transactions of the current block pay. Until a block updates it, it is the min base fee.
+func (k Keeper) GetBaseFee(ctx sdk.Context) sdk.Dec {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.BaseFeeKey)
+	if bz == nil {
+		return k.MinBaseFee(ctx)
+	}
+
+	var baseFee sdk.Dec
+	if err := baseFee.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return baseFee
+}
+
+// SetBaseFee sets the base fee
+func (k Keeper) SetBaseFee(ctx sdk.Context, baseFee sdk.Dec) {
+	bz, err := baseFee.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.BaseFeeKey, bz)
+}
+
+// UpdateBaseFee sets the base fee of the next block from the congestion of the current
+// one, like EIP-1559: blocks fuller than TargetBlockUtilization raise it and emptier
+// blocks lower it, by at most BaseFeeMaxChangeRate for a full or an empty block
+func (k Keeper) UpdateBaseFee(ctx sdk.Context, congestion sdk.Dec) {
+	params := k.GetParams(ctx)
+	baseFee := k.GetBaseFee(ctx)
+
+	next := nextBaseFee(baseFee, congestion, params)
+	if next.Equal(baseFee) {
+		return
+	}
+	k.SetBaseFee(ctx, next)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeBaseFeeUpdate,
+			sdk.NewAttribute(types.AttributeKeyBaseFee, next.String()),
+			sdk.NewAttribute(types.AttributeKeyNetworkCongestion, congestion.String()),
+		),
+	)
+}
+
+// ProjectedBaseFees returns the base fee of the block after the next one if the next
+// block is as congested as the last one, if it is full and if it is empty
+func (k Keeper) ProjectedBaseFees(ctx sdk.Context) (projected, full, empty sdk.Dec) {
+	params := k.GetParams(ctx)
+	baseFee := k.GetBaseFee(ctx)
+
+	congestion := params.TargetBlockUtilization
+	if state, found := k.GetNetworkState(ctx); found {
+		congestion = state.NetworkCongestion
+	}
+
+	projected = nextBaseFee(baseFee, congestion, params)
+	full = nextBaseFee(baseFee, sdk.OneDec(), params)
+	empty = nextBaseFee(baseFee, sdk.ZeroDec(), params)
+	return projected, full, empty
+}
+
+// baseFeeResponse returns the current and projected base fees for queries
+func (k Keeper) baseFeeResponse(ctx sdk.Context) *types.QueryBaseFeeResponse {
+	projected, full, empty := k.ProjectedBaseFees(ctx)
+
+	return &types.QueryBaseFeeResponse{
+		Enabled:           k.BaseFeeEnabled(ctx),
+		Denom:             k.BaseFeeDenom(ctx),
+		BaseFee:           k.GetBaseFee(ctx),
+		ProjectedBaseFee:  projected,
+		FullBlockBaseFee:  full,
+		EmptyBlockBaseFee: empty,
+	}
+}
+
+// RequiredBaseFee returns the base fee a transaction owes for its gas limit, rounded up.
+// Fees are paid for the gas limit, not the gas used.
+func (k Keeper) RequiredBaseFee(ctx sdk.Context, gas uint64) sdk.Coin {
+	amount := k.GetBaseFee(ctx).MulInt(sdk.NewIntFromUint64(gas)).Ceil().TruncateInt()
+	return sdk.NewCoin(k.BaseFeeDenom(ctx), amount)
+}
+
+// BurnBaseFee burns BaseFeeBurnFraction of the base fee paid by a transaction from the
+// fee collector. The rest of the base fee and the tip above it are left to distribution,
+// which pays them to validators and delegators.
+func (k Keeper) BurnBaseFee(ctx sdk.Context, baseFee sdk.Coin) error {
+	burn := sdk.NewCoin(baseFee.Denom, k.BaseFeeBurnFraction(ctx).MulInt(baseFee.Amount).TruncateInt())
+	if !burn.IsPositive() {
+		return nil
+	}
+
+	// The fee collector cannot burn, so the coins go through the module account
+	coins := sdk.NewCoins(burn)
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, types.ModuleName, coins); err != nil {
+		return err
+	}
+	return k.bankKeeper.BurnCoins(ctx, types.ModuleName, coins)
+}
+
+// AddBlockFees adds the fee in the base fee denom and the gas limit of a transaction to
+// the totals of the current block
+func (k Keeper) AddBlockFees(ctx sdk.Context, fee sdk.Int, gas uint64) {
+	fees, gasWanted := k.getBlockFees(ctx)
+	fees = fees.Add(fee)
+	gasWanted += gas
+
+	bz, err := fees.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.BlockFeesKey, bz)
+	store.Set(types.BlockGasWantedKey, sdk.Uint64ToBigEndian(gasWanted))
+}
+
+// getBlockFees returns the fees in the base fee denom and the gas limits of the
+// transactions of the current block
+func (k Keeper) getBlockFees(ctx sdk.Context) (sdk.Int, uint64) {
+	store := ctx.KVStore(k.storeKey)
+
+	fees := sdk.ZeroInt()
+	if bz := store.Get(types.BlockFeesKey); bz != nil {
+		if err := fees.Unmarshal(bz); err != nil {
+			panic(err)
+		}
+	}
+
+	var gasWanted uint64
+	if bz := store.Get(types.BlockGasWantedKey); bz != nil {
+		gasWanted = sdk.BigEndianToUint64(bz)
+	}
+
+	return fees, gasWanted
+}
+
+// PopBlockFeeRate returns the fee per unit of gas the transactions of the current block
+// paid in the base fee denom, and clears the totals for the next block. It returns false
+// if the block had no transactions.
+func (k Keeper) PopBlockFeeRate(ctx sdk.Context) (sdk.Dec, bool) {
+	fees, gasWanted := k.getBlockFees(ctx)
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.BlockFeesKey)
+	store.Delete(types.BlockGasWantedKey)
+
+	return blockFeeRate(fees, gasWanted)
+}
+
+// blockFeeRate divides the fees of a block by the gas limits they paid for, as fees are
+// paid for the gas limit and not the gas used
+func blockFeeRate(fees sdk.Int, gasWanted uint64) (sdk.Dec, bool) {
+	if gasWanted == 0 {
+		return sdk.ZeroDec(), false
+	}
+	return sdk.NewDecFromInt(fees).QuoInt(sdk.NewIntFromUint64(gasWanted)), true
+}
+
+// nextBaseFee moves a base fee by the deviation of the congestion from the target,
+// relative to the room between the target and a full or an empty block, times the max
+// change rate. The base fee never falls below the min base fee.
+func nextBaseFee(baseFee, congestion sdk.Dec, params types.Params) sdk.Dec {
+	target := params.TargetBlockUtilization
+	if congestion.IsNil() || congestion.IsNegative() {
+		congestion = sdk.ZeroDec()
+	} else if congestion.GT(sdk.OneDec()) {
+		congestion = sdk.OneDec()
+	}
+
+	var deviation sdk.Dec
+	if congestion.GTE(target) {
+		deviation = congestion.Sub(target).Quo(sdk.OneDec().Sub(target))
+	} else {
+		deviation = congestion.Sub(target).Quo(target)
+	}
+
+	next := baseFee.Add(baseFee.Mul(params.BaseFeeMaxChangeRate).Mul(deviation))
+	if next.LT(params.MinBaseFee) {
+		return params.MinBaseFee
+	}
+	return next
+}
\ No newline at end of file
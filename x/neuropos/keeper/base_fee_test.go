@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	params := types.Params{
+		TargetBlockUtilization: sdk.NewDecWithPrec(5, 1),
+		BaseFeeMaxChangeRate:   sdk.NewDecWithPrec(125, 3),
+		MinBaseFee:             sdk.NewDecWithPrec(1, 2),
+	}
+
+	tests := []struct {
+		name       string
+		baseFee    string
+		congestion sdk.Dec
+		expected   string
+	}{
+		{"at target", "1", sdk.NewDecWithPrec(5, 1), "1"},
+		{"full block", "1", sdk.OneDec(), "1.125"},
+		{"empty block", "1", sdk.ZeroDec(), "0.875"},
+		{"above target", "1", sdk.NewDecWithPrec(75, 2), "1.0625"},
+		{"below target", "1", sdk.NewDecWithPrec(25, 2), "0.9375"},
+		{"congestion above 1", "1", sdk.NewDecWithPrec(15, 1), "1.125"},
+		{"negative congestion", "1", sdk.NewDec(-1), "0.875"},
+		{"nil congestion", "1", sdk.Dec{}, "0.875"},
+		{"min base fee", "0.01", sdk.ZeroDec(), "0.01"},
+		{"rises from min base fee", "0.01", sdk.OneDec(), "0.01125"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next := nextBaseFee(sdk.MustNewDecFromStr(tc.baseFee), tc.congestion, params)
+			require.True(t, sdk.MustNewDecFromStr(tc.expected).Equal(next), next.String())
+		})
+	}
+}
+
+func TestNextBaseFeeCompounds(t *testing.T) {
+	params := types.Params{
+		TargetBlockUtilization: sdk.NewDecWithPrec(5, 1),
+		BaseFeeMaxChangeRate:   sdk.NewDecWithPrec(125, 3),
+		MinBaseFee:             sdk.NewDecWithPrec(1, 2),
+	}
+
+	// Full blocks raise the base fee by 12.5% per block, rounded to 18 decimals every block.
+	// As many empty blocks lower it by 12.5% per block, which brings it below where it started.
+	baseFee := sdk.OneDec()
+	for i := 0; i < 10; i++ {
+		baseFee = nextBaseFee(baseFee, sdk.OneDec(), params)
+	}
+	require.True(t, sdk.MustNewDecFromStr("3.247321025468409062").Equal(baseFee), baseFee.String())
+
+	for i := 0; i < 10; i++ {
+		baseFee = nextBaseFee(baseFee, sdk.ZeroDec(), params)
+	}
+	require.True(t, baseFee.LT(sdk.OneDec()), baseFee.String())
+	require.True(t, baseFee.GTE(params.MinBaseFee), baseFee.String())
+}
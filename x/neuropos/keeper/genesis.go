@@ -92,6 +92,18 @@ func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) {
 
 	// Set the params
 	k.SetParams(ctx, genState.Params)
+
+	// Record the voting powers of the bonded validators consensus starts from, keeping
+	// the multipliers of exported validators
+	k.InitValidatorPowers(ctx, genState.ValidatorPowers)
+
+	// Set all the anomaly penalties, queueing the unresolved ones
+	k.InitPenalties(ctx, genState.Penalties)
+
+	// Set the base fee, which otherwise starts at the min base fee
+	if !genState.BaseFee.IsNil() {
+		k.SetBaseFee(ctx, genState.BaseFee)
+	}
 }
 
 // ExportGenesis returns the module's exported genesis.
@@ -146,6 +158,17 @@ func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
 	validatorSigningInfos := k.GetAllValidatorSigningInfos(ctx)
 	genesis.ValidatorSigningInfos = validatorSigningInfos
 
+	// Get all validator powers
+	validatorPowers := k.GetAllValidatorPowers(ctx)
+	genesis.ValidatorPowers = validatorPowers
+
+	// Get all anomaly penalties
+	penalties := k.GetAllPenalties(ctx)
+	genesis.Penalties = penalties
+
+	// Get the base fee
+	genesis.BaseFee = k.GetBaseFee(ctx)
+
 	// Get params
 	genesis.Params = k.GetParams(ctx)
 
@@ -45,8 +45,14 @@ func ModuleAccountInvariant(k Keeper) sdk.Invariant {
 			}
 		}
 
-		// Check if module balance matches total delegated tokens
+		// Check if module balance matches total delegated tokens and the bonds escrowed
+		// for disputed anomaly penalties
 		expectedBalance := sdk.NewCoins(sdk.NewCoin("unomx", totalDelegated))
+		for _, penalty := range k.GetAllPenalties(ctx) {
+			if penalty.Status == types.PenaltyStatusDisputed {
+				expectedBalance = expectedBalance.Add(penalty.Bond)
+			}
+		}
 		if !moduleBalance.IsEqual(expectedBalance) {
 			return fmt.Sprintf("module account balance (%s) does not match total delegated tokens (%s)", moduleBalance, expectedBalance), true
 		}
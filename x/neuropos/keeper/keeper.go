@@ -3,7 +3,7 @@ package keeper
 import (
 	"fmt"
 
-	"github.com/tendermint/tendermint/libs/log"
+	"github.com/cometbft/cometbft/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -173,12 +173,22 @@ func (k Keeper) UpdateNetworkState(ctx sdk.Context) {
 	txCount := uint64(ctx.TxCount())
 	blockGasUsed := ctx.BlockGasMeter().GasConsumed()
 	maxBlockGas := ctx.BlockGasMeter().Limit()
-	congestion := sdk.NewDecFromInt(sdk.NewIntFromUint64(uint64(blockGasUsed))).
-		Quo(sdk.NewDecFromInt(sdk.NewIntFromUint64(uint64(maxBlockGas))))
+	// Blocks without a gas limit are never congested
+	congestion := sdk.ZeroDec()
+	if maxBlockGas > 0 {
+		congestion = sdk.NewDecFromInt(sdk.NewIntFromUint64(uint64(blockGasUsed))).
+			Quo(sdk.NewDecFromInt(sdk.NewIntFromUint64(uint64(maxBlockGas))))
+	}
 
-	// Calculate average fee rate (simplified example)
-	// In a real implementation, this would analyze recent transactions
-	avgFeeRate := sdk.NewDec(1) // Placeholder
+	// Average fee rate of the transactions of this block. Blocks without transactions
+	// keep the rate of the last block, so that idle blocks are not fee rate shifts.
+	avgFeeRate, found := k.PopBlockFeeRate(ctx)
+	if !found {
+		avgFeeRate = sdk.ZeroDec()
+		if last, ok := k.GetNetworkState(ctx); ok && !last.AverageFeeRate.IsNil() {
+			avgFeeRate = last.AverageFeeRate
+		}
+	}
 
 	// Calculate anomaly score based on recent anomaly reports
 	// In a real implementation, this would analyze recent anomaly reports
@@ -197,18 +207,7 @@ func (k Keeper) UpdateNetworkState(ctx sdk.Context) {
 	}
 
 	k.SetNetworkState(ctx, state)
-}
-
-// DetectAnomalies uses AI models to detect anomalies in the network
-func (k Keeper) DetectAnomalies(ctx sdk.Context) []types.AnomalyReport {
-	// This is a placeholder for the actual AI-based anomaly detection
-	// In a real implementation, this would:
-	// 1. Load validator AI models
-	// 2. Process recent transactions and blocks through the models
-	// 3. Generate anomaly reports based on model outputs
-	
-	// For now, we'll return an empty slice
-	return []types.AnomalyReport{}
+	k.SetNetworkStateHistory(ctx, state)
 }
 
 // AdjustBlockParameters dynamically adjusts block parameters based on network state
@@ -218,18 +217,7 @@ func (k Keeper) AdjustBlockParameters(ctx sdk.Context) {
 		return
 	}
 
-	// This is a placeholder for the actual parameter adjustment logic
-	// In a real implementation, this would:
-	// 1. Analyze network state
-	// 2. Adjust parameters like max gas, block size, etc.
-	// 3. Apply the new parameters
-
-	// Example logic (not actually implemented):
-	if state.NetworkCongestion.GT(sdk.NewDec(8).Quo(sdk.NewDec(10))) {
-		// Network is congested, increase fees
-		// k.SetMinGasPrice(ctx, currentMinGasPrice.Mul(sdk.NewDec(12).Quo(sdk.NewDec(10))))
-	} else if state.NetworkCongestion.LT(sdk.NewDec(3).Quo(sdk.NewDec(10))) {
-		// Network is underutilized, decrease fees
-		// k.SetMinGasPrice(ctx, currentMinGasPrice.Mul(sdk.NewDec(9).Quo(sdk.NewDec(10))))
-	}
+	// Raise the base fee of the next block if this block was congested and lower it if
+	// the network is underutilized
+	k.UpdateBaseFee(ctx, state.NetworkCongestion)
 }
\ No newline at end of file
@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// Migrator is a struct for handling in-place store migrations
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates the store from consensus version 1 to 2 by setting the default
+// voting power params. The voting powers are recorded from the staking powers at the
+// first end blocker.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	defaults := types.DefaultParams()
+
+	m.keeper.paramstore.Set(ctx, types.KeyMinPowerMultiplier, defaults.MinPowerMultiplier)
+	m.keeper.paramstore.Set(ctx, types.KeyMaxPowerMultiplier, defaults.MaxPowerMultiplier)
+	m.keeper.paramstore.Set(ctx, types.KeyPowerEpochBlocks, defaults.PowerEpochBlocks)
+	m.keeper.paramstore.Set(ctx, types.KeyMaxPowerMultiplierChange, defaults.MaxPowerMultiplierChange)
+
+	return nil
+}
+
+// Migrate2to3 migrates the store from consensus version 2 to 3 by setting the default
+// anomaly detection params. The detectors build their history and baselines from the
+// following blocks.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	defaults := types.DefaultParams()
+
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalyDetectors, defaults.AnomalyDetectors)
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalyDryRun, defaults.AnomalyDryRun)
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalyZScoreThreshold, defaults.AnomalyZScoreThreshold)
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalySlashConfidence, defaults.AnomalySlashConfidence)
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalyHistoryWindow, defaults.AnomalyHistoryWindow)
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalyValidatorWindow, defaults.AnomalyValidatorWindow)
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalyEWMAAlpha, defaults.AnomalyEWMAAlpha)
+	m.keeper.paramstore.Set(ctx, types.KeyAnomalyMinSamples, defaults.AnomalyMinSamples)
+
+	return nil
+}
+
+// Migrate3to4 migrates the store from consensus version 3 to 4 by setting the default
+// anomaly penalty params. Anomalies detected from then on queue their penalties behind a
+// challenge period instead of slashing at once.
+func (m Migrator) Migrate3to4(ctx sdk.Context) error {
+	defaults := types.DefaultParams()
+
+	m.keeper.paramstore.Set(ctx, types.KeyPenaltyChallengePeriod, defaults.PenaltyChallengePeriod)
+	m.keeper.paramstore.Set(ctx, types.KeyPenaltySlashFraction, defaults.PenaltySlashFraction)
+	m.keeper.paramstore.Set(ctx, types.KeyPenaltyReputationChange, defaults.PenaltyReputationChange)
+	m.keeper.paramstore.Set(ctx, types.KeyPenaltyMinDisputeBond, defaults.PenaltyMinDisputeBond)
+	m.keeper.paramstore.Set(ctx, types.KeyPenaltyVoteQuorum, defaults.PenaltyVoteQuorum)
+	m.keeper.paramstore.Set(ctx, types.KeyPenaltyReductionFactor, defaults.PenaltyReductionFactor)
+
+	return nil
+}
+
+// Migrate4to5 migrates the store from consensus version 4 to 5 by setting the default
+// base fee params. The base fee starts at the min base fee.
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	defaults := types.DefaultParams()
+
+	m.keeper.paramstore.Set(ctx, types.KeyBaseFeeEnabled, defaults.BaseFeeEnabled)
+	m.keeper.paramstore.Set(ctx, types.KeyBaseFeeDenom, defaults.BaseFeeDenom)
+	m.keeper.paramstore.Set(ctx, types.KeyMinBaseFee, defaults.MinBaseFee)
+	m.keeper.paramstore.Set(ctx, types.KeyTargetBlockUtilization, defaults.TargetBlockUtilization)
+	m.keeper.paramstore.Set(ctx, types.KeyBaseFeeMaxChangeRate, defaults.BaseFeeMaxChangeRate)
+	m.keeper.paramstore.Set(ctx, types.KeyBaseFeeBurnFraction, defaults.BaseFeeBurnFraction)
+
+	m.keeper.SetBaseFee(ctx, defaults.MinBaseFee)
+
+	return nil
+}
+
+// Migrate5to6 migrates the store from consensus version 5 to 6 by indexing the training
+// data of the neural networks under their network.
+func (m Migrator) Migrate5to6(ctx sdk.Context) error {
+	store := ctx.KVStore(m.keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.NeuralNetworkTrainingDataKeyPrefix)
+
+	var dataList []types.TrainingData
+	for ; iterator.Valid(); iterator.Next() {
+		var data types.TrainingData
+		m.keeper.cdc.MustUnmarshal(iterator.Value(), &data)
+		dataList = append(dataList, data)
+	}
+	iterator.Close()
+
+	for _, data := range dataList {
+		m.keeper.SetTrainingData(ctx, data)
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -748,4 +749,68 @@ func (k msgServer) UpdateValidatorReputation(goCtx context.Context, msg *types.M
 	return &types.MsgUpdateValidatorReputationResponse{
 		NewReputation: reputation.Reputation.String(),
 	}, nil
+}
+
+// DisputePenalty defines a method for a validator to dispute the penalty of an anomaly
+func (k msgServer) DisputePenalty(goCtx context.Context, msg *types.MsgDisputePenalty) (*types.MsgDisputePenaltyResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	valAddr, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Escrow the bond and open the vote of the other validators
+	if err := k.Keeper.DisputePenalty(ctx, msg.PenaltyID, valAddr, msg.Bond, msg.CounterEvidence); err != nil {
+		return nil, err
+	}
+
+	// Emit events
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDisputePenalty,
+			sdk.NewAttribute(types.AttributeKeyPenaltyID, fmt.Sprintf("%d", msg.PenaltyID)),
+			sdk.NewAttribute(types.AttributeKeyValidator, msg.ValidatorAddress),
+			sdk.NewAttribute(types.AttributeKeyBond, msg.Bond.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, sdk.AccAddress(valAddr).String()),
+		),
+	})
+
+	return &types.MsgDisputePenaltyResponse{}, nil
+}
+
+// VotePenalty defines a method for a validator to vote on a disputed penalty
+func (k msgServer) VotePenalty(goCtx context.Context, msg *types.MsgVotePenalty) (*types.MsgVotePenaltyResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	valAddr, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the vote, replacing a previous vote of the validator
+	if err := k.Keeper.VotePenalty(ctx, msg.PenaltyID, valAddr, msg.Option); err != nil {
+		return nil, err
+	}
+
+	// Emit events
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeVotePenalty,
+			sdk.NewAttribute(types.AttributeKeyPenaltyID, fmt.Sprintf("%d", msg.PenaltyID)),
+			sdk.NewAttribute(types.AttributeKeyValidator, msg.ValidatorAddress),
+			sdk.NewAttribute(types.AttributeKeyVoteOption, msg.Option),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, sdk.AccAddress(valAddr).String()),
+		),
+	})
+
+	return &types.MsgVotePenaltyResponse{}, nil
 }
\ No newline at end of file
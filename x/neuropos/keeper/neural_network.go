@@ -6,9 +6,25 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/nomercychain/nmxchain/x/neuropos/nn"
 	"github.com/nomercychain/nmxchain/x/neuropos/types"
 )
 
+const (
+	// neuralNetworkUpdateGasLimit bounds the work of the periodic update of a neural
+	// network, which no transaction pays for
+	neuralNetworkUpdateGasLimit uint64 = 50_000_000
+
+	// neuralNetworkUpdateBlockGasLimit bounds the work of the periodic updates of all
+	// neural networks within a block; an update round continues in the next block
+	neuralNetworkUpdateBlockGasLimit uint64 = 200_000_000
+
+	// neuralNetworkSampleWindow is the number of samples of the most recent training
+	// data that networks are retrained and evaluated on
+	neuralNetworkSampleWindow = 1024
+)
+
 // SetNeuralNetwork sets a neural network in the store
 func (k Keeper) SetNeuralNetwork(ctx sdk.Context, network types.NeuralNetwork) {
 	store := ctx.KVStore(k.storeKey)
@@ -92,12 +108,13 @@ func (k Keeper) GetLatestNeuralNetworkWeights(ctx sdk.Context, networkID string)
 	return types.NeuralNetworkWeights{}, false
 }
 
-// SetTrainingData sets training data in the store
+// SetTrainingData sets training data in the store and indexes it under its network
 func (k Keeper) SetTrainingData(ctx sdk.Context, data types.TrainingData) {
 	store := ctx.KVStore(k.storeKey)
 	key := types.TrainingDataKey(data.ID)
 	value := k.cdc.MustMarshal(&data)
 	store.Set(key, value)
+	store.Set(types.TrainingDataIndexKey(data.NetworkID, data.CreatedAt, data.ID), []byte(data.ID))
 }
 
 // GetTrainingData returns training data by ID
@@ -114,17 +131,15 @@ func (k Keeper) GetTrainingData(ctx sdk.Context, id string) (types.TrainingData,
 	return data, true
 }
 
-// GetTrainingDataByNetwork returns all training data for a specific network
+// GetTrainingDataByNetwork returns all training data for a specific network, oldest first
 func (k Keeper) GetTrainingDataByNetwork(ctx sdk.Context, networkID string) []types.TrainingData {
 	var dataList []types.TrainingData
 	store := ctx.KVStore(k.storeKey)
-	iterator := sdk.KVStorePrefixIterator(store, types.NeuralNetworkTrainingDataKeyPrefix)
+	iterator := sdk.KVStorePrefixIterator(store, types.TrainingDataIndexPrefix(networkID))
 	defer iterator.Close()
 
 	for ; iterator.Valid(); iterator.Next() {
-		var data types.TrainingData
-		k.cdc.MustUnmarshal(iterator.Value(), &data)
-		if data.NetworkID == networkID {
+		if data, found := k.GetTrainingData(ctx, string(iterator.Value())); found {
 			dataList = append(dataList, data)
 		}
 	}
@@ -132,6 +147,15 @@ func (k Keeper) GetTrainingDataByNetwork(ctx sdk.Context, networkID string) []ty
 	return dataList
 }
 
+// hasTrainingData returns true if training data was submitted for a network
+func (k Keeper) hasTrainingData(ctx sdk.Context, networkID string) bool {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.TrainingDataIndexPrefix(networkID))
+	defer iterator.Close()
+
+	return iterator.Valid()
+}
+
 // SetNeuralPrediction sets a neural prediction in the store
 func (k Keeper) SetNeuralPrediction(ctx sdk.Context, prediction types.NeuralPrediction) {
 	store := ctx.KVStore(k.storeKey)
@@ -196,15 +220,15 @@ func (k Keeper) CreateNeuralNetwork(ctx sdk.Context, architecture string, layers
 		return types.NeuralNetwork{}, types.ErrInvalidNeuralNetworkArchitecture
 	}
 
-	if len(layers) == 0 {
-		return types.NeuralNetwork{}, types.ErrInvalidNeuralNetworkArchitecture
+	if err := nn.ValidateLayers(layers); err != nil {
+		return types.NeuralNetwork{}, sdkerrors.Wrap(types.ErrInvalidNeuralNetworkArchitecture, err.Error())
 	}
 
 	// Save the neural network
 	k.SetNeuralNetwork(ctx, network)
 
-	// Create initial weights (zeros)
-	initialWeights, err := createInitialWeights(layers)
+	// Create initial weights seeded with the network ID
+	initialWeights, err := createInitialWeights(ctx, id, layers)
 	if err != nil {
 		return types.NeuralNetwork{}, err
 	}
@@ -239,8 +263,8 @@ func (k Keeper) UpdateNeuralNetwork(ctx sdk.Context, networkID string, architect
 		return types.ErrInvalidNeuralNetworkArchitecture
 	}
 
-	if len(layers) == 0 {
-		return types.ErrInvalidNeuralNetworkArchitecture
+	if err := nn.ValidateLayers(layers); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidNeuralNetworkArchitecture, err.Error())
 	}
 
 	if len(weights) == 0 {
@@ -308,6 +332,22 @@ func (k Keeper) TrainNeuralNetwork(ctx sdk.Context, networkID string, features j
 		return types.ErrInvalidLearningRate
 	}
 
+	rate := nn.FromDec(learningRate)
+	if rate == 0 {
+		return sdkerrors.Wrap(types.ErrInvalidLearningRate, "learning rate is below the fixed-point precision of 2^-16")
+	}
+
+	// Load the network with its latest weights and check the data against its shape
+	model, err := k.loadNeuralNetwork(ctx, network)
+	if err != nil {
+		return err
+	}
+
+	samples, err := nn.ParseSamples(features, labels, model.InputSize(), model.OutputSize())
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidTrainingData, err.Error())
+	}
+
 	// Save the training data
 	trainingDataID := fmt.Sprintf("td-%d-%s", ctx.BlockHeight(), ctx.TxHash())
 	trainingData := types.TrainingData{
@@ -320,22 +360,191 @@ func (k Keeper) TrainNeuralNetwork(ctx sdk.Context, networkID string, features j
 	}
 	k.SetTrainingData(ctx, trainingData)
 
-	// Update the neural network status
-	network.Status = types.NeuralNetworkStatusTraining
+	// Train on the new data, paid for by the transaction's gas
+	if err := model.Train(ctx.GasMeter(), samples, epochs, rate); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidTrainingData, err.Error())
+	}
+
+	// Measure the accuracy and loss on the most recent training data of the network
+	metrics, err := model.Evaluate(ctx.GasMeter(), k.neuralNetworkSamples(ctx, network.ID, model))
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidTrainingData, err.Error())
+	}
+
 	network.LastTrainedTime = ctx.BlockTime()
-	k.SetNeuralNetwork(ctx, network)
+	_, err = k.saveTrainedNeuralNetwork(ctx, network, model, metrics)
+	return err
+}
+
+// RetrainNeuralNetworks continues the periodic update round of the neural networks.
+// Networks are retrained in ID order, starting after the last network updated in an
+// earlier block of the round, as long as the block's neuralNetworkUpdateBlockGasLimit
+// can cover another network. It returns the networks retrained in the block and
+// whether the round is complete.
+func (k Keeper) RetrainNeuralNetworks(ctx sdk.Context) ([]types.NeuralNetwork, bool) {
+	store := ctx.KVStore(k.storeKey)
+	cursor := string(store.Get(types.NeuralNetworkUpdateCursorKey))
+
+	var retrained []types.NeuralNetwork
+	remaining := neuralNetworkUpdateBlockGasLimit
+	for _, network := range k.GetAllNeuralNetworks(ctx) {
+		if network.ID <= cursor {
+			continue
+		}
+		if remaining < neuralNetworkUpdateGasLimit {
+			store.Set(types.NeuralNetworkUpdateCursorKey, []byte(cursor))
+			return retrained, false
+		}
+		cursor = network.ID
+
+		// Skip networks that are being updated or trained, or have no training data
+		if network.Status == types.NeuralNetworkStatusUpdating || network.Status == types.NeuralNetworkStatusTraining {
+			continue
+		}
+		if !k.hasTrainingData(ctx, network.ID) {
+			continue
+		}
+
+		// Networks that cannot be trained on chain are left as is
+		network, gasUsed, err := k.RetrainNeuralNetwork(ctx, network)
+		remaining -= gasUsed
+		if err != nil {
+			k.Logger(ctx).Error("failed to update neural network", "network_id", network.ID, "error", err)
+			continue
+		}
+		retrained = append(retrained, network)
+	}
+
+	store.Delete(types.NeuralNetworkUpdateCursorKey)
+	return retrained, true
+}
+
+// RetrainNeuralNetwork trains a neural network for an epoch on its most recent training
+// data with the learning rate of the params and updates its accuracy and loss. The work
+// is bounded by neuralNetworkUpdateGasLimit; the gas used is returned.
+func (k Keeper) RetrainNeuralNetwork(ctx sdk.Context, network types.NeuralNetwork) (types.NeuralNetwork, uint64, error) {
+	model, err := k.loadNeuralNetwork(ctx, network)
+	if err != nil {
+		return network, 0, err
+	}
+
+	samples := k.neuralNetworkSamples(ctx, network.ID, model)
+	if len(samples) == 0 {
+		return network, 0, sdkerrors.Wrap(types.ErrInvalidTrainingData, "no training data fits the network")
+	}
 
-	// In a real implementation, this would trigger the actual training process
-	// For now, we'll simulate training by updating the accuracy and loss
-	// This would typically be done in an EndBlocker or a separate process
+	rate := nn.FromDec(k.GetParams(ctx).NeuralNetworkLearningRate)
+	if rate == 0 {
+		return network, 0, sdkerrors.Wrap(types.ErrInvalidLearningRate, "learning rate is below the fixed-point precision of 2^-16")
+	}
+
+	// Run on a meter of its own and turn running out of gas into an error
+	meter := sdk.NewGasMeter(neuralNetworkUpdateGasLimit)
+	var metrics nn.Metrics
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				outOfGas, ok := r.(sdk.ErrorOutOfGas)
+				if !ok {
+					panic(r)
+				}
+				err = fmt.Errorf("out of gas in %s", outOfGas.Descriptor)
+			}
+		}()
+
+		if err := model.Train(meter, samples, 1, rate); err != nil {
+			return err
+		}
+		metrics, err = model.Evaluate(meter, samples)
+		return err
+	}()
+	if err != nil {
+		return network, meter.GasConsumedToLimit(), sdkerrors.Wrap(types.ErrInvalidTrainingData, err.Error())
+	}
+
+	network.LastUpdatedTime = ctx.BlockTime()
+	network, err = k.saveTrainedNeuralNetwork(ctx, network, model, metrics)
+	return network, meter.GasConsumedToLimit(), err
+}
+
+// loadNeuralNetwork returns a neural network with its latest weights
+func (k Keeper) loadNeuralNetwork(ctx sdk.Context, network types.NeuralNetwork) (*nn.Network, error) {
+	latest, found := k.GetLatestNeuralNetworkWeights(ctx, network.ID)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidNeuralNetworkWeights, "network %s has no weights", network.ID)
+	}
+
+	weights, err := nn.UnmarshalWeights(latest.Weights)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidNeuralNetworkWeights, err.Error())
+	}
+
+	model, err := nn.New(network.Layers, weights)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalidNeuralNetworkArchitecture, err.Error())
+	}
+	return model, nil
+}
+
+// neuralNetworkSamples returns the last neuralNetworkSampleWindow samples of the
+// training data of a network, oldest first, so that the work of training and evaluating
+// a network does not grow with its training data. Data that no longer fits the network,
+// e.g. after its layers were updated, is skipped.
+func (k Keeper) neuralNetworkSamples(ctx sdk.Context, networkID string, model *nn.Network) []nn.Sample {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.TrainingDataIndexPrefix(networkID))
+	defer iterator.Close()
 
-	// Simulate training results
-	network.Accuracy = sdk.NewDecWithPrec(85, 2) // 0.85
-	network.Loss = sdk.NewDecWithPrec(15, 2)     // 0.15
+	// Collect the samples of the newest data until the window is full
+	var batches [][]nn.Sample
+	count := 0
+	for ; iterator.Valid() && count < neuralNetworkSampleWindow; iterator.Next() {
+		data, found := k.GetTrainingData(ctx, string(iterator.Value()))
+		if !found {
+			continue
+		}
+		parsed, err := nn.ParseSamples(data.Features, data.Labels, model.InputSize(), model.OutputSize())
+		if err != nil {
+			continue
+		}
+		if count+len(parsed) > neuralNetworkSampleWindow {
+			parsed = parsed[len(parsed)-(neuralNetworkSampleWindow-count):]
+		}
+		batches = append(batches, parsed)
+		count += len(parsed)
+	}
+
+	samples := make([]nn.Sample, 0, count)
+	for i := len(batches) - 1; i >= 0; i-- {
+		samples = append(samples, batches[i]...)
+	}
+	return samples
+}
+
+// saveTrainedNeuralNetwork stores the trained weights of a network as a new version and
+// records its accuracy and loss
+func (k Keeper) saveTrainedNeuralNetwork(ctx sdk.Context, network types.NeuralNetwork, model *nn.Network, metrics nn.Metrics) (types.NeuralNetwork, error) {
+	weightsJSON, err := nn.MarshalWeights(model.Weights)
+	if err != nil {
+		return network, err
+	}
+
+	var version uint64 = 1
+	if latest, found := k.GetLatestNeuralNetworkWeights(ctx, network.ID); found {
+		version = latest.Version + 1
+	}
+	k.SetNeuralNetworkWeights(ctx, types.NeuralNetworkWeights{
+		NetworkID: network.ID,
+		Weights:   weightsJSON,
+		UpdatedAt: ctx.BlockTime(),
+		Version:   version,
+	})
+
+	network.Accuracy = metrics.Accuracy.Dec()
+	network.Loss = metrics.Loss.Dec()
 	network.Status = types.NeuralNetworkStatusActive
 	k.SetNeuralNetwork(ctx, network)
-
-	return nil
+	return network, nil
 }
 
 // SubmitNeuralPrediction submits a prediction from a neural network
@@ -397,29 +606,13 @@ func isValidArchitecture(architecture string) bool {
 	return found
 }
 
-// createInitialWeights creates initial weights for a neural network
-func createInitialWeights(layers []types.Layer) (json.RawMessage, error) {
-	// In a real implementation, this would create appropriate initial weights
-	// based on the network architecture and layers
-	// For now, we'll create a simple placeholder
-
-	// Create a simple structure to represent weights
-	weights := make([]map[string]interface{}, len(layers))
-	for i, layer := range layers {
-		weights[i] = map[string]interface{}{
-			"layer_type":  layer.Type,
-			"input_size":  layer.InputSize,
-			"output_size": layer.OutputSize,
-			"weights":     make([]float64, layer.InputSize*layer.OutputSize), // Initialize with zeros
-			"biases":      make([]float64, layer.OutputSize),                 // Initialize with zeros
-		}
-	}
-
-	// Convert to JSON
-	weightsJSON, err := json.Marshal(weights)
+// createInitialWeights creates the initial weights of a neural network, seeded with its
+// ID so that every validator initializes the same weights
+func createInitialWeights(ctx sdk.Context, networkID string, layers []types.Layer) (json.RawMessage, error) {
+	weights, err := nn.InitWeights(ctx.GasMeter(), []byte(networkID), layers)
 	if err != nil {
-		return nil, err
+		return nil, sdkerrors.Wrap(types.ErrInvalidNeuralNetworkArchitecture, err.Error())
 	}
 
-	return weightsJSON, nil
+	return nn.MarshalWeights(weights)
 }
\ No newline at end of file
@@ -29,6 +29,30 @@ func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 		ReputationBonusRate:         k.ReputationBonusRate(ctx),
 		ReputationPenaltyRate:       k.ReputationPenaltyRate(ctx),
 		NeuralNetworkInfluenceRate:  k.NeuralNetworkInfluenceRate(ctx),
+		MinPowerMultiplier:          k.MinPowerMultiplier(ctx),
+		MaxPowerMultiplier:          k.MaxPowerMultiplier(ctx),
+		PowerEpochBlocks:            k.PowerEpochBlocks(ctx),
+		MaxPowerMultiplierChange:    k.MaxPowerMultiplierChange(ctx),
+		AnomalyDetectors:            k.AnomalyDetectors(ctx),
+		AnomalyDryRun:               k.AnomalyDryRun(ctx),
+		AnomalyZScoreThreshold:      k.AnomalyZScoreThreshold(ctx),
+		AnomalySlashConfidence:      k.AnomalySlashConfidence(ctx),
+		AnomalyHistoryWindow:        k.AnomalyHistoryWindow(ctx),
+		AnomalyValidatorWindow:      k.AnomalyValidatorWindow(ctx),
+		AnomalyEWMAAlpha:            k.AnomalyEWMAAlpha(ctx),
+		AnomalyMinSamples:           k.AnomalyMinSamples(ctx),
+		PenaltyChallengePeriod:      k.PenaltyChallengePeriod(ctx),
+		PenaltySlashFraction:        k.PenaltySlashFraction(ctx),
+		PenaltyReputationChange:     k.PenaltyReputationChange(ctx),
+		PenaltyMinDisputeBond:       k.PenaltyMinDisputeBond(ctx),
+		PenaltyVoteQuorum:           k.PenaltyVoteQuorum(ctx),
+		PenaltyReductionFactor:      k.PenaltyReductionFactor(ctx),
+		BaseFeeEnabled:              k.BaseFeeEnabled(ctx),
+		BaseFeeDenom:                k.BaseFeeDenom(ctx),
+		MinBaseFee:                  k.MinBaseFee(ctx),
+		TargetBlockUtilization:      k.TargetBlockUtilization(ctx),
+		BaseFeeMaxChangeRate:        k.BaseFeeMaxChangeRate(ctx),
+		BaseFeeBurnFraction:         k.BaseFeeBurnFraction(ctx),
 	}
 }
 
@@ -149,4 +173,148 @@ func (k Keeper) ReputationPenaltyRate(ctx sdk.Context) (res sdk.Dec) {
 func (k Keeper) NeuralNetworkInfluenceRate(ctx sdk.Context) (res sdk.Dec) {
 	k.paramstore.Get(ctx, types.KeyNeuralNetworkInfluenceRate, &res)
 	return
+}
+
+// MinPowerMultiplier returns the min power multiplier param
+func (k Keeper) MinPowerMultiplier(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyMinPowerMultiplier, &res)
+	return
+}
+
+// MaxPowerMultiplier returns the max power multiplier param
+func (k Keeper) MaxPowerMultiplier(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyMaxPowerMultiplier, &res)
+	return
+}
+
+// PowerEpochBlocks returns the power epoch blocks param
+func (k Keeper) PowerEpochBlocks(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyPowerEpochBlocks, &res)
+	return
+}
+
+// MaxPowerMultiplierChange returns the max power multiplier change param
+func (k Keeper) MaxPowerMultiplierChange(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyMaxPowerMultiplierChange, &res)
+	return
+}
+
+// AnomalyDetectors returns the anomaly detectors param
+func (k Keeper) AnomalyDetectors(ctx sdk.Context) (res []string) {
+	k.paramstore.Get(ctx, types.KeyAnomalyDetectors, &res)
+	return
+}
+
+// AnomalyDryRun returns the anomaly dry run param
+func (k Keeper) AnomalyDryRun(ctx sdk.Context) (res bool) {
+	k.paramstore.Get(ctx, types.KeyAnomalyDryRun, &res)
+	return
+}
+
+// AnomalyZScoreThreshold returns the anomaly z-score threshold param
+func (k Keeper) AnomalyZScoreThreshold(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyAnomalyZScoreThreshold, &res)
+	return
+}
+
+// AnomalySlashConfidence returns the anomaly slash confidence param
+func (k Keeper) AnomalySlashConfidence(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyAnomalySlashConfidence, &res)
+	return
+}
+
+// AnomalyHistoryWindow returns the anomaly history window param
+func (k Keeper) AnomalyHistoryWindow(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyAnomalyHistoryWindow, &res)
+	return
+}
+
+// AnomalyValidatorWindow returns the anomaly validator window param
+func (k Keeper) AnomalyValidatorWindow(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyAnomalyValidatorWindow, &res)
+	return
+}
+
+// AnomalyEWMAAlpha returns the anomaly EWMA alpha param
+func (k Keeper) AnomalyEWMAAlpha(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyAnomalyEWMAAlpha, &res)
+	return
+}
+
+// AnomalyMinSamples returns the anomaly min samples param
+func (k Keeper) AnomalyMinSamples(ctx sdk.Context) (res uint64) {
+	k.paramstore.Get(ctx, types.KeyAnomalyMinSamples, &res)
+	return
+}
+
+// PenaltyChallengePeriod returns the penalty challenge period param
+func (k Keeper) PenaltyChallengePeriod(ctx sdk.Context) (res time.Duration) {
+	k.paramstore.Get(ctx, types.KeyPenaltyChallengePeriod, &res)
+	return
+}
+
+// PenaltySlashFraction returns the penalty slash fraction param
+func (k Keeper) PenaltySlashFraction(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyPenaltySlashFraction, &res)
+	return
+}
+
+// PenaltyReputationChange returns the penalty reputation change param
+func (k Keeper) PenaltyReputationChange(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyPenaltyReputationChange, &res)
+	return
+}
+
+// PenaltyMinDisputeBond returns the penalty min dispute bond param
+func (k Keeper) PenaltyMinDisputeBond(ctx sdk.Context) (res sdk.Coin) {
+	k.paramstore.Get(ctx, types.KeyPenaltyMinDisputeBond, &res)
+	return
+}
+
+// PenaltyVoteQuorum returns the penalty vote quorum param
+func (k Keeper) PenaltyVoteQuorum(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyPenaltyVoteQuorum, &res)
+	return
+}
+
+// PenaltyReductionFactor returns the penalty reduction factor param
+func (k Keeper) PenaltyReductionFactor(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyPenaltyReductionFactor, &res)
+	return
+}
+
+// BaseFeeEnabled returns the base fee enabled param
+func (k Keeper) BaseFeeEnabled(ctx sdk.Context) (res bool) {
+	k.paramstore.Get(ctx, types.KeyBaseFeeEnabled, &res)
+	return
+}
+
+// BaseFeeDenom returns the base fee denom param
+func (k Keeper) BaseFeeDenom(ctx sdk.Context) (res string) {
+	k.paramstore.Get(ctx, types.KeyBaseFeeDenom, &res)
+	return
+}
+
+// MinBaseFee returns the min base fee param
+func (k Keeper) MinBaseFee(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyMinBaseFee, &res)
+	return
+}
+
+// TargetBlockUtilization returns the target block utilization param
+func (k Keeper) TargetBlockUtilization(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyTargetBlockUtilization, &res)
+	return
+}
+
+// BaseFeeMaxChangeRate returns the base fee max change rate param
+func (k Keeper) BaseFeeMaxChangeRate(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyBaseFeeMaxChangeRate, &res)
+	return
+}
+
+// BaseFeeBurnFraction returns the base fee burn fraction param
+func (k Keeper) BaseFeeBurnFraction(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.Get(ctx, types.KeyBaseFeeBurnFraction, &res)
+	return
 }
\ No newline at end of file
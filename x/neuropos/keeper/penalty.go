@@ -0,0 +1,392 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// SetPenalty sets an anomaly penalty
+func (k Keeper) SetPenalty(ctx sdk.Context, penalty types.PendingPenalty) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.PenaltyKey(penalty.ID)
+	value := k.cdc.MustMarshal(&penalty)
+	store.Set(key, value)
+}
+
+// GetPenalty returns an anomaly penalty by ID
+func (k Keeper) GetPenalty(ctx sdk.Context, id uint64) (types.PendingPenalty, bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.PenaltyKey(id))
+	if value == nil {
+		return types.PendingPenalty{}, false
+	}
+
+	var penalty types.PendingPenalty
+	k.cdc.MustUnmarshal(value, &penalty)
+	return penalty, true
+}
+
+// GetAllPenalties returns all anomaly penalties, resolved or not
+func (k Keeper) GetAllPenalties(ctx sdk.Context) []types.PendingPenalty {
+	var penalties []types.PendingPenalty
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.PenaltyKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var penalty types.PendingPenalty
+		k.cdc.MustUnmarshal(iterator.Value(), &penalty)
+		penalties = append(penalties, penalty)
+	}
+
+	return penalties
+}
+
+// nextPenaltyID returns the ID of the next anomaly penalty and increments it
+func (k Keeper) nextPenaltyID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	id := uint64(1)
+	if bz := store.Get(types.NextPenaltyIDKey); bz != nil {
+		id = sdk.BigEndianToUint64(bz)
+	}
+	store.Set(types.NextPenaltyIDKey, sdk.Uint64ToBigEndian(id+1))
+	return id
+}
+
+// setNextPenaltyID sets the ID of the next anomaly penalty
+func (k Keeper) setNextPenaltyID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextPenaltyIDKey, sdk.Uint64ToBigEndian(id))
+}
+
+// insertPenaltyQueue adds a penalty to the queue of challenge periods
+func (k Keeper) insertPenaltyQueue(ctx sdk.Context, penalty types.PendingPenalty) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.PenaltyQueueKey(penalty.ChallengeEndTime, penalty.ID), sdk.Uint64ToBigEndian(penalty.ID))
+}
+
+// removePenaltyQueue removes a penalty from the queue of challenge periods
+func (k Keeper) removePenaltyQueue(ctx sdk.Context, penalty types.PendingPenalty) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.PenaltyQueueKey(penalty.ChallengeEndTime, penalty.ID))
+}
+
+// expiredPenaltyIDs returns the IDs of the penalties whose challenge period ended at or
+// before the given time
+func (k Keeper) expiredPenaltyIDs(ctx sdk.Context, now time.Time) []uint64 {
+	store := ctx.KVStore(k.storeKey)
+	end := sdk.PrefixEndBytes(types.PenaltyQueueTimeKey(now))
+	iterator := store.Iterator(types.PenaltyQueueKeyPrefix, end)
+	defer iterator.Close()
+
+	var ids []uint64
+	for ; iterator.Valid(); iterator.Next() {
+		ids = append(ids, sdk.BigEndianToUint64(iterator.Value()))
+	}
+
+	return ids
+}
+
+// InitPenalties sets the penalties of a genesis state, queueing the unresolved ones
+func (k Keeper) InitPenalties(ctx sdk.Context, penalties []types.PendingPenalty) {
+	nextID := uint64(1)
+	for _, penalty := range penalties {
+		k.SetPenalty(ctx, penalty)
+		if isOpenPenalty(penalty) {
+			k.insertPenaltyQueue(ctx, penalty)
+		}
+		if penalty.ID >= nextID {
+			nextID = penalty.ID + 1
+		}
+	}
+	k.setNextPenaltyID(ctx, nextID)
+}
+
+// QueuePenalty opens the challenge period of the penalty for an anomaly of a validator
+// and returns the ID of the penalty. The penalty is resolved by ResolvePenalties once the
+// challenge period has ended.
+func (k Keeper) QueuePenalty(ctx sdk.Context, report types.AnomalyReport) uint64 {
+	params := k.GetParams(ctx)
+
+	penalty := types.PendingPenalty{
+		ID:               k.nextPenaltyID(ctx),
+		ReportID:         report.ID,
+		ValidatorAddress: report.ValidatorAddress.String(),
+		AnomalyType:      report.AnomalyType,
+		InfractionHeight: report.BlockHeight,
+		SlashFraction:    params.PenaltySlashFraction,
+		ReputationChange: params.PenaltyReputationChange.Neg(),
+		Status:           types.PenaltyStatusPending,
+		CreatedAt:        ctx.BlockTime(),
+		ChallengeEndTime: ctx.BlockTime().Add(params.PenaltyChallengePeriod),
+		Bond:             sdk.NewCoin(params.PenaltyMinDisputeBond.Denom, sdk.ZeroInt()),
+		Votes:            []types.PenaltyVote{},
+	}
+	k.SetPenalty(ctx, penalty)
+	k.insertPenaltyQueue(ctx, penalty)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypePenaltyQueued,
+			sdk.NewAttribute(types.AttributeKeyPenaltyID, fmt.Sprintf("%d", penalty.ID)),
+			sdk.NewAttribute(types.AttributeKeyAnomalyID, fmt.Sprintf("%d", report.ID)),
+			sdk.NewAttribute(types.AttributeKeyValidator, penalty.ValidatorAddress),
+			sdk.NewAttribute(types.AttributeKeySlashFactor, penalty.SlashFraction.String()),
+			sdk.NewAttribute(types.AttributeKeyChallengeEndTime, penalty.ChallengeEndTime.Format(time.RFC3339)),
+		),
+	)
+
+	return penalty.ID
+}
+
+// DisputePenalty records the dispute of a penalty by its validator, escrowing the bond of
+// the validator in the module account until the penalty is resolved
+func (k Keeper) DisputePenalty(ctx sdk.Context, id uint64, valAddr sdk.ValAddress, bond sdk.Coin, counterEvidence string) error {
+	penalty, found := k.GetPenalty(ctx, id)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrPenaltyNotFound, "penalty %d", id)
+	}
+	if penalty.ValidatorAddress != valAddr.String() {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "only validator %s can dispute penalty %d", penalty.ValidatorAddress, id)
+	}
+	if !isOpenPenalty(penalty) || !ctx.BlockTime().Before(penalty.ChallengeEndTime) {
+		return sdkerrors.Wrapf(types.ErrPenaltyChallengeClosed, "penalty %d", id)
+	}
+	if penalty.Status == types.PenaltyStatusDisputed {
+		return sdkerrors.Wrapf(types.ErrPenaltyAlreadyDisputed, "penalty %d", id)
+	}
+
+	minBond := k.PenaltyMinDisputeBond(ctx)
+	if bond.Denom != minBond.Denom || bond.IsLT(minBond) {
+		return sdkerrors.Wrapf(types.ErrInsufficientDisputeBond, "bond %s, minimum %s", bond, minBond)
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, sdk.AccAddress(valAddr), types.ModuleName, sdk.NewCoins(bond)); err != nil {
+		return err
+	}
+
+	penalty.Status = types.PenaltyStatusDisputed
+	penalty.Bond = bond
+	penalty.CounterEvidence = counterEvidence
+	k.SetPenalty(ctx, penalty)
+
+	return nil
+}
+
+// VotePenalty records the vote of a validator on a disputed penalty, replacing its
+// previous vote. Only bonded validators other than the accused one can vote.
+func (k Keeper) VotePenalty(ctx sdk.Context, id uint64, voter sdk.ValAddress, option string) error {
+	if !isValidPenaltyVoteOption(option) {
+		return sdkerrors.Wrapf(types.ErrInvalidPenaltyVote, "unknown option %s", option)
+	}
+
+	penalty, found := k.GetPenalty(ctx, id)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrPenaltyNotFound, "penalty %d", id)
+	}
+	if penalty.Status != types.PenaltyStatusDisputed {
+		return sdkerrors.Wrapf(types.ErrPenaltyNotDisputed, "penalty %d is %s", id, penalty.Status)
+	}
+	if !ctx.BlockTime().Before(penalty.ChallengeEndTime) {
+		return sdkerrors.Wrapf(types.ErrPenaltyChallengeClosed, "penalty %d", id)
+	}
+	if penalty.ValidatorAddress == voter.String() {
+		return sdkerrors.Wrap(types.ErrInvalidPenaltyVote, "validators cannot vote on their own penalty")
+	}
+	if k.stakingKeeper.GetLastValidatorPower(ctx, voter) <= 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidPenaltyVote, "validator %s is not bonded", voter)
+	}
+
+	vote := types.PenaltyVote{Voter: voter.String(), Option: option}
+	replaced := false
+	for i := range penalty.Votes {
+		if penalty.Votes[i].Voter == vote.Voter {
+			penalty.Votes[i] = vote
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		penalty.Votes = append(penalty.Votes, vote)
+	}
+	k.SetPenalty(ctx, penalty)
+
+	return nil
+}
+
+// ResolvePenalties resolves the penalties whose challenge period has ended. An undisputed
+// penalty is executed. A disputed penalty is executed in full, reduced or cancelled by
+// the vote of the validators; the bond of the accused validator is burned if the penalty
+// is upheld and refunded otherwise.
+func (k Keeper) ResolvePenalties(ctx sdk.Context) {
+	// Collect the expired penalties first, resolving them changes the queue
+	for _, id := range k.expiredPenaltyIDs(ctx, ctx.BlockTime()) {
+		penalty, found := k.GetPenalty(ctx, id)
+		if !found {
+			continue
+		}
+		k.removePenaltyQueue(ctx, penalty)
+
+		outcome := types.PenaltyVoteUphold
+		if penalty.Status == types.PenaltyStatusDisputed {
+			outcome = k.tallyPenaltyVotes(ctx, penalty)
+		}
+
+		var err error
+		switch outcome {
+		case types.PenaltyVoteCancel:
+			penalty.Status = types.PenaltyStatusCancelled
+			err = k.refundDisputeBond(ctx, penalty)
+		case types.PenaltyVoteReduce:
+			factor := k.PenaltyReductionFactor(ctx)
+			penalty.SlashFraction = penalty.SlashFraction.Mul(factor)
+			penalty.ReputationChange = penalty.ReputationChange.Mul(factor)
+			penalty.Status = types.PenaltyStatusReduced
+			k.executePenalty(ctx, penalty)
+			err = k.refundDisputeBond(ctx, penalty)
+		default:
+			penalty.Status = types.PenaltyStatusExecuted
+			k.executePenalty(ctx, penalty)
+			err = k.burnDisputeBond(ctx, penalty)
+		}
+		if err != nil {
+			// The bond is held by the module account, so this is an invariant violation
+			panic(fmt.Sprintf("failed to settle the dispute bond of penalty %d: %s", penalty.ID, err))
+		}
+
+		k.SetPenalty(ctx, penalty)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypePenaltyResolved,
+				sdk.NewAttribute(types.AttributeKeyPenaltyID, fmt.Sprintf("%d", penalty.ID)),
+				sdk.NewAttribute(types.AttributeKeyValidator, penalty.ValidatorAddress),
+				sdk.NewAttribute(types.AttributeKeyPenaltyStatus, penalty.Status),
+				sdk.NewAttribute(types.AttributeKeySlashFactor, penalty.SlashFraction.String()),
+			),
+		)
+	}
+}
+
+// tallyPenaltyVotes returns the outcome of the vote on a disputed penalty. Votes are
+// weighted by the current voting power of the voters, and the eligible power is the
+// bonded voting power of the validators other than the accused.
+func (k Keeper) tallyPenaltyVotes(ctx sdk.Context, penalty types.PendingPenalty) string {
+	tally := make(map[string]int64)
+	var voted int64
+	for _, vote := range penalty.Votes {
+		voter, err := sdk.ValAddressFromBech32(vote.Voter)
+		if err != nil {
+			continue
+		}
+		// Validators that left the bonded set since voting have no power
+		power := k.stakingKeeper.GetLastValidatorPower(ctx, voter)
+		tally[vote.Option] += power
+		voted += power
+	}
+
+	eligible := k.stakingKeeper.GetLastTotalPower(ctx).Int64()
+	if accused, err := sdk.ValAddressFromBech32(penalty.ValidatorAddress); err == nil {
+		eligible -= k.stakingKeeper.GetLastValidatorPower(ctx, accused)
+	}
+
+	return penaltyVoteOutcome(tally, voted, eligible, k.PenaltyVoteQuorum(ctx))
+}
+
+// penaltyVoteOutcome returns the outcome of a weighted vote on a penalty. Without a
+// quorum of the eligible power the penalty is upheld. Otherwise the outcome is the
+// weighted median of the votes: the penalty is cancelled if a majority votes to cancel
+// it, reduced if a majority votes to reduce or cancel it, and upheld otherwise.
+func penaltyVoteOutcome(tally map[string]int64, voted, eligible int64, quorum sdk.Dec) string {
+	if voted == 0 || eligible <= 0 || sdk.NewDec(voted).QuoInt64(eligible).LT(quorum) {
+		return types.PenaltyVoteUphold
+	}
+
+	switch {
+	case tally[types.PenaltyVoteCancel]*2 > voted:
+		return types.PenaltyVoteCancel
+	case (tally[types.PenaltyVoteCancel]+tally[types.PenaltyVoteReduce])*2 > voted:
+		return types.PenaltyVoteReduce
+	default:
+		return types.PenaltyVoteUphold
+	}
+}
+
+// executePenalty slashes a validator and lowers its reputation by the amounts of a
+// penalty. Validators that staking no longer knows have nothing left to slash.
+func (k Keeper) executePenalty(ctx sdk.Context, penalty types.PendingPenalty) {
+	valAddr, err := sdk.ValAddressFromBech32(penalty.ValidatorAddress)
+	if err != nil {
+		return
+	}
+	validator, found := k.stakingKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return
+	}
+
+	switch {
+	case !penalty.SlashFraction.IsPositive():
+	case validator.IsUnbonded():
+		// x/staking panics when slashing a validator that finished unbonding during the
+		// challenge period; like x/slashing, such a validator is no longer slashed
+		k.Logger(ctx).Info("not slashing unbonded validator", "penalty_id", penalty.ID, "validator", penalty.ValidatorAddress)
+	default:
+		consAddr, err := validator.GetConsAddr()
+		if err != nil {
+			return
+		}
+		powerReduction := k.stakingKeeper.PowerReduction(ctx)
+		power := validator.ConsensusPower(powerReduction)
+		k.stakingKeeper.Slash(ctx, consAddr, penalty.InfractionHeight, power, penalty.SlashFraction)
+
+		k.AddValidatorSlashEvent(ctx, penalty.ValidatorAddress, ctx.BlockHeight(), "anomaly: "+penalty.AnomalyType, penalty.SlashFraction, sdk.TokensFromConsensusPower(power, powerReduction))
+	}
+
+	if penalty.ReputationChange.IsNegative() {
+		if err := k.UpdateValidatorReputation(ctx, penalty.ValidatorAddress, penalty.ReputationChange, "anomaly penalty"); err != nil {
+			k.Logger(ctx).Error("failed to update validator reputation", "penalty_id", penalty.ID, "error", err)
+		}
+	}
+}
+
+// refundDisputeBond returns the bond of a disputed penalty to its validator
+func (k Keeper) refundDisputeBond(ctx sdk.Context, penalty types.PendingPenalty) error {
+	if penalty.Bond.Amount.IsNil() || !penalty.Bond.IsPositive() {
+		return nil
+	}
+
+	valAddr, err := sdk.ValAddressFromBech32(penalty.ValidatorAddress)
+	if err != nil {
+		return err
+	}
+	return k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sdk.AccAddress(valAddr), sdk.NewCoins(penalty.Bond))
+}
+
+// burnDisputeBond burns the bond of a disputed penalty
+func (k Keeper) burnDisputeBond(ctx sdk.Context, penalty types.PendingPenalty) error {
+	if penalty.Bond.Amount.IsNil() || !penalty.Bond.IsPositive() {
+		return nil
+	}
+
+	return k.bankKeeper.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(penalty.Bond))
+}
+
+// isOpenPenalty returns true if a penalty has not been resolved yet
+func isOpenPenalty(penalty types.PendingPenalty) bool {
+	return penalty.Status == types.PenaltyStatusPending || penalty.Status == types.PenaltyStatusDisputed
+}
+
+// isValidPenaltyVoteOption returns true if an option is a known penalty vote
+func isValidPenaltyVoteOption(option string) bool {
+	switch option {
+	case types.PenaltyVoteUphold, types.PenaltyVoteReduce, types.PenaltyVoteCancel:
+		return true
+	default:
+		return false
+	}
+}
\ No newline at end of file
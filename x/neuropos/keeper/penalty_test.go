@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+func TestPenaltyVoteOutcome(t *testing.T) {
+	quorum := sdk.NewDecWithPrec(334, 3)
+
+	tests := []struct {
+		name     string
+		uphold   int64
+		reduce   int64
+		cancel   int64
+		eligible int64
+		expected string
+	}{
+		{"no votes", 0, 0, 0, 100, types.PenaltyVoteUphold},
+		{"no quorum", 0, 0, 33, 100, types.PenaltyVoteUphold},
+		{"no eligible power", 0, 0, 40, 0, types.PenaltyVoteUphold},
+		{"cancel majority", 10, 10, 40, 100, types.PenaltyVoteCancel},
+		{"reduce majority", 10, 40, 10, 100, types.PenaltyVoteReduce},
+		{"cancel and reduce majority", 20, 20, 20, 100, types.PenaltyVoteReduce},
+		{"tie is upheld", 30, 20, 10, 100, types.PenaltyVoteUphold},
+		{"cancel tie is reduced", 10, 20, 30, 100, types.PenaltyVoteReduce},
+		{"uphold majority", 40, 10, 10, 100, types.PenaltyVoteUphold},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tally := map[string]int64{
+				types.PenaltyVoteUphold: tc.uphold,
+				types.PenaltyVoteReduce: tc.reduce,
+				types.PenaltyVoteCancel: tc.cancel,
+			}
+			voted := tc.uphold + tc.reduce + tc.cancel
+			require.Equal(t, tc.expected, penaltyVoteOutcome(tally, voted, tc.eligible, quorum))
+		})
+	}
+}
+
+func TestIsValidPenaltyVoteOption(t *testing.T) {
+	for _, option := range []string{types.PenaltyVoteUphold, types.PenaltyVoteReduce, types.PenaltyVoteCancel} {
+		require.True(t, isValidPenaltyVoteOption(option))
+	}
+	require.False(t, isValidPenaltyVoteOption("abstain"))
+	require.False(t, isValidPenaltyVoteOption(""))
+}
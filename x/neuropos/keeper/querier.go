@@ -1,10 +1,10 @@
 package keeper
 
 import (
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/nomercychain/nmxchain/x/neuropos/types"
 )
@@ -65,6 +65,8 @@ func NewQuerier(k Keeper, legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
 			return queryNetworkState(ctx, k, legacyQuerierCdc)
 		case types.QueryAnomalyReports:
 			return queryAnomalyReports(ctx, k, legacyQuerierCdc)
+		case types.QueryBaseFee:
+			return queryBaseFee(ctx, k, legacyQuerierCdc)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", types.ModuleName, path[0])
 		}
@@ -596,5 +598,14 @@ func queryAnomalyReports(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.Lega
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
+	return res, nil
+}
+
+func queryBaseFee(ctx sdk.Context, k Keeper, legacyQuerierCdc *codec.LegacyAmino) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(legacyQuerierCdc, k.baseFeeResponse(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
 	return res, nil
 }
\ No newline at end of file
@@ -534,4 +534,11 @@ func (k queryServer) AnomalyReports(goCtx context.Context, req *types.QueryAnoma
 	reports := k.GetAllAnomalyReports(ctx)
 
 	return &types.QueryAnomalyReportsResponse{Reports: reports}, nil
+}
+
+// BaseFee returns the current base fee and the base fees projected after the next block
+func (k queryServer) BaseFee(goCtx context.Context, req *types.QueryBaseFeeRequest) (*types.QueryBaseFeeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	return k.baseFeeResponse(ctx), nil
 }
\ No newline at end of file
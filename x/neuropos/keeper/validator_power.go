@@ -0,0 +1,268 @@
+package keeper
+
+import (
+	"bytes"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmprotocrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// SetValidatorPower sets the voting power sent to consensus for a validator
+func (k Keeper) SetValidatorPower(ctx sdk.Context, power types.ValidatorPower) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.ValidatorPowerKey(power.ValidatorAddress)
+	value := k.cdc.MustMarshal(&power)
+	store.Set(key, value)
+}
+
+// GetValidatorPower returns the voting power sent to consensus for a validator
+func (k Keeper) GetValidatorPower(ctx sdk.Context, validatorAddr string) (types.ValidatorPower, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.ValidatorPowerKey(validatorAddr)
+	value := store.Get(key)
+	if value == nil {
+		return types.ValidatorPower{}, false
+	}
+
+	var power types.ValidatorPower
+	k.cdc.MustUnmarshal(value, &power)
+	return power, true
+}
+
+// DeleteValidatorPower deletes the voting power of a validator
+func (k Keeper) DeleteValidatorPower(ctx sdk.Context, validatorAddr string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.ValidatorPowerKey(validatorAddr))
+}
+
+// GetAllValidatorPowers returns the voting powers of all validators
+func (k Keeper) GetAllValidatorPowers(ctx sdk.Context) []types.ValidatorPower {
+	var powers []types.ValidatorPower
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ValidatorPowerKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var power types.ValidatorPower
+		k.cdc.MustUnmarshal(iterator.Value(), &power)
+		powers = append(powers, power)
+	}
+
+	return powers
+}
+
+// hasValidatorPowers returns true if any voting power has been recorded
+func (k Keeper) hasValidatorPowers(ctx sdk.Context) bool {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ValidatorPowerKeyPrefix)
+	defer iterator.Close()
+
+	return iterator.Valid()
+}
+
+// InitValidatorPowers records the voting powers consensus starts from, which are the
+// powers staking last computed for the bonded validators. The multipliers of the given
+// powers, e.g. from genesis, are kept.
+func (k Keeper) InitValidatorPowers(ctx sdk.Context, powers []types.ValidatorPower) {
+	params := k.GetParams(ctx)
+
+	for _, power := range powers {
+		power.Power = 0
+		k.SetValidatorPower(ctx, power)
+	}
+
+	for _, validator := range k.stakingKeeper.GetLastValidators(ctx) {
+		valAddr := validator.GetOperator()
+		_, pubKeyBytes := consensusPubKey(validator)
+
+		power, found := k.GetValidatorPower(ctx, valAddr.String())
+		if !found {
+			power = types.ValidatorPower{
+				ValidatorAddress: valAddr.String(),
+				Multiplier:       neutralPowerMultiplier(params),
+			}
+		}
+		power.ConsensusPubKey = pubKeyBytes
+		power.Power = k.stakingKeeper.GetLastValidatorPower(ctx, valAddr)
+		k.SetValidatorPower(ctx, power)
+	}
+}
+
+// ApplyValidatorPowerUpdates computes the voting power of the bonded validators and
+// returns the updates of the powers that changed. The voting power of a validator is its
+// staked consensus power times its reputation multiplier. Every PowerEpochBlocks blocks
+// the multipliers move toward the reputation of the validators by at most
+// MaxPowerMultiplierChange, so stake changes and validators joining or leaving the set
+// take effect at once while reputation swings are spread over epochs.
+//
+// neuropos is the only module that sends validator updates to consensus; staking keeps
+// selecting the bonded validators but its updates are dropped by StakingAppModule.
+// Slashing and evidence slash on the staked power through SlashingStakingKeeper.
+func (k Keeper) ApplyValidatorPowerUpdates(ctx sdk.Context) []abci.ValidatorUpdate {
+	// Chains that ran staking updates before start from the powers staking sent
+	if !k.hasValidatorPowers(ctx) {
+		k.InitValidatorPowers(ctx, nil)
+	}
+
+	params := k.GetParams(ctx)
+	epoch := uint64(ctx.BlockHeight())%params.PowerEpochBlocks == 0
+	powerReduction := k.stakingKeeper.PowerReduction(ctx)
+
+	updates := []abci.ValidatorUpdate{}
+	bonded := make(map[string]bool)
+	for _, validator := range k.stakingKeeper.GetLastValidators(ctx) {
+		valAddr := validator.GetOperator().String()
+		bonded[valAddr] = true
+		pubKey, pubKeyBytes := consensusPubKey(validator)
+
+		power, found := k.GetValidatorPower(ctx, valAddr)
+		if !found {
+			power = types.ValidatorPower{
+				ValidatorAddress: valAddr,
+				Multiplier:       neutralPowerMultiplier(params),
+			}
+		}
+
+		multiplier := clampPowerMultiplier(power.Multiplier, params)
+		if epoch {
+			multiplier = stepPowerMultiplier(multiplier, k.targetPowerMultiplier(ctx, valAddr, params), params.MaxPowerMultiplierChange)
+		}
+		votingPower := weightedPower(validator.ConsensusPower(powerReduction), multiplier)
+
+		changed := votingPower != power.Power
+		if !found || changed || !multiplier.Equal(power.Multiplier) || !bytes.Equal(pubKeyBytes, power.ConsensusPubKey) {
+			power.ConsensusPubKey = pubKeyBytes
+			power.Multiplier = multiplier
+			power.Power = votingPower
+			k.SetValidatorPower(ctx, power)
+		}
+
+		if changed {
+			updates = append(updates, abci.ValidatorUpdate{PubKey: pubKey, Power: votingPower})
+			emitValidatorPowerUpdate(ctx, power)
+		}
+	}
+
+	// Remove the validators that left the bonded set from consensus. Their multipliers
+	// are kept while staking knows them, so leaving and rejoining the set does not reset
+	// a low reputation.
+	for _, power := range k.GetAllValidatorPowers(ctx) {
+		if bonded[power.ValidatorAddress] {
+			continue
+		}
+
+		if power.Power != 0 {
+			var pubKey tmprotocrypto.PublicKey
+			if err := pubKey.Unmarshal(power.ConsensusPubKey); err != nil {
+				panic(err)
+			}
+			updates = append(updates, abci.ValidatorUpdate{PubKey: pubKey, Power: 0})
+
+			power.Power = 0
+			k.SetValidatorPower(ctx, power)
+			emitValidatorPowerUpdate(ctx, power)
+		}
+
+		valAddr, err := sdk.ValAddressFromBech32(power.ValidatorAddress)
+		if err != nil {
+			continue
+		}
+		if _, found := k.stakingKeeper.GetValidator(ctx, valAddr); !found {
+			k.DeleteValidatorPower(ctx, power.ValidatorAddress)
+		}
+	}
+
+	return updates
+}
+
+// targetPowerMultiplier maps the reputation of a validator, adjusted by the influence of
+// its neural network predictions, linearly onto [MinPowerMultiplier, MaxPowerMultiplier]
+func (k Keeper) targetPowerMultiplier(ctx sdk.Context, validatorAddr string, params types.Params) sdk.Dec {
+	// Validators start with full reputation
+	score := sdk.OneDec()
+	if reputation, found := k.GetValidatorReputation(ctx, validatorAddr); found {
+		score = reputation.Reputation
+	}
+
+	score = score.Add(k.CalculateNeuralNetworkInfluence(ctx, validatorAddr))
+	if score.IsNegative() {
+		score = sdk.ZeroDec()
+	} else if score.GT(sdk.OneDec()) {
+		score = sdk.OneDec()
+	}
+
+	return params.MinPowerMultiplier.Add(params.MaxPowerMultiplier.Sub(params.MinPowerMultiplier).Mul(score))
+}
+
+// neutralPowerMultiplier returns the multiplier of a validator joining the set, which
+// leaves its stake unchanged if the params allow it
+func neutralPowerMultiplier(params types.Params) sdk.Dec {
+	return clampPowerMultiplier(sdk.OneDec(), params)
+}
+
+// clampPowerMultiplier keeps a multiplier within the bounds of the params
+func clampPowerMultiplier(multiplier sdk.Dec, params types.Params) sdk.Dec {
+	if multiplier.IsNil() || multiplier.LT(params.MinPowerMultiplier) {
+		return params.MinPowerMultiplier
+	}
+	if multiplier.GT(params.MaxPowerMultiplier) {
+		return params.MaxPowerMultiplier
+	}
+	return multiplier
+}
+
+// stepPowerMultiplier moves a multiplier toward its target by at most maxChange
+func stepPowerMultiplier(multiplier, target, maxChange sdk.Dec) sdk.Dec {
+	diff := target.Sub(multiplier)
+	if diff.GT(maxChange) {
+		return multiplier.Add(maxChange)
+	}
+	if diff.LT(maxChange.Neg()) {
+		return multiplier.Sub(maxChange)
+	}
+	return target
+}
+
+// weightedPower scales a consensus power by a multiplier. A bonded validator keeps a
+// power of at least 1 so that it stays in the consensus set.
+func weightedPower(power int64, multiplier sdk.Dec) int64 {
+	if power <= 0 {
+		return 0
+	}
+
+	weighted := multiplier.MulInt64(power).TruncateInt64()
+	if weighted < 1 {
+		return 1
+	}
+	return weighted
+}
+
+// consensusPubKey returns the consensus public key of a bonded validator and its encoding
+func consensusPubKey(validator stakingtypes.Validator) (tmprotocrypto.PublicKey, []byte) {
+	// Staking only bonds validators with valid consensus keys
+	pubKey, err := validator.TmConsPublicKey()
+	if err != nil {
+		panic(err)
+	}
+	bz, err := pubKey.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return pubKey, bz
+}
+
+// emitValidatorPowerUpdate emits an event for a voting power sent to consensus
+func emitValidatorPowerUpdate(ctx sdk.Context, power types.ValidatorPower) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeValidatorPowerUpdate,
+			sdk.NewAttribute(types.AttributeKeyValidator, power.ValidatorAddress),
+			sdk.NewAttribute(types.AttributeKeyVotingPower, sdk.NewInt(power.Power).String()),
+			sdk.NewAttribute(types.AttributeKeyPowerMultiplier, power.Multiplier.String()),
+		),
+	)
+}
\ No newline at end of file
@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+func TestWeightedPower(t *testing.T) {
+	tests := []struct {
+		name       string
+		power      int64
+		multiplier string
+		expected   int64
+	}{
+		{"neutral", 100, "1", 100},
+		{"boosted", 100, "1.5", 150},
+		{"reduced", 100, "0.5", 50},
+		{"truncated", 7, "1.25", 8},
+		{"bonded validators keep a power of 1", 1, "0.5", 1},
+		{"no stake", 0, "1.5", 0},
+		{"negative stake", -5, "1.5", 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, weightedPower(tc.power, sdk.MustNewDecFromStr(tc.multiplier)))
+		})
+	}
+}
+
+func TestStepPowerMultiplier(t *testing.T) {
+	maxChange := sdk.MustNewDecFromStr("0.05")
+
+	tests := []struct {
+		name       string
+		multiplier string
+		target     string
+		expected   string
+	}{
+		{"at target", "1", "1", "1"},
+		{"within reach above", "1", "1.03", "1.03"},
+		{"within reach below", "1", "0.95", "0.95"},
+		{"limited rise", "1", "1.5", "1.05"},
+		{"limited fall", "1", "0.5", "0.95"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stepped := stepPowerMultiplier(sdk.MustNewDecFromStr(tc.multiplier), sdk.MustNewDecFromStr(tc.target), maxChange)
+			require.Equal(t, sdk.MustNewDecFromStr(tc.expected), stepped)
+		})
+	}
+
+	// A multiplier reaches a distant target in steps of maxChange
+	multiplier, target := sdk.OneDec(), sdk.MustNewDecFromStr("1.22")
+	for i := 0; i < 4; i++ {
+		multiplier = stepPowerMultiplier(multiplier, target, maxChange)
+	}
+	require.Equal(t, sdk.MustNewDecFromStr("1.2"), multiplier)
+	require.Equal(t, target, stepPowerMultiplier(multiplier, target, maxChange))
+}
+
+func TestClampPowerMultiplier(t *testing.T) {
+	params := types.Params{
+		MinPowerMultiplier: sdk.MustNewDecFromStr("0.5"),
+		MaxPowerMultiplier: sdk.MustNewDecFromStr("1.5"),
+	}
+
+	require.Equal(t, sdk.MustNewDecFromStr("0.8"), clampPowerMultiplier(sdk.MustNewDecFromStr("0.8"), params))
+	require.Equal(t, params.MinPowerMultiplier, clampPowerMultiplier(sdk.MustNewDecFromStr("0.1"), params))
+	require.Equal(t, params.MaxPowerMultiplier, clampPowerMultiplier(sdk.NewDec(2), params))
+	require.Equal(t, params.MinPowerMultiplier, clampPowerMultiplier(sdk.Dec{}, params))
+
+	// A validator joining the set keeps its stake unless the params rule it out
+	require.Equal(t, sdk.OneDec(), neutralPowerMultiplier(params))
+	params.MinPowerMultiplier = sdk.MustNewDecFromStr("1.1")
+	require.Equal(t, params.MinPowerMultiplier, neutralPowerMultiplier(params))
+}
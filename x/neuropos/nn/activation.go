@@ -0,0 +1,95 @@
+package nn
+
+// Activation functions
+const (
+	ActivationLinear  = "linear"
+	ActivationReLU    = "relu"
+	ActivationSigmoid = "sigmoid"
+	ActivationTanh    = "tanh"
+)
+
+// Breakpoints and coefficients of the piecewise linear sigmoid approximation (PLAN),
+// all exact in Q16.16
+var (
+	sigmoidBreak1 = One             // 1
+	sigmoidBreak2 = One*2 + One*3/8 // 2.375
+	sigmoidBreak3 = One * 5         // 5
+	sigmoidSlope1 = One / 4         // 0.25
+	sigmoidSlope2 = One / 8         // 0.125
+	sigmoidSlope3 = One / 32        // 0.03125
+	sigmoidBias2  = One * 5 / 8     // 0.625
+	sigmoidBias3  = One * 27 / 32   // 0.84375
+)
+
+// isValidActivation returns true if the activation function is known
+func isValidActivation(activation string) bool {
+	switch activation {
+	case "", ActivationLinear, ActivationReLU, ActivationSigmoid, ActivationTanh:
+		return true
+	default:
+		return false
+	}
+}
+
+// activate applies an activation function; an empty activation is linear
+func activate(activation string, x Fixed) Fixed {
+	switch activation {
+	case ActivationReLU:
+		if x < 0 {
+			return 0
+		}
+		return x
+	case ActivationSigmoid:
+		return sigmoid(x)
+	case ActivationTanh:
+		return tanh(x)
+	default:
+		return x
+	}
+}
+
+// derivative returns the derivative of an activation function at the pre-activation z
+// given its output y
+func derivative(activation string, z, y Fixed) Fixed {
+	switch activation {
+	case ActivationReLU:
+		if z > 0 {
+			return One
+		}
+		return 0
+	case ActivationSigmoid:
+		return y.Mul(One.Sub(y))
+	case ActivationTanh:
+		return One.Sub(y.Mul(y))
+	default:
+		return One
+	}
+}
+
+// sigmoid approximates 1 / (1 + e^-x) piecewise linearly. The approximation is
+// symmetric, sigmoid(-x) = 1 - sigmoid(x), and stays within 0.019 of the sigmoid.
+func sigmoid(x Fixed) Fixed {
+	a := x.Abs()
+
+	var y Fixed
+	switch {
+	case a >= sigmoidBreak3:
+		y = One
+	case a >= sigmoidBreak2:
+		y = sigmoidSlope3.Mul(a).Add(sigmoidBias3)
+	case a >= sigmoidBreak1:
+		y = sigmoidSlope2.Mul(a).Add(sigmoidBias2)
+	default:
+		y = sigmoidSlope1.Mul(a).Add(Half)
+	}
+
+	if x < 0 {
+		return One.Sub(y)
+	}
+	return y
+}
+
+// tanh approximates the hyperbolic tangent as 2 * sigmoid(2x) - 1
+func tanh(x Fixed) Fixed {
+	return sigmoid(x.Add(x)).Mul(2 * One).Sub(One)
+}
\ No newline at end of file
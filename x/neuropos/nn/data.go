@@ -0,0 +1,82 @@
+package nn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Sample is a feature vector with the outputs the network should produce for it
+type Sample struct {
+	Features []Fixed
+	Target   []Fixed
+}
+
+// ParseSamples decodes training data into samples for a network. Features are a JSON
+// array of feature vectors, e.g. [["0.5", 1], [0, "-0.25"]]. Labels hold one entry per
+// feature vector: a vector of target outputs, or a single number that is the target of a
+// network with one output and the class index of a network with several outputs.
+func ParseSamples(features, labels json.RawMessage, inputSize, outputSize int) ([]Sample, error) {
+	var featureVectors [][]Fixed
+	if err := json.Unmarshal(features, &featureVectors); err != nil {
+		return nil, fmt.Errorf("invalid features: %w", err)
+	}
+
+	var rawLabels []json.RawMessage
+	if err := json.Unmarshal(labels, &rawLabels); err != nil {
+		return nil, fmt.Errorf("invalid labels: %w", err)
+	}
+
+	if len(featureVectors) == 0 {
+		return nil, fmt.Errorf("no samples")
+	}
+	if len(rawLabels) != len(featureVectors) {
+		return nil, fmt.Errorf("%d feature vectors but %d labels", len(featureVectors), len(rawLabels))
+	}
+
+	samples := make([]Sample, len(featureVectors))
+	for i, vector := range featureVectors {
+		if len(vector) != inputSize {
+			return nil, fmt.Errorf("sample %d has %d features, expected %d", i, len(vector), inputSize)
+		}
+
+		target, err := parseTarget(rawLabels[i], outputSize)
+		if err != nil {
+			return nil, fmt.Errorf("sample %d: %w", i, err)
+		}
+
+		samples[i] = Sample{Features: vector, Target: target}
+	}
+
+	return samples, nil
+}
+
+// parseTarget decodes the label of a sample into the target outputs
+func parseTarget(label json.RawMessage, outputSize int) ([]Fixed, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(label), []byte("[")) {
+		var target []Fixed
+		if err := json.Unmarshal(label, &target); err != nil {
+			return nil, fmt.Errorf("invalid label: %w", err)
+		}
+		if len(target) != outputSize {
+			return nil, fmt.Errorf("label has %d targets, expected %d", len(target), outputSize)
+		}
+		return target, nil
+	}
+
+	var value Fixed
+	if err := json.Unmarshal(label, &value); err != nil {
+		return nil, fmt.Errorf("invalid label: %w", err)
+	}
+	if outputSize == 1 {
+		return []Fixed{value}, nil
+	}
+
+	// A one-hot encoding of the class index
+	if value < 0 || value%One != 0 || int64(value/One) >= int64(outputSize) {
+		return nil, fmt.Errorf("class %s is not an index of the %d outputs", value, outputSize)
+	}
+	target := make([]Fixed, outputSize)
+	target[value/One] = One
+	return target, nil
+}
\ No newline at end of file
@@ -0,0 +1,137 @@
+package nn
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FracBits is the number of fractional bits of a Fixed
+const FracBits = 16
+
+// Fixed is a signed Q16.16 fixed-point number held in an int64. Values saturate at
+// MaxFixed and MinFixed, so the product of two values always fits in an int64 before
+// it is rescaled.
+type Fixed int64
+
+// Fixed-point constants
+const (
+	One      Fixed = 1 << FracBits
+	Half     Fixed = One / 2
+	MaxFixed Fixed = 1<<31 - 1
+	MinFixed Fixed = -(1 << 31)
+)
+
+// FromInt returns the Fixed value of an integer, saturating out of range values
+func FromInt(i int64) Fixed {
+	if i > int64(MaxFixed>>FracBits) {
+		return MaxFixed
+	}
+	if i < int64(MinFixed>>FracBits) {
+		return MinFixed
+	}
+	return Fixed(i << FracBits)
+}
+
+// FromDec returns the Fixed value of a decimal, rounded to the nearest multiple of
+// 2^-16 and saturated
+func FromDec(d sdk.Dec) Fixed {
+	scaled := d.MulInt64(int64(One)).RoundInt()
+	if scaled.GT(sdk.NewInt(int64(MaxFixed))) {
+		return MaxFixed
+	}
+	if scaled.LT(sdk.NewInt(int64(MinFixed))) {
+		return MinFixed
+	}
+	return Fixed(scaled.Int64())
+}
+
+// Dec returns the exact decimal value of a Fixed
+func (a Fixed) Dec() sdk.Dec {
+	return sdk.NewDec(int64(a)).QuoInt64(int64(One))
+}
+
+// String returns the decimal representation of a Fixed
+func (a Fixed) String() string {
+	return a.Dec().String()
+}
+
+// MarshalJSON encodes a Fixed as a decimal string
+func (a Fixed) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + a.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a Fixed from a decimal string or a JSON number. Exponent
+// notation is rejected so that every validator parses the same value.
+func (a *Fixed) UnmarshalJSON(bz []byte) error {
+	s := strings.Trim(string(bz), `"`)
+	d, err := sdk.NewDecFromStr(s)
+	if err != nil {
+		return fmt.Errorf("invalid fixed-point value %s: %w", s, err)
+	}
+	*a = FromDec(d)
+	return nil
+}
+
+// saturate clamps a raw value to the range of Fixed
+func saturate(v int64) Fixed {
+	if v > int64(MaxFixed) {
+		return MaxFixed
+	}
+	if v < int64(MinFixed) {
+		return MinFixed
+	}
+	return Fixed(v)
+}
+
+// Add returns a + b
+func (a Fixed) Add(b Fixed) Fixed {
+	return saturate(int64(a) + int64(b))
+}
+
+// Sub returns a - b
+func (a Fixed) Sub(b Fixed) Fixed {
+	return saturate(int64(a) - int64(b))
+}
+
+// Neg returns -a
+func (a Fixed) Neg() Fixed {
+	return saturate(-int64(a))
+}
+
+// Mul returns a * b, rounded half away from zero
+func (a Fixed) Mul(b Fixed) Fixed {
+	p := int64(a) * int64(b)
+	if p < 0 {
+		return saturate(-((-p + int64(Half)) >> FracBits))
+	}
+	return saturate((p + int64(Half)) >> FracBits)
+}
+
+// QuoInt returns a / n truncated toward zero
+func (a Fixed) QuoInt(n int64) Fixed {
+	return saturate(int64(a) / n)
+}
+
+// Abs returns |a|
+func (a Fixed) Abs() Fixed {
+	if a < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// sqrt returns the integer square root of n
+func sqrt(n uint64) uint64 {
+	if n < 2 {
+		return n
+	}
+	x := n
+	y := (x + 1) / 2
+	for y < x {
+		x = y
+		y = (x + n/x) / 2
+	}
+	return x
+}
\ No newline at end of file
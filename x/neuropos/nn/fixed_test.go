@@ -0,0 +1,101 @@
+package nn
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedMul(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Fixed
+		expected Fixed
+	}{
+		{"exact", One + Half, One + Half, 2*One + One/4},
+		{"negative", -(One + Half), One + Half, -(2*One + One/4)},
+		{"rounds down below half", 1, 1, 0},
+		{"rounds half away from zero", 1, Half, 1},
+		{"rounds negative half away from zero", -1, Half, -1},
+		{"saturates", MaxFixed, 2 * One, MaxFixed},
+		{"saturates negative", MaxFixed, -2 * One, MinFixed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.a.Mul(tc.b))
+		})
+	}
+}
+
+func TestFixedSaturates(t *testing.T) {
+	require.Equal(t, MaxFixed, MaxFixed.Add(One))
+	require.Equal(t, MinFixed, MinFixed.Sub(One))
+	require.Equal(t, MaxFixed, MinFixed.Neg())
+	require.Equal(t, MaxFixed, MinFixed.Abs())
+	require.Equal(t, MaxFixed, FromInt(1<<20))
+	require.Equal(t, MinFixed, FromInt(-1<<20))
+	require.Equal(t, 3*One, FromInt(3))
+	require.Equal(t, -One, (3 * One).Neg().QuoInt(3))
+}
+
+func TestFixedFromDec(t *testing.T) {
+	require.Equal(t, Fixed(6554), FromDec(sdk.MustNewDecFromStr("0.1")))
+	require.Equal(t, Fixed(-6554), FromDec(sdk.MustNewDecFromStr("-0.1")))
+	require.Equal(t, One+Half, FromDec(sdk.MustNewDecFromStr("1.5")))
+	require.Equal(t, MaxFixed, FromDec(sdk.NewDec(1<<20)))
+	require.Equal(t, MinFixed, FromDec(sdk.NewDec(-1<<20)))
+
+	// Every Fixed has an exact decimal value
+	for _, a := range []Fixed{1, -1, 6554, One + Half, MaxFixed, MinFixed} {
+		require.Equal(t, a, FromDec(a.Dec()))
+	}
+}
+
+func TestFixedJSON(t *testing.T) {
+	bz, err := json.Marshal([]Fixed{One + Half, -One / 4})
+	require.NoError(t, err)
+	require.Equal(t, `["1.500000000000000000","-0.250000000000000000"]`, string(bz))
+
+	var values []Fixed
+	require.NoError(t, json.Unmarshal([]byte(`["1.5", 2, "-0.25"]`), &values))
+	require.Equal(t, []Fixed{One + Half, 2 * One, -One / 4}, values)
+
+	require.Error(t, json.Unmarshal([]byte(`["1e3"]`), &values))
+	require.Error(t, json.Unmarshal([]byte(`[1e3]`), &values))
+}
+
+func TestActivations(t *testing.T) {
+	require.Equal(t, Half, sigmoid(0))
+	require.Equal(t, One*3/4, sigmoid(One))
+	require.Equal(t, One/4, sigmoid(-One))
+	require.Equal(t, Fixed(60160), sigmoid(sigmoidBreak2))
+	require.Equal(t, One, sigmoid(5*One))
+	require.Equal(t, Fixed(0), sigmoid(-100*One))
+	require.Equal(t, Fixed(0), tanh(0))
+	require.Equal(t, One/4, tanh(One/4))
+	require.Equal(t, -One/4, tanh(-One/4))
+
+	// The approximation is symmetric and stays within 0.019 of the sigmoid
+	for x := -6 * One; x <= 6*One; x += One / 64 {
+		y := sigmoid(x)
+		require.Equal(t, One-y, sigmoid(-x), "x = %s", x)
+
+		exact := 1 / (1 + math.Exp(-float64(x)/float64(One)))
+		require.InDelta(t, exact, float64(y)/float64(One), 0.019, "x = %s", x)
+	}
+
+	require.Equal(t, Fixed(0), activate(ActivationReLU, -One))
+	require.Equal(t, One, activate(ActivationReLU, One))
+	require.Equal(t, -One, activate(ActivationLinear, -One))
+	require.Equal(t, -One, activate("", -One))
+}
+
+func TestSqrt(t *testing.T) {
+	for n, expected := range map[uint64]uint64{0: 0, 1: 1, 2: 1, 3: 1, 4: 2, 99: 9, 100: 10, 1 << 62: 1 << 31} {
+		require.Equal(t, expected, sqrt(n), "sqrt(%d)", n)
+	}
+}
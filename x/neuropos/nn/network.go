@@ -0,0 +1,227 @@
+package nn
+
+import (
+	"fmt"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// Network is a multi-layer perceptron of dense layers with their weights
+type Network struct {
+	Layers  []types.Layer
+	Weights []LayerWeights
+}
+
+// Metrics are the loss and accuracy of a network on a set of samples
+type Metrics struct {
+	Loss     Fixed // mean squared error
+	Accuracy Fixed // fraction of the samples classified correctly
+}
+
+// New returns the network of the given layers and weights, checking that every layer
+// is dense and that the weights match the layers
+func New(layers []types.Layer, weights []LayerWeights) (*Network, error) {
+	if err := ValidateLayers(layers); err != nil {
+		return nil, err
+	}
+	if len(weights) != len(layers) {
+		return nil, fmt.Errorf("network has %d layers but weights for %d", len(layers), len(weights))
+	}
+
+	for i, layer := range layers {
+		if layer.Type != LayerTypeDense {
+			return nil, fmt.Errorf("layer %d is of type %q, only %q layers can be evaluated", i, layer.Type, LayerTypeDense)
+		}
+		w := weights[i]
+		if w.InputSize != layer.InputSize || w.OutputSize != layer.OutputSize {
+			return nil, fmt.Errorf("weights of layer %d are %dx%d, expected %dx%d", i, w.InputSize, w.OutputSize, layer.InputSize, layer.OutputSize)
+		}
+		if len(w.Weights) != int(layer.InputSize)*int(layer.OutputSize) || len(w.Biases) != int(layer.OutputSize) {
+			return nil, fmt.Errorf("layer %d has %d weights and %d biases, expected %d and %d", i, len(w.Weights), len(w.Biases), int(layer.InputSize)*int(layer.OutputSize), layer.OutputSize)
+		}
+	}
+
+	return &Network{Layers: layers, Weights: weights}, nil
+}
+
+// InputSize returns the number of inputs of the network
+func (n *Network) InputSize() int {
+	return int(n.Layers[0].InputSize)
+}
+
+// OutputSize returns the number of outputs of the network
+func (n *Network) OutputSize() int {
+	return int(n.Layers[len(n.Layers)-1].OutputSize)
+}
+
+// Forward computes the outputs of the network
+func (n *Network) Forward(meter GasMeter, input []Fixed) ([]Fixed, error) {
+	_, outputs, err := n.forward(meter, input)
+	if err != nil {
+		return nil, err
+	}
+	return outputs[len(outputs)-1], nil
+}
+
+// forward computes the pre-activations and the outputs of every layer. outputs[0] is
+// the input and outputs[i+1] the output of layer i.
+func (n *Network) forward(meter GasMeter, input []Fixed) ([][]Fixed, [][]Fixed, error) {
+	if len(input) != n.InputSize() {
+		return nil, nil, fmt.Errorf("network expects %d inputs, got %d", n.InputSize(), len(input))
+	}
+
+	pre := make([][]Fixed, len(n.Layers))
+	outputs := make([][]Fixed, len(n.Layers)+1)
+	outputs[0] = input
+
+	for i, layer := range n.Layers {
+		in := outputs[i]
+		w := n.Weights[i]
+		size := int(layer.OutputSize)
+
+		meter.ConsumeGas(uint64(size*len(in))*GasPerMultiplyAccumulate, "neuropos nn multiply-accumulate")
+		meter.ConsumeGas(uint64(size)*GasPerActivation, "neuropos nn activation")
+
+		z := make([]Fixed, size)
+		y := make([]Fixed, size)
+		for j := 0; j < size; j++ {
+			sum := w.Biases[j]
+			row := w.Weights[j*len(in) : (j+1)*len(in)]
+			for k, x := range in {
+				sum = sum.Add(row[k].Mul(x))
+			}
+			z[j] = sum
+			y[j] = activate(layer.Activation, sum)
+		}
+		pre[i] = z
+		outputs[i+1] = y
+	}
+
+	return pre, outputs, nil
+}
+
+// Train runs stochastic gradient descent on the mean squared error for the given number
+// of passes over the samples in order, updating the weights in place after every sample
+func (n *Network) Train(meter GasMeter, samples []Sample, epochs uint64, learningRate Fixed) error {
+	for epoch := uint64(0); epoch < epochs; epoch++ {
+		for s, sample := range samples {
+			if err := n.step(meter, sample, learningRate); err != nil {
+				return fmt.Errorf("sample %d: %w", s, err)
+			}
+		}
+	}
+	return nil
+}
+
+// step backpropagates the error of a sample and updates the weights
+func (n *Network) step(meter GasMeter, sample Sample, learningRate Fixed) error {
+	pre, outputs, err := n.forward(meter, sample.Features)
+	if err != nil {
+		return err
+	}
+	prediction := outputs[len(outputs)-1]
+	if len(sample.Target) != len(prediction) {
+		return fmt.Errorf("network has %d outputs, got %d targets", len(prediction), len(sample.Target))
+	}
+
+	// The gradient of 1/2 (y - t)^2 with respect to the output is y - t
+	delta := make([]Fixed, len(prediction))
+	for j := range delta {
+		delta[j] = prediction[j].Sub(sample.Target[j])
+	}
+
+	for i := len(n.Layers) - 1; i >= 0; i-- {
+		layer := n.Layers[i]
+		w := &n.Weights[i]
+		in := outputs[i]
+		size := int(layer.OutputSize)
+
+		meter.ConsumeGas(uint64(size)*GasPerGradient, "neuropos nn gradient")
+		for j := 0; j < size; j++ {
+			delta[j] = delta[j].Mul(derivative(layer.Activation, pre[i][j], outputs[i+1][j]))
+		}
+
+		// Propagate the error to the inputs with the weights before the update
+		var next []Fixed
+		if i > 0 {
+			meter.ConsumeGas(uint64(size*len(in))*GasPerMultiplyAccumulate, "neuropos nn multiply-accumulate")
+			next = make([]Fixed, len(in))
+			for j := 0; j < size; j++ {
+				row := w.Weights[j*len(in) : (j+1)*len(in)]
+				for k := range in {
+					next[k] = next[k].Add(row[k].Mul(delta[j]))
+				}
+			}
+		}
+
+		meter.ConsumeGas(uint64(size*(len(in)+1))*GasPerWeightUpdate, "neuropos nn weight update")
+		for j := 0; j < size; j++ {
+			step := learningRate.Mul(delta[j])
+			row := w.Weights[j*len(in) : (j+1)*len(in)]
+			for k, x := range in {
+				row[k] = row[k].Sub(step.Mul(x))
+			}
+			w.Biases[j] = w.Biases[j].Sub(step)
+		}
+
+		delta = next
+	}
+
+	return nil
+}
+
+// Evaluate returns the mean squared error and the accuracy of the network on samples.
+// A single output classifies a sample as positive at 0.5 or more; with several outputs
+// the class is the largest output, the first one on ties.
+func (n *Network) Evaluate(meter GasMeter, samples []Sample) (Metrics, error) {
+	if len(samples) == 0 {
+		return Metrics{}, fmt.Errorf("no samples to evaluate")
+	}
+
+	var squaredError int64
+	var correct int64
+	for s, sample := range samples {
+		prediction, err := n.Forward(meter, sample.Features)
+		if err != nil {
+			return Metrics{}, fmt.Errorf("sample %d: %w", s, err)
+		}
+		if len(sample.Target) != len(prediction) {
+			return Metrics{}, fmt.Errorf("sample %d: network has %d outputs, got %d targets", s, len(prediction), len(sample.Target))
+		}
+
+		var sampleError Fixed
+		for j := range prediction {
+			diff := prediction[j].Sub(sample.Target[j])
+			sampleError = sampleError.Add(diff.Mul(diff))
+		}
+		squaredError += int64(sampleError.QuoInt(int64(len(prediction))))
+
+		if classify(prediction) == classify(sample.Target) {
+			correct++
+		}
+	}
+
+	count := int64(len(samples))
+	return Metrics{
+		Loss:     saturate(squaredError / count),
+		Accuracy: saturate(correct * int64(One) / count),
+	}, nil
+}
+
+// classify returns the class of an output vector
+func classify(values []Fixed) int {
+	if len(values) == 1 {
+		if values[0] >= Half {
+			return 1
+		}
+		return 0
+	}
+
+	class := 0
+	for j, v := range values {
+		if v > values[class] {
+			class = j
+		}
+	}
+	return class
+}
\ No newline at end of file
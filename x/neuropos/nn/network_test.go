@@ -0,0 +1,192 @@
+package nn
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// gasCounter is a gas meter without a limit that counts the gas consumed
+type gasCounter struct {
+	consumed uint64
+}
+
+func (m *gasCounter) ConsumeGas(amount uint64, _ string) {
+	m.consumed += amount
+}
+
+func xorLayers() []types.Layer {
+	return []types.Layer{
+		{Type: LayerTypeDense, InputSize: 2, OutputSize: 3, Activation: ActivationTanh},
+		{Type: LayerTypeDense, InputSize: 3, OutputSize: 1, Activation: ActivationSigmoid},
+	}
+}
+
+func xorSamples() []Sample {
+	return []Sample{
+		{Features: []Fixed{0, 0}, Target: []Fixed{0}},
+		{Features: []Fixed{0, One}, Target: []Fixed{One}},
+		{Features: []Fixed{One, 0}, Target: []Fixed{One}},
+		{Features: []Fixed{One, One}, Target: []Fixed{0}},
+	}
+}
+
+func newXORNetwork(t *testing.T) *Network {
+	weights, err := InitWeights(&gasCounter{}, []byte("neuropos"), xorLayers())
+	require.NoError(t, err)
+	network, err := New(xorLayers(), weights)
+	require.NoError(t, err)
+	return network
+}
+
+// The golden vectors below pin the weights, metrics and gas of every validator. They
+// only change if the arithmetic of the package changes, which breaks consensus.
+
+func TestInitWeightsGolden(t *testing.T) {
+	meter := &gasCounter{}
+	weights, err := InitWeights(meter, []byte("neuropos"), xorLayers())
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(65), meter.consumed)
+	require.Equal(t, []Fixed{58040, 32058, -47424, 23483, -16248, 71570}, weights[0].Weights)
+	require.Equal(t, []Fixed{0, 0, 0}, weights[0].Biases)
+	require.Equal(t, []Fixed{48411, 69828, -35722}, weights[1].Weights)
+	require.Equal(t, []Fixed{0}, weights[1].Biases)
+
+	// Another seed draws other weights
+	other, err := InitWeights(&gasCounter{}, []byte("neuropos2"), xorLayers())
+	require.NoError(t, err)
+	require.NotEqual(t, weights, other)
+}
+
+func TestTrainGolden(t *testing.T) {
+	network := newXORNetwork(t)
+	samples := xorSamples()
+
+	meter := &gasCounter{}
+	metrics, err := network.Evaluate(meter, samples)
+	require.NoError(t, err)
+	require.Equal(t, uint64(680), meter.consumed)
+	require.Equal(t, Metrics{Loss: 14756, Accuracy: Half}, metrics)
+
+	meter = &gasCounter{}
+	require.NoError(t, network.Train(meter, samples, 500, Half))
+	require.Equal(t, uint64(820000), meter.consumed)
+
+	require.Equal(t, []Fixed{153932, 149316, -209635, 133281, -128756, 209756}, network.Weights[0].Weights)
+	require.Equal(t, []Fixed{-13090, -50935, 46400}, network.Weights[0].Biases)
+	require.Equal(t, []Fixed{196911, 245879, -244557}, network.Weights[1].Weights)
+	require.Equal(t, []Fixed{63492}, network.Weights[1].Biases)
+
+	metrics, err = network.Evaluate(&gasCounter{}, samples)
+	require.NoError(t, err)
+	require.Equal(t, Metrics{Loss: 171, Accuracy: One}, metrics)
+
+	for i, expected := range []Fixed{1472, 61957, 61935, 4118} {
+		output, err := network.Forward(&gasCounter{}, samples[i].Features)
+		require.NoError(t, err)
+		require.Equal(t, []Fixed{expected}, output)
+	}
+}
+
+func TestTrainIsBitIdentical(t *testing.T) {
+	samples := xorSamples()
+
+	trained := newXORNetwork(t)
+	require.NoError(t, trained.Train(&gasCounter{}, samples, 500, Half))
+	expected, err := MarshalWeights(trained.Weights)
+	require.NoError(t, err)
+
+	// Training from the same seed again gives the same weights
+	again := newXORNetwork(t)
+	require.NoError(t, again.Train(&gasCounter{}, samples, 500, Half))
+	bz, err := MarshalWeights(again.Weights)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(bz))
+
+	// Training in rounds whose weights are stored in between gives the same weights
+	resumed := newXORNetwork(t)
+	for round := 0; round < 5; round++ {
+		require.NoError(t, resumed.Train(&gasCounter{}, samples, 100, Half))
+
+		stored, err := MarshalWeights(resumed.Weights)
+		require.NoError(t, err)
+		weights, err := UnmarshalWeights(stored)
+		require.NoError(t, err)
+		resumed, err = New(xorLayers(), weights)
+		require.NoError(t, err)
+	}
+	bz, err = MarshalWeights(resumed.Weights)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(bz))
+}
+
+func TestNewRejectsMismatchedWeights(t *testing.T) {
+	weights, err := InitWeights(&gasCounter{}, []byte("neuropos"), xorLayers())
+	require.NoError(t, err)
+
+	_, err = New(xorLayers(), weights[:1])
+	require.Error(t, err)
+
+	layers := xorLayers()
+	layers[0].Type = "conv"
+	_, err = New(layers, weights)
+	require.Error(t, err)
+
+	weights[1].Weights = weights[1].Weights[:2]
+	_, err = New(xorLayers(), weights)
+	require.Error(t, err)
+}
+
+func TestValidateLayers(t *testing.T) {
+	require.NoError(t, ValidateLayers(xorLayers()))
+	require.Error(t, ValidateLayers(nil))
+
+	layers := xorLayers()
+	layers[1].InputSize = 2
+	require.Error(t, ValidateLayers(layers))
+
+	layers = xorLayers()
+	layers[0].Activation = "softmax"
+	require.Error(t, ValidateLayers(layers))
+
+	layers = []types.Layer{{Type: LayerTypeDense, InputSize: 1024, OutputSize: 1024}}
+	require.Error(t, ValidateLayers(layers))
+}
+
+func TestParseSamples(t *testing.T) {
+	samples, err := ParseSamples(json.RawMessage(`[["0.5", 1], [0, "-0.25"]]`), json.RawMessage(`[2, [0, 1, 0]]`), 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, []Sample{
+		{Features: []Fixed{Half, One}, Target: []Fixed{0, 0, One}},
+		{Features: []Fixed{0, -One / 4}, Target: []Fixed{0, One, 0}},
+	}, samples)
+
+	samples, err = ParseSamples(json.RawMessage(`[[1]]`), json.RawMessage(`["0.75"]`), 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []Sample{{Features: []Fixed{One}, Target: []Fixed{One * 3 / 4}}}, samples)
+
+	tests := []struct {
+		name     string
+		features string
+		labels   string
+	}{
+		{"no samples", `[]`, `[]`},
+		{"missing label", `[[1, 2]]`, `[]`},
+		{"wrong feature count", `[[1]]`, `[0]`},
+		{"class out of range", `[[1, 2]]`, `[3]`},
+		{"fractional class", `[[1, 2]]`, `["0.5"]`},
+		{"negative class", `[[1, 2]]`, `[-1]`},
+		{"wrong target count", `[[1, 2]]`, `[[0, 1]]`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSamples(json.RawMessage(tc.features), json.RawMessage(tc.labels), 2, 3)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package nn trains and evaluates the neural networks of the neuropos module inside
+// consensus. All arithmetic uses Q16.16 integer fixed-point numbers, weights are
+// initialized from a seeded hash stream, and samples are processed in order, so every
+// validator computes bit-identical weights, loss and accuracy. Every operation is
+// charged to a gas meter before it runs.
+package nn
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nomercychain/nmxchain/x/neuropos/types"
+)
+
+// LayerTypeDense is a fully connected layer, the only layer type nn can evaluate
+const LayerTypeDense = "dense"
+
+// MaxParameters is the maximum number of weights and biases of a network
+const MaxParameters = 1 << 20
+
+// Gas costs of neural network operations
+const (
+	GasPerParameterInit      uint64 = 5
+	GasPerMultiplyAccumulate uint64 = 10
+	GasPerActivation         uint64 = 20
+	GasPerGradient           uint64 = 20
+	GasPerWeightUpdate       uint64 = 10
+)
+
+// GasMeter is the subset of the SDK gas meter used to charge for training and evaluation
+type GasMeter interface {
+	ConsumeGas(amount uint64, descriptor string)
+}
+
+// LayerWeights are the parameters of a layer. Weights holds InputSize weights per
+// output neuron, row by row.
+type LayerWeights struct {
+	LayerType  string  `json:"layer_type"`
+	InputSize  uint32  `json:"input_size"`
+	OutputSize uint32  `json:"output_size"`
+	Weights    []Fixed `json:"weights"`
+	Biases     []Fixed `json:"biases"`
+}
+
+// ValidateLayers checks that the layers of a network chain together and that the
+// network is small enough to be stored and trained on chain
+func ValidateLayers(layers []types.Layer) error {
+	if len(layers) == 0 {
+		return fmt.Errorf("network has no layers")
+	}
+
+	parameters := uint64(0)
+	for i, layer := range layers {
+		if layer.InputSize == 0 || layer.OutputSize == 0 {
+			return fmt.Errorf("layer %d has no inputs or outputs", i)
+		}
+		if i > 0 && layer.InputSize != layers[i-1].OutputSize {
+			return fmt.Errorf("layer %d takes %d inputs but layer %d has %d outputs", i, layer.InputSize, i-1, layers[i-1].OutputSize)
+		}
+		if !isValidActivation(layer.Activation) {
+			return fmt.Errorf("layer %d has unknown activation %q", i, layer.Activation)
+		}
+
+		parameters += uint64(layer.InputSize)*uint64(layer.OutputSize) + uint64(layer.OutputSize)
+		if parameters > MaxParameters {
+			return fmt.Errorf("network has more than %d parameters", MaxParameters)
+		}
+	}
+
+	return nil
+}
+
+// InitWeights initializes the weights of a network uniformly in ±sqrt(6 / (inputs +
+// outputs)) of every layer (Glorot initialization), drawing from a SHA-256 stream of the
+// seed. Biases start at zero.
+func InitWeights(meter GasMeter, seed []byte, layers []types.Layer) ([]LayerWeights, error) {
+	if err := ValidateLayers(layers); err != nil {
+		return nil, err
+	}
+
+	stream := newStream(seed)
+	weights := make([]LayerWeights, len(layers))
+	for i, layer := range layers {
+		n := int(layer.InputSize) * int(layer.OutputSize)
+		meter.ConsumeGas(uint64(n+int(layer.OutputSize))*GasPerParameterInit, "neuropos nn weight initialization")
+
+		// sqrt(6 / fan) in Q16.16 is sqrt(6 * 2^32 / fan) as an integer
+		limit := int64(sqrt((6 << (2 * FracBits)) / uint64(layer.InputSize+layer.OutputSize)))
+
+		w := make([]Fixed, n)
+		for j := range w {
+			w[j] = Fixed(int64(stream.next()%uint64(2*limit+1)) - limit)
+		}
+
+		weights[i] = LayerWeights{
+			LayerType:  layer.Type,
+			InputSize:  layer.InputSize,
+			OutputSize: layer.OutputSize,
+			Weights:    w,
+			Biases:     make([]Fixed, layer.OutputSize),
+		}
+	}
+
+	return weights, nil
+}
+
+// MarshalWeights encodes the weights of a network as stored on chain
+func MarshalWeights(weights []LayerWeights) (json.RawMessage, error) {
+	return json.Marshal(weights)
+}
+
+// UnmarshalWeights decodes the weights of a network as stored on chain
+func UnmarshalWeights(bz json.RawMessage) ([]LayerWeights, error) {
+	var weights []LayerWeights
+	if err := json.Unmarshal(bz, &weights); err != nil {
+		return nil, fmt.Errorf("invalid weights: %w", err)
+	}
+	return weights, nil
+}
+
+// stream is a deterministic pseudo-random stream of SHA-256(seed || counter) blocks
+type stream struct {
+	seed    []byte
+	counter uint64
+	block   [sha256.Size]byte
+	offset  int
+}
+
+func newStream(seed []byte) *stream {
+	s := &stream{seed: seed}
+	s.refill()
+	return s
+}
+
+func (s *stream) refill() {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], s.counter)
+	s.block = sha256.Sum256(append(append([]byte{}, s.seed...), counter[:]...))
+	s.counter++
+	s.offset = 0
+}
+
+// next returns the next 64 bits of the stream
+func (s *stream) next() uint64 {
+	if s.offset+8 > len(s.block) {
+		s.refill()
+	}
+	v := binary.BigEndian.Uint64(s.block[s.offset:])
+	s.offset += 8
+	return v
+}
\ No newline at end of file
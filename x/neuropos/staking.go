@@ -0,0 +1,66 @@
+package neuropos
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+var _ module.EndBlockAppModule = StakingAppModule{}
+
+// StakingAppModule wraps the staking module so that neuropos is the only module that
+// sends validator updates to consensus. Staking still selects the bonded validators and
+// processes its queues at the end of every block, but the updates it returns are dropped;
+// neuropos sends the reputation-weighted powers of the same validators instead. Staking
+// must end blocks before neuropos.
+type StakingAppModule struct {
+	staking.AppModule
+}
+
+// NewStakingAppModule creates a new StakingAppModule
+func NewStakingAppModule(am staking.AppModule) StakingAppModule {
+	return StakingAppModule{AppModule: am}
+}
+
+// EndBlock runs the staking end blocker and drops its validator updates
+func (am StakingAppModule) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.ValidatorUpdate {
+	am.AppModule.EndBlock(ctx, req)
+	return []abci.ValidatorUpdate{}
+}
+
+// SlashingStakingKeeper wraps the staking keeper given to the slashing module, through
+// which evidence slashes too. Consensus reports the reputation-weighted powers neuropos
+// sends, so the powers of misbehaving validators are replaced by their staked consensus
+// power before staking computes the slash amount; a reputation multiplier never changes
+// how much stake a validator loses.
+type SlashingStakingKeeper struct {
+	*stakingkeeper.Keeper
+}
+
+// NewSlashingStakingKeeper creates a new SlashingStakingKeeper
+func NewSlashingStakingKeeper(k *stakingkeeper.Keeper) SlashingStakingKeeper {
+	return SlashingStakingKeeper{Keeper: k}
+}
+
+// Slash slashes a validator on its staked consensus power
+func (k SlashingStakingKeeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64, slashFactor sdk.Dec) sdk.Int {
+	return k.Keeper.Slash(ctx, consAddr, infractionHeight, k.stakedPower(ctx, consAddr, power), slashFactor)
+}
+
+// SlashWithInfractionReason slashes a validator on its staked consensus power
+func (k SlashingStakingKeeper) SlashWithInfractionReason(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64, slashFactor sdk.Dec, infraction stakingtypes.Infraction) sdk.Int {
+	return k.Keeper.SlashWithInfractionReason(ctx, consAddr, infractionHeight, k.stakedPower(ctx, consAddr, power), slashFactor, infraction)
+}
+
+// stakedPower returns the consensus power of a validator's stake. The reported power is
+// kept for validators staking no longer knows, which staking does not slash.
+func (k SlashingStakingKeeper) stakedPower(ctx sdk.Context, consAddr sdk.ConsAddress, power int64) int64 {
+	validator, found := k.Keeper.GetValidatorByConsAddr(ctx, consAddr)
+	if !found {
+		return power
+	}
+	return validator.ConsensusPower(k.Keeper.PowerReduction(ctx))
+}
\ No newline at end of file
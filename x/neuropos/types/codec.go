@@ -18,6 +18,8 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgTrainNeuralNetwork{}, "neuropos/TrainNeuralNetwork", nil)
 	cdc.RegisterConcrete(&MsgSubmitNeuralPrediction{}, "neuropos/SubmitNeuralPrediction", nil)
 	cdc.RegisterConcrete(&MsgUpdateValidatorReputation{}, "neuropos/UpdateValidatorReputation", nil)
+	cdc.RegisterConcrete(&MsgDisputePenalty{}, "neuropos/DisputePenalty", nil)
+	cdc.RegisterConcrete(&MsgVotePenalty{}, "neuropos/VotePenalty", nil)
 }
 
 func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
@@ -32,6 +34,8 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 		&MsgTrainNeuralNetwork{},
 		&MsgSubmitNeuralPrediction{},
 		&MsgUpdateValidatorReputation{},
+		&MsgDisputePenalty{},
+		&MsgVotePenalty{},
 	)
 
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
@@ -54,4 +54,10 @@ var (
 	ErrNeuralNetworkUpdateInProgress     = sdkerrors.Register(ModuleName, 46, "neural network update already in progress")
 	ErrInvalidNeuralNetworkInfluenceRate = sdkerrors.Register(ModuleName, 47, "invalid neural network influence rate")
 	ErrInvalidReputationRate             = sdkerrors.Register(ModuleName, 48, "invalid reputation rate")
+	ErrPenaltyNotFound                   = sdkerrors.Register(ModuleName, 49, "anomaly penalty not found")
+	ErrPenaltyChallengeClosed            = sdkerrors.Register(ModuleName, 50, "anomaly penalty challenge period is closed")
+	ErrPenaltyAlreadyDisputed            = sdkerrors.Register(ModuleName, 51, "anomaly penalty already disputed")
+	ErrPenaltyNotDisputed                = sdkerrors.Register(ModuleName, 52, "anomaly penalty is not disputed")
+	ErrInsufficientDisputeBond           = sdkerrors.Register(ModuleName, 53, "dispute bond below minimum")
+	ErrInvalidPenaltyVote                = sdkerrors.Register(ModuleName, 54, "invalid anomaly penalty vote")
 )
\ No newline at end of file
@@ -44,6 +44,8 @@ type StakingKeeper interface {
 	GetAllValidators(ctx sdk.Context) (validators []stakingtypes.Validator)
 	GetLastTotalPower(ctx sdk.Context) sdk.Int
 	GetLastValidatorPower(ctx sdk.Context, valAddr sdk.ValAddress) int64
+	GetLastValidators(ctx sdk.Context) (validators []stakingtypes.Validator)
+	PowerReduction(ctx sdk.Context) sdk.Int
 	IterateValidators(ctx sdk.Context, cb func(index int64, validator stakingtypes.ValidatorI) bool)
 	IterateDelegations(ctx sdk.Context, delegator sdk.AccAddress, cb func(index int64, delegation stakingtypes.DelegationI) bool)
 	SetValidator(ctx sdk.Context, validator stakingtypes.Validator)
@@ -53,6 +55,7 @@ type StakingKeeper interface {
 	TombstoneValidator(ctx sdk.Context, consAddr sdk.ConsAddress)
 	IsTombstoned(ctx sdk.Context, consAddr sdk.ConsAddress) bool
 	SlashValidator(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64, slashFactor sdk.Dec)
+	Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64, slashFactor sdk.Dec)
 	GetParams(ctx sdk.Context) stakingtypes.Params
 	SetParams(ctx sdk.Context, params stakingtypes.Params)
 }
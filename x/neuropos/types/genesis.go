@@ -22,6 +22,9 @@ func DefaultGenesis() *GenesisState {
 		ValidatorReputations:   []ValidatorReputation{},
 		ValidatorSlashEvents:   []ValidatorSlashEvent{},
 		ValidatorSigningInfos:  []ValidatorSigningInfo{},
+		ValidatorPowers:        []ValidatorPower{},
+		Penalties:              []PendingPenalty{},
+		BaseFee:                sdk.MustNewDecFromStr(DefaultMinBaseFee),
 		Params:                 DefaultParams(),
 	}
 }
@@ -330,11 +333,64 @@ func (gs GenesisState) Validate() error {
 		}
 	}
 
+	// Validate validator powers
+	powerKeys := make(map[string]bool)
+	for _, power := range gs.ValidatorPowers {
+		if powerKeys[power.ValidatorAddress] {
+			return fmt.Errorf("duplicate validator power key: %s", power.ValidatorAddress)
+		}
+		powerKeys[power.ValidatorAddress] = true
+
+		if _, err := sdk.ValAddressFromBech32(power.ValidatorAddress); err != nil {
+			return fmt.Errorf("invalid validator power address %s: %w", power.ValidatorAddress, err)
+		}
+
+		if power.Multiplier.IsNil() || !power.Multiplier.IsPositive() {
+			return fmt.Errorf("validator power multiplier must be positive: %s", power.Multiplier)
+		}
+
+		if power.Power < 0 {
+			return fmt.Errorf("validator power cannot be negative: %d", power.Power)
+		}
+	}
+
+	// Validate anomaly penalties
+	penaltyIDs := make(map[uint64]bool)
+	for _, penalty := range gs.Penalties {
+		if penalty.ID == 0 || penaltyIDs[penalty.ID] {
+			return fmt.Errorf("invalid or duplicate anomaly penalty ID: %d", penalty.ID)
+		}
+		penaltyIDs[penalty.ID] = true
+
+		if _, err := sdk.ValAddressFromBech32(penalty.ValidatorAddress); err != nil {
+			return fmt.Errorf("invalid anomaly penalty validator address %s: %w", penalty.ValidatorAddress, err)
+		}
+
+		if penalty.SlashFraction.IsNil() || penalty.SlashFraction.IsNegative() || penalty.SlashFraction.GT(sdk.OneDec()) {
+			return fmt.Errorf("anomaly penalty slash fraction must be between 0 and 1: %s", penalty.SlashFraction)
+		}
+
+		switch penalty.Status {
+		case PenaltyStatusPending, PenaltyStatusDisputed, PenaltyStatusExecuted, PenaltyStatusReduced, PenaltyStatusCancelled:
+		default:
+			return fmt.Errorf("invalid anomaly penalty status: %s", penalty.Status)
+		}
+
+		if !penalty.Bond.IsValid() {
+			return fmt.Errorf("invalid anomaly penalty bond: %s", penalty.Bond)
+		}
+	}
+
 	// Validate params
 	if err := gs.Params.Validate(); err != nil {
 		return fmt.Errorf("invalid params: %w", err)
 	}
 
+	// Validate base fee
+	if !gs.BaseFee.IsNil() && gs.BaseFee.LT(gs.Params.MinBaseFee) {
+		return fmt.Errorf("base fee %s cannot be lower than the min base fee %s", gs.BaseFee, gs.Params.MinBaseFee)
+	}
+
 	return nil
 }
 
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -67,14 +69,53 @@ var (
 	// ValidatorSigningInfoKeyPrefix is the prefix for validator signing info keys
 	ValidatorSigningInfoKeyPrefix = []byte{0x0F}
 
+	// ValidatorPowerKeyPrefix is the prefix for the voting powers sent to consensus
+	ValidatorPowerKeyPrefix = []byte{0x10}
+
+	// TrainingDataIndexKeyPrefix is the prefix for the index of the training data of a
+	// neural network by creation time
+	TrainingDataIndexKeyPrefix = []byte{0x11}
+
+	// NeuralNetworkUpdateCursorKey is the key for the last neural network updated in an
+	// update round that did not finish within a block
+	NeuralNetworkUpdateCursorKey = []byte{0x12}
+
 	// ValidatorAIModelKey is the prefix for validator AI model keys
 	ValidatorAIModelKey = []byte{0x30}
 
 	// AnomalyReportKey is the prefix for anomaly report keys
 	AnomalyReportKey = []byte{0x31}
 
+	// NextAnomalyReportIDKey is the key for the ID of the next anomaly report
+	NextAnomalyReportIDKey = []byte{0x32}
+
+	// ValidatorAnomalyStatsKeyPrefix is the prefix for validator anomaly statistics keys
+	ValidatorAnomalyStatsKeyPrefix = []byte{0x33}
+
+	// PenaltyKeyPrefix is the prefix for anomaly penalty keys
+	PenaltyKeyPrefix = []byte{0x34}
+
+	// NextPenaltyIDKey is the key for the ID of the next anomaly penalty
+	NextPenaltyIDKey = []byte{0x35}
+
+	// PenaltyQueueKeyPrefix is the prefix for the queue of penalties by the end of their
+	// challenge period
+	PenaltyQueueKeyPrefix = []byte{0x36}
+
 	// NetworkStateKey is the key for the network state
 	NetworkStateKey = []byte{0x40}
+
+	// NetworkStateHistoryKeyPrefix is the prefix for the network states of past blocks
+	NetworkStateHistoryKeyPrefix = []byte{0x41}
+
+	// BaseFeeKey is the key for the base fee
+	BaseFeeKey = []byte{0x42}
+
+	// BlockFeesKey is the key for the fees in the base fee denom paid in the current block
+	BlockFeesKey = []byte{0x43}
+
+	// BlockGasWantedKey is the key for the gas limits of the transactions of the current block
+	BlockGasWantedKey = []byte{0x44}
 )
 
 // Parameter store keys
@@ -117,8 +158,17 @@ const (
 	EventTypeUpdateValidatorReputation = "update_validator_reputation"
 	EventTypeValidatorPerformance      = "validator_performance"
 	EventTypeAnomalyDetected           = "anomaly_detected"
+	EventTypeValidatorPowerUpdate      = "validator_power_update"
+	EventTypePenaltyQueued             = "penalty_queued"
+	EventTypeDisputePenalty            = "dispute_penalty"
+	EventTypeVotePenalty               = "vote_penalty"
+	EventTypePenaltyResolved           = "penalty_resolved"
+	EventTypeBaseFeeUpdate             = "base_fee_update"
 )
 
+// QueryBaseFee is the legacy querier route of the base fee
+const QueryBaseFee = "base-fee"
+
 // Neural network architectures
 const (
 	// NeuralNetworkArchitectureMLP is the architecture for a multi-layer perceptron
@@ -173,6 +223,53 @@ const (
 	BondStatusBonded = "bonded"
 )
 
+// Anomaly types, which are also the names of the detectors
+const (
+	// AnomalyTypeMissedBlocks is a validator missing more blocks than it used to
+	AnomalyTypeMissedBlocks = "missed_blocks"
+
+	// AnomalyTypeProposerSkew is a validator proposing more or fewer blocks than its
+	// voting power entitles it to, compared with past windows
+	AnomalyTypeProposerSkew = "proposer_skew"
+
+	// AnomalyTypeCongestionSpike is a block far fuller than recent blocks
+	AnomalyTypeCongestionSpike = "congestion_spike"
+
+	// AnomalyTypeFeeRateShift is a sudden change of the average fee rate
+	AnomalyTypeFeeRateShift = "fee_rate_shift"
+)
+
+// Anomaly penalty statuses
+const (
+	// PenaltyStatusPending is a penalty in its challenge period that is not disputed
+	PenaltyStatusPending = "pending"
+
+	// PenaltyStatusDisputed is a penalty disputed by its validator, which the other
+	// validators vote on until the end of the challenge period
+	PenaltyStatusDisputed = "disputed"
+
+	// PenaltyStatusExecuted is a penalty executed in full
+	PenaltyStatusExecuted = "executed"
+
+	// PenaltyStatusReduced is a penalty executed at a reduced rate after a vote
+	PenaltyStatusReduced = "reduced"
+
+	// PenaltyStatusCancelled is a penalty cancelled after a vote
+	PenaltyStatusCancelled = "cancelled"
+)
+
+// Votes on disputed penalties, from the most to the least severe
+const (
+	// PenaltyVoteUphold executes the penalty in full
+	PenaltyVoteUphold = "uphold"
+
+	// PenaltyVoteReduce executes the penalty at the reduction factor
+	PenaltyVoteReduce = "reduce"
+
+	// PenaltyVoteCancel cancels the penalty
+	PenaltyVoteCancel = "cancel"
+)
+
 // Key functions
 
 // ValidatorKey returns the key for a validator
@@ -211,6 +308,16 @@ func TrainingDataKey(dataID string) []byte {
 	return append(NeuralNetworkTrainingDataKeyPrefix, []byte(dataID)...)
 }
 
+// TrainingDataIndexPrefix returns the prefix of the index of a neural network's training data
+func TrainingDataIndexPrefix(networkID string) []byte {
+	return append(TrainingDataIndexKeyPrefix, []byte(networkID+"/")...)
+}
+
+// TrainingDataIndexKey returns the key of training data in the index of its neural network
+func TrainingDataIndexKey(networkID string, createdAt time.Time, dataID string) []byte {
+	return append(append(TrainingDataIndexPrefix(networkID), sdk.FormatTimeBytes(createdAt)...), []byte(dataID)...)
+}
+
 // NeuralPredictionKey returns the key for a neural prediction
 func NeuralPredictionKey(predictionID string) []byte {
 	return append(NeuralNetworkPredictionKeyPrefix, []byte(predictionID)...)
@@ -237,6 +344,37 @@ func ValidatorSigningInfoKey(validatorAddr string) []byte {
 	return append(ValidatorSigningInfoKeyPrefix, []byte(validatorAddr)...)
 }
 
+// ValidatorPowerKey returns the key for the voting power of a validator
+func ValidatorPowerKey(validatorAddr string) []byte {
+	return append(ValidatorPowerKeyPrefix, []byte(validatorAddr)...)
+}
+
+// ValidatorAnomalyStatsKey returns the key for a validator's anomaly statistics
+func ValidatorAnomalyStatsKey(validatorAddr string) []byte {
+	return append(ValidatorAnomalyStatsKeyPrefix, []byte(validatorAddr)...)
+}
+
+// PenaltyKey returns the key for an anomaly penalty
+func PenaltyKey(id uint64) []byte {
+	return append(PenaltyKeyPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// PenaltyQueueTimeKey returns the prefix of the penalties whose challenge period ends at
+// the given time
+func PenaltyQueueTimeKey(endTime time.Time) []byte {
+	return append(PenaltyQueueKeyPrefix, sdk.FormatTimeBytes(endTime)...)
+}
+
+// PenaltyQueueKey returns the key for a penalty in the queue of challenge periods
+func PenaltyQueueKey(endTime time.Time, id uint64) []byte {
+	return append(PenaltyQueueTimeKey(endTime), sdk.Uint64ToBigEndian(id)...)
+}
+
+// NetworkStateHistoryKey returns the key for the network state of a block
+func NetworkStateHistoryKey(height int64) []byte {
+	return append(NetworkStateHistoryKeyPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
 // Event attribute keys
 const (
 	AttributeKeyValidator             = "validator"
@@ -268,4 +406,14 @@ const (
 	AttributeKeyAnomalyID             = "anomaly_id"
 	AttributeKeyAnomalyConfidence     = "anomaly_confidence"
 	AttributeKeyAnomalyType           = "anomaly_type"
+	AttributeKeyVotingPower           = "voting_power"
+	AttributeKeyPowerMultiplier       = "power_multiplier"
+	AttributeKeyDryRun                = "dry_run"
+	AttributeKeyPenaltyID             = "penalty_id"
+	AttributeKeyPenaltyStatus         = "penalty_status"
+	AttributeKeyChallengeEndTime      = "challenge_end_time"
+	AttributeKeyVoteOption            = "vote_option"
+	AttributeKeyBond                  = "bond"
+	AttributeKeyBaseFee               = "base_fee"
+	AttributeKeyNetworkCongestion     = "network_congestion"
 )
@@ -18,6 +18,8 @@ const (
 	TypeMsgTrainNeuralNetwork       = "train_neural_network"
 	TypeMsgSubmitNeuralPrediction   = "submit_neural_prediction"
 	TypeMsgUpdateValidatorReputation = "update_validator_reputation"
+	TypeMsgDisputePenalty            = "dispute_penalty"
+	TypeMsgVotePenalty               = "vote_penalty"
 )
 
 var _ sdk.Msg = &MsgCreateValidator{}
@@ -717,4 +719,149 @@ func (msg MsgUpdateValidatorReputation) GetSigners() []sdk.AccAddress {
 		panic(err)
 	}
 	return []sdk.AccAddress{adminAddr}
+}
+
+// MsgDisputePenalty defines a message for a validator to dispute the pending penalty of
+// an anomaly report by posting a bond and counter-evidence
+type MsgDisputePenalty struct {
+	ValidatorAddress string   `json:"validator_address"`
+	PenaltyID        uint64   `json:"penalty_id"`
+	Bond             sdk.Coin `json:"bond"`
+	CounterEvidence  string   `json:"counter_evidence"`
+}
+
+// MsgDisputePenaltyResponse defines the response of MsgDisputePenalty
+type MsgDisputePenaltyResponse struct{}
+
+var _ sdk.Msg = &MsgDisputePenalty{}
+
+// NewMsgDisputePenalty creates a new MsgDisputePenalty instance
+func NewMsgDisputePenalty(
+	valAddr sdk.ValAddress,
+	penaltyID uint64,
+	bond sdk.Coin,
+	counterEvidence string,
+) *MsgDisputePenalty {
+	return &MsgDisputePenalty{
+		ValidatorAddress: valAddr.String(),
+		PenaltyID:        penaltyID,
+		Bond:             bond,
+		CounterEvidence:  counterEvidence,
+	}
+}
+
+// Route implements Msg
+func (msg MsgDisputePenalty) Route() string { return RouterKey }
+
+// Type implements Msg
+func (msg MsgDisputePenalty) Type() string { return TypeMsgDisputePenalty }
+
+// ValidateBasic implements Msg
+func (msg MsgDisputePenalty) ValidateBasic() error {
+	// Validate validator address
+	_, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
+	}
+
+	// Validate penalty ID
+	if msg.PenaltyID == 0 {
+		return sdkerrors.Wrap(ErrPenaltyNotFound, "penalty ID cannot be zero")
+	}
+
+	// Validate bond
+	if !msg.Bond.IsValid() || !msg.Bond.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "bond must be a valid positive amount")
+	}
+
+	// Validate counter-evidence
+	if msg.CounterEvidence == "" {
+		return sdkerrors.Wrap(ErrInvalidInput, "counter-evidence cannot be empty")
+	}
+
+	return nil
+}
+
+// GetSignBytes implements Msg
+func (msg MsgDisputePenalty) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements Msg
+func (msg MsgDisputePenalty) GetSigners() []sdk.AccAddress {
+	valAddr, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{sdk.AccAddress(valAddr)}
+}
+
+// MsgVotePenalty defines a message for a validator to vote on a disputed penalty
+type MsgVotePenalty struct {
+	ValidatorAddress string `json:"validator_address"`
+	PenaltyID        uint64 `json:"penalty_id"`
+	Option           string `json:"option"`
+}
+
+// MsgVotePenaltyResponse defines the response of MsgVotePenalty
+type MsgVotePenaltyResponse struct{}
+
+var _ sdk.Msg = &MsgVotePenalty{}
+
+// NewMsgVotePenalty creates a new MsgVotePenalty instance
+func NewMsgVotePenalty(
+	valAddr sdk.ValAddress,
+	penaltyID uint64,
+	option string,
+) *MsgVotePenalty {
+	return &MsgVotePenalty{
+		ValidatorAddress: valAddr.String(),
+		PenaltyID:        penaltyID,
+		Option:           option,
+	}
+}
+
+// Route implements Msg
+func (msg MsgVotePenalty) Route() string { return RouterKey }
+
+// Type implements Msg
+func (msg MsgVotePenalty) Type() string { return TypeMsgVotePenalty }
+
+// ValidateBasic implements Msg
+func (msg MsgVotePenalty) ValidateBasic() error {
+	// Validate validator address
+	_, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
+	}
+
+	// Validate penalty ID
+	if msg.PenaltyID == 0 {
+		return sdkerrors.Wrap(ErrPenaltyNotFound, "penalty ID cannot be zero")
+	}
+
+	// Validate option
+	switch msg.Option {
+	case PenaltyVoteUphold, PenaltyVoteReduce, PenaltyVoteCancel:
+	default:
+		return sdkerrors.Wrapf(ErrInvalidPenaltyVote, "option must be %s, %s or %s", PenaltyVoteUphold, PenaltyVoteReduce, PenaltyVoteCancel)
+	}
+
+	return nil
+}
+
+// GetSignBytes implements Msg
+func (msg MsgVotePenalty) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements Msg
+func (msg MsgVotePenalty) GetSigners() []sdk.AccAddress {
+	valAddr, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{sdk.AccAddress(valAddr)}
 }
\ No newline at end of file
@@ -63,6 +63,91 @@ const (
 
 	// DefaultNeuralNetworkInfluenceRate is the default influence rate of neural networks
 	DefaultNeuralNetworkInfluenceRate = "0.3"
+
+	// DefaultMinPowerMultiplier is the default voting power multiplier of a validator with no reputation
+	DefaultMinPowerMultiplier = "0.5"
+
+	// DefaultMaxPowerMultiplier is the default voting power multiplier of a validator with full reputation
+	DefaultMaxPowerMultiplier = "1.5"
+
+	// DefaultPowerEpochBlocks is the default number of blocks between voting power multiplier updates
+	DefaultPowerEpochBlocks = 100
+
+	// DefaultMaxPowerMultiplierChange is the default maximum change of a multiplier per epoch
+	DefaultMaxPowerMultiplierChange = "0.05"
+
+	// DefaultAnomalyDryRun is the default dry-run mode of the anomaly detectors, in which
+	// reports are stored but validators are never slashed
+	DefaultAnomalyDryRun = true
+
+	// DefaultAnomalyZScoreThreshold is the default z-score above which a metric is reported
+	DefaultAnomalyZScoreThreshold = "3"
+
+	// DefaultAnomalySlashConfidence is the default confidence from which a validator is
+	// slashed for an anomaly
+	DefaultAnomalySlashConfidence = "0.9"
+
+	// DefaultAnomalyHistoryWindow is the default number of past blocks the network
+	// detectors compare a block with
+	DefaultAnomalyHistoryWindow = 100
+
+	// DefaultAnomalyValidatorWindow is the default number of blocks over which the
+	// activity of validators is measured
+	DefaultAnomalyValidatorWindow = 100
+
+	// DefaultAnomalyEWMAAlpha is the default weight of a new window in the baselines of
+	// validators
+	DefaultAnomalyEWMAAlpha = "0.1"
+
+	// DefaultAnomalyMinSamples is the default number of samples a baseline needs before
+	// it is used for detection
+	DefaultAnomalyMinSamples = 10
+
+	// DefaultPenaltyChallengePeriod is the default time during which an anomaly penalty
+	// can be disputed and voted on before it is resolved
+	DefaultPenaltyChallengePeriod = time.Hour * 24 * 3 // 3 days
+
+	// DefaultPenaltySlashFraction is the default fraction of stake slashed for an anomaly
+	DefaultPenaltySlashFraction = "0.01"
+
+	// DefaultPenaltyReputationChange is the default reputation lost for an anomaly
+	DefaultPenaltyReputationChange = "0.05"
+
+	// DefaultPenaltyMinDisputeBond is the default minimum bond to dispute a penalty
+	DefaultPenaltyMinDisputeBond = 100000000 // 100 NMX
+
+	// DefaultPenaltyVoteQuorum is the default fraction of the bonded voting power that
+	// must vote on a disputed penalty for the vote to count
+	DefaultPenaltyVoteQuorum = "0.334"
+
+	// DefaultPenaltyReductionFactor is the default fraction of a penalty executed when the
+	// validators vote to reduce it
+	DefaultPenaltyReductionFactor = "0.5"
+
+	// DefaultBaseFeeEnabled is the default switch of the base fee checked by the ante handler
+	DefaultBaseFeeEnabled = true
+
+	// DefaultBaseFeeDenom is the default denom of the base fee
+	DefaultBaseFeeDenom = "unmx"
+
+	// DefaultMinBaseFee is the default lowest base fee, in base fee denom per unit of gas
+	DefaultMinBaseFee = "0.025"
+
+	// DefaultTargetBlockUtilization is the default network congestion at which the base
+	// fee stays unchanged
+	DefaultTargetBlockUtilization = "0.5"
+
+	// DefaultBaseFeeMaxChangeRate is the default change of the base fee after a full or an
+	// empty block
+	DefaultBaseFeeMaxChangeRate = "0.125"
+
+	// DefaultBaseFeeBurnFraction is the default fraction of the base fee paid by
+	// transactions that is burned; the rest is distributed with the other fees
+	DefaultBaseFeeBurnFraction = "1"
+
+	// MaxPowerMultiplier bounds the multipliers so that the total voting power stays far
+	// below the consensus limit
+	MaxPowerMultiplier = 10
 )
 
 // Parameter store keys
@@ -86,8 +171,40 @@ var (
 	KeyReputationBonusRate         = []byte("ReputationBonusRate")
 	KeyReputationPenaltyRate       = []byte("ReputationPenaltyRate")
 	KeyNeuralNetworkInfluenceRate  = []byte("NeuralNetworkInfluenceRate")
+	KeyMinPowerMultiplier          = []byte("MinPowerMultiplier")
+	KeyMaxPowerMultiplier          = []byte("MaxPowerMultiplier")
+	KeyPowerEpochBlocks            = []byte("PowerEpochBlocks")
+	KeyMaxPowerMultiplierChange    = []byte("MaxPowerMultiplierChange")
+	KeyAnomalyDetectors            = []byte("AnomalyDetectors")
+	KeyAnomalyDryRun               = []byte("AnomalyDryRun")
+	KeyAnomalyZScoreThreshold      = []byte("AnomalyZScoreThreshold")
+	KeyAnomalySlashConfidence      = []byte("AnomalySlashConfidence")
+	KeyAnomalyHistoryWindow        = []byte("AnomalyHistoryWindow")
+	KeyAnomalyValidatorWindow      = []byte("AnomalyValidatorWindow")
+	KeyAnomalyEWMAAlpha            = []byte("AnomalyEWMAAlpha")
+	KeyAnomalyMinSamples           = []byte("AnomalyMinSamples")
+	KeyPenaltyChallengePeriod      = []byte("PenaltyChallengePeriod")
+	KeyPenaltySlashFraction        = []byte("PenaltySlashFraction")
+	KeyPenaltyReputationChange     = []byte("PenaltyReputationChange")
+	KeyPenaltyMinDisputeBond       = []byte("PenaltyMinDisputeBond")
+	KeyPenaltyVoteQuorum           = []byte("PenaltyVoteQuorum")
+	KeyPenaltyReductionFactor      = []byte("PenaltyReductionFactor")
+	KeyBaseFeeEnabled              = []byte("BaseFeeEnabled")
+	KeyBaseFeeDenom                = []byte("BaseFeeDenom")
+	KeyMinBaseFee                  = []byte("MinBaseFee")
+	KeyTargetBlockUtilization      = []byte("TargetBlockUtilization")
+	KeyBaseFeeMaxChangeRate        = []byte("BaseFeeMaxChangeRate")
+	KeyBaseFeeBurnFraction         = []byte("BaseFeeBurnFraction")
 )
 
+// AnomalyDetectors are all the anomaly detectors
+var AnomalyDetectors = []string{
+	AnomalyTypeMissedBlocks,
+	AnomalyTypeProposerSkew,
+	AnomalyTypeCongestionSpike,
+	AnomalyTypeFeeRateShift,
+}
+
 // ParamKeyTable returns the parameter key table
 func ParamKeyTable() paramtypes.KeyTable {
 	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
@@ -114,6 +231,30 @@ type Params struct {
 	ReputationBonusRate         sdk.Dec       `json:"reputation_bonus_rate"`
 	ReputationPenaltyRate       sdk.Dec       `json:"reputation_penalty_rate"`
 	NeuralNetworkInfluenceRate  sdk.Dec       `json:"neural_network_influence_rate"`
+	MinPowerMultiplier          sdk.Dec       `json:"min_power_multiplier"`
+	MaxPowerMultiplier          sdk.Dec       `json:"max_power_multiplier"`
+	PowerEpochBlocks            uint64        `json:"power_epoch_blocks"`
+	MaxPowerMultiplierChange    sdk.Dec       `json:"max_power_multiplier_change"`
+	AnomalyDetectors            []string      `json:"anomaly_detectors"`
+	AnomalyDryRun               bool          `json:"anomaly_dry_run"`
+	AnomalyZScoreThreshold      sdk.Dec       `json:"anomaly_z_score_threshold"`
+	AnomalySlashConfidence      sdk.Dec       `json:"anomaly_slash_confidence"`
+	AnomalyHistoryWindow        uint64        `json:"anomaly_history_window"`
+	AnomalyValidatorWindow      uint64        `json:"anomaly_validator_window"`
+	AnomalyEWMAAlpha            sdk.Dec       `json:"anomaly_ewma_alpha"`
+	AnomalyMinSamples           uint64        `json:"anomaly_min_samples"`
+	PenaltyChallengePeriod      time.Duration `json:"penalty_challenge_period"`
+	PenaltySlashFraction        sdk.Dec       `json:"penalty_slash_fraction"`
+	PenaltyReputationChange     sdk.Dec       `json:"penalty_reputation_change"`
+	PenaltyMinDisputeBond       sdk.Coin      `json:"penalty_min_dispute_bond"`
+	PenaltyVoteQuorum           sdk.Dec       `json:"penalty_vote_quorum"`
+	PenaltyReductionFactor      sdk.Dec       `json:"penalty_reduction_factor"`
+	BaseFeeEnabled              bool          `json:"base_fee_enabled"`
+	BaseFeeDenom                string        `json:"base_fee_denom"`
+	MinBaseFee                  sdk.Dec       `json:"min_base_fee"`
+	TargetBlockUtilization      sdk.Dec       `json:"target_block_utilization"`
+	BaseFeeMaxChangeRate        sdk.Dec       `json:"base_fee_max_change_rate"`
+	BaseFeeBurnFraction         sdk.Dec       `json:"base_fee_burn_fraction"`
 }
 
 // DefaultParams returns default parameters
@@ -138,6 +279,30 @@ func DefaultParams() Params {
 		ReputationBonusRate:         sdk.MustNewDecFromStr(DefaultReputationBonusRate),
 		ReputationPenaltyRate:       sdk.MustNewDecFromStr(DefaultReputationPenaltyRate),
 		NeuralNetworkInfluenceRate:  sdk.MustNewDecFromStr(DefaultNeuralNetworkInfluenceRate),
+		MinPowerMultiplier:          sdk.MustNewDecFromStr(DefaultMinPowerMultiplier),
+		MaxPowerMultiplier:          sdk.MustNewDecFromStr(DefaultMaxPowerMultiplier),
+		PowerEpochBlocks:            DefaultPowerEpochBlocks,
+		MaxPowerMultiplierChange:    sdk.MustNewDecFromStr(DefaultMaxPowerMultiplierChange),
+		AnomalyDetectors:            append([]string{}, AnomalyDetectors...),
+		AnomalyDryRun:               DefaultAnomalyDryRun,
+		AnomalyZScoreThreshold:      sdk.MustNewDecFromStr(DefaultAnomalyZScoreThreshold),
+		AnomalySlashConfidence:      sdk.MustNewDecFromStr(DefaultAnomalySlashConfidence),
+		AnomalyHistoryWindow:        DefaultAnomalyHistoryWindow,
+		AnomalyValidatorWindow:      DefaultAnomalyValidatorWindow,
+		AnomalyEWMAAlpha:            sdk.MustNewDecFromStr(DefaultAnomalyEWMAAlpha),
+		AnomalyMinSamples:           DefaultAnomalyMinSamples,
+		PenaltyChallengePeriod:      DefaultPenaltyChallengePeriod,
+		PenaltySlashFraction:        sdk.MustNewDecFromStr(DefaultPenaltySlashFraction),
+		PenaltyReputationChange:     sdk.MustNewDecFromStr(DefaultPenaltyReputationChange),
+		PenaltyMinDisputeBond:       sdk.NewCoin("unmx", sdk.NewInt(DefaultPenaltyMinDisputeBond)),
+		PenaltyVoteQuorum:           sdk.MustNewDecFromStr(DefaultPenaltyVoteQuorum),
+		PenaltyReductionFactor:      sdk.MustNewDecFromStr(DefaultPenaltyReductionFactor),
+		BaseFeeEnabled:              DefaultBaseFeeEnabled,
+		BaseFeeDenom:                DefaultBaseFeeDenom,
+		MinBaseFee:                  sdk.MustNewDecFromStr(DefaultMinBaseFee),
+		TargetBlockUtilization:      sdk.MustNewDecFromStr(DefaultTargetBlockUtilization),
+		BaseFeeMaxChangeRate:        sdk.MustNewDecFromStr(DefaultBaseFeeMaxChangeRate),
+		BaseFeeBurnFraction:         sdk.MustNewDecFromStr(DefaultBaseFeeBurnFraction),
 	}
 }
 
@@ -163,6 +328,30 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(KeyReputationBonusRate, &p.ReputationBonusRate, validateReputationBonusRate),
 		paramtypes.NewParamSetPair(KeyReputationPenaltyRate, &p.ReputationPenaltyRate, validateReputationPenaltyRate),
 		paramtypes.NewParamSetPair(KeyNeuralNetworkInfluenceRate, &p.NeuralNetworkInfluenceRate, validateNeuralNetworkInfluenceRate),
+		paramtypes.NewParamSetPair(KeyMinPowerMultiplier, &p.MinPowerMultiplier, validatePowerMultiplier),
+		paramtypes.NewParamSetPair(KeyMaxPowerMultiplier, &p.MaxPowerMultiplier, validatePowerMultiplier),
+		paramtypes.NewParamSetPair(KeyPowerEpochBlocks, &p.PowerEpochBlocks, validatePowerEpochBlocks),
+		paramtypes.NewParamSetPair(KeyMaxPowerMultiplierChange, &p.MaxPowerMultiplierChange, validateMaxPowerMultiplierChange),
+		paramtypes.NewParamSetPair(KeyAnomalyDetectors, &p.AnomalyDetectors, validateAnomalyDetectors),
+		paramtypes.NewParamSetPair(KeyAnomalyDryRun, &p.AnomalyDryRun, validateAnomalyDryRun),
+		paramtypes.NewParamSetPair(KeyAnomalyZScoreThreshold, &p.AnomalyZScoreThreshold, validateAnomalyZScoreThreshold),
+		paramtypes.NewParamSetPair(KeyAnomalySlashConfidence, &p.AnomalySlashConfidence, validateAnomalySlashConfidence),
+		paramtypes.NewParamSetPair(KeyAnomalyHistoryWindow, &p.AnomalyHistoryWindow, validateAnomalyWindow),
+		paramtypes.NewParamSetPair(KeyAnomalyValidatorWindow, &p.AnomalyValidatorWindow, validateAnomalyWindow),
+		paramtypes.NewParamSetPair(KeyAnomalyEWMAAlpha, &p.AnomalyEWMAAlpha, validateAnomalyEWMAAlpha),
+		paramtypes.NewParamSetPair(KeyAnomalyMinSamples, &p.AnomalyMinSamples, validateAnomalyMinSamples),
+		paramtypes.NewParamSetPair(KeyPenaltyChallengePeriod, &p.PenaltyChallengePeriod, validatePenaltyChallengePeriod),
+		paramtypes.NewParamSetPair(KeyPenaltySlashFraction, &p.PenaltySlashFraction, validatePenaltyFraction),
+		paramtypes.NewParamSetPair(KeyPenaltyReputationChange, &p.PenaltyReputationChange, validatePenaltyFraction),
+		paramtypes.NewParamSetPair(KeyPenaltyMinDisputeBond, &p.PenaltyMinDisputeBond, validatePenaltyMinDisputeBond),
+		paramtypes.NewParamSetPair(KeyPenaltyVoteQuorum, &p.PenaltyVoteQuorum, validatePenaltyVoteQuorum),
+		paramtypes.NewParamSetPair(KeyPenaltyReductionFactor, &p.PenaltyReductionFactor, validatePenaltyFraction),
+		paramtypes.NewParamSetPair(KeyBaseFeeEnabled, &p.BaseFeeEnabled, validateBaseFeeEnabled),
+		paramtypes.NewParamSetPair(KeyBaseFeeDenom, &p.BaseFeeDenom, validateBaseFeeDenom),
+		paramtypes.NewParamSetPair(KeyMinBaseFee, &p.MinBaseFee, validateMinBaseFee),
+		paramtypes.NewParamSetPair(KeyTargetBlockUtilization, &p.TargetBlockUtilization, validateTargetBlockUtilization),
+		paramtypes.NewParamSetPair(KeyBaseFeeMaxChangeRate, &p.BaseFeeMaxChangeRate, validateBaseFeeMaxChangeRate),
+		paramtypes.NewParamSetPair(KeyBaseFeeBurnFraction, &p.BaseFeeBurnFraction, validateBaseFeeBurnFraction),
 	}
 }
 
@@ -225,6 +414,88 @@ func (p Params) Validate() error {
 	if err := validateNeuralNetworkInfluenceRate(p.NeuralNetworkInfluenceRate); err != nil {
 		return err
 	}
+	if err := validatePowerMultiplier(p.MinPowerMultiplier); err != nil {
+		return err
+	}
+	if err := validatePowerMultiplier(p.MaxPowerMultiplier); err != nil {
+		return err
+	}
+	if p.MinPowerMultiplier.GT(p.MaxPowerMultiplier) {
+		return fmt.Errorf("min power multiplier %s cannot be greater than max power multiplier %s", p.MinPowerMultiplier, p.MaxPowerMultiplier)
+	}
+	if err := validatePowerEpochBlocks(p.PowerEpochBlocks); err != nil {
+		return err
+	}
+	if err := validateMaxPowerMultiplierChange(p.MaxPowerMultiplierChange); err != nil {
+		return err
+	}
+	if err := validateAnomalyDetectors(p.AnomalyDetectors); err != nil {
+		return err
+	}
+	if err := validateAnomalyDryRun(p.AnomalyDryRun); err != nil {
+		return err
+	}
+	if err := validateAnomalyZScoreThreshold(p.AnomalyZScoreThreshold); err != nil {
+		return err
+	}
+	if err := validateAnomalySlashConfidence(p.AnomalySlashConfidence); err != nil {
+		return err
+	}
+	if err := validateAnomalyWindow(p.AnomalyHistoryWindow); err != nil {
+		return err
+	}
+	if err := validateAnomalyWindow(p.AnomalyValidatorWindow); err != nil {
+		return err
+	}
+	if err := validateAnomalyEWMAAlpha(p.AnomalyEWMAAlpha); err != nil {
+		return err
+	}
+	if err := validateAnomalyMinSamples(p.AnomalyMinSamples); err != nil {
+		return err
+	}
+	if p.AnomalyMinSamples > p.AnomalyHistoryWindow {
+		return fmt.Errorf("anomaly min samples %d cannot be greater than the anomaly history window %d", p.AnomalyMinSamples, p.AnomalyHistoryWindow)
+	}
+	if err := validatePenaltyChallengePeriod(p.PenaltyChallengePeriod); err != nil {
+		return err
+	}
+	// A validator must not be able to unbond before its penalties are resolved
+	if p.PenaltyChallengePeriod >= p.UnbondingTime {
+		return fmt.Errorf("penalty challenge period %s must be shorter than the unbonding time %s", p.PenaltyChallengePeriod, p.UnbondingTime)
+	}
+	if err := validatePenaltyFraction(p.PenaltySlashFraction); err != nil {
+		return err
+	}
+	if err := validatePenaltyFraction(p.PenaltyReputationChange); err != nil {
+		return err
+	}
+	if err := validatePenaltyMinDisputeBond(p.PenaltyMinDisputeBond); err != nil {
+		return err
+	}
+	if err := validatePenaltyVoteQuorum(p.PenaltyVoteQuorum); err != nil {
+		return err
+	}
+	if err := validatePenaltyFraction(p.PenaltyReductionFactor); err != nil {
+		return err
+	}
+	if err := validateBaseFeeEnabled(p.BaseFeeEnabled); err != nil {
+		return err
+	}
+	if err := validateBaseFeeDenom(p.BaseFeeDenom); err != nil {
+		return err
+	}
+	if err := validateMinBaseFee(p.MinBaseFee); err != nil {
+		return err
+	}
+	if err := validateTargetBlockUtilization(p.TargetBlockUtilization); err != nil {
+		return err
+	}
+	if err := validateBaseFeeMaxChangeRate(p.BaseFeeMaxChangeRate); err != nil {
+		return err
+	}
+	if err := validateBaseFeeBurnFraction(p.BaseFeeBurnFraction); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -527,5 +798,309 @@ func validateNeuralNetworkInfluenceRate(i interface{}) error {
 		return fmt.Errorf("neural network influence rate cannot be greater than 1: %s", v)
 	}
 
+	return nil
+}
+
+func validatePowerMultiplier(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsPositive() {
+		return fmt.Errorf("power multiplier must be positive: %s", v)
+	}
+
+	if v.GT(sdk.NewDec(MaxPowerMultiplier)) {
+		return fmt.Errorf("power multiplier cannot be greater than %d: %s", MaxPowerMultiplier, v)
+	}
+
+	return nil
+}
+
+func validatePowerEpochBlocks(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("power epoch blocks must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMaxPowerMultiplierChange(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsPositive() {
+		return fmt.Errorf("max power multiplier change must be positive: %s", v)
+	}
+
+	if v.GT(sdk.NewDec(MaxPowerMultiplier)) {
+		return fmt.Errorf("max power multiplier change cannot be greater than %d: %s", MaxPowerMultiplier, v)
+	}
+
+	return nil
+}
+
+func validateAnomalyDetectors(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool)
+	for _, detector := range v {
+		if seen[detector] {
+			return fmt.Errorf("duplicate anomaly detector: %s", detector)
+		}
+		seen[detector] = true
+
+		valid := false
+		for _, known := range AnomalyDetectors {
+			if detector == known {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("invalid anomaly detector: %s", detector)
+		}
+	}
+
+	return nil
+}
+
+func validateAnomalyDryRun(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateAnomalyZScoreThreshold(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// The confidence of a report is 1 - 1/z^2, which is only positive above 1
+	if v.LTE(sdk.OneDec()) {
+		return fmt.Errorf("anomaly z-score threshold must be greater than 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateAnomalySlashConfidence(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsPositive() {
+		return fmt.Errorf("anomaly slash confidence must be positive: %s", v)
+	}
+
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("anomaly slash confidence cannot be greater than 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateAnomalyWindow(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("anomaly window must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateAnomalyEWMAAlpha(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsPositive() {
+		return fmt.Errorf("anomaly EWMA alpha must be positive: %s", v)
+	}
+
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("anomaly EWMA alpha cannot be greater than 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateAnomalyMinSamples(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// A standard deviation needs at least two samples
+	if v < 2 {
+		return fmt.Errorf("anomaly min samples must be at least 2: %d", v)
+	}
+
+	return nil
+}
+
+func validatePenaltyChallengePeriod(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("penalty challenge period must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validatePenaltyFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("penalty fraction cannot be negative: %s", v)
+	}
+
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("penalty fraction cannot be greater than 1: %s", v)
+	}
+
+	return nil
+}
+
+func validatePenaltyMinDisputeBond(i interface{}) error {
+	v, ok := i.(sdk.Coin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() || !v.IsPositive() {
+		return fmt.Errorf("penalty min dispute bond must be a valid positive amount: %s", v)
+	}
+
+	return nil
+}
+
+func validatePenaltyVoteQuorum(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsPositive() {
+		return fmt.Errorf("penalty vote quorum must be positive: %s", v)
+	}
+
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("penalty vote quorum cannot be greater than 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateBaseFeeEnabled(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateBaseFeeDenom(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if err := sdk.ValidateDenom(v); err != nil {
+		return fmt.Errorf("invalid base fee denom: %w", err)
+	}
+
+	return nil
+}
+
+func validateMinBaseFee(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// The base fee changes by a fraction of itself, so it could never leave zero
+	if v.IsNil() || !v.IsPositive() {
+		return fmt.Errorf("min base fee must be positive: %s", v)
+	}
+
+	return nil
+}
+
+func validateTargetBlockUtilization(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || !v.IsPositive() || v.GTE(sdk.OneDec()) {
+		return fmt.Errorf("target block utilization must be between 0 and 1 exclusive: %s", v)
+	}
+
+	return nil
+}
+
+func validateBaseFeeMaxChangeRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || !v.IsPositive() {
+		return fmt.Errorf("base fee max change rate must be positive: %s", v)
+	}
+
+	// A larger rate would drive the base fee negative after an empty block
+	if v.GTE(sdk.OneDec()) {
+		return fmt.Errorf("base fee max change rate must be less than 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateBaseFeeBurnFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("base fee burn fraction cannot be negative: %s", v)
+	}
+
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("base fee burn fraction cannot be greater than 1: %s", v)
+	}
+
 	return nil
 }
\ No newline at end of file
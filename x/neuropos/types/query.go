@@ -0,0 +1,19 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QueryBaseFeeRequest is the request type for the Query/BaseFee RPC method
+type QueryBaseFeeRequest struct{}
+
+// QueryBaseFeeResponse is the response type for the Query/BaseFee RPC method. Wallets can
+// price a transaction at FullBlockBaseFee to be included even if the next block is full.
+type QueryBaseFeeResponse struct {
+	Enabled           bool    `json:"enabled"`
+	Denom             string  `json:"denom"`
+	BaseFee           sdk.Dec `json:"base_fee"`            // gas price the transactions of the next block pay
+	ProjectedBaseFee  sdk.Dec `json:"projected_base_fee"`  // if the next block is as congested as the last one
+	FullBlockBaseFee  sdk.Dec `json:"full_block_base_fee"` // if the next block is full
+	EmptyBlockBaseFee sdk.Dec `json:"empty_block_base_fee"`
+}
\ No newline at end of file
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -70,6 +72,36 @@ type AnomalyReport struct {
 	Description      string         `json:"description"`
 	Evidence         string         `json:"evidence"`
 	Timestamp        int64          `json:"timestamp"`
+	PenaltyID        uint64         `json:"penalty_id"` // 0 if no penalty was queued
+}
+
+// AnomalyEvidence is the statistical evidence of an anomaly report
+type AnomalyEvidence struct {
+	Metric  string  `json:"metric"`
+	Value   sdk.Dec `json:"value"`
+	Mean    sdk.Dec `json:"mean"`
+	StdDev  sdk.Dec `json:"std_dev"`
+	ZScore  sdk.Dec `json:"z_score"`
+	Samples uint64  `json:"samples"`
+}
+
+// EWMAStat is an exponentially weighted moving average of a metric and its variance
+type EWMAStat struct {
+	Mean     sdk.Dec `json:"mean"`
+	Variance sdk.Dec `json:"variance"`
+	Samples  uint64  `json:"samples"`
+}
+
+// ValidatorAnomalyStats are the activity of a validator in the current detection window
+// and the baselines of its metrics over past windows
+type ValidatorAnomalyStats struct {
+	ValidatorAddress string   `json:"validator_address"`
+	WindowSigned     uint64   `json:"window_signed"`
+	WindowMissed     uint64   `json:"window_missed"`
+	WindowProposed   uint64   `json:"window_proposed"`
+	WindowExpected   sdk.Dec  `json:"window_expected"` // proposals expected from the voting power
+	MissRate         EWMAStat `json:"miss_rate"`
+	ProposerSkew     EWMAStat `json:"proposer_skew"`
 }
 
 // NetworkState represents the current state of the network
@@ -82,4 +114,38 @@ type NetworkState struct {
 	ValidatorCount    uint64   `json:"validator_count"`
 	ActiveValidators  uint64   `json:"active_validators"`
 	Timestamp         int64    `json:"timestamp"`
+}
+
+// ValidatorPower is the voting power neuropos last sent to consensus for a validator:
+// its staked consensus power scaled by its reputation multiplier
+type ValidatorPower struct {
+	ValidatorAddress string  `json:"validator_address"`
+	ConsensusPubKey  []byte  `json:"consensus_pub_key"` // protobuf encoded tendermint public key
+	Multiplier       sdk.Dec `json:"multiplier"`
+	Power            int64   `json:"power"`
+}
+
+// PendingPenalty is the penalty of an anomaly report, executed when its challenge period
+// ends unless the accused validator disputes it and the other validators vote to reduce
+// or cancel it
+type PendingPenalty struct {
+	ID               uint64        `json:"id"`
+	ReportID         uint64        `json:"report_id"`
+	ValidatorAddress string        `json:"validator_address"`
+	AnomalyType      string        `json:"anomaly_type"`
+	InfractionHeight int64         `json:"infraction_height"`
+	SlashFraction    sdk.Dec       `json:"slash_fraction"`
+	ReputationChange sdk.Dec       `json:"reputation_change"` // negative
+	Status           string        `json:"status"`
+	CreatedAt        time.Time     `json:"created_at"`
+	ChallengeEndTime time.Time     `json:"challenge_end_time"`
+	Bond             sdk.Coin      `json:"bond"` // escrowed by the accused validator to dispute
+	CounterEvidence  string        `json:"counter_evidence"`
+	Votes            []PenaltyVote `json:"votes"`
+}
+
+// PenaltyVote is the vote of a validator on a disputed penalty
+type PenaltyVote struct {
+	Voter  string `json:"voter"` // validator operator address
+	Option string `json:"option"`
 }
\ No newline at end of file